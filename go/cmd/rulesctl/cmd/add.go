@@ -20,8 +20,12 @@ var (
 	addOptPlans             []string
 	addOptTables            []string
 	addOptQueryRE           string
+	addOptUserRE            string
 	addOptLeadingCommentRE  string
 	addOptTrailingCommentRE string
+	addOptMaxQPS            float64
+	addOptBurst             int
+	addOptMaxConcurrency    int
 	// TODO: other stuff, bind vars etc
 )
 
@@ -43,6 +47,11 @@ func runAdd(cmd *cobra.Command, args []string) {
 			log.Fatalf("Query condition invalid '%v': %v", addOptQueryRE, err)
 		}
 	}
+	if addOptUserRE != "" {
+		if err := rule.SetUserCond(addOptUserRE); err != nil {
+			log.Fatalf("User condition invalid '%v': %v", addOptUserRE, err)
+		}
+	}
 	if addOptLeadingCommentRE != "" {
 		if err := rule.SetLeadingCommentCond(addOptLeadingCommentRE); err != nil {
 			log.Fatalf("Leading comment condition invalid '%v': %v", addOptLeadingCommentRE, err)
@@ -54,6 +63,17 @@ func runAdd(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	switch ruleAction {
+	case vtrules.QRRateLimit:
+		if err := rule.SetRateLimit(addOptMaxQPS, addOptBurst); err != nil {
+			log.Fatalf("Rate limit invalid: %v", err)
+		}
+	case vtrules.QRConcurrencyLimit:
+		if err := rule.SetConcurrencyLimit(addOptMaxConcurrency); err != nil {
+			log.Fatalf("Concurrency limit invalid: %v", err)
+		}
+	}
+
 	var rules *vtrules.Rules
 	_, err := os.Stat(configFile)
 	if os.IsNotExist(err) {
@@ -105,6 +125,10 @@ func mkAction() vtrules.Action {
 		return vtrules.QRFailRetry
 	case "continue":
 		return vtrules.QRContinue
+	case "rate_limit":
+		return vtrules.QRRateLimit
+	case "concurrency_limit":
+		return vtrules.QRConcurrencyLimit
 	default:
 		log.Fatalf("Unknown action '%v'", addOptAction)
 	}
@@ -139,7 +163,7 @@ func Add() *cobra.Command {
 		&addOptAction,
 		"action", "a",
 		"",
-		"What action should be taken when this rule is matched {continue, fail, fail-retry} (required)")
+		"What action should be taken when this rule is matched {continue, fail, fail_retry, rate_limit, concurrency_limit} (required)")
 	addCmd.Flags().StringSliceVarP(
 		&addOptPlans,
 		"plan", "p",
@@ -155,6 +179,11 @@ func Add() *cobra.Command {
 		"query", "q",
 		"",
 		"A regexp that will be applied to a query in order to determine if it matches")
+	addCmd.Flags().StringVarP(
+		&addOptUserRE,
+		"user", "u",
+		"",
+		"A regexp that will be applied to the user running a query in order to determine if it matches")
 	addCmd.Flags().StringVarP(
 		&addOptLeadingCommentRE,
 		"leading-comment", "l",
@@ -166,6 +195,22 @@ func Add() *cobra.Command {
 		"",
 		"A regexp that will be applied to comments after a SQL statement")
 
+	addCmd.Flags().Float64Var(
+		&addOptMaxQPS,
+		"max-qps",
+		0,
+		"The rate at which matching queries are admitted by a rate_limit rule, in queries per second")
+	addCmd.Flags().IntVar(
+		&addOptBurst,
+		"burst",
+		0,
+		"The number of matching queries a rate_limit rule admits at once; defaults to max-qps")
+	addCmd.Flags().IntVar(
+		&addOptMaxConcurrency,
+		"max-concurrency",
+		0,
+		"The number of matching queries a concurrency_limit rule lets run at the same time")
+
 	for _, f := range []string{"name", "action"} {
 		addCmd.MarkFlagRequired(f)
 	}
@@ -102,6 +102,37 @@ func TestAdd(t *testing.T) {
     ]
   }
 ]
+`,
+		},
+		{
+			name: "Action rate_limit",
+			args: []string{"--dry-run=true", "--name=Rule", `--description="New rules that will be added to the file"`, "--action=rate_limit", "--user=app", "--max-qps=10", "--burst=20"},
+			expectedOutput: `[
+  {
+    "Description": "Some value",
+    "Name": "Name",
+    "Action": "FAIL"
+  },
+  {
+    "Description": "\"New rules that will be added to the file\"",
+    "Name": "Rule",
+    "User": "app",
+    "Query": "secret",
+    "LeadingComment": "None",
+    "TrailingComment": "Yoho",
+    "Plans": [
+      "Select",
+      "Select",
+      "Select"
+    ],
+    "TableNames": [
+      "Temp"
+    ],
+    "Action": "RATE_LIMIT",
+    "MaxQPS": 10,
+    "Burst": 20
+  }
+]
 `,
 		},
 	}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+// Imports and register the 'raft' topo.Server, and runs a node of the
+// raft topo server when --topo_raft_data_dir is set.
+
+import (
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo/rafttopo"
+)
+
+func init() {
+	servenv.OnRun(func() {
+		if err := rafttopo.StartNode(servenv.GRPCServer); err != nil {
+			log.Exitf("Cannot start the raft topo server node: %v", err)
+		}
+	})
+	servenv.OnClose(rafttopo.StopNode)
+}
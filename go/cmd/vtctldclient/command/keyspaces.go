@@ -19,6 +19,7 @@ package command
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -98,14 +99,30 @@ Otherwise, the keyspace must be empty (have no shards), or returns an error.`,
 	}
 	// SetKeyspaceDurabilityPolicy makes a SetKeyspaceDurabilityPolicy gRPC call to a vtcltd.
 	SetKeyspaceDurabilityPolicy = &cobra.Command{
-		Use:   "SetKeyspaceDurabilityPolicy [--durability-policy=policy_name] <keyspace name>",
+		Use:   "SetKeyspaceDurabilityPolicy [--durability-policy=policy_name|--durability-policy-file=policy_file] <keyspace name>",
 		Short: "Sets the durability-policy used by the specified keyspace.",
 		Long: `Sets the durability-policy used by the specified keyspace. 
 Durability policy governs the durability of the keyspace by describing which tablets should be sending semi-sync acknowledgements to the primary.
 Possible values include 'semi_sync', 'none' and others as dictated by registered plugins.
 
 To set the durability policy of customer keyspace to semi_sync, you would use the following command:
-SetKeyspaceDurabilityPolicy --durability-policy='semi_sync' customer`,
+SetKeyspaceDurabilityPolicy --durability-policy='semi_sync' customer
+
+A durability policy may also be declared in a JSON or YAML document, which describes the promotion rules
+of tablets and the semi-sync setup of primaries by cell and tablet type. The document is given in a file,
+or after the 'declarative:' prefix in --durability-policy. For example, to require semi-sync
+acks from replicas in two different cells, and to prefer promoting tablets in the cells of one region:
+
+promotion_rules:
+- cells: [us_east_1a, us_east_1b]
+  tablet_types: [primary, replica]
+  rule: prefer
+semi_sync:
+- ackers: 2
+  min_ack_cells: 2
+  acker_tablet_types: [replica]
+
+SetKeyspaceDurabilityPolicy --durability-policy-file=policy.yaml customer`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandSetKeyspaceDurabilityPolicy,
@@ -318,11 +335,22 @@ func commandRemoveKeyspaceCell(cmd *cobra.Command, args []string) error {
 }
 
 var setKeyspaceDurabilityPolicyOptions = struct {
-	DurabilityPolicy string
+	DurabilityPolicy     string
+	DurabilityPolicyFile string
 }{}
 
 func commandSetKeyspaceDurabilityPolicy(cmd *cobra.Command, args []string) error {
 	keyspace := cmd.Flags().Arg(0)
+	if setKeyspaceDurabilityPolicyOptions.DurabilityPolicyFile != "" {
+		data, err := os.ReadFile(setKeyspaceDurabilityPolicyOptions.DurabilityPolicyFile)
+		if err != nil {
+			return err
+		}
+		setKeyspaceDurabilityPolicyOptions.DurabilityPolicy = string(data)
+		if !policy.IsDeclarativeDurabilityPolicy(setKeyspaceDurabilityPolicyOptions.DurabilityPolicy) {
+			setKeyspaceDurabilityPolicyOptions.DurabilityPolicy = policy.DeclarativeDurabilityPolicyPrefix + setKeyspaceDurabilityPolicyOptions.DurabilityPolicy
+		}
+	}
 	cli.FinishedParsing(cmd)
 
 	resp, err := client.SetKeyspaceDurabilityPolicy(commandCtx, &vtctldatapb.SetKeyspaceDurabilityPolicyRequest{
@@ -369,7 +397,7 @@ func init() {
 	CreateKeyspace.Flags().Var(&createKeyspaceOptions.KeyspaceType, "type", "The type of the keyspace.")
 	CreateKeyspace.Flags().StringVar(&createKeyspaceOptions.BaseKeyspace, "base-keyspace", "", "The base keyspace for a snapshot keyspace.")
 	CreateKeyspace.Flags().StringVar(&createKeyspaceOptions.SnapshotTimestamp, "snapshot-timestamp", "", "The snapshot time for a snapshot keyspace, as a timestamp in RFC3339 format.")
-	CreateKeyspace.Flags().StringVar(&createKeyspaceOptions.DurabilityPolicy, "durability-policy", policy.DurabilityNone, "Type of durability to enforce for this keyspace. Default is none. Possible values include 'semi_sync' and others as dictated by registered plugins.")
+	CreateKeyspace.Flags().StringVar(&createKeyspaceOptions.DurabilityPolicy, "durability-policy", policy.DurabilityNone, "Type of durability to enforce for this keyspace. Default is none. Possible values include 'semi_sync' and others as dictated by registered plugins, or a declarative durability policy in JSON or YAML after the 'declarative:' prefix.")
 	CreateKeyspace.Flags().StringVar(&createKeyspaceOptions.SidecarDBName, "sidecar-db-name", sidecar.DefaultName, "(Experimental) Name of the Vitess sidecar database that tablets in this keyspace will use for internal metadata.")
 	Root.AddCommand(CreateKeyspace)
 
@@ -385,7 +413,9 @@ func init() {
 	RemoveKeyspaceCell.Flags().BoolVarP(&removeKeyspaceCellOptions.Recursive, "recursive", "r", false, "Also delete all tablets in that cell beloning to the specified keyspace.")
 	Root.AddCommand(RemoveKeyspaceCell)
 
-	SetKeyspaceDurabilityPolicy.Flags().StringVar(&setKeyspaceDurabilityPolicyOptions.DurabilityPolicy, "durability-policy", policy.DurabilityNone, "Type of durability to enforce for this keyspace. Default is none. Other values include 'semi_sync' and others as dictated by registered plugins.")
+	SetKeyspaceDurabilityPolicy.Flags().StringVar(&setKeyspaceDurabilityPolicyOptions.DurabilityPolicy, "durability-policy", policy.DurabilityNone, "Type of durability to enforce for this keyspace. Default is none. Other values include 'semi_sync' and others as dictated by registered plugins, or a declarative durability policy in JSON or YAML after the 'declarative:' prefix.")
+	SetKeyspaceDurabilityPolicy.Flags().StringVar(&setKeyspaceDurabilityPolicyOptions.DurabilityPolicyFile, "durability-policy-file", "", "Path to a JSON or YAML file with a declarative durability policy to enforce for this keyspace.")
+	SetKeyspaceDurabilityPolicy.MarkFlagsMutuallyExclusive("durability-policy", "durability-policy-file")
 	Root.AddCommand(SetKeyspaceDurabilityPolicy)
 
 	Root.AddCommand(ValidateVersionKeyspace)
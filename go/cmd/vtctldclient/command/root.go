@@ -52,6 +52,7 @@ import (
 	// These imports register the topo factories to use when --server=internal.
 	_ "vitess.io/vitess/go/vt/topo/consultopo"
 	_ "vitess.io/vitess/go/vt/topo/etcd2topo"
+	_ "vitess.io/vitess/go/vt/topo/rafttopo"
 	_ "vitess.io/vitess/go/vt/topo/zk2topo"
 )
 
@@ -40,7 +40,7 @@ import (
 var (
 	// ApplySchema makes an ApplySchema gRPC call to a vtctld.
 	ApplySchema = &cobra.Command{
-		Use:   "ApplySchema [--ddl-strategy <strategy>] [--uuid <uuid> ...] [--migration-context <context>] [--wait-replicas-timeout <duration>] [--caller-id <caller_id>] {--sql-file <file> | --sql <sql>} <keyspace>",
+		Use:   "ApplySchema [--ddl-strategy <strategy>] [--uuid <uuid> ...] [--migration-context <context>] [--wait-replicas-timeout <duration>] [--caller-id <caller_id>] [--dry-run-analysis] {--sql-file <file> | --sql <sql>} <keyspace>",
 		Short: "Applies the schema change to the specified keyspace on every primary, running in parallel on all shards. The changes are then propagated to replicas via replication.",
 		Long: `Applies the schema change to the specified keyspace on every primary, running in parallel on all shards. The changes are then propagated to replicas via replication.
 
@@ -48,6 +48,7 @@ If --allow-long-unavailability is set, schema changes affecting a large number o
 --ddl-strategy is used to instruct migrations via vreplication, mysql or direct with optional parameters.
 --migration-context allows the user to specify a custom migration context for online DDL migrations.
 If --skip-preflight, SQL goes directly to shards without going through sanity checks.
+If --dry-run-analysis is set, the ALTER TABLE statements are not applied. Instead, the primary of every shard reports, for each statement, whether it is INSTANT-eligible, the unique key that the table copy would iterate by, the estimated row count and copy duration, the throttler's state, and anything blocking the migration. The analysis assumes the 'vitess' strategy unless --ddl-strategy is given.
 
 The --uuid and --sql flags are repeatable, so they can be passed multiple times to build a list of values.
 For --uuid, this is used like "--uuid $first_uuid --uuid $second_uuid".
@@ -132,6 +133,7 @@ type ApplySchemaOptions struct {
 	SkipPreflight           bool
 	CallerID                string
 	BatchSize               int64
+	DryRunAnalysis          bool
 }
 
 // CallerIDProto returns a *vtrpcpb.CallerID constructed from this options
@@ -173,6 +175,28 @@ func commandApplySchema(cmd *cobra.Command, args []string) error {
 
 	ks := cmd.Flags().Arg(0)
 
+	if applySchemaOptions.DryRunAnalysis {
+		ddlStrategy := applySchemaOptions.DDLStrategy
+		if !cmd.Flags().Changed("ddl-strategy") {
+			ddlStrategy = string(schema.DDLStrategyVitess)
+		}
+		resp, err := client.AnalyzeSchemaMigrations(commandCtx, &vtctldatapb.AnalyzeSchemaMigrationsRequest{
+			Keyspace:    ks,
+			Sql:         parts,
+			DdlStrategy: ddlStrategy,
+		})
+		if err != nil {
+			return err
+		}
+
+		data, err := cli.MarshalJSON(resp)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", data)
+		return nil
+	}
+
 	resp, err := client.ApplySchema(commandCtx, &vtctldatapb.ApplySchemaRequest{
 		Keyspace:            ks,
 		DdlStrategy:         applySchemaOptions.DDLStrategy,
@@ -448,6 +472,7 @@ func init() {
 	ApplySchema.Flags().StringArrayVar(&applySchemaOptions.SQL, "sql", nil, "Semicolon-delimited, repeatable SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().StringVar(&applySchemaOptions.SQLFile, "sql-file", "", "Path to a file containing semicolon-delimited SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().Int64Var(&applySchemaOptions.BatchSize, "batch-size", 0, "How many queries to batch together. Only applicable when all queries are CREATE TABLE|VIEW")
+	ApplySchema.Flags().BoolVar(&applySchemaOptions.DryRunAnalysis, "dry-run-analysis", false, "Analyze the Online DDL migrations of the ALTER TABLE statements on every shard, without applying them.")
 	Root.AddCommand(ApplySchema)
 
 	CopySchemaShard.Flags().StringSliceVar(&copySchemaShardOptions.tables, "tables", nil, "Specifies a comma-separated list of tables to copy. Each is either an exact match, or a regular expression of the form /regexp/")
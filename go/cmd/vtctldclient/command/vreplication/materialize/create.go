@@ -51,7 +51,11 @@ in the target-keyspace to store the results in. The second required key is 'sour
 and its value is the select query to run against the source table. An optional key/value pair
 can also be specified for 'create_ddl' which provides the DDL to create the target table if it
 does not exist -- you can alternatively specify a value of 'copy' if the target table schema
-should be copied as-is from the source keyspace. Here's an example value for table-settings:
+should be copied as-is from the source keyspace. Another optional key is 'column_transforms',
+which maps source column names to a transformation applied to their values before they are
+written to the target table: 'sha256' hashes the values, 'redact' replaces them with NULL,
+'constant' replaces them with the given 'value' and 'truncate' keeps their first 'length'
+characters. Primary key columns cannot be transformed. Here's an example value for table-settings:
 [
   {
     "target_table": "customer_one_email",
@@ -66,6 +70,14 @@ should be copied as-is from the source keyspace. Here's an example value for tab
     "target_table": "sales_by_sku",
     "source_expression": "select sku, count(*) as orders, sum(price) as revenue from corder group by sku",
     "create_ddl": "create table sales_by_sku (sku varbinary(128) not null primary key, orders bigint, revenue bigint)"
+  },
+  {
+    "target_table": "customer_masked",
+    "source_expression": "select customer_id, email, name from customer",
+    "column_transforms": {
+      "email": {"function": "sha256"},
+      "name": {"function": "truncate", "length": 1}
+    }
   }
 ]
 `,
@@ -22,6 +22,7 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -69,12 +70,21 @@ var (
 		MaxDiffDuration             time.Duration
 		RowDiffColumnTruncateAt     int64
 		AutoStart                   bool
+		Repair                      bool
+		Checksum                    bool
+		SamplePct                   int64
 	}{}
 
 	deleteOptions = struct {
 		Arg string
 	}{}
 
+	repairOptions = struct {
+		UUID         uuid.UUID
+		TargetShards []string
+		Apply        bool
+	}{}
+
 	resumeOptions = struct {
 		UUID         uuid.UUID
 		TargetShards []string
@@ -127,6 +137,9 @@ var (
 		if createOptions.MaxExtraRowsToCompare < 0 {
 			return fmt.Errorf("--max-extra-rows-to-compare must not be a negative value")
 		}
+		if createOptions.SamplePct < 1 || createOptions.SamplePct > 100 {
+			return fmt.Errorf("--sample-pct must be a value between 1 and 100")
+		}
 		return nil
 	}
 
@@ -178,6 +191,27 @@ vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --targe
 		RunE: commandDelete,
 	}
 
+	// repair makes a VDiffRepair gRPC call to a vtctld.
+	repair = &cobra.Command{
+		Use:   "repair",
+		Short: "Show the statements that fix the differences found by a VDiff created with --repair, and optionally apply them on the target.",
+		Example: `vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --target-keyspace customer repair a037a9e2-5628-11ee-8c99-0242ac120002
+vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --target-keyspace customer repair a037a9e2-5628-11ee-8c99-0242ac120002 --apply --target-shards 80-`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Repair"},
+		Args:                  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			uuid, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid UUID provided: %v", err)
+			}
+			repairOptions.UUID = uuid
+
+			return common.ValidateShards(repairOptions.TargetShards)
+		},
+		RunE: commandRepair,
+	}
+
 	// resume makes a VDiffResume gRPC call to a vtctld.
 	resume = &cobra.Command{
 		Use:                   "resume",
@@ -300,6 +334,9 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		MaxDiffDuration:             protoutil.DurationToProto(createOptions.MaxDiffDuration),
 		RowDiffColumnTruncateAt:     createOptions.RowDiffColumnTruncateAt,
 		AutoStart:                   &createOptions.AutoStart,
+		Repair:                      createOptions.Repair,
+		Checksum:                    createOptions.Checksum,
+		SamplePct:                   createOptions.SamplePct,
 	})
 
 	if err != nil {
@@ -372,6 +409,96 @@ func commandDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// repairStatement is a statement that fixes a difference found by a vdiff.
+type repairStatement struct {
+	Shard     string
+	Table     string
+	Statement string
+	AppliedAt string `json:"AppliedAt,omitempty"`
+}
+
+func commandRepair(cmd *cobra.Command, args []string) error {
+	format, err := common.GetOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	cli.FinishedParsing(cmd)
+
+	resp, err := common.GetClient().VDiffRepair(common.GetCommandCtx(), &vtctldatapb.VDiffRepairRequest{
+		Workflow:       common.BaseOptions.Workflow,
+		TargetKeyspace: common.BaseOptions.TargetKeyspace,
+		Uuid:           repairOptions.UUID.String(),
+		TargetShards:   repairOptions.TargetShards,
+		Apply:          repairOptions.Apply,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return displayRepairResponse(cmd.OutOrStdout(), format, repairOptions.UUID.String(), resp)
+}
+
+func displayRepairResponse(out io.Writer, format, uuid string, resp *vtctldatapb.VDiffRepairResponse) error {
+	statements := buildRepairStatements(resp)
+	if format == "json" {
+		jsonText, err := cli.MarshalJSONPretty(statements)
+		if err != nil {
+			return err
+		}
+		output := string(jsonText)
+		if output == "null" {
+			output = "[]"
+		}
+		fmt.Fprintln(out, output)
+		return nil
+	}
+	if len(statements) == 0 {
+		fmt.Fprintf(out, "No repair statements found for vdiff %s\n", uuid)
+		return nil
+	}
+	shard := ""
+	for i, stmt := range statements {
+		if i == 0 || stmt.Shard != shard {
+			shard = stmt.Shard
+			fmt.Fprintf(out, "-- Shard %s\n", shard)
+		}
+		if stmt.AppliedAt != "" {
+			fmt.Fprintf(out, "%s; -- applied at %s\n", stmt.Statement, stmt.AppliedAt)
+		} else {
+			fmt.Fprintf(out, "%s;\n", stmt.Statement)
+		}
+	}
+	return nil
+}
+
+// buildRepairStatements returns the statements of all the shards, sorted by
+// shard and then in the order they were recorded.
+func buildRepairStatements(resp *vtctldatapb.VDiffRepairResponse) []*repairStatement {
+	shards := make([]string, 0, len(resp.TabletResponses))
+	for shard := range resp.TabletResponses {
+		shards = append(shards, shard)
+	}
+	sort.Strings(shards)
+	var statements []*repairStatement
+	for _, shard := range shards {
+		tabletResp := resp.TabletResponses[shard]
+		if tabletResp == nil || tabletResp.Output == nil {
+			continue
+		}
+		qr := sqltypes.Proto3ToResult(tabletResp.Output)
+		for _, row := range qr.Named().Rows {
+			statements = append(statements, &repairStatement{
+				Shard:     shard,
+				Table:     row["table_name"].ToString(),
+				Statement: row["statement"].ToString(),
+				AppliedAt: row["applied_at"].ToString(),
+			})
+		}
+	}
+	return statements
+}
+
 func commandResume(cmd *cobra.Command, args []string) error {
 	format, err := common.GetOutputFormat(cmd)
 	if err != nil {
@@ -404,7 +531,12 @@ type tableSummary struct {
 	MismatchedRows  int64
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
-	LastUpdated     string `json:"LastUpdated,omitempty"`
+	// These are only set for checksum and sampled diffs.
+	MatchingRanges   int64  `json:"MatchingRanges,omitempty"`
+	MismatchedRanges int64  `json:"MismatchedRanges,omitempty"`
+	SkippedRanges    int64  `json:"SkippedRanges,omitempty"`
+	RepairStatements int64  `json:"RepairStatements,omitempty"` // Only set with the repair option.
+	LastUpdated      string `json:"LastUpdated,omitempty"`
 }
 
 // summary aggregates the current state of the vdiff from all shards.
@@ -446,6 +578,10 @@ Table {{$table.TableName}}:
 {{if $table.MismatchedRows}}	MismatchedRows:   {{$table.MismatchedRows}}{{end}}
 {{if $table.ExtraRowsSource}}	ExtraRowsSource:  {{$table.ExtraRowsSource}}{{end}}
 {{if $table.ExtraRowsTarget}}	ExtraRowsTarget:  {{$table.ExtraRowsTarget}}{{end}}
+{{if $table.MatchingRanges}}	MatchingRanges:   {{$table.MatchingRanges}}{{end}}
+{{if $table.MismatchedRanges}}	MismatchedRanges: {{$table.MismatchedRanges}}{{end}}
+{{if $table.SkippedRanges}}	SkippedRanges:    {{$table.SkippedRanges}}{{end}}
+{{if $table.RepairStatements}}	RepairStatements: {{$table.RepairStatements}}{{end}}
 {{end}}
  
 Use "--format=json" for more detailed output.
@@ -683,10 +819,17 @@ func registerCommands(root *cobra.Command) {
 	create.Flags().DurationVar(&createOptions.MaxDiffDuration, "max-diff-duration", 0, "How long should an individual table diff run before being stopped and restarted in order to lessen the impact on tablets due to holding open database snapshots for long periods of time (0 is the default and means no time limit).")
 	create.Flags().Int64Var(&createOptions.RowDiffColumnTruncateAt, "row-diff-column-truncate-at", 128, "When showing row differences, truncate the non Primary Key column values to this length. A value less than 1 means do not truncate.")
 	create.Flags().BoolVar(&createOptions.AutoStart, "auto-start", true, "Start the vdiff upon creation. When false, the vdiff will be created but will not run until resumed.")
+	create.Flags().BoolVar(&createOptions.Repair, "repair", false, "Record a statement that fixes each difference found in the target, which can then be reviewed and applied with the repair command.")
+	create.Flags().BoolVar(&createOptions.Checksum, "checksum", false, "Compare the checksums of ranges of rows, computed in MySQL on the source and the target, and only compare the rows of the ranges whose checksums don't match.")
+	create.Flags().Int64Var(&createOptions.SamplePct, "sample-pct", 100, "Percentage of the ranges of rows to compare, which are picked deterministically by primary key.")
 	base.AddCommand(create)
 
 	base.AddCommand(delete)
 
+	repair.Flags().StringSliceVar(&repairOptions.TargetShards, "target-shards", nil, "The target shards to get or apply the repair statements on; default is all shards.")
+	repair.Flags().BoolVar(&repairOptions.Apply, "apply", false, "Run the repair statements that were not applied yet on the target primaries. The workflow must be stopped.")
+	base.AddCommand(repair)
+
 	resume.Flags().StringSliceVar(&resumeOptions.TargetShards, "target-shards", nil, "The target shards to resume the vdiff on; default is all shards.")
 	base.AddCommand(resume)
 
@@ -19,13 +19,18 @@ package vdiff
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
 
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
@@ -721,6 +726,30 @@ Table t1:
  
 Use "--format=json" for more detailed output.
 
+`, UUID, starttime, starttime),
+	}, {
+		id: "2",
+		res: sqltypes.MakeTestResult(fields,
+			"started||t1|"+UUID+"|started|300|"+starttime+"|30||0|"+
+				`{"TableName": "t1", "MatchingRows": 20, "ProcessedRows": 30, "MismatchedRows": 0, "ExtraRowsSource": 0, `+
+				`"ExtraRowsTarget": 0, "MatchingRanges": 2, "SkippedRanges": 1}`),
+		report: fmt.Sprintf(`
+VDiff Summary for targetks.vdiffTest (%s)
+State:        started
+RowsCompared: 30
+HasMismatch:  false
+StartedAt:    %s
+Progress:     10.00%%, ETA: %s
+ 
+Table t1:
+	State:            started
+	ProcessedRows:    30
+	MatchingRows:     20
+	MatchingRanges:   2
+	SkippedRanges:    1
+ 
+Use "--format=json" for more detailed output.
+
 `, UUID, starttime, starttime),
 	}}
 
@@ -758,3 +787,122 @@ func TestGetStructNames(t *testing.T) {
 	want := []string{"A", "B"}
 	require.EqualValues(t, want, got)
 }
+
+func TestDisplayRepairResponse(t *testing.T) {
+	repairFields := sqltypes.MakeTestFields(
+		"id|table_name|statement|applied_at",
+		"int64|varbinary|blob|timestamp",
+	)
+	resp := &vtctldatapb.VDiffRepairResponse{
+		TabletResponses: map[string]*tabletmanagerdatapb.VDiffResponse{
+			"80-": {
+				Output: sqltypes.ResultToProto3(sqltypes.MakeTestResult(repairFields,
+					"1|t1|delete from t1 where c1 = 9|NULL",
+				)),
+			},
+			"-80": {
+				Output: sqltypes.ResultToProto3(sqltypes.MakeTestResult(repairFields,
+					"1|t1|update t1 set c2 = 2 where c1 = 1|2025-01-01 00:00:00",
+					"2|t1|insert into t1(c1, c2) values (3, 3) on duplicate key update c2 = 3|NULL",
+				)),
+			},
+		},
+	}
+	UUID := uuid.New().String()
+
+	out := new(strings.Builder)
+	require.NoError(t, displayRepairResponse(out, "text", UUID, resp))
+	require.Equal(t, `-- Shard -80
+update t1 set c2 = 2 where c1 = 1; -- applied at 2025-01-01 00:00:00
+insert into t1(c1, c2) values (3, 3) on duplicate key update c2 = 3;
+-- Shard 80-
+delete from t1 where c1 = 9;
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, displayRepairResponse(out, "json", UUID, resp))
+	require.Contains(t, out.String(), `"AppliedAt": "2025-01-01 00:00:00"`)
+	require.Contains(t, out.String(), `"Shard": "80-"`)
+
+	out.Reset()
+	require.NoError(t, displayRepairResponse(out, "text", UUID, &vtctldatapb.VDiffRepairResponse{}))
+	require.Equal(t, fmt.Sprintf("No repair statements found for vdiff %s\n", UUID), out.String())
+}
+
+// createClient records the VDiffCreate requests.
+type createClient struct {
+	vtctldclient.VtctldClient
+	req *vtctldatapb.VDiffCreateRequest
+}
+
+func (c *createClient) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffCreateResponse, error) {
+	c.req = req
+	return &vtctldatapb.VDiffCreateResponse{UUID: req.Uuid}, nil
+}
+
+func TestCreateChecksumAndSamplePct(t *testing.T) {
+	if create.Flags().Lookup("checksum") == nil {
+		registerCommands(&cobra.Command{})
+	}
+	common.SetCommandCtx(context.Background())
+	client := &createClient{}
+	common.SetClient(client)
+	format := common.BaseOptions.Format
+	common.BaseOptions.Format = "text"
+	t.Cleanup(func() {
+		common.BaseOptions.Format = format
+		require.NoError(t, create.Flags().Set("checksum", "false"))
+		require.NoError(t, create.Flags().Set("sample-pct", "100"))
+	})
+
+	tests := []struct {
+		name          string
+		checksum      string
+		samplePct     string
+		wantChecksum  bool
+		wantSamplePct int64
+		wantErr       string
+	}{
+		{
+			name:          "defaults",
+			checksum:      "false",
+			samplePct:     "100",
+			wantSamplePct: 100,
+		},
+		{
+			name:          "checksum and sample percentage",
+			checksum:      "true",
+			samplePct:     "10",
+			wantChecksum:  true,
+			wantSamplePct: 10,
+		},
+		{
+			name:      "no sample percentage",
+			checksum:  "false",
+			samplePct: "0",
+			wantErr:   "--sample-pct must be a value between 1 and 100",
+		},
+		{
+			name:      "sample percentage too high",
+			checksum:  "false",
+			samplePct: "101",
+			wantErr:   "--sample-pct must be a value between 1 and 100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, create.Flags().Set("checksum", tt.checksum))
+			require.NoError(t, create.Flags().Set("sample-pct", tt.samplePct))
+			err := parseAndValidateCreate(create, nil)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			create.SetOut(new(strings.Builder))
+			require.NoError(t, commandCreate(create, nil))
+			require.Equal(t, tt.wantChecksum, client.req.Checksum)
+			require.Equal(t, tt.wantSamplePct, client.req.SamplePct)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/grpccommon"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtgate/debezium"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+
+	// Include the gRPC implementation of the vtgate client.
+	_ "vitess.io/vitess/go/vt/vtgate/grpcvtgateconn"
+)
+
+var (
+	server        string
+	keyspace      string
+	shards        []string
+	tables        []string
+	tabletType    = "primary"
+	snapshot      bool
+	serverName    = "vitess"
+	includeSchema = true
+	outputDir     string
+	offsetFile    string
+
+	Main = &cobra.Command{
+		Use:   "vtdebezium --server <vtgate> --keyspace <keyspace>",
+		Short: "vtdebezium streams the changes of a keyspace from a vtgate server as Debezium change events.",
+		Long: `vtdebezium streams the changes of a keyspace from a vtgate server as Debezium change events.
+
+Every row change is written as a JSON document that uses the Debezium envelope:
+the before and after images of the row, the operation, the source of the change
+with its keyspace, shard and GTID and, optionally, the schema of the message.
+
+The change events are written to stdout, one per line, or appended to one file
+per topic in the output directory. When an offset file is given, the VGTID of
+every delivered transaction is saved in it and the stream resumes from it on
+the next run, so that no change event is lost.`,
+		Example: `vtdebezium --server vtgate:15991 --keyspace commerce
+
+vtdebezium --server vtgate:15991 --keyspace commerce --tables customer,corder --snapshot --output-dir /var/lib/cdc --offset-file /var/lib/cdc/offset.json`,
+		Args:    cobra.NoArgs,
+		Version: servenv.AppVersion.String(),
+		RunE:    run,
+	}
+)
+
+func InitializeFlags() {
+	servenv.MoveFlagsToCobraCommand(Main)
+
+	Main.Flags().StringVar(&server, "server", server, "vtgate server to connect to")
+	Main.Flags().StringVar(&keyspace, "keyspace", keyspace, "keyspace to stream the changes of")
+	Main.Flags().StringSliceVar(&shards, "shards", shards, "shards to stream the changes of (default all shards of the keyspace)")
+	Main.Flags().StringSliceVar(&tables, "tables", tables, "tables to stream the changes of (default all tables of the keyspace)")
+	Main.Flags().StringVar(&tabletType, "tablet-type", tabletType, "type of the tablets to stream from")
+	Main.Flags().BoolVar(&snapshot, "snapshot", snapshot, "read the existing rows of the tables before streaming their changes, when there is no saved offset")
+	Main.Flags().StringVar(&serverName, "server-name", serverName, "logical name of the source, used as the prefix of the topics and in the source of the change events")
+	Main.Flags().BoolVar(&includeSchema, "include-schema", includeSchema, "include the schema of the key and the value in the change events")
+	Main.Flags().StringVar(&outputDir, "output-dir", outputDir, "directory to write one file of change events per topic into, instead of stdout")
+	Main.Flags().StringVar(&offsetFile, "offset-file", offsetFile, "file to save the offset of the delivered change events into and to resume the stream from")
+	Main.MarkFlagRequired("server")
+	Main.MarkFlagRequired("keyspace")
+
+	acl.RegisterFlags(Main.Flags())
+	grpccommon.RegisterFlags(Main.Flags())
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	defer logutil.Flush()
+
+	tt, err := topoproto.ParseTabletType(tabletType)
+	if err != nil {
+		return err
+	}
+
+	var offsets debezium.OffsetStore
+	var vgtid *binlogdatapb.VGtid
+	if offsetFile != "" {
+		offsets = &debezium.FileOffsetStore{Path: offsetFile}
+		if vgtid, err = offsets.Load(); err != nil {
+			return fmt.Errorf("cannot load the offset: %w", err)
+		}
+	}
+	if vgtid == nil {
+		vgtid = initialVGtid()
+	} else {
+		log.Infof("Resuming the stream from %v", vgtid)
+	}
+
+	var sink debezium.Sink
+	if outputDir == "" {
+		sink = debezium.NewWriterSink(cmd.OutOrStdout())
+	} else if sink, err = debezium.NewFileSink(outputDir); err != nil {
+		return err
+	}
+	defer sink.Close()
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	conn, err := vtgateconn.Dial(ctx, server)
+	if err != nil {
+		return fmt.Errorf("cannot connect to %s: %w", server, err)
+	}
+	defer conn.Close()
+
+	reader, err := conn.VStream(ctx, tt, vgtid, buildFilter(), &vtgatepb.VStreamFlags{})
+	if err != nil {
+		return err
+	}
+	conv := debezium.NewConverter(debezium.Config{
+		ServerName:    serverName,
+		IncludeSchema: includeSchema,
+	})
+	return debezium.Stream(ctx, reader, conv, sink, offsets)
+}
+
+// initialVGtid returns the position to start streaming from when there is no
+// saved offset.
+func initialVGtid() *binlogdatapb.VGtid {
+	// An empty position copies the tables first.
+	position := "current"
+	if snapshot {
+		position = ""
+	}
+	if len(shards) == 0 {
+		// VTGate streams from all the shards of the keyspace.
+		return &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: keyspace, Gtid: position}}}
+	}
+	vgtid := &binlogdatapb.VGtid{}
+	for _, shard := range shards {
+		vgtid.ShardGtids = append(vgtid.ShardGtids, &binlogdatapb.ShardGtid{Keyspace: keyspace, Shard: shard, Gtid: position})
+	}
+	return vgtid
+}
+
+func buildFilter() *binlogdatapb.Filter {
+	if len(tables) == 0 {
+		return &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "/.*"}}}
+	}
+	filter := &binlogdatapb.Filter{}
+	for _, table := range tables {
+		filter.Rules = append(filter.Rules, &binlogdatapb.Rule{
+			Match:  table,
+			Filter: "select * from " + sqlparser.String(sqlparser.NewIdentifierCS(table)),
+		})
+	}
+	return filter
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"testing"
+
+	"vitess.io/vitess/go/test/utils"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+)
+
+func TestInitialVGtid(t *testing.T) {
+	defer func() {
+		keyspace, shards, snapshot = "", nil, false
+	}()
+
+	keyspace = "commerce"
+	utils.MustMatch(t, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+		{Keyspace: "commerce", Gtid: "current"},
+	}}, initialVGtid())
+
+	shards, snapshot = []string{"-80", "80-"}, true
+	utils.MustMatch(t, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+		{Keyspace: "commerce", Shard: "-80"},
+		{Keyspace: "commerce", Shard: "80-"},
+	}}, initialVGtid())
+}
+
+func TestBuildFilter(t *testing.T) {
+	defer func() {
+		tables = nil
+	}()
+
+	utils.MustMatch(t, &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "/.*"}}}, buildFilter())
+
+	tables = []string{"customer", "order"}
+	utils.MustMatch(t, &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{
+		{Match: "customer", Filter: "select * from customer"},
+		{Match: "order", Filter: "select * from `order`"},
+	}}, buildFilter())
+}
@@ -50,6 +50,15 @@ var (
 )
 
 func run(cmd *cobra.Command, args []string) {
+	// Simulating the recoveries on a snapshot doesn't start VTOrc, it only prints the result.
+	if simulateRecoverySnapshot != "" {
+		config.MarkConfigurationLoaded()
+		if err := simulateRecovery(cmd.OutOrStdout(), simulateRecoverySnapshot); err != nil {
+			log.Exitf("Cannot simulate the recoveries: %v", err)
+		}
+		return
+	}
+
 	servenv.Init()
 	inst.RegisterStats()
 
@@ -91,4 +100,6 @@ func init() {
 
 	logic.RegisterFlags(Main.Flags())
 	acl.RegisterFlags(Main.Flags())
+
+	Main.Flags().StringVar(&simulateRecoverySnapshot, "simulate-recovery-snapshot", "", "File or URL of a snapshot of the VTOrc database, as served by /api/database-state. If set, VTOrc prints the recoveries it would run on the snapshot and exits, without running any of them")
 }
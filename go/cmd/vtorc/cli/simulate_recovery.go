@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+	"vitess.io/vitess/go/vt/vtorc/logic"
+)
+
+// simulateRecoverySnapshot is the snapshot of the backend database to simulate the recoveries on,
+// as served by the /api/database-state endpoint of a running VTOrc.
+var simulateRecoverySnapshot string
+
+// simulationSQLiteDataFile is the backend database the snapshot is loaded into. Loading the snapshot
+// replaces the contents of the backend database, so the simulation never uses the one set by
+// --sqlite-data-file: that could be the persistent backend of a running VTOrc.
+const simulationSQLiteDataFile = "file:vtorc_simulation?mode=memory&cache=shared"
+
+// simulateRecovery loads the snapshot of the backend database, runs the replication analysis on it, and
+// writes the recoveries that VTOrc would choose to w, without running any of them.
+func simulateRecovery(w io.Writer, snapshot string) error {
+	state, err := readSnapshot(snapshot)
+	if err != nil {
+		return fmt.Errorf("cannot read the snapshot %v: %w", snapshot, err)
+	}
+	config.SetSQLiteDataFile(simulationSQLiteDataFile)
+	if err := inst.LoadDatabaseState(state); err != nil {
+		return err
+	}
+	simulatedRecoveries, err := logic.SimulateRecoveries("", "")
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(simulatedRecoveries, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
+
+// readSnapshot reads a snapshot from a file, or fetches it from a URL.
+func readSnapshot(snapshot string) (string, error) {
+	if !strings.HasPrefix(snapshot, "http://") && !strings.HasPrefix(snapshot, "https://") {
+		data, err := os.ReadFile(snapshot)
+		return string(data), err
+	}
+	resp, err := http.Get(snapshot)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %v: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return string(data), nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/external/golib/sqlutils"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/db"
+)
+
+func TestSimulateRecoveryLeavesDataFileUntouched(t *testing.T) {
+	oldDataFile := config.GetSQLiteDataFile()
+	defer config.SetSQLiteDataFile(oldDataFile)
+
+	// Set up a persistent backend, as a running VTOrc would have.
+	dataFile := filepath.Join(t.TempDir(), "vtorc.db")
+	config.SetSQLiteDataFile(dataFile)
+	_, err := db.ExecVTOrc("INSERT INTO vitess_keyspace (keyspace, keyspace_type, durability_policy) VALUES ('ks', 0, 'semi_sync')")
+	require.NoError(t, err)
+
+	snapshot := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(snapshot, []byte(`[{"TableName": "vitess_keyspace", "Rows": [{"keyspace": "other", "keyspace_type": "0", "durability_policy": "none"}]}]`), 0o644))
+
+	var out bytes.Buffer
+	require.NoError(t, simulateRecovery(&out, snapshot))
+	require.Equal(t, "[]\n", out.String())
+
+	// The snapshot was loaded in a private in-memory database.
+	var keyspaces []string
+	err = db.QueryVTOrc("SELECT keyspace FROM vitess_keyspace", nil, func(row sqlutils.RowMap) error {
+		keyspaces = append(keyspaces, row.GetString("keyspace"))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"other"}, keyspaces)
+
+	// The data file is left untouched.
+	config.SetSQLiteDataFile(dataFile)
+	keyspaces = nil
+	err = db.QueryVTOrc("SELECT keyspace FROM vitess_keyspace", nil, func(row sqlutils.RowMap) error {
+		keyspaces = append(keyspaces, row.GetString("keyspace"))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ks"}, keyspaces)
+}
@@ -50,6 +50,9 @@ var (
 	//go:embed vtexplain.txt
 	vtexplainTxt string
 
+	//go:embed vtdebezium.txt
+	vtdebeziumTxt string
+
 	//go:embed vtgate.txt
 	vtgateTxt string
 
@@ -99,6 +102,7 @@ var (
 		"vtctlclient":      vtctlclientTxt,
 		"vtctld":           vtctldTxt,
 		"vtctldclient":     vtctldclientTxt,
+		"vtdebezium":       vtdebeziumTxt,
 		"vtexplain":        vtexplainTxt,
 		"vtgate":           vtgateTxt,
 		"vtgateclienttest": vtgateclienttestTxt,
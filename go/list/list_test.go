@@ -133,3 +133,17 @@ func TestPushFrontValue(t *testing.T) {
 	assert.Equal(t, a, l.Front())
 	assert.Equal(t, a, e.prev)
 }
+
+func TestInsertValueAfter(t *testing.T) {
+	l := New[int]()
+	e := l.PushBack(1)
+	f := l.PushBack(3)
+	g := &Element[int]{Value: 2}
+	l.InsertValueAfter(g, e)
+	assert.Equal(t, 3, l.Len())
+	assert.Equal(t, g, e.Next())
+	assert.Equal(t, f, g.Next())
+
+	m := New[int]()
+	assert.Panics(t, func() { m.InsertValueAfter(&Element[int]{}, e) })
+}
@@ -40,6 +40,7 @@ type logbv struct {
 type Logger struct {
 	b     []byte
 	bvars []logbv
+	keys  []string
 	n     int
 	json  bool
 }
@@ -188,6 +189,26 @@ func (log *Logger) Strings(strs []string) {
 	log.b = append(log.b, ']')
 }
 
+// StringMap prints the map as a JSON object, with sorted keys.
+func (log *Logger) StringMap(m map[string]string) {
+	for k := range m {
+		log.keys = append(log.keys, k)
+	}
+	slices.Sort(log.keys)
+
+	log.b = append(log.b, '{')
+	for i, k := range log.keys {
+		if i > 0 {
+			log.b = append(log.b, ',', ' ')
+		}
+		log.b = strconv.AppendQuote(log.b, k)
+		log.b = append(log.b, ':', ' ')
+		log.b = strconv.AppendQuote(log.b, m[k])
+	}
+	log.b = append(log.b, '}')
+	log.keys = log.keys[:0]
+}
+
 func (log *Logger) Flush(w io.Writer) (err error) {
 	if log.json {
 		log.b = append(log.b, '}')
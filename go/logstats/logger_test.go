@@ -230,6 +230,21 @@ func (*mockWriter) Write(p []byte) (int, error) {
 	return 1, nil
 }
 
+func TestStringMap(t *testing.T) {
+	tl := Logger{}
+	tl.Init(false)
+
+	tl.StringMap(map[string]string{"testKey2": "testValue2", "testKey1": "testValue1"})
+	assert.Equal(t, []byte("{\"testKey1\": \"testValue1\", \"testKey2\": \"testValue2\"}"), tl.b)
+	assert.Empty(t, tl.keys)
+
+	tl.b = []byte{}
+	tl.Init(true)
+
+	tl.StringMap(nil)
+	assert.Equal(t, []byte("{{}"), tl.b)
+}
+
 func TestFlush(t *testing.T) {
 	tl := NewLogger()
 	tl.Init(true)
@@ -295,6 +295,14 @@ func (c *Conn) clientHandshake(params *ConnParams) error {
 		return sqlerror.NewSQLErrorf(sqlerror.CRSSLConnectionError, sqlerror.SSUnknownSQLState, "server doesn't support ClientSessionTrack but client asked for it")
 	}
 
+	// Protocol compression, if the server supports one of the
+	// algorithms we want. It is enabled once we are authenticated.
+	compression, err := clientCompressionCapability(capabilities, params.CompressionAlgorithms)
+	if err != nil {
+		return sqlerror.NewSQLErrorf(sqlerror.CRUnknownError, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	c.Capabilities |= compression
+
 	// Build and send our handshake response 41.
 	// Note this one will never have SSL flag on.
 	if err := c.writeHandshakeResponse41(capabilities, scrambledPassword, uint8(params.Charset), params); err != nil {
@@ -306,6 +314,10 @@ func (c *Conn) clientHandshake(params *ConnParams) error {
 		return err
 	}
 
+	if compression != 0 {
+		c.enableCompression(compression, params.ZstdCompressionLevel)
+	}
+
 	// If the server didn't support DbName in its handshake, set
 	// it now. This is what the 'mysql' client does.
 	if capabilities&CapabilityClientConnectWithDB == 0 && params.DbName != "" {
@@ -537,7 +549,9 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		CapabilityClientFoundRows&uint32(params.Flags) |
 		// If the server supported
 		// CapabilityClientSessionTrack, we also support it.
-		c.Capabilities&CapabilityClientSessionTrack
+		c.Capabilities&CapabilityClientSessionTrack |
+		// The compression algorithm we picked, if any.
+		c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm)
 
 	// FIXME(alainjobart) add multi statement.
 
@@ -564,6 +578,11 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		length++
 	}
 
+	// The zstd compression level, if we use zstd.
+	if capabilityFlags&CapabilityClientZstdCompressionAlgorithm != 0 {
+		length++
+	}
+
 	data, pos := c.startEphemeralPacketWithHeader(length)
 
 	// Client capability flags.
@@ -600,6 +619,14 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 	// Assume native client during response
 	pos = writeNullString(data, pos, string(c.authPluginName))
 
+	if capabilityFlags&CapabilityClientZstdCompressionAlgorithm != 0 {
+		level := params.ZstdCompressionLevel
+		if level == 0 {
+			level = DefaultZstdCompressionLevel
+		}
+		pos = writeByte(data, pos, byte(level))
+	}
+
 	// Sanity-check the length.
 	if pos != len(data) {
 		return sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "writeHandshakeResponse41: only packed %v bytes, out of %v allocated", pos, len(data))
@@ -147,6 +147,12 @@ type Conn struct {
 	// Buffered writing has a timer which flushes on inactivity.
 	bufferedWriter *bufio.Writer
 
+	// compressedReader and compressedWriter are set once the
+	// compressed protocol was negotiated during the handshake.
+	// All packets are then read and written through them.
+	compressedReader *compressedReader
+	compressedWriter *compressedWriter
+
 	// PrepareData is the map to use a prepared statement.
 	PrepareData map[uint32]*PrepareData
 
@@ -158,10 +164,26 @@ type Conn struct {
 	// the client and the server, and currently in use.
 	// It is set during the initial handshake.
 	//
-	// It is only used for CapabilityClientDeprecateEOF
-	// and CapabilityClientFoundRows.
+	// It is only used for CapabilityClientDeprecateEOF,
+	// CapabilityClientFoundRows, CapabilityClientQueryAttributes
+	// and the compression capabilities.
 	Capabilities uint32
 
+	// zstdCompressionLevel is the compression level the client asked
+	// for with CapabilityClientZstdCompressionAlgorithm.
+	// It is only used by the server.
+	zstdCompressionLevel int
+
+	// clientCapabilities are the capability flags the client sent in
+	// its handshake response. They tell how to parse COM_CHANGE_USER.
+	// It is only used by the server.
+	clientCapabilities uint32
+
+	// queryAttributes are the query attributes sent with the
+	// COM_QUERY or COM_STMT_EXECUTE being handled.
+	// It is only used by the server.
+	queryAttributes map[string]string
+
 	// closed is set to true when Close() is called on the connection.
 	closed atomic.Bool
 
@@ -226,6 +248,18 @@ type PrepareData struct {
 	BindVars    map[string]*querypb.BindVariable
 	StatementID uint32
 	ParamsCount uint16
+	// CursorType is the cursor type the statement is being executed
+	// with. Handlers should stream the result of cursors.
+	CursorType byte
+
+	// cursor is set while the statement has an open cursor.
+	cursor *stmtCursor
+
+	// attributeNames and attributeTypes are the names and types of the
+	// query attributes last bound to the statement. The client only
+	// sends them again when they change.
+	attributeNames []string
+	attributeTypes []querypb.Type
 }
 
 // execResult is an enum signifying the result of executing a query
@@ -322,7 +356,7 @@ func (c *Conn) startWriterBuffering() {
 	defer c.bufMu.Unlock()
 
 	c.bufferedWriter = writersPool.Get().(*bufio.Writer)
-	c.bufferedWriter.Reset(c.conn)
+	c.bufferedWriter.Reset(c.getWriter())
 }
 
 // endWriterBuffering must be called to terminate startWriteBuffering.
@@ -370,14 +404,27 @@ func (c *Conn) startFlushTimer() {
 }
 
 // getReader returns reader for connection. It can be *bufio.Reader or net.Conn
-// depending on which buffer size was passed to newServerConn.
+// depending on which buffer size was passed to newServerConn, wrapped in a
+// compressedReader if compression is enabled.
 func (c *Conn) getReader() io.Reader {
+	if c.compressedReader != nil {
+		return c.compressedReader
+	}
 	if c.bufferedReader != nil {
 		return c.bufferedReader
 	}
 	return c.conn
 }
 
+// getWriter returns the unbuffered writer for the connection. It is the
+// net.Conn, or a compressedWriter if compression is enabled.
+func (c *Conn) getWriter() io.Writer {
+	if c.compressedWriter != nil {
+		return c.compressedWriter
+	}
+	return c.conn
+}
+
 func (c *Conn) readHeaderFrom(r io.Reader) (int, error) {
 	// Note io.ReadFull will return two different types of errors:
 	// 1. if the socket is already closed, and the go runtime knows it,
@@ -398,6 +445,12 @@ func (c *Conn) readHeaderFrom(r io.Reader) (int, error) {
 		return 0, vterrors.Wrapf(err, "io.ReadFull(header size) failed")
 	}
 
+	// With compression, the sequence of the compressed packets was
+	// checked already, and the one of the packets within is ignored.
+	if c.compressedReader != nil {
+		return int(uint32(c.header[0]) | uint32(c.header[1])<<8 | uint32(c.header[2])<<16), nil
+	}
+
 	sequence := c.header[3]
 	if sequence != c.sequence {
 		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid sequence, expected %v got %v", c.sequence, sequence)
@@ -610,7 +663,7 @@ func (c *Conn) writePacket(data []byte) error {
 		}()
 	} else {
 		c.bufMu.Unlock()
-		w = c.conn
+		w = c.getWriter()
 	}
 
 	var header [packetHeaderSize]byte
@@ -641,8 +694,11 @@ func (c *Conn) writePacket(data []byte) error {
 		// restore the first 4 bytes once the network send is done
 		copy(data[index:index+packetHeaderSize], header[0:packetHeaderSize])
 
-		// Update our state.
-		c.sequence++
+		// Update our state. With compression, the sequence is
+		// incremented for every compressed packet instead.
+		if c.compressedWriter == nil {
+			c.sequence++
+		}
 		dataLength -= toBeSent
 		if dataLength == 0 {
 			if toBeSent == MaxPacketSize {
@@ -658,7 +714,9 @@ func (c *Conn) writePacket(data []byte) error {
 				} else if n != packetHeaderSize {
 					return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Write(empty header) returned a short write: %v < 4", n)
 				}
-				c.sequence++
+				if c.compressedWriter == nil {
+					c.sequence++
+				}
 			}
 			return nil
 		}
@@ -734,6 +792,13 @@ func (c *Conn) ID() int64 {
 	return int64(c.ConnectionID)
 }
 
+// QueryAttributes returns the query attributes the client sent with the
+// query being executed, by name. Attributes with a NULL value are left out.
+// It is only valid while the handler executes the query.
+func (c *Conn) QueryAttributes() map[string]string {
+	return c.queryAttributes
+}
+
 // Ident returns a useful identification string for error logging
 func (c *Conn) String() string {
 	return fmt.Sprintf("client %v (%s)", c.ConnectionID, c.RemoteAddr().String())
@@ -904,6 +969,13 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 		return false
 	}
 
+	switch data[0] {
+	case ComInitDB, ComQuery, ComPrepare, ComBinlogDump, ComBinlogDumpGTID, ComRegisterReplica:
+		// The handler can't stream the results of open cursors while
+		// it executes another command.
+		c.materializeCursors()
+	}
+
 	switch data[0] {
 	case ComQuit:
 		c.recycleReadPacket()
@@ -925,10 +997,13 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 		return c.handleComStmtExecute(handler, data)
 	case ComStmtSendLongData:
 		return c.handleComStmtSendLongData(data)
+	case ComStmtFetch:
+		return c.handleComStmtFetch(handler, data)
 	case ComStmtClose:
 		stmtID, ok := c.parseComStmtClose(data)
 		c.recycleReadPacket()
 		if ok {
+			c.closeCursor(c.PrepareData[stmtID])
 			delete(c.PrepareData, stmtID)
 		}
 	case ComStmtReset:
@@ -936,6 +1011,8 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 	case ComResetConnection:
 		c.handleComResetConnection(handler)
 		return true
+	case ComChangeUser:
+		return c.handleComChangeUser(handler, data)
 	case ComFieldList:
 		c.recycleReadPacket()
 		if !c.writeErrorAndLog(sqlerror.ERUnknownComError, sqlerror.SSNetError, "command handling not implemented yet: %v", data[0]) {
@@ -1027,6 +1104,7 @@ func (c *Conn) handleComBinlogDumpGTID(handler Handler, data []byte) (kontinue b
 func (c *Conn) handleComResetConnection(handler Handler) {
 	// Clean up and reset the connection
 	c.recycleReadPacket()
+	c.closeCursors()
 	handler.ComResetConnection(c)
 	// Reset prepared statements
 	c.PrepareData = make(map[uint32]*PrepareData)
@@ -1036,6 +1114,59 @@ func (c *Conn) handleComResetConnection(handler Handler) {
 	}
 }
 
+// handleComChangeUser authenticates the connection again, as the user
+// in the COM_CHANGE_USER packet, and resets the session. If that fails,
+// the connection is closed, as its previous user is gone.
+func (c *Conn) handleComChangeUser(handler Handler, data []byte) bool {
+	user, authMethod, authResponse, err := c.parseComChangeUser(data)
+	c.recycleReadPacket()
+	if err != nil {
+		log.Errorf("Cannot parse COM_CHANGE_USER from %s: %v", c, err)
+		c.writeErrorPacketFromError(err)
+		return false
+	}
+	if c.listener == nil {
+		c.writeErrorAndLog(sqlerror.ERUnknownComError, sqlerror.SSNetError, "command handling not implemented yet: %v", ComChangeUser)
+		return false
+	}
+
+	// Prepared statements belong to the session.
+	c.closeCursors()
+	c.PrepareData = make(map[uint32]*PrepareData)
+
+	userData, err := c.listener.authenticate(c, user, authMethod, c.salt, authResponse)
+	if err != nil {
+		c.writeErrorPacketFromError(err)
+		return false
+	}
+
+	if c.User != "" {
+		connCountPerUser.Add(c.User, -1)
+	}
+	c.User = user
+	c.UserData = userData
+	if c.User != "" {
+		connCountPerUser.Add(c.User, 1)
+	}
+	handler.ComChangeUser(c)
+
+	if c.schemaName != "" {
+		err = handler.ComQuery(c, "use "+sqlescape.EscapeID(c.schemaName), func(result *sqltypes.Result) error {
+			return nil
+		})
+		if err != nil {
+			c.writeErrorPacketFromError(err)
+			return false
+		}
+	}
+
+	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
+		log.Errorf("Cannot write OK packet to %s: %v", c, err)
+		return false
+	}
+	return true
+}
+
 func (c *Conn) handleComStmtReset(data []byte) bool {
 	stmtID, ok := c.parseComStmtReset(data)
 	c.recycleReadPacket()
@@ -1054,6 +1185,7 @@ func (c *Conn) handleComStmtReset(data []byte) bool {
 		}
 	}
 
+	c.closeCursor(prepare)
 	if prepare.BindVars != nil {
 		for k := range prepare.BindVars {
 			prepare.BindVars[k] = nil
@@ -1106,8 +1238,10 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		}
 	}()
 	queryStart := time.Now()
-	stmtID, _, err := c.parseComStmtExecute(c.PrepareData, data)
+	stmtID, cursorType, attributes, err := c.parseComStmtExecute(c.PrepareData, data)
 	c.recycleReadPacket()
+	c.queryAttributes = attributes
+	defer func() { c.queryAttributes = nil }()
 
 	if stmtID != uint32(0) {
 		defer func() {
@@ -1121,10 +1255,20 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		return c.writeErrorPacketFromErrorAndLog(err)
 	}
 
+	// Executing a statement again closes its cursor, and the handler
+	// can't stream the results of the other ones while it executes.
+	prepare := c.PrepareData[stmtID]
+	c.closeCursor(prepare)
+	c.materializeCursors()
+
+	prepare.CursorType = cursorType & (CursorTypeReadOnly | CursorTypeForUpdate | CursorTypeScrollable)
+	if prepare.CursorType != CursorTypeNoCursor {
+		return c.executeWithCursor(handler, prepare)
+	}
+
 	receivedResult := false
 	// sendFinished is set if the response should just be an OK packet.
 	sendFinished := false
-	prepare := c.PrepareData[stmtID]
 	err = handler.ComStmtExecute(c, prepare, func(qr *sqltypes.Result) error {
 		if sendFinished {
 			// Failsafe: Unreachable if server is well-behaved.
@@ -1291,11 +1435,15 @@ func (c *Conn) handleComQuery(handler Handler, data []byte) (kontinue bool) {
 	}()
 
 	queryStart := time.Now()
-	query := c.parseComQuery(data)
+	query, attributes, err := c.parseComQuery(data)
 	c.recycleReadPacket()
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+	c.queryAttributes = attributes
+	defer func() { c.queryAttributes = nil }()
 
 	var queries []string
-	var err error
 	if c.Capabilities&CapabilityClientMultiStatements != 0 {
 		queries, err = handler.Env().Parser().SplitStatementToPieces(query)
 		if err != nil {
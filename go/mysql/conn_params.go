@@ -64,6 +64,17 @@ type ConnParams struct {
 	// FlushDelay is the delay after which buffered response will be flushed to the client.
 	FlushDelay time.Duration
 
+	// CompressionAlgorithms is the comma separated list of protocol
+	// compression algorithms the client can use, in order of preference:
+	// CompressionZlib and/or CompressionZstd, like MySQL's
+	// --compression-algorithms. The first one the server supports is used.
+	// The connection is not compressed if the server supports none of them.
+	CompressionAlgorithms string
+
+	// ZstdCompressionLevel is the compression level to use with zstd.
+	// DefaultZstdCompressionLevel is used if it is not set.
+	ZstdCompressionLevel int
+
 	TruncateErrLen int
 }
 
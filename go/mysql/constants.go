@@ -76,8 +76,10 @@ const (
 	// CLIENT_NO_SCHEMA 1 << 4
 	// Do not permit database.table.column. We do permit it.
 
-	// CLIENT_COMPRESS 1 << 5
-	// We do not support compression. CPU is usually our bottleneck.
+	// CapabilityClientCompress is CLIENT_COMPRESS.
+	// Use the compressed protocol, with zlib. It is only advertised
+	// by the server if it is enabled on the Listener.
+	CapabilityClientCompress = 1 << 5
 
 	// CLIENT_ODBC 1 << 6
 	// No special behavior since 3.22.
@@ -148,6 +150,19 @@ const (
 	// CapabilityClientDeprecateEOF is CLIENT_DEPRECATE_EOF
 	// Expects an OK (instead of EOF) after the resultset rows of a Text Resultset.
 	CapabilityClientDeprecateEOF = 1 << 24
+
+	// CLIENT_OPTIONAL_RESULTSET_METADATA 1 << 25
+	// Not supported.
+
+	// CapabilityClientZstdCompressionAlgorithm is CLIENT_ZSTD_COMPRESSION_ALGORITHM.
+	// Use the compressed protocol, with zstd. The client sends the
+	// compression level it wants at the end of the handshake response.
+	CapabilityClientZstdCompressionAlgorithm = 1 << 26
+
+	// CapabilityClientQueryAttributes is CLIENT_QUERY_ATTRIBUTES.
+	// COM_QUERY and COM_STMT_EXECUTE can carry query attributes,
+	// sent as named parameters.
+	CapabilityClientQueryAttributes = 1 << 27
 )
 
 // Status flags. They are returned by the server in a few cases.
@@ -179,6 +194,28 @@ const (
 	ServerSessionStateChanged uint16 = 0x4000
 )
 
+// Cursor type flags of COM_STMT_EXECUTE.
+// Originally found in include/mysql/mysql_com.h
+// See https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_execute.html
+const (
+	// CursorTypeNoCursor executes the statement without a cursor.
+	CursorTypeNoCursor byte = 0x00
+
+	// CursorTypeReadOnly opens a read only cursor, the rows are then
+	// fetched with COM_STMT_FETCH.
+	CursorTypeReadOnly byte = 0x01
+
+	// CursorTypeForUpdate and CursorTypeScrollable are not supported
+	// by MySQL, and are handled as CursorTypeReadOnly.
+	CursorTypeForUpdate  byte = 0x02
+	CursorTypeScrollable byte = 0x04
+
+	// parameterCountAvailable is PARAMETER_COUNT_AVAILABLE. With
+	// CapabilityClientQueryAttributes, the number of parameters is sent
+	// even if the statement has none, as query attributes follow them.
+	parameterCountAvailable byte = 0x08
+)
+
 // State Change Information
 const (
 	// one or more system variables changed.
@@ -230,12 +267,15 @@ const (
 	// ComStmtReset is COM_STMT_RESET
 	ComStmtReset = 0x1a
 
-	//ComStmtFetch is COM_STMT_FETCH
+	// ComStmtFetch is COM_STMT_FETCH
 	ComStmtFetch = 0x1c
 
 	// ComSetOption is COM_SET_OPTION
 	ComSetOption = 0x1b
 
+	// ComChangeUser is COM_CHANGE_USER
+	ComChangeUser = 0x11
+
 	// ComResetConnection is COM_RESET_CONNECTION
 	ComResetConnection = 0x1f
 
@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/tb"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the server side cursors. When COM_STMT_EXECUTE asks
+// for a cursor, only the fields of the result are returned, and the client
+// then reads the rows in batches with COM_STMT_FETCH:
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_fetch.html
+//
+// The handler streams the result from its own go routine, which only runs
+// while the connection is waiting for the next result, so the handler is
+// never used concurrently with the connection. When the connection needs
+// the handler for another command while cursors are open, the rest of
+// their results is read in memory first, which is what MySQL does too.
+
+var errCursorClosed = vterrors.New(vtrpcpb.Code_CANCELED, "cursor was closed")
+
+// stmtCursor is the result of a statement executed with a cursor.
+type stmtCursor struct {
+	// results receives the results streamed by the handler. It is closed
+	// once the handler returned, and err is set.
+	results chan *sqltypes.Result
+	// resume lets the handler stream its next result.
+	resume chan struct{}
+	// done is closed to abort the stream.
+	done chan struct{}
+	err  error
+
+	// streaming is set until results is closed, and waiting is set
+	// while the handler waits on resume.
+	streaming bool
+	waiting   bool
+
+	fields []*querypb.Field
+	// rows were received from the handler, but not fetched yet.
+	rows [][]sqltypes.Value
+}
+
+// openCursor executes the statement with the handler, in its own go routine.
+func (c *Conn) openCursor(handler Handler, prepare *PrepareData) *stmtCursor {
+	cur := &stmtCursor{
+		results:   make(chan *sqltypes.Result),
+		resume:    make(chan struct{}),
+		done:      make(chan struct{}),
+		streaming: true,
+	}
+	go func() {
+		defer func() {
+			if x := recover(); x != nil {
+				log.Errorf("mysql_server caught panic in cursor:\n%v\n%s", x, tb.Stack(4))
+				cur.err = sqlerror.NewSQLErrorFromError(fmt.Errorf("panic while executing statement: %v", x))
+			}
+			close(cur.results)
+		}()
+		cur.err = handler.ComStmtExecute(c, prepare, func(qr *sqltypes.Result) error {
+			select {
+			case cur.results <- qr:
+			case <-cur.done:
+				return errCursorClosed
+			}
+			select {
+			case <-cur.resume:
+				return nil
+			case <-cur.done:
+				return errCursorClosed
+			}
+		})
+	}()
+	return cur
+}
+
+// next returns the next result streamed by the handler, or nil when
+// the handler is done.
+func (cur *stmtCursor) next() (*sqltypes.Result, error) {
+	if !cur.streaming {
+		return nil, cur.err
+	}
+	if cur.waiting {
+		cur.waiting = false
+		cur.resume <- struct{}{}
+	}
+	qr, ok := <-cur.results
+	if !ok {
+		cur.streaming = false
+		return nil, cur.err
+	}
+	cur.waiting = true
+	return qr, nil
+}
+
+// fetch returns up to n rows, and whether there are no rows left after them.
+func (cur *stmtCursor) fetch(n int) ([][]sqltypes.Value, bool, error) {
+	// We read one more row than needed, so we know if these are the last ones.
+	for len(cur.rows) <= n && cur.streaming {
+		qr, err := cur.next()
+		if err != nil {
+			return nil, false, err
+		}
+		if qr != nil {
+			cur.rows = append(cur.rows, qr.Rows...)
+		}
+	}
+	if cur.err != nil {
+		return nil, false, cur.err
+	}
+
+	n = min(n, len(cur.rows))
+	rows := cur.rows[:n:n]
+	cur.rows = cur.rows[n:]
+	return rows, len(cur.rows) == 0 && !cur.streaming, nil
+}
+
+// materialize reads the rest of the result in memory, so the handler
+// is done with the statement. An error is returned by the next fetch.
+func (cur *stmtCursor) materialize() {
+	for cur.streaming {
+		qr, err := cur.next()
+		if err != nil {
+			return
+		}
+		if qr != nil {
+			cur.rows = append(cur.rows, qr.Rows...)
+		}
+	}
+}
+
+// close aborts the stream if it is still running, and waits for
+// the handler to return.
+func (cur *stmtCursor) close() {
+	if cur.streaming {
+		close(cur.done)
+		for range cur.results {
+		}
+		cur.streaming = false
+	}
+	cur.rows = nil
+}
+
+// closeCursor closes the cursor of a statement, if it has one.
+func (c *Conn) closeCursor(prepare *PrepareData) {
+	if prepare != nil && prepare.cursor != nil {
+		prepare.cursor.close()
+		prepare.cursor = nil
+	}
+}
+
+// closeCursors closes all the cursors of the connection.
+func (c *Conn) closeCursors() {
+	for _, prepare := range c.PrepareData {
+		c.closeCursor(prepare)
+	}
+}
+
+// materializeCursors reads the rest of the results of all the cursors
+// of the connection, before the handler is used for another command.
+func (c *Conn) materializeCursors() {
+	for _, prepare := range c.PrepareData {
+		if prepare.cursor != nil {
+			prepare.cursor.materialize()
+		}
+	}
+}
+
+// executeWithCursor handles a COM_STMT_EXECUTE that asked for a cursor.
+// If the statement returns rows, only the fields are sent back.
+func (c *Conn) executeWithCursor(handler Handler, prepare *PrepareData) bool {
+	queryStart := time.Now()
+	cur := c.openCursor(handler, prepare)
+
+	qr, err := cur.next()
+	if err == nil && qr == nil {
+		// This is just a failsafe. Should never happen.
+		err = sqlerror.NewSQLErrorFromError(errors.New("unexpected: query ended without no results and no error"))
+	}
+	if err != nil {
+		cur.close()
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+
+	if len(qr.Fields) == 0 {
+		// As in MySQL, no cursor is opened for statements that don't
+		// return rows. We let the handler finish on its own, so that
+		// it commits what it has to.
+		for err == nil && cur.streaming {
+			_, err = cur.next()
+		}
+		if err != nil {
+			return c.writeErrorPacketFromErrorAndLog(err)
+		}
+		ok := PacketOK{
+			affectedRows:     qr.RowsAffected,
+			lastInsertID:     qr.InsertID,
+			statusFlags:      c.StatusFlags,
+			sessionStateData: qr.SessionStateChanges,
+		}
+		if err := c.writeOKPacket(&ok); err != nil {
+			log.Errorf("Error writing result to %s: %v", c, err)
+			return false
+		}
+		timings.Record(queryTimingKey, queryStart)
+		return true
+	}
+
+	cur.fields = qr.Fields
+	cur.rows = qr.Rows
+	prepare.cursor = cur
+
+	if err := c.sendColumnCount(uint64(len(qr.Fields))); err != nil {
+		log.Errorf("Error writing result to %s: %v", c, err)
+		return false
+	}
+	for _, field := range qr.Fields {
+		if err := c.writeColumnDefinition(field); err != nil {
+			log.Errorf("Error writing result to %s: %v", c, err)
+			return false
+		}
+	}
+	// This single packet ends the fields, and tells the client
+	// the cursor is open.
+	if err := c.writeEndResultWithFlags(c.StatusFlags|ServerStatusCursorExists, 0, 0, handler.WarningCount(c)); err != nil {
+		log.Errorf("Error writing result to %s: %v", c, err)
+		return false
+	}
+
+	timings.Record(queryTimingKey, queryStart)
+	return true
+}
+
+func (c *Conn) handleComStmtFetch(handler Handler, data []byte) (kontinue bool) {
+	c.startWriterBuffering()
+	defer func() {
+		if err := c.endWriterBuffering(); err != nil {
+			log.Errorf("conn %v: flush() failed: %v", c.ID(), err)
+			kontinue = false
+		}
+	}()
+
+	stmtID, numRows, ok := c.parseComStmtFetch(data)
+	c.recycleReadPacket()
+	if !ok {
+		return c.writeErrorPacketFromErrorAndLog(sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "error parsing statement fetch"))
+	}
+	prepare, ok := c.PrepareData[stmtID]
+	if !ok {
+		return c.writeErrorPacketFromErrorAndLog(sqlerror.NewSQLErrorf(sqlerror.ERUnknownStmtHandler, sqlerror.SSUnknownSQLState, "Unknown prepared statement handler (%v) given to mysqld_stmt_fetch", stmtID))
+	}
+	cur := prepare.cursor
+	if cur == nil {
+		return c.writeErrorPacketFromErrorAndLog(sqlerror.NewSQLErrorf(sqlerror.ERStmtHasNoOpenCursor, sqlerror.SSUnknownSQLState, "The statement (%v) has no open cursor.", stmtID))
+	}
+
+	rows, last, err := cur.fetch(int(numRows))
+	if err != nil {
+		c.closeCursor(prepare)
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+
+	for _, row := range rows {
+		if err := c.writeBinaryRow(cur.fields, row); err != nil {
+			log.Errorf("Error writing result to %s: %v", c, err)
+			return false
+		}
+	}
+
+	flags := c.StatusFlags | ServerStatusCursorExists
+	if last {
+		flags |= ServerStatusLastRowSent
+		c.closeCursor(prepare)
+	}
+	if err := c.writeEndResultWithFlags(flags, 0, 0, handler.WarningCount(c)); err != nil {
+		log.Errorf("Error writing result to %s: %v", c, err)
+		return false
+	}
+	return true
+}
+
+func (c *Conn) parseComStmtFetch(data []byte) (uint32, uint32, bool) {
+	stmtID, pos, ok := readUint32(data, 1)
+	if !ok {
+		return 0, 0, false
+	}
+	numRows, _, ok := readUint32(data, pos)
+	return stmtID, numRows, ok
+}
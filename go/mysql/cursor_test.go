@@ -0,0 +1,339 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// cursorHandler streams batches of rows, and records how many of them
+// were streamed.
+type cursorHandler struct {
+	testRun
+	batches      int
+	rowsPerBatch int
+	err          error
+
+	cursorType byte
+	streamed   int
+	returned   bool
+}
+
+func (h *cursorHandler) ComStmtExecute(c *Conn, prepare *PrepareData, callback func(*sqltypes.Result) error) error {
+	h.cursorType = prepare.CursorType
+	h.streamed = 0
+	h.returned = false
+	defer func() { h.returned = true }()
+
+	if strings.HasPrefix(prepare.PrepareStmt, "insert") {
+		return callback(&sqltypes.Result{RowsAffected: 3})
+	}
+
+	fields := []*querypb.Field{{Name: "id", Type: querypb.Type_INT64, Charset: 63}}
+	if err := callback(&sqltypes.Result{Fields: fields}); err != nil {
+		return err
+	}
+	for i := range h.batches {
+		qr := &sqltypes.Result{}
+		for j := range h.rowsPerBatch {
+			qr.Rows = append(qr.Rows, []sqltypes.Value{sqltypes.NewInt64(int64(i*h.rowsPerBatch + j))})
+		}
+		if err := callback(qr); err != nil {
+			return err
+		}
+		h.streamed++
+	}
+	return h.err
+}
+
+func writeComStmtExecute(t *testing.T, cConn *Conn, stmtID uint32, cursorType byte) {
+	data := make([]byte, packetHeaderSize+10)
+	pos := writeByte(data, packetHeaderSize, ComStmtExecute)
+	pos = writeUint32(data, pos, stmtID)
+	pos = writeByte(data, pos, cursorType)
+	writeUint32(data, pos, 1)
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(data))
+}
+
+func writeComStmtFetch(t *testing.T, cConn *Conn, stmtID uint32, numRows uint32) {
+	data := make([]byte, packetHeaderSize+9)
+	pos := writeByte(data, packetHeaderSize, ComStmtFetch)
+	pos = writeUint32(data, pos, stmtID)
+	writeUint32(data, pos, numRows)
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(data))
+}
+
+// readFetchedRows reads binary rows up to the EOF packet, and returns
+// the values of their single int64 column, along with the status flags.
+func readFetchedRows(t *testing.T, cConn *Conn) ([]int64, uint16) {
+	var ids []int64
+	for {
+		data, err := cConn.ReadPacket()
+		require.NoError(t, err)
+		if isErrorPacket(data) {
+			require.NoError(t, ParseErrorPacket(data))
+		}
+		if cConn.isEOFPacket(data) {
+			_, flags, err := parseEOFPacket(data)
+			require.NoError(t, err)
+			return ids, flags
+		}
+		// A binary row has a 0x00 header and a null bitmap of one byte.
+		require.Len(t, data, 10)
+		ids = append(ids, int64(binary.LittleEndian.Uint64(data[2:])))
+	}
+}
+
+func TestCursorFetch(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	handler := &cursorHandler{batches: 4, rowsPerBatch: 3}
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "select id from t"}
+
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.Equal(t, CursorTypeReadOnly, handler.cursorType)
+
+	// Only the fields are returned, the rows have to be fetched.
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	assert.EqualValues(t, []byte{1}, data)
+	field := &querypb.Field{}
+	require.NoError(t, cConn.readColumnDefinition(field, 0))
+	assert.Equal(t, "id", field.Name)
+	ids, flags := readFetchedRows(t, cConn)
+	assert.Empty(t, ids)
+	assert.Equal(t, ServerStatusCursorExists, flags&(ServerStatusCursorExists|ServerStatusLastRowSent))
+	assert.Zero(t, handler.streamed)
+
+	// The result is streamed as the rows are fetched.
+	var fetched []int64
+	for _, numRows := range []uint32{2, 5, 4} {
+		writeComStmtFetch(t, cConn, 1, numRows)
+		require.True(t, sConn.handleNextCommand(handler))
+		ids, flags := readFetchedRows(t, cConn)
+		assert.Len(t, ids, int(numRows))
+		assert.Equal(t, ServerStatusCursorExists, flags&(ServerStatusCursorExists|ServerStatusLastRowSent))
+		fetched = append(fetched, ids...)
+	}
+	// The last batch was received, but the handler didn't get to return yet.
+	assert.Equal(t, 3, handler.streamed)
+	assert.False(t, handler.returned)
+
+	writeComStmtFetch(t, cConn, 1, 10)
+	require.True(t, sConn.handleNextCommand(handler))
+	ids, flags = readFetchedRows(t, cConn)
+	assert.Len(t, ids, 1)
+	assert.Equal(t, ServerStatusCursorExists|ServerStatusLastRowSent, flags&(ServerStatusCursorExists|ServerStatusLastRowSent))
+	fetched = append(fetched, ids...)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, fetched)
+	assert.True(t, handler.returned)
+
+	// The cursor is closed after the last row.
+	writeComStmtFetch(t, cConn, 1, 10)
+	require.True(t, sConn.handleNextCommand(handler))
+	data, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	require.True(t, isErrorPacket(data))
+	assert.ErrorContains(t, ParseErrorPacket(data), "The statement (1) has no open cursor. (errno 1421)")
+
+	writeComStmtFetch(t, cConn, 2, 10)
+	require.True(t, sConn.handleNextCommand(handler))
+	data, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	require.True(t, isErrorPacket(data))
+	assert.ErrorContains(t, ParseErrorPacket(data), "Unknown prepared statement handler (2) given to mysqld_stmt_fetch (errno 1243)")
+}
+
+func TestCursorFetchAll(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// The last batch is exactly the size of the fetch, so we only know
+	// it is the last one when the handler returns.
+	handler := &cursorHandler{batches: 2, rowsPerBatch: 5}
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "select id from t"}
+
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	_, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.NoError(t, cConn.readColumnDefinition(&querypb.Field{}, 0))
+	readFetchedRows(t, cConn)
+
+	writeComStmtFetch(t, cConn, 1, 10)
+	require.True(t, sConn.handleNextCommand(handler))
+	ids, flags := readFetchedRows(t, cConn)
+	assert.Len(t, ids, 10)
+	assert.Equal(t, ServerStatusCursorExists|ServerStatusLastRowSent, flags&(ServerStatusCursorExists|ServerStatusLastRowSent))
+}
+
+func TestCursorWithoutRows(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	handler := &cursorHandler{}
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "insert into t values (1), (2), (3)"}
+
+	// No cursor is opened for statements that don't return rows.
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.True(t, handler.returned)
+	assert.Nil(t, sConn.PrepareData[1].cursor)
+
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	var packetOK PacketOK
+	require.NoError(t, cConn.parseOKPacket(&packetOK, data))
+	assert.EqualValues(t, 3, packetOK.affectedRows)
+}
+
+func TestCursorClose(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	handler := &cursorHandler{batches: 100, rowsPerBatch: 10}
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "select id from t"}
+
+	openCursor := func() {
+		writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+		require.True(t, sConn.handleNextCommand(handler))
+		_, err := cConn.ReadPacket()
+		require.NoError(t, err)
+		require.NoError(t, cConn.readColumnDefinition(&querypb.Field{}, 0))
+		readFetchedRows(t, cConn)
+		require.NotNil(t, sConn.PrepareData[1].cursor)
+	}
+
+	// Executing the statement again closes its cursor.
+	openCursor()
+	cur := sConn.PrepareData[1].cursor
+	openCursor()
+	assert.False(t, cur.streaming)
+	assert.NotSame(t, cur, sConn.PrepareData[1].cursor)
+
+	// So does resetting it, which stops the stream.
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket([]byte{0, 0, 0, 0, ComStmtReset, 1, 0, 0, 0}))
+	require.True(t, sConn.handleNextCommand(handler))
+	_, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	assert.Nil(t, sConn.PrepareData[1].cursor)
+	assert.True(t, handler.returned)
+	assert.Less(t, handler.streamed, 100)
+
+	// And closing the statement.
+	openCursor()
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket([]byte{0, 0, 0, 0, ComStmtClose, 1, 0, 0, 0}))
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.True(t, handler.returned)
+	assert.NotContains(t, sConn.PrepareData, uint32(1))
+}
+
+func TestCursorMaterialize(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	handler := &cursorHandler{batches: 3, rowsPerBatch: 2, err: sqlerror.NewSQLError(sqlerror.ERQueryInterrupted, sqlerror.SSUnknownSQLState, "stream failed")}
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "select id from t"}
+
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	_, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.NoError(t, cConn.readColumnDefinition(&querypb.Field{}, 0))
+	readFetchedRows(t, cConn)
+
+	// Another command needs the handler, so the rest of the result is
+	// read before it runs.
+	require.NoError(t, cConn.WriteComQuery("select 1"))
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.True(t, handler.returned)
+	assert.Equal(t, 3, handler.streamed)
+	_, _, _, err = cConn.ReadQueryResult(10, false)
+	require.NoError(t, err)
+
+	// The error of the stream is returned by the next fetch.
+	writeComStmtFetch(t, cConn, 1, 1)
+	require.True(t, sConn.handleNextCommand(handler))
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.True(t, isErrorPacket(data))
+	assert.ErrorContains(t, ParseErrorPacket(data), "stream failed")
+	assert.Nil(t, sConn.PrepareData[1].cursor)
+}
+
+func TestCursorPanic(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// The handler runs in its own go routine, and a panic is returned
+	// to the client as an error.
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "select id from t"}
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(&panicHandler{}))
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.True(t, isErrorPacket(data))
+	assert.ErrorContains(t, ParseErrorPacket(data), "panic while executing statement: test panic")
+	assert.Nil(t, sConn.PrepareData[1].cursor)
+}
+
+type panicHandler struct {
+	testRun
+}
+
+func (h *panicHandler) ComStmtExecute(c *Conn, prepare *PrepareData, callback func(*sqltypes.Result) error) error {
+	panic("test panic")
+}
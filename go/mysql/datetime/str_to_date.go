@@ -0,0 +1,334 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"strings"
+	"time"
+)
+
+// STR_TO_DATE is much more lenient than the parsers for the formats of
+// DATE_FORMAT, so it has its own implementation, which follows the one
+// in MySQL (`extract_date_time` in sql/item_timefunc.cc) closely.
+
+// MySQL's day names start on Monday, so the weekdays parsed by
+// STR_TO_DATE are numbered from 1 (Monday) to 7 (Sunday).
+var (
+	strToDateDayNames      = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+	strToDateShortDayNames = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	strToDateMonthNames    []string
+)
+
+func init() {
+	for m := time.January; m <= time.December; m++ {
+		strToDateMonthNames = append(strToDateMonthNames, m.String())
+	}
+}
+
+// StrToDateParts returns which parts of a temporal value can be set by
+// the given STR_TO_DATE format: its date, its time and its fractional
+// seconds. MySQL uses these to decide the type of the result.
+func StrToDateParts(format string) (date, time, frac bool) {
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'a', 'b', 'c', 'D', 'd', 'e', 'j', 'M', 'm', 'U', 'u', 'V', 'v', 'W', 'w', 'X', 'x', 'Y', 'y':
+			date = true
+		case 'f':
+			time = true
+			frac = true
+		case 'H', 'h', 'I', 'i', 'k', 'l', 'p', 'r', 'S', 's', 'T':
+			time = true
+		}
+	}
+	return
+}
+
+type strToDateState struct {
+	tp timeparts
+
+	weekday int
+	yearday int
+	daypart int
+	usaTime bool
+
+	week            int
+	weekSundayFirst bool
+	weekStrict      bool
+	weekYear        int
+	weekYearSunday  bool
+}
+
+// StrToDate parses the given string with a format that uses the same
+// specifiers as DATE_FORMAT, the way MySQL's STR_TO_DATE does: spaces
+// before each value are skipped, numbers can be shorter than their
+// width, and anything left after the format was matched is ignored.
+// The date and the time of the result are always valid, but they can
+// contain zero parts.
+func StrToDate(format, s string) (DateTime, bool) {
+	st := strToDateState{week: -1, weekYear: -1}
+	if _, ok := st.parse(format, s); !ok {
+		return DateTime{}, false
+	}
+	return st.finish()
+}
+
+// readNum reads a number of up to width digits at the start of s.
+func readNum(s string, width int) (int, string, bool) {
+	var n, i int
+	for i < width && i < len(s) && isDigit(s, i) {
+		n = n*10 + int(s[i]-'0')
+		i++
+	}
+	return n, s[i:], i > 0
+}
+
+// readWord reads the word at the start of s and returns the position
+// of the name it matches in names, plus one. Like in MySQL, a word can
+// be an unambiguous prefix of one of the names.
+func readWord(names []string, s string) (int, string, bool) {
+	end := 0
+	for end < len(s) && isAlpha(s[end]) {
+		end++
+	}
+	if end == 0 {
+		return 0, s, false
+	}
+	word := s[:end]
+	found := 0
+	for i, name := range names {
+		if len(word) > len(name) || !strings.EqualFold(word, name[:len(word)]) {
+			continue
+		}
+		if len(word) == len(name) {
+			return i + 1, s[end:], true
+		}
+		if found != 0 {
+			return 0, s, false
+		}
+		found = i + 1
+	}
+	return found, s[end:], found != 0
+}
+
+func isAlpha(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+func year2000(year int) int {
+	if year < 70 {
+		return year + 2000
+	}
+	return year + 1900
+}
+
+func skipSpaces(s string) string {
+	for len(s) > 0 && isSpace(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
+
+func (st *strToDateState) parse(format, s string) (string, bool) {
+	var ok bool
+	for len(format) > 0 {
+		if s = skipSpaces(s); len(s) == 0 {
+			break
+		}
+
+		if format[0] != '%' || len(format) == 1 {
+			if !isSpace(format[0]) {
+				if s[0] != format[0] {
+					return s, false
+				}
+				s = s[1:]
+			}
+			format = format[1:]
+			continue
+		}
+
+		spec := format[1]
+		format = format[2:]
+		tp := &st.tp
+
+		switch spec {
+		case 'Y':
+			l := len(s)
+			tp.year, s, ok = readNum(s, 4)
+			if ok && l-len(s) <= 2 {
+				tp.year = year2000(tp.year)
+			}
+		case 'y':
+			tp.year, s, ok = readNum(s, 2)
+			tp.year = year2000(tp.year)
+		case 'm', 'c':
+			tp.month, s, ok = readNum(s, 2)
+		case 'M':
+			tp.month, s, ok = readWord(strToDateMonthNames, s)
+		case 'b':
+			tp.month, s, ok = readWord(shortMonthNames, s)
+		case 'd', 'e':
+			tp.day, s, ok = readNum(s, 2)
+		case 'D':
+			tp.day, s, ok = readNum(s, 2)
+			// skip the suffix of the day: st, nd, rd, th
+			s = s[min(len(s), 2):]
+		case 'h', 'I', 'l':
+			st.usaTime = true
+			tp.hour, s, ok = readNum(s, 2)
+		case 'k', 'H':
+			tp.hour, s, ok = readNum(s, 2)
+		case 'i':
+			tp.min, s, ok = readNum(s, 2)
+		case 's', 'S':
+			tp.sec, s, ok = readNum(s, 2)
+		case 'f':
+			l := len(s)
+			tp.nsec, s, ok = readNum(s, 6)
+			for n := l - len(s); n < 6; n++ {
+				tp.nsec *= 10
+			}
+			tp.nsec *= 1000
+		case 'p':
+			if len(s) < 2 || !st.usaTime {
+				return s, false
+			}
+			switch {
+			case strings.EqualFold(s[:2], "PM"):
+				st.daypart = 12
+			case strings.EqualFold(s[:2], "AM"):
+			default:
+				return s, false
+			}
+			s, ok = s[2:], true
+		case 'W':
+			st.weekday, s, ok = readWord(strToDateDayNames, s)
+		case 'a':
+			st.weekday, s, ok = readWord(strToDateShortDayNames, s)
+		case 'w':
+			st.weekday, s, ok = readNum(s, 1)
+			if !ok || st.weekday >= 7 {
+				return s, false
+			}
+			// %w counts from Sunday, like %a and %W we want Sunday to be 7
+			if st.weekday == 0 {
+				st.weekday = 7
+			}
+		case 'j':
+			st.yearday, s, ok = readNum(s, 3)
+		case 'U', 'u', 'V', 'v':
+			st.weekSundayFirst = spec == 'U' || spec == 'V'
+			st.weekStrict = spec == 'V' || spec == 'v'
+			st.week, s, ok = readNum(s, 2)
+			if !ok || (st.weekStrict && st.week == 0) || st.week > 53 {
+				return s, false
+			}
+		case 'X', 'x':
+			st.weekYearSunday = spec == 'X'
+			st.weekYear, s, ok = readNum(s, 4)
+		case 'r':
+			s, ok = st.parse("%I:%i:%S %p", s)
+		case 'T':
+			s, ok = st.parse("%H:%i:%S", s)
+		case '.':
+			for len(s) > 0 && isSeparator(s[0]) {
+				s = s[1:]
+			}
+			ok = true
+		case '@':
+			for len(s) > 0 && isAlpha(s[0]) {
+				s = s[1:]
+			}
+			ok = true
+		case '#':
+			for len(s) > 0 && isDigit(s, 0) {
+				s = s[1:]
+			}
+			ok = true
+		default:
+			return s, false
+		}
+		if !ok {
+			return s, false
+		}
+	}
+	return s, true
+}
+
+func (st *strToDateState) finish() (DateTime, bool) {
+	tp := &st.tp
+
+	if st.usaTime {
+		if tp.hour > 12 || tp.hour < 1 {
+			return DateTime{}, false
+		}
+		tp.hour = tp.hour%12 + st.daypart
+	}
+
+	if st.yearday > 0 {
+		days := MysqlDayNumber(tp.year, 1, 1) + st.yearday - 1
+		y, m, d := mysqlDateFromDayNumber(days)
+		tp.year, tp.month, tp.day = int(y), int(m), int(d)
+	}
+
+	if st.week >= 0 && st.weekday > 0 {
+		// %V and %v need %X and %x respectively, while %U and %u
+		// must be used with %Y and not with %X or %x.
+		if st.weekStrict && (st.weekYear < 0 || st.weekYearSunday != st.weekSundayFirst) {
+			return DateTime{}, false
+		}
+		if !st.weekStrict && st.weekYear >= 0 {
+			return DateTime{}, false
+		}
+
+		year := tp.year
+		if st.weekStrict {
+			year = st.weekYear
+		}
+		days := MysqlDayNumber(year, 1, 1)
+		if st.weekSundayFirst {
+			firstDay := (days + 6) % 7
+			if firstDay != 0 {
+				days += 7
+			}
+			days += -firstDay + (st.week-1)*7 + st.weekday%7
+		} else {
+			firstDay := (days + 5) % 7
+			if firstDay > 3 {
+				days += 7
+			}
+			days += -firstDay + (st.week-1)*7 + st.weekday - 1
+		}
+		y, m, d := mysqlDateFromDayNumber(days)
+		tp.year, tp.month, tp.day = int(y), int(m), int(d)
+	}
+
+	if tp.month > 12 || tp.day > 31 || tp.hour > 23 || tp.min > 59 || tp.sec > 59 {
+		return DateTime{}, false
+	}
+	if tp.month > 0 && tp.day > daysIn(time.Month(tp.month), tp.year) {
+		return DateTime{}, false
+	}
+
+	return DateTime{
+		Date: Date{year: uint16(tp.year), month: uint8(tp.month), day: uint8(tp.day)},
+		Time: Time{hour: uint16(tp.hour), minute: uint8(tp.min), second: uint8(tp.sec), nanosecond: uint32(tp.nsec)},
+	}, true
+}
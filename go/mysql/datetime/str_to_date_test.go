@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrToDate(t *testing.T) {
+	testCases := []struct {
+		format string
+		input  string
+		ok     bool
+		want   string
+	}{
+		{format: "%d,%m,%Y", input: "01,5,2013", ok: true, want: "2013-05-01 00:00:00"},
+		{format: "%M %d,%Y", input: "May 1, 2013", ok: true, want: "2013-05-01 00:00:00"},
+		{format: "%b %e %y", input: "sep 7 69", ok: true, want: "2069-09-07 00:00:00"},
+		{format: "%M %Y", input: "Ju 2013", ok: false},
+		{format: "%M %Y", input: "Jul 2013", ok: true, want: "2013-07-00 00:00:00"},
+		{format: "a%h:%i:%s", input: "a09:30:17", ok: true, want: "0000-00-00 09:30:17"},
+		{format: "%h:%i:%s", input: "a09:30:17", ok: false},
+		{format: "%h:%i:%s", input: "09:30:17a", ok: true, want: "0000-00-00 09:30:17"},
+		{format: "%r", input: "12:05:00 AM", ok: true, want: "0000-00-00 00:05:00"},
+		{format: "%r", input: "10:05:00 pm", ok: true, want: "0000-00-00 22:05:00"},
+		{format: "%H %p", input: "10 PM", ok: false},
+		{format: "%T.%f", input: "10:05:00.25", ok: true, want: "0000-00-00 10:05:00.250000"},
+		{format: "%Y-%m-%d", input: "2004-02-30", ok: false},
+		{format: "%Y-%m-%d", input: "2004-13-01", ok: false},
+		{format: "%Y %j", input: "2013 032", ok: true, want: "2013-02-01 00:00:00"},
+		{format: "%D %b %Y", input: "1st May 2013", ok: true, want: "2013-05-01 00:00:00"},
+		{format: "%a %x %v", input: "Tue 2013 18", ok: true, want: "2013-04-30 00:00:00"},
+		{format: "%a %X %v", input: "Tue 2013 18", ok: false},
+		{format: "%w %Y %U", input: "2 2013 18", ok: true, want: "2013-05-07 00:00:00"},
+		{format: "%Y%.%m%#%d", input: "2013//05123401", ok: true, want: "2013-05-00 00:00:00"},
+		{format: "%Y%.%m%@%d", input: "2013//05abc01", ok: true, want: "2013-05-01 00:00:00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format+" "+tc.input, func(t *testing.T) {
+			dt, ok := StrToDate(tc.format, tc.input)
+			assert.Equal(t, tc.ok, ok)
+			if tc.ok {
+				prec := uint8(0)
+				if dt.Time.Nanosecond() != 0 {
+					prec = 6
+				}
+				assert.Equal(t, tc.want, string(dt.Format(prec)))
+			}
+		})
+	}
+}
+
+func TestStrToDateParts(t *testing.T) {
+	date, time, frac := StrToDateParts("%Y-%m-%d")
+	assert.Equal(t, []bool{true, false, false}, []bool{date, time, frac})
+
+	date, time, frac = StrToDateParts("%H:%i:%s.%f")
+	assert.Equal(t, []bool{false, true, true}, []bool{date, time, frac})
+
+	date, time, frac = StrToDateParts("%Y-%m-%d %T %%")
+	assert.Equal(t, []bool{true, true, false}, []bool{date, time, frac})
+}
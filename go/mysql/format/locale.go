@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package format
+
+import "strings"
+
+// Locale is the numeric part of one of the locales supported by MySQL,
+// which is what the FORMAT() function uses.
+// See `sql/sql_locale.cc` in MySQL.
+type Locale struct {
+	Name         string
+	DecimalPoint byte
+	ThousandsSep byte
+	// Grouping has the number of digits in each group of the integer
+	// part, starting at the decimal point. The last group repeats. The
+	// digits are not grouped at all when it's empty.
+	Grouping []int
+}
+
+var (
+	groupingNone      []int
+	groupingThousands = []int{3, 3}
+	groupingIndian    = []int{3, 2}
+)
+
+// LocaleEnUS is the default locale, used when a locale is unknown.
+var LocaleEnUS = &Locale{Name: "en_US", DecimalPoint: '.', ThousandsSep: ',', Grouping: groupingThousands}
+
+var locales = map[string]*Locale{}
+
+func init() {
+	for _, l := range []*Locale{
+		{"ar_AE", '.', ',', groupingThousands},
+		{"ar_BH", '.', ',', groupingThousands},
+		{"ar_DZ", '.', ',', groupingThousands},
+		{"ar_EG", '.', ',', groupingThousands},
+		{"ar_IN", '.', ',', groupingThousands},
+		{"ar_IQ", '.', ',', groupingThousands},
+		{"ar_JO", '.', ',', groupingThousands},
+		{"ar_KW", '.', ',', groupingThousands},
+		{"ar_LB", '.', ',', groupingThousands},
+		{"ar_LY", '.', ',', groupingThousands},
+		{"ar_MA", '.', ',', groupingThousands},
+		{"ar_OM", '.', ',', groupingThousands},
+		{"ar_QA", '.', ',', groupingThousands},
+		{"ar_SA", '.', 0, groupingNone},
+		{"ar_SD", '.', ',', groupingThousands},
+		{"ar_SY", '.', ',', groupingThousands},
+		{"ar_TN", '.', ',', groupingThousands},
+		{"ar_YE", '.', ',', groupingThousands},
+		{"be_BY", ',', '.', groupingThousands},
+		{"bg_BG", ',', 0, groupingNone},
+		{"ca_ES", ',', 0, groupingNone},
+		{"cs_CZ", ',', ' ', groupingThousands},
+		{"da_DK", ',', '.', groupingThousands},
+		{"de_AT", ',', 0, groupingNone},
+		{"de_BE", ',', '.', groupingThousands},
+		{"de_CH", '.', '\'', groupingThousands},
+		{"de_DE", ',', '.', groupingThousands},
+		{"de_LU", ',', '.', groupingThousands},
+		{"el_GR", ',', '.', groupingNone},
+		{"en_AU", '.', ',', groupingThousands},
+		{"en_CA", '.', ',', groupingThousands},
+		{"en_GB", '.', ',', groupingThousands},
+		{"en_IN", '.', ',', groupingIndian},
+		{"en_NZ", '.', ',', groupingThousands},
+		{"en_PH", '.', ',', groupingThousands},
+		LocaleEnUS,
+		{"en_ZA", '.', ',', groupingThousands},
+		{"en_ZW", '.', ',', groupingThousands},
+		{"es_AR", ',', '.', groupingThousands},
+		{"es_BO", ',', 0, groupingNone},
+		{"es_CL", ',', 0, groupingNone},
+		{"es_CO", ',', 0, groupingNone},
+		{"es_CR", '.', 0, groupingNone},
+		{"es_DO", '.', ',', groupingThousands},
+		{"es_EC", ',', 0, groupingNone},
+		{"es_ES", ',', 0, groupingNone},
+		{"es_GT", '.', ',', groupingThousands},
+		{"es_HN", '.', ',', groupingThousands},
+		{"es_MX", '.', ',', groupingThousands},
+		{"es_NI", '.', ',', groupingThousands},
+		{"es_PA", '.', ',', groupingThousands},
+		{"es_PE", ',', 0, groupingNone},
+		{"es_PR", '.', ',', groupingThousands},
+		{"es_PY", ',', 0, groupingNone},
+		{"es_SV", '.', ',', groupingThousands},
+		{"es_US", '.', ',', groupingThousands},
+		{"es_UY", ',', 0, groupingNone},
+		{"es_VE", ',', 0, groupingNone},
+		{"et_EE", ',', ' ', groupingThousands},
+		{"eu_ES", ',', 0, groupingNone},
+		{"fi_FI", ',', ' ', groupingThousands},
+		{"fo_FO", ',', '.', groupingThousands},
+		{"fr_BE", ',', 0, groupingNone},
+		{"fr_CA", ',', 0, groupingNone},
+		{"fr_CH", ',', 0, groupingNone},
+		{"fr_FR", ',', 0, groupingNone},
+		{"fr_LU", ',', 0, groupingNone},
+		{"gl_ES", ',', 0, groupingNone},
+		{"gu_IN", '.', ',', groupingIndian},
+		{"he_IL", '.', ',', groupingThousands},
+		{"hi_IN", '.', ',', groupingIndian},
+		{"hr_HR", ',', 0, groupingNone},
+		{"hu_HU", ',', '.', groupingThousands},
+		{"id_ID", ',', '.', groupingThousands},
+		{"is_IS", ',', '.', groupingThousands},
+		{"it_CH", ',', '\'', groupingThousands},
+		{"it_IT", ',', 0, groupingNone},
+		{"ja_JP", '.', ',', groupingThousands},
+		{"ko_KR", '.', ',', groupingThousands},
+		{"lt_LT", ',', '.', groupingThousands},
+		{"lv_LV", ',', ' ', groupingThousands},
+		{"mk_MK", ',', ' ', groupingThousands},
+		{"mn_MN", ',', '.', groupingThousands},
+		{"ms_MY", '.', ',', groupingThousands},
+		{"nb_NO", ',', '.', groupingThousands},
+		{"nl_BE", ',', '.', groupingThousands},
+		{"nl_NL", ',', 0, groupingNone},
+		{"no_NO", ',', '.', groupingThousands},
+		{"pl_PL", ',', 0, groupingNone},
+		{"pt_BR", ',', 0, groupingNone},
+		{"pt_PT", ',', 0, groupingNone},
+		{"rm_CH", ',', '\'', groupingThousands},
+		{"ro_RO", ',', '.', groupingThousands},
+		{"ru_RU", ',', ' ', groupingThousands},
+		{"ru_UA", ',', '.', groupingThousands},
+		{"sk_SK", ',', ' ', groupingThousands},
+		{"sl_SI", ',', 0, groupingNone},
+		{"sq_AL", ',', '.', groupingThousands},
+		{"sr_RS", '.', 0, groupingNone},
+		{"sv_FI", ',', ' ', groupingThousands},
+		{"sv_SE", ',', ' ', groupingThousands},
+		{"ta_IN", '.', ',', groupingIndian},
+		{"te_IN", '.', ',', groupingIndian},
+		{"th_TH", '.', ',', groupingThousands},
+		{"tr_TR", ',', '.', groupingThousands},
+		{"uk_UA", ',', '.', groupingThousands},
+		{"ur_PK", '.', ',', groupingThousands},
+		{"vi_VN", ',', '.', groupingThousands},
+		{"zh_CN", '.', ',', groupingThousands},
+		{"zh_HK", '.', ',', groupingThousands},
+		{"zh_TW", '.', ',', groupingThousands},
+	} {
+		locales[strings.ToLower(l.Name)] = l
+	}
+}
+
+// LookupLocale returns the locale with the given name, which is case
+// insensitive like in MySQL, or nil if MySQL doesn't know about it.
+func LookupLocale(name string) *Locale {
+	return locales[strings.ToLower(name)]
+}
+
+// AppendNumber appends the number in num, formatted for the locale, to
+// dst. The number must be formatted like strconv.FormatFloat does with
+// the 'f' format, with dec digits after the decimal point.
+func (l *Locale) AppendNumber(dst, num []byte, dec int) []byte {
+	decLength := 0
+	if dec > 0 {
+		decLength = dec + 1
+	}
+	intEnd := len(num) - decLength
+
+	// Like MySQL, numbers that are too short to be grouped keep their
+	// sign in the length that is checked here.
+	if len(l.Grouping) == 0 || len(num) < decLength+1+l.Grouping[0] {
+		dst = append(dst, num[:intEnd]...)
+		if decLength > 0 {
+			dst = append(dst, l.DecimalPoint)
+			dst = append(dst, num[intEnd+1:]...)
+		}
+		return dst
+	}
+
+	start := 0
+	if num[0] == '-' {
+		dst = append(dst, '-')
+		start = 1
+	}
+
+	digits := intEnd - start
+	grouping := l.Grouping
+	// Split the integer digits in groups, starting at the decimal point.
+	var sizes []int
+	for g, count := 0, grouping[0]; digits > 0; {
+		n := min(count, digits)
+		sizes = append(sizes, n)
+		digits -= n
+		if g+1 < len(grouping) {
+			g++
+		}
+		count = grouping[g]
+	}
+	pos := start
+	for i := len(sizes) - 1; i >= 0; i-- {
+		dst = append(dst, num[pos:pos+sizes[i]]...)
+		pos += sizes[i]
+		if i > 0 {
+			dst = append(dst, l.ThousandsSep)
+		}
+	}
+
+	if decLength > 0 {
+		dst = append(dst, l.DecimalPoint)
+		dst = append(dst, num[intEnd+1:]...)
+	}
+	return dst
+}
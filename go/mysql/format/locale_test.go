@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleAppendNumber(t *testing.T) {
+	testCases := []struct {
+		locale string
+		num    string
+		dec    int
+		want   string
+	}{
+		{"en_US", "1234567.891", 3, "1,234,567.891"},
+		{"en_US", "-1234567", 0, "-1,234,567"},
+		{"en_US", "-123", 0, "-123"},
+		{"en_US", "123.45", 2, "123.45"},
+		{"EN_us", "1000", 0, "1,000"},
+		{"de_DE", "1234567.89", 2, "1.234.567,89"},
+		{"de_DE", "12.5", 1, "12,5"},
+		{"de_CH", "1234567.89", 2, "1'234'567.89"},
+		{"fr_FR", "1234567.89", 2, "1234567,89"},
+		{"en_IN", "123456789.5", 1, "12,34,56,789.5"},
+		{"ar_SA", "1234567.5", 1, "1234567.5"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale+"/"+tc.num, func(t *testing.T) {
+			l := LookupLocale(tc.locale)
+			if assert.NotNil(t, l) {
+				assert.Equal(t, tc.want, string(l.AppendNumber(nil, []byte(tc.num), tc.dec)))
+			}
+		})
+	}
+
+	assert.Nil(t, LookupLocale("xx_XX"))
+}
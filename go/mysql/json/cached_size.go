@@ -36,6 +36,20 @@ func (cached *Object) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *Path) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field name string
+	size += hack.RuntimeAllocSize(int64(len(cached.name)))
+	// field next *vitess.io/vitess/go/mysql/json.Path
+	size += cached.next.CachedSize(true)
+	return size
+}
 func (cached *Value) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -140,3 +140,25 @@ func (v *Value) Len() int {
 		return 1
 	}
 }
+
+// Clone returns a deep copy of the arrays and objects in v, so that they
+// can be modified without changing v. Scalars are never modified, so they
+// are shared with v.
+func (v *Value) Clone() *Value {
+	switch v.t {
+	case TypeArray:
+		a := make([]*Value, 0, len(v.a))
+		for _, item := range v.a {
+			a = append(a, item.Clone())
+		}
+		return &Value{a: a, t: TypeArray}
+	case TypeObject:
+		kvs := make([]kv, 0, len(v.o.kvs))
+		for _, item := range v.o.kvs {
+			kvs = append(kvs, kv{item.k, item.v.Clone()})
+		}
+		return &Value{o: Object{kvs: kvs}, t: TypeObject}
+	default:
+		return v
+	}
+}
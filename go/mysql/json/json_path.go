@@ -217,20 +217,117 @@ func (jp *Path) Match(doc *Value, wrap bool, match func(value *Value)) {
 	m.value(jp, doc)
 }
 
-func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
+type searcher struct {
+	targets map[*Value]struct{}
+	seen    map[string]struct{}
+	match   func(s string) bool
+	one     bool
+	found   []string
+}
+
+func appendMemberLeg(loc []byte, name string) []byte {
+	loc = append(loc, '.')
+	if jpIsIdentifier(name) {
+		return append(loc, name...)
+	}
+	return escapeString(loc, name)
+}
+
+// search looks for matching strings in v, and returns whether it's done.
+func (s *searcher) search(v *Value, loc []byte) bool {
+	switch v.Type() {
+	case TypeString:
+		if s.match(v.s) {
+			if _, seen := s.seen[string(loc)]; !seen {
+				s.seen[string(loc)] = struct{}{}
+				s.found = append(s.found, string(loc))
+			}
+			return s.one
+		}
+	case TypeArray:
+		for i, item := range v.a {
+			if s.search(item, fmt.Appendf(loc, "[%d]", i)) {
+				return true
+			}
+		}
+	case TypeObject:
+		for _, item := range v.o.kvs {
+			if s.search(item.v, appendMemberLeg(loc, item.k)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// visit walks v until it finds the values that are searched.
+func (s *searcher) visit(v *Value, loc []byte) bool {
+	if _, ok := s.targets[v]; ok {
+		return s.search(v, loc)
+	}
+	switch v.t {
+	case TypeArray:
+		for i, item := range v.a {
+			if s.visit(item, fmt.Appendf(loc, "[%d]", i)) {
+				return true
+			}
+		}
+	case TypeObject:
+		for _, item := range v.o.kvs {
+			if s.visit(item.v, appendMemberLeg(loc, item.k)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Search returns the locations of the strings in doc for which match returns
+// true, as path expressions, the way JSON_SEARCH does in MySQL. If paths are
+// given, only the values they point to are searched. If one is set, only the
+// first location that is found is returned.
+func Search(doc *Value, paths []*Path, one bool, match func(s string) bool) []string {
+	s := searcher{
+		seen:  make(map[string]struct{}),
+		match: match,
+		one:   one,
+	}
+	loc := []byte{'$'}
+	if len(paths) == 0 {
+		s.search(doc, loc)
+		return s.found
+	}
+	for _, p := range paths {
+		s.targets = make(map[*Value]struct{})
+		p.Match(doc, true, func(v *Value) {
+			s.targets[v] = struct{}{}
+		})
+		if s.visit(doc, loc) {
+			break
+		}
+	}
+	return s.found
+}
+
+// transform calls t with the value that contains the location the last leg
+// of the path points to, and with a function that replaces that value in
+// the document.
+func (jp *Path) transform(v *Value, replace func(*Value), t func(pp *Path, vv *Value, replace func(*Value))) {
 	if v == nil {
 		return
 	}
 	if jp.next == nil {
-		t(jp, v)
+		t(jp, v, replace)
 		return
 	}
 	switch jp.kind {
 	case jpDocumentRoot:
-		jp.next.transform(v, t)
+		jp.next.transform(v, replace, t)
 	case jpMember:
 		if obj, ok := v.Object(); ok {
-			jp.next.transform(obj.Get(jp.name), t)
+			jp.next.transform(obj.Get(jp.name), func(nv *Value) {
+				obj.Set(jp.name, nv, Set)
+			}, t)
 		}
 	case jpArrayLocation:
 		if ary, ok := v.Array(); ok {
@@ -239,7 +336,9 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				panic("range in transformation path expression")
 			}
 			if from >= 0 && from < len(ary) {
-				jp.next.transform(ary[from], t)
+				jp.next.transform(ary[from], func(nv *Value) {
+					ary[from] = nv
+				}, t)
 			}
 		} else if jp.offset0 == 0 || jp.offset0 == -1 {
 			/*
@@ -247,7 +346,7 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				the result of the evaluation is the same as if the value had been
 				wrapped in a single-element array:
 			*/
-			jp.next.transform(v, t)
+			jp.next.transform(v, replace, t)
 		}
 	case jpMemberAny, jpArrayLocationAny, jpAny:
 		panic("wildcard in transformation path expression")
@@ -263,38 +362,77 @@ const (
 	Remove
 )
 
-func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) error {
+var (
+	ErrInvalidPathForTransform = vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "In this situation, path expressions may not contain the * and ** tokens or an array range.")
+	ErrVacuousPath             = vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "The path expression '$' is not allowed in this context.")
+)
+
+// ApplyTransform applies the transformation to a copy of doc for each one of
+// the paths, in order, the same way JSON_SET, JSON_INSERT, JSON_REPLACE and
+// JSON_REMOVE do in MySQL, and returns the transformed copy.
+func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) (*Value, error) {
 	if t != Remove && len(paths) != len(values) {
 		panic("missing Values for transformation")
 	}
+	for _, p := range paths {
+		if p.ContainsWildcards() {
+			return nil, ErrInvalidPathForTransform
+		}
+		if t == Remove && p.next == nil {
+			return nil, ErrVacuousPath
+		}
+	}
+
+	doc = doc.Clone()
 	for i, p := range paths {
-		transform := func(pp *Path, vv *Value) {
+		var value *Value
+		if t != Remove {
+			// the value can be modified by the next paths
+			value = values[i].Clone()
+		}
+		transform := func(pp *Path, vv *Value, replace func(*Value)) {
 			switch pp.kind {
+			case jpDocumentRoot:
+				if t != Insert {
+					replace(value)
+				}
 			case jpArrayLocation:
 				if ary, ok := vv.Array(); ok {
-					from, to := pp.arrayOffsets(ary)
-					if from != to {
-						return
-					}
+					from, _ := pp.arrayOffsets(ary)
 					if t == Remove {
 						vv.DelArrayItem(from)
 					} else {
-						vv.SetArrayItem(from, values[i], t)
+						vv.SetArrayItem(from, value, t)
 					}
+					return
+				}
+				// A value that is not an array is handled as an array
+				// with only that value in it.
+				switch {
+				case t == Remove:
+				case pp.offset0 == 0 || pp.offset0 == -1:
+					if t != Insert {
+						replace(value)
+					}
+				case t == Replace:
+				case pp.offset0 < 0:
+					replace(NewArray([]*Value{value, vv}))
+				default:
+					replace(NewArray([]*Value{vv, value}))
 				}
 			case jpMember:
 				if obj, ok := vv.Object(); ok {
 					if t == Remove {
 						obj.Del(pp.name)
 					} else {
-						obj.Set(pp.name, values[i], t)
+						obj.Set(pp.name, value, t)
 					}
 				}
 			}
 		}
-		p.transform(doc, transform)
+		p.transform(doc, func(nv *Value) { doc = nv }, transform)
 	}
-	return nil
+	return doc, nil
 }
 
 func MatchPath(rawJSON, rawPath []byte, match func(value *Value)) error {
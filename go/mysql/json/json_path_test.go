@@ -132,6 +132,18 @@ func path(t *testing.T, raw string) *Path {
 	return v
 }
 
+func TestTransformationErrors(t *testing.T) {
+	doc := json(t, `[1, 2]`)
+	_, err := ApplyTransform(Set, doc, []*Path{path(t, `$[*]`)}, []*Value{ValueNull})
+	if err != ErrInvalidPathForTransform {
+		t.Errorf("expected ErrInvalidPathForTransform, got %v", err)
+	}
+	_, err = ApplyTransform(Remove, doc, []*Path{path(t, `$[0]`), path(t, `$`)}, nil)
+	if err != ErrVacuousPath {
+		t.Errorf("expected ErrVacuousPath, got %v", err)
+	}
+}
+
 func TestTransformations(t *testing.T) {
 	const Document1 = `["a", {"b": [true, false]}, [10, 20]]`
 	const Path1 = `$[1].b[0]`
@@ -171,6 +183,34 @@ func TestTransformations(t *testing.T) {
 			Paths:    []string{`$[2]`, `$[1].b[1]`, `$[1].b[1]`},
 			Expected: `["a", {"b": [true]}]`,
 		},
+		{
+			T:        Set,
+			Document: Document1,
+			Paths:    []string{`$[2][7]`, `$[2][last-5]`, `$[0][1]`, `$[1].c`, `$[1].c[0]`},
+			Values:   []string{"1", "2", "3", `{"d": 4}`, "5"},
+			Expected: `[["a", 3], {"b": [true, false], "c": 5}, [2, 10, 20, 1]]`,
+		},
+		{
+			T:        Insert,
+			Document: Document1,
+			Paths:    []string{`$`, `$[0][0]`, `$[1].b`, `$[1].c`, `$.d`},
+			Values:   []string{"1", "2", "3", "4", "5"},
+			Expected: `["a", {"b": [true, false], "c": 4}, [10, 20]]`,
+		},
+		{
+			T:        Replace,
+			Document: Document1,
+			Paths:    []string{`$[0][0]`, `$[1].b[last]`, `$[1].c`, `$[5]`},
+			Values:   []string{"1", "2", "3", "4"},
+			Expected: `[1, {"b": [true, 2]}, [10, 20]]`,
+		},
+		{
+			T:        Set,
+			Document: Document1,
+			Paths:    []string{`$`, `$.a`},
+			Values:   []string{`{}`, `"b"`},
+			Expected: `{"a": "b"}`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -186,14 +226,49 @@ func TestTransformations(t *testing.T) {
 			values = append(values, json(t, v))
 		}
 
-		err := ApplyTransform(tc.T, doc, paths, values)
+		original := string(doc.MarshalTo(nil))
+		res, err := ApplyTransform(tc.T, doc, paths, values)
 		if err != nil {
 			t.Fatal(err)
 		}
+		if string(doc.MarshalTo(nil)) != original {
+			t.Errorf("transformation (%v) modified its document", tc.T)
+		}
 
-		result := string(doc.MarshalTo(nil))
+		result := string(res.MarshalTo(nil))
 		if result != tc.Expected {
 			t.Errorf("bad transformation (%v)\nwant: %s\ngot:  %s", tc.T, tc.Expected, result)
 		}
 	}
 }
+
+func TestSearch(t *testing.T) {
+	const doc = `["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}, {"a b": "abc"}]`
+
+	cases := []struct {
+		search string
+		one    bool
+		paths  []string
+		want   []string
+	}{
+		{search: "abc", one: true, want: []string{"$[0]"}},
+		{search: "abc", want: []string{"$[0]", "$[2].x", `$[4]."a b"`}},
+		{search: "ghi"},
+		{search: "10", want: []string{"$[1][0].k"}},
+		{search: "abc", paths: []string{"$[2]", "$[*]", "$"}, want: []string{"$[2].x", "$[0]", `$[4]."a b"`}},
+		{search: "abc", one: true, paths: []string{"$[4]", "$[2]"}, want: []string{`$[4]."a b"`}},
+		{search: "10", paths: []string{"$[1][0].k"}, want: []string{"$[1][0].k"}},
+		{search: "10", paths: []string{"$[1][0].k[0]"}, want: []string{"$[1][0].k"}},
+	}
+
+	for _, tc := range cases {
+		var paths []*Path
+		for _, p := range tc.paths {
+			paths = append(paths, path(t, p))
+		}
+		got := Search(json(t, doc), paths, tc.one, func(s string) bool { return s == tc.search })
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("Search(%q, %v, one=%v)\nwant: %v\ngot:  %v", tc.search, tc.paths, tc.one, tc.want, got)
+		}
+	}
+}
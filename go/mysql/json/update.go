@@ -66,24 +66,24 @@ func (o *Object) Set(key string, value *Value, t Transformation) {
 
 // SetArrayItem sets the value in the array v at idx position.
 //
+// Like in MySQL, a position that is past the end of the array appends the
+// value to it, and a negative one prepends it, unless t is Replace.
+//
 // The value must be unchanged during v lifetime.
 func (v *Value) SetArrayItem(idx int, value *Value, t Transformation) {
-	if v == nil || v.t != TypeArray || idx < 0 {
+	if v == nil || v.t != TypeArray {
 		return
 	}
-	switch t {
-	case Insert:
-		if idx < len(v.a) {
-			return
-		}
-		fallthrough
-	case Set:
-		for idx >= len(v.a) {
-			v.a = append(v.a, ValueNull)
+	switch {
+	case idx >= 0 && idx < len(v.a):
+		if t != Insert {
+			v.a[idx] = value
 		}
-	}
-	if idx < len(v.a) {
-		v.a[idx] = value
+	case t == Replace:
+	case idx < 0:
+		v.a = slices.Insert(v.a, 0, value)
+	default:
+		v.a = append(v.a, value)
 	}
 }
 
@@ -96,3 +96,59 @@ func (v *Value) DelArrayItem(n int) {
 	}
 	v.a = append(v.a[:n], v.a[n+1:]...)
 }
+
+// MergePreserve merges two documents the way JSON_MERGE_PRESERVE does in
+// MySQL: two objects are merged into one, and the values of the keys they
+// have in common are merged too. Any other two values are merged into an
+// array with the items of both, where the ones that are not arrays are
+// handled like arrays with a single item.
+func MergePreserve(a, b *Value) *Value {
+	if a.t == TypeObject && b.t == TypeObject {
+		obj := Object{kvs: slices.Clone(a.o.kvs)}
+		for _, item := range b.o.kvs {
+			if i, found := obj.find(item.k); found {
+				obj.kvs[i].v = MergePreserve(obj.kvs[i].v, item.v)
+			} else {
+				obj.kvs = slices.Insert(obj.kvs, i, item)
+			}
+		}
+		return &Value{o: obj, t: TypeObject}
+	}
+
+	items := func(v *Value) []*Value {
+		if v.t == TypeArray {
+			return v.a
+		}
+		return []*Value{v}
+	}
+	return NewArray(slices.Concat(items(a), items(b)))
+}
+
+// MergePatch merges the patch into the target the way JSON_MERGE_PATCH does
+// in MySQL, following RFC 7396: if the patch is an object, its keys are
+// merged into the target, and the ones with null values are removed from it.
+// Otherwise, the patch replaces the target.
+func MergePatch(target, patch *Value) *Value {
+	if patch.t != TypeObject {
+		return patch
+	}
+
+	var obj Object
+	if target != nil && target.t == TypeObject {
+		obj.kvs = slices.Clone(target.o.kvs)
+	}
+	for _, item := range patch.o.kvs {
+		i, found := obj.find(item.k)
+		switch {
+		case item.v.Type() == TypeNull:
+			if found {
+				obj.kvs = slices.Delete(obj.kvs, i, i+1)
+			}
+		case found:
+			obj.kvs[i].v = MergePatch(obj.kvs[i].v, item.v)
+		default:
+			obj.kvs = slices.Insert(obj.kvs, i, kv{item.k, MergePatch(nil, item.v)})
+		}
+	}
+	return &Value{o: obj, t: TypeObject}
+}
@@ -106,3 +106,53 @@ func TestValueDelSet(t *testing.T) {
 		t.Fatalf("unexpected number of items left in the array; got %d; want %d", len(a), 2)
 	}
 }
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		preserve string
+		patch    string
+	}{
+		{`[1, 2]`, `[true, false]`, `[1, 2, true, false]`, `[true, false]`},
+		{`{"name": "x"}`, `{"id": 47}`, `{"id": 47, "name": "x"}`, `{"id": 47, "name": "x"}`},
+		{`1`, `true`, `[1, true]`, `true`},
+		{`[1, 2]`, `{"id": 47}`, `[1, 2, {"id": 47}]`, `{"id": 47}`},
+		{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`, `{"a": [1, 3], "b": 2, "c": 4}`, `{"a": 3, "b": 2, "c": 4}`},
+		{`{"a": 1, "b": 2}`, `{"b": null}`, `{"a": 1, "b": [2, null]}`, `{"a": 1}`},
+		{`{"a": {"x": 1}}`, `{"a": {"y": null, "z": 2}}`, `{"a": {"x": 1, "y": null, "z": 2}}`, `{"a": {"x": 1, "z": 2}}`},
+		{`[1]`, `{"a": {"b": null}}`, `[1, {"a": {"b": null}}]`, `{"a": {}}`},
+	}
+
+	for _, tc := range cases {
+		a, b := MustParse(tc.a), MustParse(tc.b)
+
+		if got := string(MergePreserve(a, b).MarshalTo(nil)); got != tc.preserve {
+			t.Errorf("MergePreserve(%s, %s)\nwant: %s\ngot:  %s", tc.a, tc.b, tc.preserve, got)
+		}
+		if got := string(MergePatch(a, b).MarshalTo(nil)); got != tc.patch {
+			t.Errorf("MergePatch(%s, %s)\nwant: %s\ngot:  %s", tc.a, tc.b, tc.patch, got)
+		}
+		if string(a.MarshalTo(nil)) != tc.a || string(b.MarshalTo(nil)) != tc.b {
+			t.Errorf("merging %s and %s modified them", tc.a, tc.b)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	const doc = `{"a": [1, {"b": true}], "c": null}`
+	v := MustParse(doc)
+	c := v.Clone()
+
+	o, _ := c.Object()
+	o.Del("c")
+	a, _ := o.Get("a").Array()
+	a[1].SetArrayItem(0, ValueFalse, Set)
+	a[1].o.Set("b", ValueFalse, Set)
+
+	if got := string(v.MarshalTo(nil)); got != doc {
+		t.Errorf("modifying the clone changed the original: %s", got)
+	}
+	if got := string(c.MarshalTo(nil)); got != `{"a": [1, {"b": false}]}` {
+		t.Errorf("unexpected clone: %s", got)
+	}
+}
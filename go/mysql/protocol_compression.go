@@ -0,0 +1,371 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the compressed protocol, negotiated with
+// CapabilityClientCompress (zlib) or CapabilityClientZstdCompressionAlgorithm.
+// Once the handshake is done, all packets are sent wrapped in compressed
+// packets, which have their own header and sequence:
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_compression_packet.html
+//
+// A compressed packet can contain any number of regular packets (or part of
+// one). The sequence of the compressed packets is the one that is checked,
+// the sequence of the regular packets within them is ignored, as MySQL does.
+// We use Conn.sequence for the compressed packets.
+
+// Names of the protocol compression algorithms, as used by MySQL's
+// protocol_compression_algorithms and --compression-algorithms options.
+const (
+	CompressionZlib = "zlib"
+	CompressionZstd = "zstd"
+
+	// CompressionUncompressed can be listed to explicitly allow
+	// uncompressed connections. They are always allowed.
+	CompressionUncompressed = "uncompressed"
+)
+
+const (
+	// DefaultZstdCompressionLevel is the zstd compression level used when
+	// none is configured. It is the same as MySQL's default.
+	DefaultZstdCompressionLevel = 3
+
+	// compressedPacketHeaderSize is the size of the header of a compressed
+	// packet: 3 bytes of compressed payload length, 1 byte of sequence and
+	// 3 bytes of uncompressed payload length.
+	compressedPacketHeaderSize = 7
+
+	// minCompressLength is the size below which payloads are not worth
+	// compressing. They are sent as is, with an uncompressed length of 0.
+	// This is MIN_COMPRESS_LENGTH in MySQL.
+	minCompressLength = 50
+)
+
+var (
+	compressedBytes   = stats.NewCountersWithSingleLabel("MysqlCompressedBytes", "Bytes sent and received on compressed MySQL protocol connections, as they are on the wire", "direction")
+	uncompressedBytes = stats.NewCountersWithSingleLabel("MysqlUncompressedBytes", "Bytes sent and received on compressed MySQL protocol connections, before compression", "direction")
+
+	zlibWriters = sync.Pool{New: func() any { return zlib.NewWriter(nil) }}
+	zlibReaders sync.Pool
+
+	zstdDecoderOnce sync.Once
+	zstdDecoder     *zstd.Decoder
+	zstdDecoderErr  error
+
+	zstdEncodersMu sync.Mutex
+	zstdEncoders   = map[zstd.EncoderLevel]*zstd.Encoder{}
+)
+
+const (
+	directionSent     = "sent"
+	directionReceived = "received"
+)
+
+// compressionCapabilities returns the capability flags for a list of
+// compression algorithm names.
+func compressionCapabilities(algorithms []string) (uint32, error) {
+	var capabilities uint32
+	for _, algorithm := range algorithms {
+		switch strings.ToLower(strings.TrimSpace(algorithm)) {
+		case CompressionZlib:
+			capabilities |= CapabilityClientCompress
+		case CompressionZstd:
+			capabilities |= CapabilityClientZstdCompressionAlgorithm
+		case CompressionUncompressed, "":
+		default:
+			return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown compression algorithm %q, must be one of %v, %v or %v", algorithm, CompressionZlib, CompressionZstd, CompressionUncompressed)
+		}
+	}
+	return capabilities, nil
+}
+
+// clientCompressionCapability returns the capability flag of the first
+// algorithm in the comma separated algorithms that the server supports,
+// or 0 if there is none.
+func clientCompressionCapability(serverCapabilities uint32, algorithms string) (uint32, error) {
+	if algorithms == "" {
+		return 0, nil
+	}
+	for _, algorithm := range strings.Split(algorithms, ",") {
+		capability, err := compressionCapabilities([]string{algorithm})
+		if err != nil {
+			return 0, err
+		}
+		if capability != 0 && serverCapabilities&capability != 0 {
+			return capability, nil
+		}
+	}
+	return 0, nil
+}
+
+// enableCompression switches the connection to the compressed protocol,
+// with the algorithm of the given capability flag. It has to be called
+// once the handshake is done, by both sides.
+func (c *Conn) enableCompression(capability uint32, zstdLevel int) {
+	if zstdLevel == 0 {
+		zstdLevel = DefaultZstdCompressionLevel
+	}
+	c.compressedReader = &compressedReader{
+		conn:       c,
+		r:          c.getReader(),
+		capability: capability,
+	}
+	c.compressedWriter = &compressedWriter{
+		conn:       c,
+		w:          c.conn,
+		capability: capability,
+		zstdLevel:  zstdLevel,
+	}
+}
+
+// compressedReader reads compressed packets, and returns the regular
+// packets they contain.
+type compressedReader struct {
+	conn       *Conn
+	r          io.Reader
+	capability uint32
+
+	header [compressedPacketHeaderSize]byte
+	// payload and data are reused for the compressed and the uncompressed
+	// payloads of the packets that are not too large.
+	payload []byte
+	data    []byte
+	// pending is the part of the last payload that was not read yet.
+	pending []byte
+}
+
+// Read is part of the io.Reader interface.
+func (cr *compressedReader) Read(p []byte) (int, error) {
+	for len(cr.pending) == 0 {
+		if err := cr.readCompressedPacket(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, cr.pending)
+	cr.pending = cr.pending[n:]
+	return n, nil
+}
+
+func (cr *compressedReader) readCompressedPacket() error {
+	// Errors reading the header are returned as is, so readHeaderFrom
+	// can recognize the client disconnecting.
+	if _, err := io.ReadFull(cr.r, cr.header[:]); err != nil {
+		return err
+	}
+
+	length := int(uint32(cr.header[0]) | uint32(cr.header[1])<<8 | uint32(cr.header[2])<<16)
+	uncompressedLength := int(uint32(cr.header[4]) | uint32(cr.header[5])<<8 | uint32(cr.header[6])<<16)
+	if sequence := cr.header[3]; sequence != cr.conn.sequence {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid compressed packet sequence, expected %v got %v", cr.conn.sequence, sequence)
+	}
+	cr.conn.sequence++
+
+	payload := reuseBuffer(&cr.payload, length)
+	if _, err := io.ReadFull(cr.r, payload); err != nil {
+		return vterrors.Wrapf(err, "io.ReadFull(compressed packet body of length %v) failed", length)
+	}
+	compressedBytes.Add(directionReceived, int64(compressedPacketHeaderSize+length))
+
+	if uncompressedLength == 0 {
+		// This payload was too small to be compressed.
+		uncompressedBytes.Add(directionReceived, int64(compressedPacketHeaderSize+length))
+		cr.pending = payload
+		return nil
+	}
+
+	data := reuseBuffer(&cr.data, uncompressedLength)
+	if err := decompressPayload(cr.capability, data, payload); err != nil {
+		return err
+	}
+	uncompressedBytes.Add(directionReceived, int64(compressedPacketHeaderSize+uncompressedLength))
+	cr.pending = data
+	return nil
+}
+
+// compressedWriter wraps everything that is written to it in compressed
+// packets.
+type compressedWriter struct {
+	conn       *Conn
+	w          io.Writer
+	capability uint32
+	zstdLevel  int
+
+	// packet is reused for the packets that are not too large.
+	packet []byte
+}
+
+// Write is part of the io.Writer interface.
+func (cw *compressedWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), MaxPacketSize)
+		if err := cw.writeCompressedPacket(p[:n]); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+func (cw *compressedWriter) writeCompressedPacket(payload []byte) error {
+	packet := reuseBuffer(&cw.packet, compressedPacketHeaderSize+len(payload))[:compressedPacketHeaderSize]
+
+	uncompressedLength := 0
+	if len(payload) >= minCompressLength {
+		var err error
+		packet, err = compressPayload(cw.capability, cw.zstdLevel, packet, payload)
+		if err != nil {
+			return err
+		}
+		uncompressedLength = len(payload)
+	}
+	if uncompressedLength == 0 || len(packet)-compressedPacketHeaderSize >= len(payload) {
+		// Not compressible, send the payload as is.
+		packet = append(packet[:compressedPacketHeaderSize], payload...)
+		uncompressedLength = 0
+	}
+
+	length := len(packet) - compressedPacketHeaderSize
+	packet[0] = byte(length)
+	packet[1] = byte(length >> 8)
+	packet[2] = byte(length >> 16)
+	packet[3] = cw.conn.sequence
+	packet[4] = byte(uncompressedLength)
+	packet[5] = byte(uncompressedLength >> 8)
+	packet[6] = byte(uncompressedLength >> 16)
+
+	if n, err := cw.w.Write(packet); err != nil {
+		return vterrors.Wrapf(err, "Write(compressed packet) failed")
+	} else if n != len(packet) {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Write(compressed packet) returned a short write: %v < %v", n, len(packet))
+	}
+	cw.conn.sequence++
+
+	compressedBytes.Add(directionSent, int64(len(packet)))
+	uncompressedBytes.Add(directionSent, int64(compressedPacketHeaderSize+len(payload)))
+	return nil
+}
+
+// reuseBuffer returns a buffer of the given size. Buffers up to
+// connBufferSize are kept in buf for the next calls, larger ones are
+// allocated every time so we don't hold on to them.
+func reuseBuffer(buf *[]byte, size int) []byte {
+	if size > connBufferSize {
+		return make([]byte, size)
+	}
+	if *buf == nil {
+		*buf = make([]byte, connBufferSize)
+	}
+	return (*buf)[:size]
+}
+
+// compressPayload appends the compressed payload to dst.
+func compressPayload(capability uint32, zstdLevel int, dst, payload []byte) ([]byte, error) {
+	switch capability {
+	case CapabilityClientCompress:
+		buf := bytes.NewBuffer(dst)
+		zw := zlibWriters.Get().(*zlib.Writer)
+		defer zlibWriters.Put(zw)
+		zw.Reset(buf)
+		if _, err := zw.Write(payload); err != nil {
+			return nil, vterrors.Wrapf(err, "cannot compress packet")
+		}
+		if err := zw.Close(); err != nil {
+			return nil, vterrors.Wrapf(err, "cannot compress packet")
+		}
+		return buf.Bytes(), nil
+	case CapabilityClientZstdCompressionAlgorithm:
+		return zstdEncoder(zstdLevel).EncodeAll(payload, dst), nil
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unknown compression capability %v", capability)
+	}
+}
+
+// decompressPayload decompresses payload into data, which has to be exactly
+// the size of the uncompressed payload.
+func decompressPayload(capability uint32, data, payload []byte) error {
+	switch capability {
+	case CapabilityClientCompress:
+		var zr io.ReadCloser
+		var err error
+		if pooled := zlibReaders.Get(); pooled != nil {
+			zr = pooled.(io.ReadCloser)
+			err = zr.(zlib.Resetter).Reset(bytes.NewReader(payload), nil)
+		} else {
+			zr, err = zlib.NewReader(bytes.NewReader(payload))
+		}
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot decompress packet")
+		}
+		defer zlibReaders.Put(zr)
+		if _, err := io.ReadFull(zr, data); err != nil {
+			return vterrors.Wrapf(err, "cannot decompress packet of uncompressed length %v", len(data))
+		}
+		return nil
+	case CapabilityClientZstdCompressionAlgorithm:
+		zstdDecoderOnce.Do(func() {
+			zstdDecoder, zstdDecoderErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(MaxPacketSize))
+		})
+		if zstdDecoderErr != nil {
+			return vterrors.Wrapf(zstdDecoderErr, "cannot create zstd decoder")
+		}
+		decompressed, err := zstdDecoder.DecodeAll(payload, data[:0])
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot decompress packet")
+		}
+		if len(decompressed) != len(data) {
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "decompressed packet is %v bytes, expected %v", len(decompressed), len(data))
+		}
+		if &decompressed[0] != &data[0] {
+			copy(data, decompressed)
+		}
+		return nil
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unknown compression capability %v", capability)
+	}
+}
+
+// zstdEncoder returns the shared encoder for a zstd compression level.
+// Encoders are safe to use concurrently with EncodeAll.
+func zstdEncoder(level int) *zstd.Encoder {
+	encoderLevel := zstd.EncoderLevelFromZstd(level)
+
+	zstdEncodersMu.Lock()
+	defer zstdEncodersMu.Unlock()
+	encoder, ok := zstdEncoders[encoderLevel]
+	if !ok {
+		// NewWriter only fails on invalid options.
+		encoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(encoderLevel))
+		zstdEncoders[encoderLevel] = encoder
+	}
+	return encoder
+}
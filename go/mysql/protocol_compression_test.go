@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+)
+
+func newCompressionTestListener(t *testing.T, ctx context.Context, algorithms ...string) (*testHandler, *ConnParams) {
+	th := &testHandler{}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	t.Cleanup(authServer.close)
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, l.SetCompressionAlgorithms(algorithms))
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+	}
+	go l.Accept()
+	t.Cleanup(func() { cleanupListener(ctx, l, params) })
+	return th, params
+}
+
+func TestCompressedProtocol(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	testcases := []struct {
+		algorithms string
+		capability uint32
+	}{{
+		algorithms: CompressionZlib,
+		capability: CapabilityClientCompress,
+	}, {
+		algorithms: CompressionZstd,
+		capability: CapabilityClientZstdCompressionAlgorithm,
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.algorithms, func(t *testing.T) {
+			th, params := newCompressionTestListener(t, ctx, CompressionZlib, CompressionZstd)
+			params.CompressionAlgorithms = tcase.algorithms
+			params.DbName = "fancy_db"
+
+			compressedBytes.ResetAll()
+			uncompressedBytes.ResetAll()
+
+			c, err := Connect(ctx, params)
+			require.NoError(t, err)
+			defer c.Close()
+			assert.Equal(t, tcase.capability, c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm))
+			assert.Equal(t, tcase.capability, th.LastConn().Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm))
+
+			result, err := c.ExecuteFetch("select rows", 10, true)
+			require.NoError(t, err)
+			assert.Equal(t, selectRowsResult.Rows, result.Rows)
+
+			result, err = c.ExecuteFetch("schema echo", 10, false)
+			require.NoError(t, err)
+			assert.Equal(t, "fancy_db", result.Rows[0][0].ToString())
+
+			require.NoError(t, c.Ping())
+
+			// Queries and results larger than a packet, in both directions.
+			for _, size := range []int{10, 100 * 1024, MaxPacketSize + 10} {
+				query := benchmarkQueryPrefix + strings.Repeat("vitess", size/6)
+				result, err = c.ExecuteFetch(query, 10, false)
+				require.NoError(t, err)
+				require.Len(t, result.Rows, 1)
+				assert.Equal(t, query, result.Rows[0][0].ToString())
+			}
+
+			// Everything was compressed, and that was worth it.
+			sent := compressedBytes.Counts()[directionSent]
+			received := compressedBytes.Counts()[directionReceived]
+			assert.NotZero(t, sent)
+			assert.NotZero(t, received)
+			assert.Less(t, sent, uncompressedBytes.Counts()[directionSent]/10)
+			assert.Less(t, received, uncompressedBytes.Counts()[directionReceived]/10)
+		})
+	}
+}
+
+func TestCompressedProtocolNegotiation(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th, params := newCompressionTestListener(t, ctx, CompressionZlib)
+
+	testcases := []struct {
+		algorithms string
+		capability uint32
+	}{{
+		algorithms: "",
+	}, {
+		// The server doesn't support it, so the connection is uncompressed.
+		algorithms: CompressionZstd,
+	}, {
+		algorithms: "zstd,zlib",
+		capability: CapabilityClientCompress,
+	}, {
+		algorithms: "uncompressed,zlib",
+		capability: CapabilityClientCompress,
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.algorithms, func(t *testing.T) {
+			params.CompressionAlgorithms = tcase.algorithms
+			c, err := Connect(ctx, params)
+			require.NoError(t, err)
+			defer c.Close()
+			assert.Equal(t, tcase.capability, c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm))
+			assert.Equal(t, tcase.capability, th.LastConn().Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm))
+
+			result, err := c.ExecuteFetch("select rows", 10, true)
+			require.NoError(t, err)
+			assert.Equal(t, selectRowsResult.Rows, result.Rows)
+		})
+	}
+
+	params.CompressionAlgorithms = "lz4"
+	_, err := Connect(ctx, params)
+	assert.ErrorContains(t, err, `unknown compression algorithm "lz4"`)
+
+	assert.ErrorContains(t, (&Listener{}).SetCompressionAlgorithms([]string{"zlib", "lz4"}), `unknown compression algorithm "lz4"`)
+}
+
+func TestCompressedPackets(t *testing.T) {
+	for _, capability := range []uint32{CapabilityClientCompress, CapabilityClientZstdCompressionAlgorithm} {
+		var wire bytes.Buffer
+		writer := &Conn{}
+		cw := &compressedWriter{conn: writer, w: &wire, capability: capability, zstdLevel: DefaultZstdCompressionLevel}
+
+		// A payload that is too small to be compressed, one that
+		// is not compressible and one that is.
+		payloads := [][]byte{[]byte("small"), make([]byte, 1000), bytes.Repeat([]byte("vitess"), 1000)}
+		_, err := rand.Read(payloads[1])
+		require.NoError(t, err)
+		for _, payload := range payloads {
+			n, err := cw.Write(payload)
+			require.NoError(t, err)
+			require.Equal(t, len(payload), n)
+		}
+		assert.EqualValues(t, 3, writer.sequence)
+
+		reader := &Conn{}
+		cr := &compressedReader{conn: reader, r: &wire, capability: capability}
+		for _, payload := range payloads {
+			got := make([]byte, len(payload))
+			_, err := cr.Read(got)
+			require.NoError(t, err)
+			assert.Equal(t, payload, got)
+			assert.Empty(t, cr.pending)
+		}
+		assert.EqualValues(t, 3, reader.sequence)
+
+		// A compressed packet out of sequence is rejected.
+		_, err = cw.Write(payloads[2])
+		require.NoError(t, err)
+		reader.sequence = 7
+		_, err = cr.Read(make([]byte, 10))
+		assert.ErrorContains(t, err, "invalid compressed packet sequence, expected 7 got 3")
+	}
+}
@@ -580,8 +580,50 @@ func (c *Conn) readComQueryResponse(packetOk *PacketOK) (int, error) {
 // Server side methods.
 //
 
-func (c *Conn) parseComQuery(data []byte) string {
-	return string(data[1:])
+func (c *Conn) parseComQuery(data []byte) (string, map[string]string, error) {
+	if c.Capabilities&CapabilityClientQueryAttributes == 0 {
+		return string(data[1:]), nil, nil
+	}
+
+	paramsCount, pos, ok := readLenEncInt(data, 1)
+	if !ok {
+		return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter count failed")
+	}
+	// The parameter set count is always 1.
+	_, pos, ok = readLenEncInt(data, pos)
+	if !ok {
+		return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter set count failed")
+	}
+
+	var attributes map[string]string
+	if paramsCount > 0 {
+		var bitMap []byte
+		var newParamsBoundFlag byte
+		bitMap, pos, ok = readBytes(data, pos, (int(paramsCount)+7)/8)
+		if !ok {
+			return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
+		}
+		// The types are always sent with COM_QUERY.
+		newParamsBoundFlag, pos, ok = readByte(data, pos)
+		if !ok || newParamsBoundFlag != 0x01 {
+			return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter types failed")
+		}
+
+		names := make([]string, paramsCount)
+		types := make([]querypb.Type, paramsCount)
+		var err error
+		for i := range names {
+			types[i], names[i], pos, err = c.parseParamTypeAndName(data, pos)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		attributes, pos, err = c.parseQueryAttributes(data, pos, bitMap, 0, names, types)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	return string(data[pos:]), attributes, nil
 }
 
 func (c *Conn) parseComSetOption(data []byte) (uint16, bool) {
@@ -593,7 +635,7 @@ func (c *Conn) parseComPrepare(data []byte) string {
 	return string(data[1:])
 }
 
-func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []byte) (uint32, byte, error) {
+func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []byte) (uint32, byte, map[string]string, error) {
 	pos := 0
 	payload := data[1:]
 	bitMap := make([]byte, 0)
@@ -601,57 +643,72 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 	// statement ID
 	stmtID, pos, ok := readUint32(payload, 0)
 	if !ok {
-		return 0, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading statement ID failed")
+		return 0, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading statement ID failed")
 	}
 	prepare, ok := prepareData[stmtID]
 	if !ok {
-		return 0, 0, sqlerror.NewSQLError(sqlerror.CRCommandsOutOfSync, sqlerror.SSUnknownSQLState, "statement ID is not found from record")
+		return 0, 0, nil, sqlerror.NewSQLError(sqlerror.CRCommandsOutOfSync, sqlerror.SSUnknownSQLState, "statement ID is not found from record")
 	}
 
 	// cursor type flags
 	cursorType, pos, ok := readByte(payload, pos)
 	if !ok {
-		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading cursor type flags failed")
+		return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading cursor type flags failed")
 	}
 
 	// iteration count
 	iterCount, pos, ok := readUint32(payload, pos)
 	if !ok {
-		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading iteration count failed")
+		return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading iteration count failed")
 	}
 	if iterCount != uint32(1) {
-		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "iteration count is not equal to 1")
+		return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "iteration count is not equal to 1")
+	}
+
+	// With query attributes, the parameter count includes them, they
+	// follow the parameters of the statement.
+	queryAttributes := c.Capabilities&CapabilityClientQueryAttributes != 0
+	paramsCount := uint64(prepare.ParamsCount)
+	if queryAttributes && (prepare.ParamsCount > 0 || cursorType&parameterCountAvailable != 0) {
+		paramsCount, pos, ok = readLenEncInt(payload, pos)
+		if !ok {
+			return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter count failed")
+		}
+		if paramsCount < uint64(prepare.ParamsCount) {
+			return stmtID, 0, nil, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "parameter count %v is less than the %v parameters of the statement", paramsCount, prepare.ParamsCount)
+		}
 	}
+	attributesCount := int(paramsCount - uint64(prepare.ParamsCount))
 
-	if prepare.ParamsCount > 0 {
-		bitMap, pos, ok = readBytes(payload, pos, (int(prepare.ParamsCount)+7)/8)
+	if paramsCount > 0 {
+		bitMap, pos, ok = readBytes(payload, pos, (int(paramsCount)+7)/8)
 		if !ok {
-			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
+			return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
 		}
 	}
 
 	newParamsBoundFlag, pos, ok := readByte(payload, pos)
 	if ok && newParamsBoundFlag == 0x01 {
-		var mysqlType, flags byte
+		var valType querypb.Type
+		var err error
 		for i := range prepare.ParamsCount {
-			mysqlType, pos, ok = readByte(payload, pos)
-			if !ok {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter type failed")
-			}
-
-			flags, pos, ok = readByte(payload, pos)
-			if !ok {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter flags failed")
+			valType, _, pos, err = c.parseParamTypeAndName(payload, pos)
+			if err != nil {
+				return stmtID, 0, nil, err
 			}
+			prepare.ParamsType[i] = int32(valType)
+		}
 
-			// convert MySQL type to internal type.
-			valType, err := sqltypes.MySQLToType(mysqlType, int64(flags))
+		prepare.attributeNames = make([]string, attributesCount)
+		prepare.attributeTypes = make([]querypb.Type, attributesCount)
+		for i := range attributesCount {
+			prepare.attributeTypes[i], prepare.attributeNames[i], pos, err = c.parseParamTypeAndName(payload, pos)
 			if err != nil {
-				return stmtID, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "MySQLToType(%v,%v) failed: %v", mysqlType, flags, err)
+				return stmtID, 0, nil, err
 			}
-
-			prepare.ParamsType[i] = int32(valType)
 		}
+	} else if attributesCount > 0 && len(prepare.attributeNames) != attributesCount {
+		return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "query attributes were sent without their types")
 	}
 
 	for i := range prepare.ParamsCount {
@@ -669,13 +726,71 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 			val, pos, ok = c.parseStmtArgs(payload, querypb.Type(prepare.ParamsType[i]), pos)
 		}
 		if !ok {
-			return stmtID, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding parameter value failed: %v", prepare.ParamsType[i])
+			return stmtID, 0, nil, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding parameter value failed: %v", prepare.ParamsType[i])
 		}
 
 		prepare.BindVars[parameterID] = sqltypes.ValueBindVariable(val)
 	}
 
-	return stmtID, cursorType, nil
+	var attributes map[string]string
+	if attributesCount > 0 {
+		var err error
+		attributes, _, err = c.parseQueryAttributes(payload, pos, bitMap, int(prepare.ParamsCount), prepare.attributeNames, prepare.attributeTypes)
+		if err != nil {
+			return stmtID, 0, nil, err
+		}
+	}
+
+	return stmtID, cursorType, attributes, nil
+}
+
+// parseParamTypeAndName reads the type of a parameter of COM_QUERY or
+// COM_STMT_EXECUTE, and its name if query attributes are enabled. Only
+// query attributes have names.
+func (c *Conn) parseParamTypeAndName(data []byte, pos int) (querypb.Type, string, int, error) {
+	mysqlType, pos, ok := readByte(data, pos)
+	if !ok {
+		return 0, "", 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter type failed")
+	}
+
+	flags, pos, ok := readByte(data, pos)
+	if !ok {
+		return 0, "", 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter flags failed")
+	}
+
+	// convert MySQL type to internal type.
+	valType, err := sqltypes.MySQLToType(mysqlType, int64(flags))
+	if err != nil {
+		return 0, "", 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "MySQLToType(%v,%v) failed: %v", mysqlType, flags, err)
+	}
+
+	var name string
+	if c.Capabilities&CapabilityClientQueryAttributes != 0 {
+		name, pos, ok = readLenEncString(data, pos)
+		if !ok {
+			return 0, "", 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter name failed")
+		}
+	}
+	return valType, name, pos, nil
+}
+
+// parseQueryAttributes reads the values of the query attributes, which
+// are the parameters after the first offset ones. NULL values are skipped.
+func (c *Conn) parseQueryAttributes(data []byte, pos int, nullBitMap []byte, offset int, names []string, types []querypb.Type) (map[string]string, int, error) {
+	attributes := make(map[string]string, len(names))
+	for i, name := range names {
+		if j := offset + i; nullBitMap[j/8]&(1<<uint(j%8)) > 0 {
+			continue
+		}
+		var val sqltypes.Value
+		var ok bool
+		val, pos, ok = c.parseStmtArgs(data, types[i], pos)
+		if !ok {
+			return nil, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute %v failed: %v", name, types[i])
+		}
+		attributes[name] = val.ToString()
+	}
+	return attributes, pos, nil
 }
 
 func (c *Conn) parseStmtArgs(data []byte, typ querypb.Type, pos int) (sqltypes.Value, int, bool) {
@@ -1083,6 +1198,12 @@ func (c *Conn) writeEndResult(more bool, affectedRows, lastInsertID uint64, warn
 	if more {
 		flags |= ServerMoreResultsExists
 	}
+	return c.writeEndResultWithFlags(flags, affectedRows, lastInsertID, warnings)
+}
+
+// writeEndResultWithFlags sends either an EOF or an OK packet
+// with the given status flags.
+func (c *Conn) writeEndResultWithFlags(flags uint16, affectedRows, lastInsertID uint64, warnings uint16) error {
 	if c.Capabilities&CapabilityClientDeprecateEOF == 0 {
 		if err := c.writeEOFPacket(flags, warnings); err != nil {
 			return err
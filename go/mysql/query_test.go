@@ -244,7 +244,7 @@ func TestComStmtExecute(t *testing.T) {
 	// This is simulated packets for `select * from test_table where id = ?`
 	data := []byte{23, 18, 0, 0, 0, 128, 1, 0, 0, 0, 0, 1, 1, 128, 1}
 
-	stmtID, _, err := sConn.parseComStmtExecute(cConn.PrepareData, data)
+	stmtID, _, _, err := sConn.parseComStmtExecute(cConn.PrepareData, data)
 	require.NoError(t, err, "parseComStmtExeute failed: %v", err)
 	require.Equal(t, uint32(18), stmtID, "Parsed incorrect values")
 
@@ -288,7 +288,7 @@ func TestComStmtExecuteUpdStmt(t *testing.T) {
 		0x35, 0x36, 0x37, 0x38, 0x0c, 0xe9, 0x9f, 0xa9, 0xe5, 0x86, 0xac, 0xe7, 0x9c, 0x9f, 0xe8, 0xb5,
 		0x9e, 0x03, 0x66, 0x6f, 0x6f, 0x07, 0x66, 0x6f, 0x6f, 0x2c, 0x62, 0x61, 0x72}
 
-	stmtID, _, err := sConn.parseComStmtExecute(prepareDataMap, data[4:]) // first 4 are header
+	stmtID, _, _, err := sConn.parseComStmtExecute(prepareDataMap, data[4:]) // first 4 are header
 	require.NoError(t, err)
 	require.EqualValues(t, 1, stmtID)
 
@@ -324,6 +324,113 @@ func TestComStmtExecuteUpdStmt(t *testing.T) {
 	assert.EqualValues(t, querypb.Type_CHAR, prepData.ParamsType[28], "got: %s", querypb.Type(prepData.ParamsType[28]))
 }
 
+func TestComQueryAttributes(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// Without the capability, the packet is only the query.
+	query, attributes, err := sConn.parseComQuery([]byte("\x03select 1"))
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	assert.Nil(t, attributes)
+
+	sConn.Capabilities |= CapabilityClientQueryAttributes
+
+	// Three attributes, the last one is NULL.
+	data := []byte{ComQuery, 3, 1, 0x04, 1}
+	data = append(data, 0xfe, 0x00, 11)
+	data = append(data, "traceparent"...)
+	data = append(data, 0x08, 0x00, 7)
+	data = append(data, "attempt"...)
+	data = append(data, 0xfe, 0x00, 7)
+	data = append(data, "nothing"...)
+	data = append(data, 6)
+	data = append(data, "00-abc"...)
+	data = append(data, 2, 0, 0, 0, 0, 0, 0, 0)
+	data = append(data, "select 1"...)
+
+	query, attributes, err = sConn.parseComQuery(data)
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	assert.Equal(t, map[string]string{"traceparent": "00-abc", "attempt": "2"}, attributes)
+
+	// Clients that support query attributes send a count of 0 without any.
+	query, attributes, err = sConn.parseComQuery([]byte("\x03\x00\x01select 1"))
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	assert.Nil(t, attributes)
+
+	_, _, err = sConn.parseComQuery(data[:12])
+	assert.ErrorContains(t, err, "reading parameter name failed")
+}
+
+func TestComStmtExecuteQueryAttributes(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	sConn.Capabilities |= CapabilityClientQueryAttributes
+
+	prepareDataMap := map[uint32]*PrepareData{
+		1: {
+			StatementID: 1,
+			ParamsCount: 1,
+			ParamsType:  make([]int32, 1),
+			BindVars:    map[string]*querypb.BindVariable{},
+		},
+		2: {
+			StatementID: 2,
+			BindVars:    map[string]*querypb.BindVariable{},
+		},
+	}
+
+	// One parameter, and one attribute. Only the attribute has a name.
+	data := []byte{ComStmtExecute, 1, 0, 0, 0, 0x00, 1, 0, 0, 0, 2, 0x00, 1}
+	data = append(data, 0x08, 0x00, 0)
+	data = append(data, 0xfe, 0x00, 11)
+	data = append(data, "traceparent"...)
+	values := []byte{5, 0, 0, 0, 0, 0, 0, 0, 6}
+	values = append(values, "00-abc"...)
+
+	stmtID, _, attributes, err := sConn.parseComStmtExecute(prepareDataMap, append(data, values...))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stmtID)
+	assert.Equal(t, map[string]string{"traceparent": "00-abc"}, attributes)
+	assert.EqualValues(t, querypb.Type_INT64, prepareDataMap[1].ParamsType[0])
+	assert.Equal(t, sqltypes.Int64BindVariable(5), prepareDataMap[1].BindVars["v1"])
+
+	// The types and names are not sent again when they didn't change.
+	prepareDataMap[1].BindVars = map[string]*querypb.BindVariable{}
+	data = []byte{ComStmtExecute, 1, 0, 0, 0, 0x00, 1, 0, 0, 0, 2, 0x00, 0}
+	_, _, attributes, err = sConn.parseComStmtExecute(prepareDataMap, append(data, values...))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"traceparent": "00-abc"}, attributes)
+
+	// A statement without parameters has the count when the flag says so.
+	data = []byte{ComStmtExecute, 2, 0, 0, 0, parameterCountAvailable, 1, 0, 0, 0, 1, 0x00, 1}
+	data = append(data, 0x08, 0x00, 7)
+	data = append(data, "attempt"...)
+	data = append(data, 2, 0, 0, 0, 0, 0, 0, 0)
+	stmtID, cursorType, attributes, err := sConn.parseComStmtExecute(prepareDataMap, data)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, stmtID)
+	assert.Equal(t, CursorTypeNoCursor, cursorType&CursorTypeReadOnly)
+	assert.Equal(t, map[string]string{"attempt": "2"}, attributes)
+
+	// Attributes can't be sent without their types the first time.
+	prepareDataMap[2].attributeNames = nil
+	data = []byte{ComStmtExecute, 2, 0, 0, 0, parameterCountAvailable, 1, 0, 0, 0, 1, 0x00, 0}
+	data = append(data, 2, 0, 0, 0, 0, 0, 0, 0)
+	_, _, _, err = sConn.parseComStmtExecute(prepareDataMap, data)
+	assert.ErrorContains(t, err, "query attributes were sent without their types")
+}
+
 func TestComStmtClose(t *testing.T) {
 	listener, sConn, cConn := createSocketPair(t)
 	defer func() {
@@ -133,6 +133,12 @@ type Handler interface {
 
 	ComResetConnection(c *Conn)
 
+	// ComChangeUser is called when a connection was authenticated
+	// again with COM_CHANGE_USER. c.User and c.UserData are those
+	// of the new user. The handler should reset the session, as
+	// if the connection was new.
+	ComChangeUser(c *Conn)
+
 	Env() *vtenv.Environment
 }
 
@@ -146,6 +152,7 @@ func (UnimplementedHandler) NewConnection(*Conn)      {}
 func (UnimplementedHandler) ConnectionReady(*Conn)    {}
 func (UnimplementedHandler) ConnectionClosed(*Conn)   {}
 func (UnimplementedHandler) ComResetConnection(*Conn) {}
+func (UnimplementedHandler) ComChangeUser(*Conn)      {}
 
 // Listener is the MySQL server protocol listener.
 type Listener struct {
@@ -207,6 +214,10 @@ type Listener struct {
 	// RequireSecureTransport configures the server to reject connections from insecure clients
 	RequireSecureTransport bool
 
+	// compressionCapabilities are the compression capability flags
+	// the server advertises. It is set by SetCompressionAlgorithms.
+	compressionCapabilities uint32
+
 	// PreHandleFunc is called for each incoming connection, immediately after
 	// accepting a new connection. By default it's no-op. Useful for custom
 	// connection inspection or TLS termination. The returned connection is
@@ -319,6 +330,18 @@ func NewListenerWithConfig(cfg ListenerConfig) (*Listener, error) {
 	}, nil
 }
 
+// SetCompressionAlgorithms configures the protocol compression algorithms
+// the server accepts, CompressionZlib and/or CompressionZstd. Compression is
+// disabled by default. It must be called before Accept.
+func (l *Listener) SetCompressionAlgorithms(algorithms []string) error {
+	capabilities, err := compressionCapabilities(algorithms)
+	if err != nil {
+		return err
+	}
+	l.compressionCapabilities = capabilities
+	return nil
+}
+
 // Addr returns the listener address.
 func (l *Listener) Addr() net.Addr {
 	return l.listener.Addr()
@@ -386,12 +409,13 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	// Tell the handler about the connection coming and going.
 	l.handler.NewConnection(c)
 	defer l.handler.ConnectionClosed(c)
+	defer c.closeCursors()
 
 	// Adjust the count of open connections
 	defer connCount.Add(-1)
 
 	// First build and send the server handshake packet.
-	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil)
+	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil, l.compressionCapabilities)
 	if err != nil {
 		if err != io.EOF {
 			log.Errorf("Cannot send HandshakeV10 packet to %s: %v", c, err)
@@ -450,61 +474,8 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 		defer connCountByTLSVer.Add(versionNoTLS, -1)
 	}
 
-	// See what auth method the AuthServer wants to use for that user.
-	negotiatedAuthMethod, err := negotiateAuthMethod(c, l.authServer, user, clientAuthMethod)
-
-	// We need to send down an additional packet if we either have no negotiated method
-	// at all or incomplete authentication data.
-	//
-	// The latter case happens for example for MySQL 8.0 clients until 8.0.25 who advertise
-	// support for caching_sha2_password by default but with no plugin data.
-	if err != nil || len(clientAuthResponse) == 0 {
-		// If we have no negotiated method yet, we pick the first one
-		// we know about ourselves as that's the last resort option we have here.
-		if err != nil {
-			// The client will disconnect if it doesn't understand
-			// the first auth method that we send, so we only have to send the
-			// first one that we allow for the user.
-			for _, m := range l.authServer.AuthMethods() {
-				if m.HandleUser(c, user) {
-					negotiatedAuthMethod = m
-					break
-				}
-			}
-		}
-
-		if negotiatedAuthMethod == nil {
-			c.writeErrorPacket(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "No authentication methods available for authentication.")
-			return
-		}
-
-		if !l.AllowClearTextWithoutTLS.Load() && !c.TLSEnabled() && !negotiatedAuthMethod.AllowClearTextWithoutTLS() {
-			c.writeErrorPacket(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "Cannot use clear text authentication over non-SSL connections.")
-			return
-		}
-
-		serverAuthPluginData, err = negotiatedAuthMethod.AuthPluginData()
-		if err != nil {
-			log.Errorf("Error generating auth switch packet for %s: %v", c, err)
-			return
-		}
-
-		if err := c.writeAuthSwitchRequest(string(negotiatedAuthMethod.Name()), serverAuthPluginData); err != nil {
-			log.Errorf("Error writing auth switch packet for %s: %v", c, err)
-			return
-		}
-
-		clientAuthResponse, err = c.readEphemeralPacket()
-		if err != nil {
-			log.Errorf("Error reading auth switch response for %s: %v", c, err)
-			return
-		}
-		c.recycleReadPacket()
-	}
-
-	userData, err := negotiatedAuthMethod.HandleAuthPluginData(c, user, serverAuthPluginData, clientAuthResponse, conn.RemoteAddr())
+	userData, err := l.authenticate(c, user, clientAuthMethod, serverAuthPluginData, clientAuthResponse)
 	if err != nil {
-		log.Warningf("Error authenticating user %s using: %s", user, negotiatedAuthMethod.Name())
 		c.writeErrorPacketFromError(err)
 		return
 	}
@@ -512,9 +483,14 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	c.User = user
 	c.UserData = userData
 
+	// The user can be changed by COM_CHANGE_USER.
+	defer func() {
+		if c.User != "" {
+			connCountPerUser.Add(c.User, -1)
+		}
+	}()
 	if c.User != "" {
 		connCountPerUser.Add(c.User, 1)
-		defer connCountPerUser.Add(c.User, -1)
 	}
 
 	// Set initial db name.
@@ -534,6 +510,11 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 		return
 	}
 
+	// Switch to the compressed protocol if the client asked for it.
+	if compression := c.Capabilities & (CapabilityClientCompress | CapabilityClientZstdCompressionAlgorithm); compression != 0 {
+		c.enableCompression(compression, c.zstdCompressionLevel)
+	}
+
 	// Record how long we took to establish the connection
 	timings.Record(connectTimingKey, acceptTime)
 
@@ -557,6 +538,72 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	}
 }
 
+// authenticate authenticates the user with the AuthServer, given the
+// response of the client to the auth plugin data it was sent. It can
+// switch to another auth method, and returns the UserData of the user.
+func (l *Listener) authenticate(c *Conn, user string, clientAuthMethod AuthMethodDescription, serverAuthPluginData, clientAuthResponse []byte) (Getter, error) {
+	// See what auth method the AuthServer wants to use for that user.
+	negotiatedAuthMethod, err := negotiateAuthMethod(c, l.authServer, user, clientAuthMethod)
+
+	// We need to send down an additional packet if we either have no negotiated method
+	// at all or incomplete authentication data.
+	//
+	// The latter case happens for example for MySQL 8.0 clients until 8.0.25 who advertise
+	// support for caching_sha2_password by default but with no plugin data.
+	if err != nil || len(clientAuthResponse) == 0 {
+		// If we have no negotiated method yet, we pick the first one
+		// we know about ourselves as that's the last resort option we have here.
+		if err != nil {
+			// The client will disconnect if it doesn't understand
+			// the first auth method that we send, so we only have to send the
+			// first one that we allow for the user.
+			for _, m := range l.authServer.AuthMethods() {
+				if m.HandleUser(c, user) {
+					negotiatedAuthMethod = m
+					break
+				}
+			}
+		}
+
+		if negotiatedAuthMethod == nil {
+			return nil, sqlerror.NewSQLError(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "No authentication methods available for authentication.")
+		}
+
+		if !l.AllowClearTextWithoutTLS.Load() && !c.TLSEnabled() && !negotiatedAuthMethod.AllowClearTextWithoutTLS() {
+			return nil, sqlerror.NewSQLError(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "Cannot use clear text authentication over non-SSL connections.")
+		}
+
+		serverAuthPluginData, err = negotiatedAuthMethod.AuthPluginData()
+		if err != nil {
+			log.Errorf("Error generating auth switch packet for %s: %v", c, err)
+			return nil, err
+		}
+
+		if err := c.writeAuthSwitchRequest(string(negotiatedAuthMethod.Name()), serverAuthPluginData); err != nil {
+			log.Errorf("Error writing auth switch packet for %s: %v", c, err)
+			return nil, err
+		}
+
+		clientAuthResponse, err = c.readEphemeralPacket()
+		if err != nil {
+			log.Errorf("Error reading auth switch response for %s: %v", c, err)
+			return nil, err
+		}
+		c.recycleReadPacket()
+	}
+
+	userData, err := negotiatedAuthMethod.HandleAuthPluginData(c, user, serverAuthPluginData, clientAuthResponse, c.conn.RemoteAddr())
+	if err != nil {
+		log.Warningf("Error authenticating user %s using: %s", user, negotiatedAuthMethod.Name())
+		return nil, err
+	}
+
+	// COM_CHANGE_USER is authenticated with the last auth plugin data
+	// that was sent.
+	c.salt = serverAuthPluginData
+	return userData, nil
+}
+
 // Close stops the listener, which prevents accept of any new connections. Existing connections won't be closed.
 func (l *Listener) Close() {
 	l.listener.Close()
@@ -572,7 +619,7 @@ func (l *Listener) Shutdown() {
 
 // writeHandshakeV10 writes the Initial Handshake Packet, server side.
 // It returns the salt data.
-func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS bool) ([]byte, error) {
+func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS bool, compressionCapabilities uint32) ([]byte, error) {
 	capabilities := CapabilityClientLongPassword |
 		CapabilityClientFoundRows |
 		CapabilityClientLongFlag |
@@ -585,10 +632,12 @@ func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, ch
 		CapabilityClientPluginAuth |
 		CapabilityClientPluginAuthLenencClientData |
 		CapabilityClientDeprecateEOF |
-		CapabilityClientConnAttr
+		CapabilityClientConnAttr |
+		CapabilityClientQueryAttributes
 	if enableTLS {
 		capabilities |= CapabilityClientSSL
 	}
+	capabilities |= int(compressionCapabilities)
 
 	// Grab the default auth method. This can only be either
 	// mysql_native_password or caching_sha2_password. Both
@@ -702,11 +751,13 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 		return "", "", nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "parseClientHandshakePacket: only support protocol 4.1")
 	}
 
+	c.clientCapabilities = clientFlags
+
 	// Remember a subset of the capabilities, so we can use them
 	// later in the protocol. If we re-received the handshake packet
 	// after SSL negotiation, do not overwrite capabilities.
 	if firstTime {
-		c.Capabilities = clientFlags & (CapabilityClientDeprecateEOF | CapabilityClientFoundRows)
+		c.Capabilities = clientFlags & (CapabilityClientDeprecateEOF | CapabilityClientFoundRows | CapabilityClientQueryAttributes)
 	}
 
 	// set connection capability for executing multi statements
@@ -811,9 +862,93 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 		}
 	}
 
+	// Compression, if the client asked for an algorithm we advertised.
+	// It is enabled once the handshake is done. Like MySQL, we prefer
+	// zlib if the client asked for both.
+	c.Capabilities &^= CapabilityClientCompress | CapabilityClientZstdCompressionAlgorithm
+	if compression := clientFlags & l.compressionCapabilities; compression&CapabilityClientCompress != 0 {
+		c.Capabilities |= CapabilityClientCompress
+	} else if compression&CapabilityClientZstdCompressionAlgorithm != 0 {
+		// The zstd compression level is the last byte of the packet.
+		if len(data) <= pos {
+			return "", "", nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "parseClientHandshakePacket: can't read zstd compression level")
+		}
+		c.Capabilities |= CapabilityClientZstdCompressionAlgorithm
+		c.zstdCompressionLevel = int(data[len(data)-1])
+	}
+
 	return username, AuthMethodDescription(authMethod), authResponse, nil
 }
 
+// parseComChangeUser parses a COM_CHANGE_USER packet, which is laid out
+// according to the capabilities of the client handshake response. It sets
+// the schema name and character set of the connection.
+func (c *Conn) parseComChangeUser(data []byte) (string, AuthMethodDescription, []byte, error) {
+	// Skip the command byte.
+	pos := 1
+
+	username, pos, ok := readNullString(data, pos)
+	if !ok {
+		return "", "", nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read username")
+	}
+
+	var authResponse []byte
+	if c.clientCapabilities&CapabilityClientSecureConnection != 0 {
+		var l byte
+		l, pos, ok = readByte(data, pos)
+		if !ok {
+			return "", "", nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read auth-response length")
+		}
+		authResponse, pos, ok = readBytesCopy(data, pos, int(l))
+		if !ok {
+			return "", "", nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read auth-response")
+		}
+	} else {
+		a := ""
+		a, pos, ok = readNullString(data, pos)
+		if !ok {
+			return "", "", nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read auth-response")
+		}
+		authResponse = []byte(a)
+	}
+
+	dbname, pos, ok := readNullString(data, pos)
+	if !ok {
+		return "", "", nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read dbname")
+	}
+	c.schemaName = dbname
+
+	// The rest of the packet is optional.
+	authMethod := MysqlNativePassword
+	if pos < len(data) {
+		characterSet, newPos, ok := readUint16(data, pos)
+		if !ok {
+			return "", "", nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read characterSet")
+		}
+		pos = newPos
+		c.CharacterSet = collations.ID(characterSet)
+
+		if c.clientCapabilities&CapabilityClientPluginAuth != 0 {
+			var authMethodStr string
+			authMethodStr, pos, ok = readNullString(data, pos)
+			if !ok {
+				return "", "", nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read authMethod")
+			}
+			if authMethodStr != "" {
+				authMethod = AuthMethodDescription(authMethodStr)
+			}
+		}
+
+		if c.clientCapabilities&CapabilityClientConnAttr != 0 && pos < len(data) {
+			if _, _, err := parseConnAttrs(data, pos); err != nil {
+				log.Warningf("Decode connection attributes send by the client: %v", err)
+			}
+		}
+	}
+
+	return username, authMethod, authResponse, nil
+}
+
 func parseConnAttrs(data []byte, pos int) (map[string]string, int, error) {
 	var attrLen uint64
 
@@ -518,6 +518,82 @@ func TestConnCounts(t *testing.T) {
 	}, 1*time.Second, 10*time.Millisecond)
 }
 
+func writeComChangeUser(t *testing.T, c *Conn, user, password, dbname string) {
+	scrambled := ScrambleMysqlNativePassword(c.salt, []byte(password))
+
+	data := make([]byte, packetHeaderSize, 64)
+	data = append(data, ComChangeUser)
+	data = append(data, user...)
+	data = append(data, 0, byte(len(scrambled)))
+	data = append(data, scrambled...)
+	data = append(data, dbname...)
+	data = append(data, 0, 33, 0)
+	data = append(data, MysqlNativePassword...)
+	data = append(data, 0)
+	c.sequence = 0
+	require.NoError(t, c.writePacket(data))
+}
+
+func TestComChangeUser(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th := &testHandler{}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	authServer.entries["user2"] = []*AuthServerStaticEntry{{
+		Password: "password2",
+		UserData: "userData2",
+	}}
+	defer authServer.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:   host,
+		Port:   port,
+		Uname:  "user1",
+		Pass:   "password1",
+		DbName: "db1",
+	}
+	go l.Accept()
+	defer cleanupListener(ctx, l, params)
+
+	c, err := Connect(ctx, params)
+	require.NoError(t, err)
+	defer c.Close()
+	checkCountsForUser(t, "user1", 1)
+
+	writeComChangeUser(t, c, "user2", "password2", "db2")
+	data, err := c.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, OKPacket, data[0], "unexpected response: %v", data)
+
+	assert.Equal(t, "user2", th.LastConn().User)
+	assert.Equal(t, "userData2", th.LastConn().UserData.Get().Username)
+	checkCountsForUser(t, "user1", 0)
+	checkCountsForUser(t, "user2", 1)
+
+	result, err := c.ExecuteFetch("schema echo", 10, false)
+	require.NoError(t, err)
+	assert.Equal(t, "db2", result.Rows[0][0].ToString())
+
+	// A failed authentication closes the connection.
+	writeComChangeUser(t, c, "user1", "bad password", "")
+	data, err = c.ReadPacket()
+	require.NoError(t, err)
+	require.True(t, isErrorPacket(data))
+	assert.ErrorContains(t, ParseErrorPacket(data), "Access denied for user 'user1'")
+	_, err = c.ReadPacket()
+	assert.Error(t, err)
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		checkCountsForUser(t, "user2", 0)
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
 func checkCountsForUser(t assert.TestingT, user string, expected int64) {
 	connCounts := connCountPerUser.Counts()
 
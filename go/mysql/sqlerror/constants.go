@@ -121,6 +121,7 @@ const (
 	ERSPDoesNotExist                = ErrorCode(1305)
 	ERNoDefaultForField             = ErrorCode(1364)
 	ErSPNotVarArg                   = ErrorCode(1414)
+	ERStmtHasNoOpenCursor           = ErrorCode(1421)
 	ERRowIsReferenced2              = ErrorCode(1451)
 	ErNoReferencedRow2              = ErrorCode(1452)
 	ERInnodbIndexCorrupt            = ErrorCode(1817)
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiskStats is a sample of the I/O statistics of a block device, as reported by /proc/diskstats.
+type DiskStats struct {
+	Device          string
+	ReadsCompleted  uint64
+	TimeReadingMs   uint64
+	WritesCompleted uint64
+	TimeWritingMs   uint64
+	// TimeDoingIOMs is the time the device has had I/O requests in flight.
+	TimeDoingIOMs uint64
+	SampledAt     time.Time
+}
+
+// parseDiskStats finds the statistics of the device with the given major and minor numbers in the
+// content of /proc/diskstats. Lines have the form:
+// "8 1 sda1 4641 1390 265536 1630 2341 2813 60760 2102 0 2556 3733 ..."
+func parseDiskStats(content string, major, minor uint32) (*DiskStats, error) {
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 14 {
+			continue
+		}
+		if fields[0] != strconv.FormatUint(uint64(major), 10) || fields[1] != strconv.FormatUint(uint64(minor), 10) {
+			continue
+		}
+		values := make([]uint64, 0, 11)
+		for _, field := range fields[3:14] {
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected diskstats line: %s", line)
+			}
+			values = append(values, value)
+		}
+		return &DiskStats{
+			Device:          fields[2],
+			ReadsCompleted:  values[0],
+			TimeReadingMs:   values[3],
+			WritesCompleted: values[4],
+			TimeWritingMs:   values[7],
+			TimeDoingIOMs:   values[9],
+		}, nil
+	}
+	return nil, fmt.Errorf("device %d:%d not found in diskstats", major, minor)
+}
+
+// DiskIOUtilization returns the utilization of a device between two samples of its statistics, as the
+// ratio of time the device was busy: 0.0 (idle) - 1.0 (saturated).
+func DiskIOUtilization(prev, cur *DiskStats) (float64, error) {
+	elapsed := cur.SampledAt.Sub(prev.SampledAt)
+	if elapsed <= 0 {
+		return 0, fmt.Errorf("samples of %s are not in order", cur.Device)
+	}
+	if cur.TimeDoingIOMs < prev.TimeDoingIOMs {
+		return 0, fmt.Errorf("diskstats counters of %s were reset", cur.Device)
+	}
+	busy := time.Duration(cur.TimeDoingIOMs-prev.TimeDoingIOMs) * time.Millisecond
+	return min(busy.Seconds()/elapsed.Seconds(), 1.0), nil
+}
+
+// DiskIOLatency returns the average time, in milliseconds, that read and write requests completed
+// between two samples of a device's statistics took to be served. It is 0 when there were no requests.
+func DiskIOLatency(prev, cur *DiskStats) (float64, error) {
+	if cur.ReadsCompleted < prev.ReadsCompleted || cur.WritesCompleted < prev.WritesCompleted {
+		return 0, fmt.Errorf("diskstats counters of %s were reset", cur.Device)
+	}
+	requests := (cur.ReadsCompleted - prev.ReadsCompleted) + (cur.WritesCompleted - prev.WritesCompleted)
+	if requests == 0 {
+		return 0, nil
+	}
+	waited := (cur.TimeReadingMs + cur.TimeWritingMs) - (prev.TimeReadingMs + prev.TimeWritingMs)
+	return float64(waited) / float64(requests), nil
+}
@@ -0,0 +1,45 @@
+//go:build linux
+
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osutil
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// ReadDiskStats returns the I/O statistics of the block device where the given path is located.
+// This works on linux systems. On other systems, it returns an error.
+func ReadDiskStats(path string) (*DiskStats, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile("/proc/diskstats")
+	if err != nil {
+		return nil, err
+	}
+	stats, err := parseDiskStats(string(content), unix.Major(st.Dev), unix.Minor(st.Dev))
+	if err != nil {
+		return nil, err
+	}
+	stats.SampledAt = time.Now()
+	return stats, nil
+}
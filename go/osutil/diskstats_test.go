@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleDiskStats = `   8       0 sda 45216 12039 3406742 22870 121405 95376 4412786 154012 0 98260 190137 0 0 0 0 5413 13254
+   8       1 sda1 44919 12039 3397670 22749 121405 95376 4412786 154012 0 98168 176761 0 0 0 0 0 0
+ 253       0 dm-0 56701 0 3389482 28564 216712 0 4412784 331624 0 98432 360188 0 0 0 0 0 0
+`
+
+func TestParseDiskStats(t *testing.T) {
+	stats, err := parseDiskStats(sampleDiskStats, 8, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &DiskStats{
+		Device:          "sda1",
+		ReadsCompleted:  44919,
+		TimeReadingMs:   22749,
+		WritesCompleted: 121405,
+		TimeWritingMs:   154012,
+		TimeDoingIOMs:   98168,
+	}, stats)
+
+	stats, err = parseDiskStats(sampleDiskStats, 253, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "dm-0", stats.Device)
+
+	_, err = parseDiskStats(sampleDiskStats, 8, 2)
+	assert.ErrorContains(t, err, "device 8:2 not found")
+
+	_, err = parseDiskStats("8 1 sda1 x 0 0 0 0 0 0 0 0 0 0", 8, 1)
+	assert.ErrorContains(t, err, "unexpected diskstats line")
+}
+
+func TestDiskIOUtilization(t *testing.T) {
+	now := time.Now()
+	prev := &DiskStats{
+		Device:          "sda1",
+		ReadsCompleted:  100,
+		TimeReadingMs:   200,
+		WritesCompleted: 300,
+		TimeWritingMs:   1000,
+		TimeDoingIOMs:   5000,
+		SampledAt:       now,
+	}
+	cur := &DiskStats{
+		Device:          "sda1",
+		ReadsCompleted:  150,
+		TimeReadingMs:   300,
+		WritesCompleted: 350,
+		TimeWritingMs:   1900,
+		TimeDoingIOMs:   7500,
+		SampledAt:       now.Add(10 * time.Second),
+	}
+
+	utilization, err := DiskIOUtilization(prev, cur)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, utilization, 0.0001)
+
+	latency, err := DiskIOLatency(prev, cur)
+	require.NoError(t, err)
+	assert.InDelta(t, 10.0, latency, 0.0001)
+
+	latency, err = DiskIOLatency(cur, cur)
+	require.NoError(t, err)
+	assert.Zero(t, latency)
+
+	_, err = DiskIOUtilization(cur, prev)
+	assert.Error(t, err)
+	_, err = DiskIOLatency(cur, prev)
+	assert.Error(t, err)
+}
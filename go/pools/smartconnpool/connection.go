@@ -35,6 +35,9 @@ type Pooled[C Connection] struct {
 	timeCreated timestamp
 	timeUsed    timestamp
 	pool        *ConnPool[C]
+	// workload is the workload that borrowed the connection, if it has been
+	// configured in the pool
+	workload *workload
 
 	Conn C
 }
@@ -44,6 +47,7 @@ func (dbc *Pooled[C]) Close() {
 }
 
 func (dbc *Pooled[C]) Recycle() {
+	dbc.releaseWorkload()
 	switch {
 	case dbc.pool == nil:
 		dbc.Conn.Close()
@@ -55,9 +59,17 @@ func (dbc *Pooled[C]) Recycle() {
 }
 
 func (dbc *Pooled[C]) Taint() {
+	dbc.releaseWorkload()
 	if dbc.pool == nil {
 		return
 	}
 	dbc.pool.put(nil)
 	dbc.pool = nil
 }
+
+func (dbc *Pooled[C]) releaseWorkload() {
+	if w := dbc.workload; w != nil {
+		dbc.workload = nil
+		w.release()
+	}
+}
@@ -96,6 +96,9 @@ type Config[C Connection] struct {
 	MaxLifetime     time.Duration
 	RefreshInterval time.Duration
 	LogWait         func(time.Time)
+	// Workloads configures the admission of the clients of the pool by
+	// workload, see NewAdmissionContext
+	Workloads map[string]WorkloadConfig
 }
 
 // stackMask is the number of connection setting stacks minus one;
@@ -126,6 +129,9 @@ type ConnPool[C Connection] struct {
 	// maxIdleCount is the maximum idle connections in the pool
 	idleCount atomic.Int64
 
+	// workloads are the workloads configured in the pool, by name
+	workloads map[string]*workload
+
 	// workers is a waitgroup for all the currently running worker goroutines
 	workers    sync.WaitGroup
 	close      chan struct{}
@@ -165,6 +171,10 @@ func NewPool[C Connection](config *Config[C]) *ConnPool[C] {
 	pool.config.idleTimeout.Store(config.IdleTimeout.Nanoseconds())
 	pool.config.refreshInterval.Store(config.RefreshInterval.Nanoseconds())
 	pool.config.logWait = config.LogWait
+	pool.workloads = make(map[string]*workload, len(config.Workloads))
+	for name, wc := range config.Workloads {
+		pool.workloads[name] = newWorkload(wc)
+	}
 	pool.wait.init()
 
 	return pool
@@ -387,12 +397,46 @@ func (pool *ConnPool[C]) Get(ctx context.Context, setting *Setting) (*Pooled[C],
 	if pool.capacity.Load() == 0 {
 		return nil, ErrConnPoolClosed
 	}
+	if w := pool.workloads[admissionFromContext(ctx).workload]; w != nil {
+		return pool.getForWorkload(ctx, w, setting)
+	}
 	if setting == nil {
 		return pool.get(ctx)
 	}
 	return pool.getWithSetting(ctx, setting)
 }
 
+// getForWorkload returns a connection to a client of a workload that has been
+// configured in the pool, once the workload is under its max concurrency.
+func (pool *ConnPool[C]) getForWorkload(ctx context.Context, w *workload, setting *Setting) (*Pooled[C], error) {
+	if w.config.QueueTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.config.QueueTimeout)
+		defer cancel()
+	}
+	if err := w.acquire(ctx); err != nil {
+		w.timeouts.Add(1)
+		return nil, ErrTimeout
+	}
+
+	var conn *Pooled[C]
+	var err error
+	if setting == nil {
+		conn, err = pool.get(ctx)
+	} else {
+		conn, err = pool.getWithSetting(ctx, setting)
+	}
+	if err != nil {
+		if err == ErrTimeout {
+			w.timeouts.Add(1)
+		}
+		w.release()
+		return nil, err
+	}
+	conn.workload = w
+	return conn, nil
+}
+
 // put returns a connection to the pool. This is a private API.
 // Return connections to the pool by calling Pooled.Recycle
 func (pool *ConnPool[C]) put(conn *Pooled[C]) {
@@ -857,4 +901,22 @@ func (pool *ConnPool[C]) RegisterStats(stats *servenv.Exporter, name string) {
 	stats.NewCounterFunc(name+"ResetSetting", "Number of times pool reset the setting", func() int64 {
 		return pool.Metrics.ResetSettingCount()
 	})
+
+	if len(pool.workloads) == 0 {
+		return
+	}
+	stats.NewGaugesFuncWithMultiLabels(name+"WorkloadInUse", "Tablet server conn pool in use by workload", []string{"Workload"}, func() map[string]int64 {
+		inUse := make(map[string]int64, len(pool.workloads))
+		for name, w := range pool.workloads {
+			inUse[name] = w.borrowed.Load()
+		}
+		return inUse
+	})
+	stats.NewCountersFuncWithMultiLabels(name+"WorkloadTimeouts", "Tablet server conn pool get timeouts by workload", []string{"Workload"}, func() map[string]int64 {
+		timeouts := make(map[string]int64, len(pool.workloads))
+		for name, w := range pool.workloads {
+			timeouts[name] = w.timeouts.Load()
+		}
+		return timeouts
+	})
 }
@@ -1177,6 +1177,48 @@ func TestApplySettingsFailure(t *testing.T) {
 	}
 }
 
+func TestWorkloads(t *testing.T) {
+	var state TestState
+
+	ctx := context.Background()
+	p := NewPool(&Config[*TestConn]{
+		Capacity: 5,
+		Workloads: map[string]WorkloadConfig{
+			"batch": {MaxConcurrency: 2, QueueTimeout: 10 * time.Millisecond},
+		},
+	}).Open(newConnector(&state), nil)
+	defer p.Close()
+
+	batchCtx := NewAdmissionContext(ctx, "batch", 100)
+	var batch []*Pooled[*TestConn]
+	for range 2 {
+		conn, err := p.Get(batchCtx, nil)
+		require.NoError(t, err)
+		batch = append(batch, conn)
+	}
+
+	// the batch workload is at its max concurrency, even if the pool is not
+	_, err := p.Get(batchCtx, sFoo)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.EqualValues(t, 1, p.workloads["batch"].timeouts.Load())
+
+	// the other workloads are not limited
+	other, err := p.Get(NewAdmissionContext(ctx, "oltp", 0), nil)
+	require.NoError(t, err)
+	defer other.Recycle()
+
+	batch[0].Recycle()
+	conn, err := p.Get(batchCtx, sFoo)
+	require.NoError(t, err)
+	assert.Equal(t, sFoo, conn.Conn.Setting())
+	assert.EqualValues(t, 2, p.workloads["batch"].borrowed.Load())
+
+	conn.Taint()
+	batch[1].Recycle()
+	assert.EqualValues(t, 0, p.workloads["batch"].borrowed.Load())
+	assert.EqualValues(t, 1, p.InUse())
+}
+
 func TestGetSpike(t *testing.T) {
 	var state TestState
 
@@ -37,6 +37,8 @@ type waiter[C Connection] struct {
 	sema semaphore
 	// age is the amount of cycles this client has been on the waitlist
 	age uint32
+	// priority is the priority of the client, see NewAdmissionContext
+	priority int
 }
 
 type waitlist[C Connection] struct {
@@ -51,12 +53,22 @@ type waitlist[C Connection] struct {
 // also return a `nil` connection even if our context has expired, if the pool has
 // forced an expiration of all waiters in the waitlist.
 func (wl *waitlist[C]) waitForConn(ctx context.Context, setting *Setting) (*Pooled[C], error) {
+	priority := admissionFromContext(ctx).priority
 	elem := wl.nodes.Get().(*list.Element[waiter[C]])
-	elem.Value = waiter[C]{setting: setting, conn: nil, ctx: ctx}
+	elem.Value = waiter[C]{setting: setting, conn: nil, ctx: ctx, priority: priority}
 
 	wl.mu.Lock()
-	// add ourselves as a waiter at the end of the waitlist
-	wl.list.PushBackValue(elem)
+	// add ourselves as a waiter after the last waiter with the same or a
+	// higher priority, so the waitlist is FIFO for every priority
+	at := wl.list.Back()
+	for at != nil && at.Value.priority > priority {
+		at = at.Prev()
+	}
+	if at == nil {
+		wl.list.PushFrontValue(elem)
+	} else {
+		wl.list.InsertValueAfter(elem, at)
+	}
 	wl.mu.Unlock()
 
 	// block on our waiter's semaphore until somebody can hand over a connection to us
@@ -129,8 +141,12 @@ func (wl *waitlist[D]) tryReturnConnSlow(conn *Pooled[D]) bool {
 	target = wl.list.Front()
 	// iterate through the waitlist looking for either waiters that have been
 	// here too long, or a waiter that is looking exactly for the same Setting
-	// as the one we have in our connection.
+	// as the one we have in our connection. only the waiters with the highest
+	// priority, at the front of the waitlist, are considered.
 	for e := target; e != nil; e = e.Next() {
+		if e.Value.priority != target.Value.priority {
+			break
+		}
 		if e.Value.age > maxAge || e.Value.setting == connSetting {
 			target = e
 			break
@@ -66,3 +66,33 @@ func TestWaitlistExpireWithMultipleWaiters(t *testing.T) {
 
 	assert.Equal(t, int32(waiterCount), expireCount.Load())
 }
+
+func TestWaitlistPriority(t *testing.T) {
+	wait := waitlist[*TestConn]{}
+	wait.init()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// waiters are handed over connections by priority, and then in the
+	// order they started waiting
+	priorities := []int{100, 0, 50, 0, 100}
+	served := make(chan int, len(priorities))
+	for i, priority := range priorities {
+		go func() {
+			conn, err := wait.waitForConn(NewAdmissionContext(ctx, "", priority), nil)
+			if assert.NoError(t, err) && assert.NotNil(t, conn) {
+				served <- i
+			}
+		}()
+		require.Eventually(t, func() bool {
+			return wait.waiting() == i+1
+		}, time.Second, time.Millisecond)
+	}
+
+	for _, want := range []int{1, 3, 2, 0, 4} {
+		require.True(t, wait.tryReturnConn(&Pooled[*TestConn]{Conn: &TestConn{}}))
+		assert.Equal(t, want, <-served)
+	}
+	assert.False(t, wait.tryReturnConn(&Pooled[*TestConn]{Conn: &TestConn{}}))
+}
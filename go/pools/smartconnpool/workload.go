@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smartconnpool
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// WorkloadConfig configures the admission of the clients of a workload to
+// the pool.
+type WorkloadConfig struct {
+	// MaxConcurrency is the maximum number of connections that the clients
+	// of the workload can borrow at once, or 0 for no limit.
+	MaxConcurrency int64
+	// QueueTimeout is the maximum time that a client of the workload waits
+	// for a connection, or 0 to wait until its context expires.
+	QueueTimeout time.Duration
+}
+
+// workload tracks the connections borrowed by the clients of a workload.
+type workload struct {
+	config WorkloadConfig
+	// slots holds a token for every connection borrowed by the clients of
+	// the workload, or is nil if its concurrency is not limited.
+	slots    chan struct{}
+	borrowed atomic.Int64
+	timeouts atomic.Int64
+}
+
+func newWorkload(config WorkloadConfig) *workload {
+	w := &workload{config: config}
+	if config.MaxConcurrency > 0 {
+		w.slots = make(chan struct{}, config.MaxConcurrency)
+	}
+	return w
+}
+
+// acquire blocks until the workload can borrow another connection, or until
+// the given context expires.
+func (w *workload) acquire(ctx context.Context) error {
+	if w.slots != nil {
+		select {
+		case w.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	w.borrowed.Add(1)
+	return nil
+}
+
+// release returns a connection borrowed by the workload.
+func (w *workload) release() {
+	w.borrowed.Add(-1)
+	if w.slots != nil {
+		<-w.slots
+	}
+}
+
+type admissionKey struct{}
+
+// admission identifies the client that gets a connection from the pool.
+type admission struct {
+	workload string
+	priority int
+}
+
+// NewAdmissionContext returns a context that identifies the workload and the
+// priority of the client that gets a connection from the pool with it. The
+// priority has the same scale as the PRIORITY query directive: when the pool
+// is exhausted, the waiters with the lowest value are handed over connections
+// first. Clients without an admission context have priority 0.
+func NewAdmissionContext(ctx context.Context, workload string, priority int) context.Context {
+	return context.WithValue(ctx, admissionKey{}, admission{workload: workload, priority: priority})
+}
+
+func admissionFromContext(ctx context.Context) admission {
+	adm, _ := ctx.Value(admissionKey{}).(admission)
+	return adm
+}
@@ -68,6 +68,9 @@ type QueryLogConfig struct {
 	Mode                 string
 	RowThreshold         uint64
 	sampleRate           float64
+	// QueryAttributes adds the query attributes sent by MySQL clients
+	// to the query log. Only vtgate receives them.
+	QueryAttributes bool
 }
 
 var queryLogConfigInstance = QueryLogConfig{
@@ -89,6 +92,9 @@ func init() {
 	servenv.OnParseFor("vtcombo", registerStreamLogFlags)
 	servenv.OnParseFor("vttablet", registerStreamLogFlags)
 	servenv.OnParseFor("vtgate", registerStreamLogFlags)
+
+	servenv.OnParseFor("vtcombo", registerQueryAttributesFlags)
+	servenv.OnParseFor("vtgate", registerQueryAttributesFlags)
 }
 
 func registerStreamLogFlags(fs *pflag.FlagSet) {
@@ -111,6 +117,11 @@ func registerStreamLogFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&queryLogConfigInstance.Mode, "querylog-mode", queryLogConfigInstance.Mode, `Mode for logging queries. "error" will only log queries that return an error. Otherwise all queries will be logged.`)
 }
 
+func registerQueryAttributesFlags(fs *pflag.FlagSet) {
+	// QueryLogQueryAttributes controls whether the query attributes sent by MySQL clients are logged
+	fs.BoolVar(&queryLogConfigInstance.QueryAttributes, "querylog-query-attributes", queryLogConfigInstance.QueryAttributes, "add the query attributes sent by MySQL clients with their queries to the query log")
+}
+
 // StreamLogger is a non-blocking broadcaster of messages.
 // Subscribers can use channels or HTTP.
 type StreamLogger[T any] struct {
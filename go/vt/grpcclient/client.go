@@ -62,6 +62,7 @@ var (
 		"vtctl",
 		"vtctlclient",
 		"vtctld",
+		"vtdebezium",
 		"vtgate",
 		"vtgateclienttest",
 		"vtorc",
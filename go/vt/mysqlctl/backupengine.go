@@ -338,6 +338,10 @@ type BackupManifest struct {
 
 	// IncrementalDetails is nil for non-incremental backups
 	IncrementalDetails *IncrementalBackupDetails
+
+	// Encryption is nil for backups that are not encrypted. Otherwise, it holds the
+	// wrapped data key of the backup and the ID of the key used to wrap it.
+	Encryption *BackupEncryption `json:",omitempty"`
 }
 
 func (m *BackupManifest) HashKey() string {
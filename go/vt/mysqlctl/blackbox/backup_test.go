@@ -20,6 +20,8 @@ package blackbox
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -555,6 +557,131 @@ func TestExecuteRestoreWithTimedOutContext(t *testing.T) {
 	}
 }
 
+func TestExecuteEncryptedBackupAndRestore(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	// Set up local backup directory
+	id := fmt.Sprintf("%d", time.Now().UnixNano())
+	backupRoot := fmt.Sprintf("testdata/builtinbackup_test_%s", id)
+	filebackupstorage.FileBackupStorageRoot = backupRoot
+	require.NoError(t, createBackupDir(backupRoot, "innodb", "log", "datadir"))
+	dataDir := path.Join(backupRoot, "datadir")
+	require.NoError(t, createBackupDir(dataDir, "test1"))
+	require.NoError(t, createBackupFiles(path.Join(dataDir, "test1"), 2, "ibd"))
+	defer os.RemoveAll(backupRoot)
+
+	needIt, err := NeedInnoDBRedoLogSubdir()
+	require.NoError(t, err)
+	if needIt {
+		fpath := path.Join("log", mysql.DynamicRedoLogSubdir)
+		if err := createBackupDir(backupRoot, fpath); err != nil {
+			require.Failf(t, err.Error(), "failed to create directory: %s", fpath)
+		}
+	}
+
+	// Set up the key used to encrypt the backup
+	defer func(keyFile string) { mysqlctl.BackupEncryptionKeyFile = keyFile }(mysqlctl.BackupEncryptionKeyFile)
+	mysqlctl.BackupEncryptionKeyFile = path.Join(backupRoot, "backup.key")
+	require.NoError(t, os.WriteFile(mysqlctl.BackupEncryptionKeyFile, []byte(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))), 0600))
+
+	// Set up topo
+	keyspace, shard := "mykeyspace", "-80"
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+
+	require.NoError(t, ts.CreateKeyspace(ctx, keyspace, &topodata.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, keyspace, shard))
+
+	tablet := topo.NewTablet(100, "cell1", "mykeyspace-00-80-0100")
+	tablet.Keyspace = keyspace
+	tablet.Shard = shard
+
+	require.NoError(t, ts.CreateTablet(ctx, tablet))
+
+	_, err = ts.UpdateShardFields(ctx, keyspace, shard, func(si *topo.ShardInfo) error {
+		si.PrimaryAlias = &topodata.TabletAlias{Uid: 100, Cell: "cell1"}
+
+		now := time.Now()
+		si.PrimaryTermStartTime = &vttime.Time{Seconds: int64(now.Second()), Nanoseconds: int32(now.Nanosecond())}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	be := &mysqlctl.BuiltinBackupEngine{}
+	bh := filebackupstorage.NewBackupHandle(nil, "", "", false)
+	fakedb := fakesqldb.New(t)
+	defer fakedb.Close()
+	mysqld := mysqlctl.NewFakeMysqlDaemon(fakedb)
+	defer mysqld.Close()
+	mysqld.ExpectedExecuteSuperQueryList = []string{"STOP REPLICA", "START REPLICA"}
+
+	cnf := &mysqlctl.Mycnf{
+		InnodbDataHomeDir:     path.Join(backupRoot, "innodb"),
+		InnodbLogGroupHomeDir: path.Join(backupRoot, "log"),
+		DataDir:               path.Join(backupRoot, "datadir"),
+		BinLogPath:            path.Join(backupRoot, "binlog"),
+		RelayLogPath:          path.Join(backupRoot, "relaylog"),
+		RelayLogIndexPath:     path.Join(backupRoot, "relaylogindex"),
+		RelayLogInfoPath:      path.Join(backupRoot, "relayloginfo"),
+	}
+	backupResult, err := be.ExecuteBackup(ctx, mysqlctl.BackupParams{
+		Logger:               logutil.NewConsoleLogger(),
+		Mysqld:               mysqld,
+		Cnf:                  cnf,
+		Stats:                backupstats.NewFakeStats(),
+		Concurrency:          2,
+		HookExtraEnv:         map[string]string{},
+		TopoServer:           ts,
+		Keyspace:             keyspace,
+		Shard:                shard,
+		MysqlShutdownTimeout: MysqlShutdownTimeout,
+	}, bh)
+	require.NoError(t, err)
+	assert.Equal(t, mysqlctl.BackupUsable, backupResult)
+
+	// The MANIFEST records how the backup was encrypted, and the files are not readable without the key.
+	data, err := os.ReadFile(path.Join(backupRoot, "MANIFEST"))
+	require.NoError(t, err)
+	var manifest mysqlctl.BackupManifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	require.NotNil(t, manifest.Encryption)
+	assert.Equal(t, mysqlctl.AES256GCMEncryption, manifest.Encryption.Algorithm)
+	assert.Equal(t, mysqlctl.FileKeyManager, manifest.Encryption.KeyManager)
+	assert.NotEmpty(t, manifest.Encryption.KeyID)
+	assert.NotEmpty(t, manifest.Encryption.WrappedKey)
+
+	restoreParams := mysqlctl.RestoreParams{
+		Cnf:                  cnf,
+		Logger:               logutil.NewConsoleLogger(),
+		Mysqld:               mysqld,
+		Concurrency:          2,
+		HookExtraEnv:         map[string]string{},
+		DbName:               "test",
+		Keyspace:             "test",
+		Shard:                "-",
+		StartTime:            time.Now(),
+		Stats:                backupstats.NewFakeStats(),
+		MysqlShutdownTimeout: MysqlShutdownTimeout,
+	}
+
+	// Restoring with a different key fails.
+	require.NoError(t, os.WriteFile(mysqlctl.BackupEncryptionKeyFile, []byte(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))), 0600))
+	bh = filebackupstorage.NewBackupHandle(nil, "", "", true)
+	_, err = be.ExecuteRestore(ctx, restoreParams, bh)
+	assert.ErrorContains(t, err, "cannot unwrap key of backup")
+
+	// Restoring with the right key gives us back the original files.
+	require.NoError(t, os.WriteFile(mysqlctl.BackupEncryptionKeyFile, []byte(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))), 0600))
+	bm, err := be.ExecuteRestore(ctx, restoreParams, bh)
+	require.NoError(t, err)
+	require.NotNil(t, bm)
+
+	restored, err := os.ReadFile(path.Join(dataDir, "test1", "0.ibd"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world!", string(restored))
+}
+
 type rwCloseFailFirstCall struct {
 	*bytes.Buffer
 	firstDone bool
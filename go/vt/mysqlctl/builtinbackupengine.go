@@ -223,6 +223,11 @@ func (be *BuiltinBackupEngine) ExecuteBackup(ctx context.Context, params BackupP
 	params.Logger.Infof("Executing Backup at %v for keyspace/shard %v/%v on tablet %v, concurrency: %v, compress: %v, incrementalFromPos: %v",
 		params.BackupTime, params.Keyspace, params.Shard, params.TabletAlias, params.Concurrency, backupStorageCompress, params.IncrementalFromPos)
 
+	bh, err := newEncryptedBackupHandle(ctx, bh)
+	if err != nil {
+		return BackupUnusable, vterrors.Wrap(err, "cannot set up backup encryption")
+	}
+
 	if isIncrementalBackup(params) {
 		return be.executeIncrementalBackup(ctx, params, bh)
 	}
@@ -1020,6 +1025,7 @@ func (be *BuiltinBackupEngine) backupManifest(
 				MySQLVersion:       mysqlVersion,
 				UpgradeSafe:        params.UpgradeSafe,
 				IncrementalDetails: incrDetails,
+				Encryption:         backupEncryptionFor(bh),
 			},
 
 			// Builtin-specific fields
@@ -1114,6 +1120,11 @@ func (be *BuiltinBackupEngine) ExecuteRestore(ctx context.Context, params Restor
 		return nil, err
 	}
 
+	bh, err = newDecryptedBackupHandle(ctx, bh, bm.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
 	// mark restore as in progress
 	if err := createStateFile(params.Cnf); err != nil {
 		return nil, err
@@ -0,0 +1,481 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	// AES256GCMEncryption is the algorithm used to encrypt the files of a backup
+	AES256GCMEncryption = "aes-256-gcm"
+
+	// FileKeyManager is the name of the KeyManager that reads the key encryption key
+	// from the file given by --backup-encryption-key-file
+	FileKeyManager = "file"
+
+	dataKeySize = 32
+
+	// encryptedFileVersion is the first byte of every encrypted file
+	encryptedFileVersion byte = 1
+
+	// Every encrypted file starts with a version byte and a random nonce prefix.
+	// The nonce of each segment is made of that prefix, the segment number and
+	// a flag telling whether this is the last segment, so segments can't be
+	// reordered and a truncated file is detected.
+	noncePrefixSize = 7
+	headerSize      = 1 + noncePrefixSize
+
+	// encryptedSegmentSize is the amount of plaintext sealed in a segment
+	encryptedSegmentSize = 64 * 1024
+)
+
+var (
+	// BackupEncryptionKeyFile is the file holding the key used to wrap the data keys of backups
+	BackupEncryptionKeyFile string
+	// BackupEncryptionKMS is the name of a registered KeyManager used to wrap the data keys of backups
+	BackupEncryptionKMS string
+
+	keyManagersMu sync.Mutex
+	keyManagers   = map[string]KeyManager{}
+
+	errCorruptedEncryptedFile = errors.New("encrypted backup file is corrupted or was encrypted with a different key")
+)
+
+func init() {
+	for _, cmd := range []string{"vtbackup", "vtcombo", "vttablet", "vttestserver"} {
+		servenv.OnParseFor(cmd, registerBackupEncryptionFlags)
+	}
+}
+
+func registerBackupEncryptionFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&BackupEncryptionKeyFile, "backup-encryption-key-file", BackupEncryptionKeyFile, "file with a base64 encoded 256-bit key used to encrypt the keys of builtin and xtrabackup backups. Backups are encrypted when this or --backup-encryption-kms is set, and restoring an encrypted backup with the file key manager requires this flag.")
+	fs.StringVar(&BackupEncryptionKMS, "backup-encryption-kms", BackupEncryptionKMS, "name of the registered key management service used to encrypt the keys of builtin and xtrabackup backups. Takes precedence over --backup-encryption-key-file.")
+}
+
+// BackupEncryption is stored in the manifest of an encrypted backup.
+// Every backup is encrypted with its own data key, which is only stored
+// after being wrapped by a key encryption key owned by a KeyManager.
+type BackupEncryption struct {
+	// Algorithm is the algorithm used to encrypt the backup files
+	Algorithm string
+
+	// KeyManager is the name of the KeyManager that wrapped the data key
+	KeyManager string
+
+	// KeyID identifies the key encryption key within the KeyManager
+	KeyID string
+
+	// WrappedKey is the data key, encrypted with the key encryption key
+	WrappedKey []byte
+}
+
+// KeyManager wraps and unwraps the data keys used to encrypt backups.
+// Implementations backed by an external key management service can
+// be made available with RegisterKeyManager.
+type KeyManager interface {
+	// WrapKey encrypts a data key. It returns the encrypted key,
+	// along with the ID of the key encryption key that was used.
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+
+	// UnwrapKey decrypts a data key that was encrypted with the given key encryption key.
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// RegisterKeyManager makes a KeyManager available under the given name,
+// so it can be selected with --backup-encryption-kms
+func RegisterKeyManager(name string, km KeyManager) {
+	keyManagersMu.Lock()
+	defer keyManagersMu.Unlock()
+
+	if _, ok := keyManagers[name]; ok || name == FileKeyManager {
+		panic(fmt.Sprintf("key manager %v is already registered", name))
+	}
+	keyManagers[name] = km
+}
+
+func getKeyManager(name string) (KeyManager, error) {
+	if name == FileKeyManager {
+		if BackupEncryptionKeyFile == "" {
+			return nil, vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "backup is encrypted with a key from a file, but --backup-encryption-key-file is not set")
+		}
+		return &fileKeyManager{path: BackupEncryptionKeyFile}, nil
+	}
+
+	keyManagersMu.Lock()
+	defer keyManagersMu.Unlock()
+	km, ok := keyManagers[name]
+	if !ok {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "unknown key manager %q", name)
+	}
+	return km, nil
+}
+
+// configuredKeyManager returns the name of the KeyManager new backups are encrypted with,
+// or an empty string when backup encryption is not configured
+func configuredKeyManager() string {
+	switch {
+	case BackupEncryptionKMS != "":
+		return BackupEncryptionKMS
+	case BackupEncryptionKeyFile != "":
+		return FileKeyManager
+	default:
+		return ""
+	}
+}
+
+// fileKeyManager wraps data keys with a key read from a local file
+type fileKeyManager struct {
+	path string
+}
+
+var _ KeyManager = (*fileKeyManager)(nil)
+
+func (fkm *fileKeyManager) load() (keyID string, aead cipher.AEAD, err error) {
+	data, err := os.ReadFile(fkm.path)
+	if err != nil {
+		return "", nil, vterrors.Wrapf(err, "cannot read backup encryption key file %v", fkm.path)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) != dataKeySize {
+		return "", nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "backup encryption key file %v must contain a base64 encoded %d-bit key", fkm.path, dataKeySize*8)
+	}
+
+	// The key ID is derived from the key itself, so we can tell
+	// whether the configured key is the one a backup was taken with.
+	sum := sha256.Sum256(key)
+	aead, err = newAEAD(key)
+	return hex.EncodeToString(sum[:8]), aead, err
+}
+
+// WrapKey is part of the KeyManager interface
+func (fkm *fileKeyManager) WrapKey(_ context.Context, dataKey []byte) (string, []byte, error) {
+	keyID, aead, err := fkm.load()
+	if err != nil {
+		return "", nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	return keyID, aead.Seal(nonce, nonce, dataKey, []byte(keyID)), nil
+}
+
+// UnwrapKey is part of the KeyManager interface
+func (fkm *fileKeyManager) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	currentKeyID, aead, err := fkm.load()
+	if err != nil {
+		return nil, err
+	}
+	if keyID != currentKeyID {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "backup was encrypted with key %v, but the key in %v is %v", keyID, fkm.path, currentKeyID)
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "wrapped backup key is too short")
+	}
+	nonce, ciphertext := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, vterrors.Wrap(err, "cannot unwrap backup key")
+	}
+	return dataKey, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptedBackupHandle encrypts all the files added to a backup, and decrypts
+// all the files read from it, except for the MANIFEST which has to remain readable
+// so we know how to decrypt the rest of the backup.
+type encryptedBackupHandle struct {
+	backupstorage.BackupHandle
+
+	aead       cipher.AEAD
+	encryption *BackupEncryption
+}
+
+// newEncryptedBackupHandle generates a data key for a new backup and wraps it with the
+// configured KeyManager. If backup encryption is not configured, bh is returned as is.
+func newEncryptedBackupHandle(ctx context.Context, bh backupstorage.BackupHandle) (backupstorage.BackupHandle, error) {
+	name := configuredKeyManager()
+	if name == "" {
+		return bh, nil
+	}
+	km, err := getKeyManager(name)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	keyID, wrapped, err := km.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "cannot wrap backup key")
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedBackupHandle{
+		BackupHandle: bh,
+		aead:         aead,
+		encryption: &BackupEncryption{
+			Algorithm:  AES256GCMEncryption,
+			KeyManager: name,
+			KeyID:      keyID,
+			WrappedKey: wrapped,
+		},
+	}, nil
+}
+
+// newDecryptedBackupHandle unwraps the data key of an encrypted backup, as described in its manifest.
+// If the backup is not encrypted, bh is returned as is.
+func newDecryptedBackupHandle(ctx context.Context, bh backupstorage.BackupHandle, encryption *BackupEncryption) (backupstorage.BackupHandle, error) {
+	if encryption == nil {
+		return bh, nil
+	}
+	if encryption.Algorithm != AES256GCMEncryption {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "unsupported backup encryption algorithm %q", encryption.Algorithm)
+	}
+	km, err := getKeyManager(encryption.KeyManager)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := km.UnwrapKey(ctx, encryption.KeyID, encryption.WrappedKey)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot unwrap key of backup %v", bh.Name())
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedBackupHandle{
+		BackupHandle: bh,
+		aead:         aead,
+		encryption:   encryption,
+	}, nil
+}
+
+// backupEncryptionFor returns the encryption information to store in the manifest of the backup
+func backupEncryptionFor(bh backupstorage.BackupHandle) *BackupEncryption {
+	if ebh, ok := bh.(*encryptedBackupHandle); ok {
+		return ebh.encryption
+	}
+	return nil
+}
+
+// AddFile is part of the backupstorage.BackupHandle interface
+func (ebh *encryptedBackupHandle) AddFile(ctx context.Context, filename string, filesize int64) (io.WriteCloser, error) {
+	wc, err := ebh.BackupHandle.AddFile(ctx, filename, filesize)
+	if err != nil || filename == backupManifestFileName {
+		return wc, err
+	}
+	return newEncryptingWriter(wc, ebh.aead)
+}
+
+// ReadFile is part of the backupstorage.BackupHandle interface
+func (ebh *encryptedBackupHandle) ReadFile(ctx context.Context, filename string) (io.ReadCloser, error) {
+	rc, err := ebh.BackupHandle.ReadFile(ctx, filename)
+	if err != nil || filename == backupManifestFileName {
+		return rc, err
+	}
+	return newDecryptingReader(rc, ebh.aead), nil
+}
+
+func segmentNonce(prefix []byte, segment uint32, last bool) []byte {
+	nonce := make([]byte, noncePrefixSize+5)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], segment)
+	if last {
+		nonce[noncePrefixSize+4] = 1
+	}
+	return nonce
+}
+
+// encryptingWriter seals everything written to it in segments of encryptedSegmentSize bytes
+type encryptingWriter struct {
+	wc      io.WriteCloser
+	aead    cipher.AEAD
+	prefix  []byte
+	segment uint32
+	buf     []byte
+	sealed  []byte
+}
+
+func newEncryptingWriter(wc io.WriteCloser, aead cipher.AEAD) (*encryptingWriter, error) {
+	header := make([]byte, headerSize)
+	header[0] = encryptedFileVersion
+	if _, err := rand.Read(header[1:]); err != nil {
+		return nil, err
+	}
+	if _, err := wc.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptingWriter{
+		wc:     wc,
+		aead:   aead,
+		prefix: header[1:],
+		buf:    make([]byte, 0, encryptedSegmentSize),
+	}, nil
+}
+
+func (ew *encryptingWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		// Only seal a full segment once we know more data follows it,
+		// since the last segment has to be sealed differently.
+		if len(ew.buf) == encryptedSegmentSize {
+			if err := ew.seal(false); err != nil {
+				return written, err
+			}
+		}
+		n := min(len(p), encryptedSegmentSize-len(ew.buf))
+		ew.buf = append(ew.buf, p[:n]...)
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (ew *encryptingWriter) seal(last bool) error {
+	if ew.segment == ^uint32(0) {
+		return vterrors.New(vtrpc.Code_INVALID_ARGUMENT, "backup file is too large to be encrypted")
+	}
+	ew.sealed = ew.aead.Seal(ew.sealed[:0], segmentNonce(ew.prefix, ew.segment, last), ew.buf, nil)
+	ew.segment++
+	ew.buf = ew.buf[:0]
+	_, err := ew.wc.Write(ew.sealed)
+	return err
+}
+
+// Close seals the last segment and closes the underlying writer
+func (ew *encryptingWriter) Close() error {
+	err := ew.seal(true)
+	return errors.Join(err, ew.wc.Close())
+}
+
+// decryptingReader opens the segments written by encryptingWriter
+type decryptingReader struct {
+	rc      io.ReadCloser
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	segment uint32
+	sealed  []byte
+	buf     []byte
+	done    bool
+}
+
+func newDecryptingReader(rc io.ReadCloser, aead cipher.AEAD) *decryptingReader {
+	return &decryptingReader{
+		rc:     rc,
+		r:      bufio.NewReader(rc),
+		aead:   aead,
+		sealed: make([]byte, encryptedSegmentSize+aead.Overhead()),
+	}
+}
+
+func (dr *decryptingReader) Read(p []byte) (int, error) {
+	for len(dr.buf) == 0 {
+		if dr.done {
+			return 0, io.EOF
+		}
+		if err := dr.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, dr.buf)
+	dr.buf = dr.buf[n:]
+	return n, nil
+}
+
+func (dr *decryptingReader) open() error {
+	if dr.prefix == nil {
+		header := make([]byte, headerSize)
+		if _, err := io.ReadFull(dr.r, header); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return errCorruptedEncryptedFile
+			}
+			return err
+		}
+		if header[0] != encryptedFileVersion {
+			return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "unsupported encrypted backup file version %d", header[0])
+		}
+		dr.prefix = header[1:]
+	}
+
+	n, err := io.ReadFull(dr.r, dr.sealed)
+	last := false
+	switch {
+	case err == io.ErrUnexpectedEOF:
+		last = true
+	case err == io.EOF:
+		// even an empty file has a last segment holding its authentication tag
+		return errCorruptedEncryptedFile
+	case err != nil:
+		return err
+	default:
+		// a full segment is the last one if nothing follows it
+		if _, err := dr.r.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	plaintext, err := dr.aead.Open(dr.sealed[:0], segmentNonce(dr.prefix, dr.segment, last), dr.sealed[:n], nil)
+	if err != nil {
+		return errCorruptedEncryptedFile
+	}
+	dr.segment++
+	dr.buf = plaintext
+	dr.done = last
+	return nil
+}
+
+// Close closes the underlying reader
+func (dr *decryptingReader) Close() error {
+	return dr.rc.Close()
+}
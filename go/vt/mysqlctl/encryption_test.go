@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bufferWriteCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferWriteCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+func newTestKey(t *testing.T) []byte {
+	key := make([]byte, dataKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func writeEncrypted(t *testing.T, key, data []byte, chunk int) []byte {
+	aead, err := newAEAD(key)
+	require.NoError(t, err)
+
+	var out bufferWriteCloser
+	ew, err := newEncryptingWriter(&out, aead)
+	require.NoError(t, err)
+	for len(data) > 0 {
+		n := min(chunk, len(data))
+		written, err := ew.Write(data[:n])
+		require.NoError(t, err)
+		require.Equal(t, n, written)
+		data = data[n:]
+	}
+	require.NoError(t, ew.Close())
+	require.True(t, out.closed)
+	return out.Bytes()
+}
+
+func readEncrypted(key, data []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(newDecryptingReader(io.NopCloser(bytes.NewReader(data)), aead))
+}
+
+func TestEncryptedFileRoundTrip(t *testing.T) {
+	key := newTestKey(t)
+	sizes := []int{0, 1, encryptedSegmentSize - 1, encryptedSegmentSize, encryptedSegmentSize + 1, 3*encryptedSegmentSize + 17}
+	for _, size := range sizes {
+		for _, chunk := range []int{7, 4096, encryptedSegmentSize + 5} {
+			t.Run(fmt.Sprintf("size %d chunk %d", size, chunk), func(t *testing.T) {
+				data := make([]byte, size)
+				_, err := rand.Read(data)
+				require.NoError(t, err)
+
+				encrypted := writeEncrypted(t, key, data, chunk)
+				// Shorter plaintexts can show up in the ciphertext by chance.
+				if size >= 16 {
+					assert.NotContains(t, string(encrypted), string(data[:min(size, 64)]))
+				}
+
+				decrypted, err := readEncrypted(key, encrypted)
+				require.NoError(t, err)
+				assert.Equal(t, data, decrypted)
+
+				_, err = readEncrypted(newTestKey(t), encrypted)
+				assert.ErrorIs(t, err, errCorruptedEncryptedFile)
+			})
+		}
+	}
+}
+
+func TestEncryptedFileTampering(t *testing.T) {
+	key := newTestKey(t)
+	data := bytes.Repeat([]byte("vitess"), encryptedSegmentSize)
+	encrypted := writeEncrypted(t, key, data, 1000)
+
+	t.Run("modified", func(t *testing.T) {
+		modified := bytes.Clone(encrypted)
+		modified[len(modified)/2] ^= 1
+		_, err := readEncrypted(key, modified)
+		assert.ErrorIs(t, err, errCorruptedEncryptedFile)
+	})
+
+	t.Run("truncated on a segment boundary", func(t *testing.T) {
+		// drop the last segment, so the one before it looks like the last one
+		segments := len(data)/encryptedSegmentSize - 1
+		truncated := encrypted[:headerSize+segments*(encryptedSegmentSize+16)]
+		_, err := readEncrypted(key, truncated)
+		assert.ErrorIs(t, err, errCorruptedEncryptedFile)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := readEncrypted(key, nil)
+		assert.ErrorIs(t, err, errCorruptedEncryptedFile)
+	})
+
+	t.Run("different key", func(t *testing.T) {
+		otherKey := newTestKey(t)
+		_, err := readEncrypted(otherKey, encrypted)
+		assert.ErrorIs(t, err, errCorruptedEncryptedFile)
+	})
+}
+
+func writeKeyFile(t *testing.T) string {
+	key := newTestKey(t)
+	keyFile := path.Join(t.TempDir(), "backup.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+	return keyFile
+}
+
+func TestFileKeyManager(t *testing.T) {
+	ctx := context.Background()
+	km := &fileKeyManager{path: writeKeyFile(t)}
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	keyID, wrapped, err := km.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+	assert.Len(t, keyID, 16)
+	assert.NotContains(t, string(wrapped), string(dataKey))
+
+	unwrapped, err := km.UnwrapKey(ctx, keyID, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = km.UnwrapKey(ctx, "0000000000000000", wrapped)
+	assert.ErrorContains(t, err, "backup was encrypted with key 0000000000000000")
+
+	other := &fileKeyManager{path: writeKeyFile(t)}
+	_, err = other.UnwrapKey(ctx, keyID, wrapped)
+	assert.ErrorContains(t, err, fmt.Sprintf("backup was encrypted with key %s", keyID))
+
+	badKeyFile := path.Join(t.TempDir(), "bad.key")
+	require.NoError(t, os.WriteFile(badKeyFile, []byte("not a key"), 0600))
+	_, _, err = (&fileKeyManager{path: badKeyFile}).WrapKey(ctx, dataKey)
+	assert.ErrorContains(t, err, "must contain a base64 encoded 256-bit key")
+}
+
+func TestEncryptedBackupHandle(t *testing.T) {
+	ctx := context.Background()
+	defer func(keyFile string) { BackupEncryptionKeyFile = keyFile }(BackupEncryptionKeyFile)
+
+	files := map[string]*bufferWriteCloser{}
+	fbh := &FakeBackupHandle{
+		NameV: "backup",
+		AddFileReturnF: func(filename string) FakeBackupHandleAddFileReturn {
+			files[filename] = &bufferWriteCloser{}
+			return FakeBackupHandleAddFileReturn{WriteCloser: files[filename]}
+		},
+		ReadFileReturnF: func(ctx context.Context, filename string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(files[filename].Bytes())), nil
+		},
+	}
+
+	// without any key configured, the backup handle is used as is
+	BackupEncryptionKeyFile = ""
+	bh, err := newEncryptedBackupHandle(ctx, fbh)
+	require.NoError(t, err)
+	assert.Same(t, fbh, bh)
+	assert.Nil(t, backupEncryptionFor(bh))
+
+	BackupEncryptionKeyFile = writeKeyFile(t)
+	bh, err = newEncryptedBackupHandle(ctx, fbh)
+	require.NoError(t, err)
+
+	encryption := backupEncryptionFor(bh)
+	require.NotNil(t, encryption)
+	assert.Equal(t, AES256GCMEncryption, encryption.Algorithm)
+	assert.Equal(t, FileKeyManager, encryption.KeyManager)
+	assert.NotEmpty(t, encryption.KeyID)
+
+	for name, content := range map[string]string{"0": "some data", backupManifestFileName: `{"BackupName": "backup"}`} {
+		wc, err := bh.AddFile(ctx, name, int64(len(content)))
+		require.NoError(t, err)
+		_, err = wc.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, wc.Close())
+	}
+
+	// the MANIFEST has to remain readable, everything else is encrypted
+	assert.Equal(t, `{"BackupName": "backup"}`, files[backupManifestFileName].String())
+	assert.NotContains(t, files["0"].String(), "some data")
+
+	bh, err = newDecryptedBackupHandle(ctx, fbh, encryption)
+	require.NoError(t, err)
+	rc, err := bh.ReadFile(ctx, "0")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "some data", string(data))
+
+	// restoring with a different key fails before reading any file
+	BackupEncryptionKeyFile = writeKeyFile(t)
+	_, err = newDecryptedBackupHandle(ctx, fbh, encryption)
+	assert.ErrorContains(t, err, "cannot unwrap key of backup backup")
+
+	// and so does restoring without any key
+	BackupEncryptionKeyFile = ""
+	_, err = newDecryptedBackupHandle(ctx, fbh, encryption)
+	assert.ErrorContains(t, err, "--backup-encryption-key-file is not set")
+}
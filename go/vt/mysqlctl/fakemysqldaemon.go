@@ -782,6 +782,12 @@ func (fmd *FakeMysqlDaemon) HostMetrics(ctx context.Context, cnf *Mycnf) (*mysql
 			"datadir-used-ratio": {
 				Value: 0.2,
 			},
+			"datadir-io-utilization": {
+				Value: 0.1,
+			},
+			"datadir-io-latency": {
+				Value: 1.0,
+			},
 		},
 	}, nil
 }
@@ -39,6 +39,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -1308,6 +1309,10 @@ func (mysqld *Mysqld) GetVersionComment(ctx context.Context) (string, error) {
 	return res.ToString("@@global.version_comment")
 }
 
+// lastDatadirDiskStats is the previous sample of the I/O statistics of the datadir's device. I/O
+// utilization and latency are computed over the time between two samples.
+var lastDatadirDiskStats atomic.Pointer[osutil.DiskStats]
+
 // hostMetrics returns several OS metrics to be used by the tablet throttler.
 func hostMetrics(ctx context.Context, cnf *Mycnf) (*mysqlctlpb.HostMetricsResponse, error) {
 	resp := &mysqlctlpb.HostMetricsResponse{
@@ -1354,6 +1359,32 @@ func hostMetrics(ctx context.Context, cnf *Mycnf) (*mysqlctlpb.HostMetricsRespon
 		return nil
 	}()
 
+	_ = func() error {
+		utilizationMetric := newMetric("datadir-io-utilization")
+		latencyMetric := newMetric("datadir-io-latency")
+		withErrors := func(err error) error {
+			withError(utilizationMetric, err)
+			return withError(latencyMetric, err)
+		}
+		stats, err := osutil.ReadDiskStats(cnf.DataDir)
+		if err != nil {
+			return withErrors(err)
+		}
+		prev := lastDatadirDiskStats.Swap(stats)
+		if prev == nil || prev.Device != stats.Device {
+			return withErrors(fmt.Errorf("no previous sample of the disk statistics of %s", cnf.DataDir))
+		}
+		// 0.0 for idle device, 1.0 for saturated device
+		if utilizationMetric.Value, err = osutil.DiskIOUtilization(prev, stats); err != nil {
+			return withErrors(err)
+		}
+		// Average milliseconds per I/O request
+		if latencyMetric.Value, err = osutil.DiskIOLatency(prev, stats); err != nil {
+			return withErrors(err)
+		}
+		return nil
+	}()
+
 	return resp, nil
 }
 
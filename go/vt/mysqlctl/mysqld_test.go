@@ -321,4 +321,7 @@ func TestHostMetrics(t *testing.T) {
 	metric := resp.Metrics["datadir-used-ratio"]
 	assert.Equal(t, "datadir-used-ratio", metric.Name)
 	assert.Empty(t, metric.Error)
+	// I/O metrics are computed between two samples, and may not be available, e.g. on a tmpfs datadir.
+	assert.Contains(t, resp.Metrics, "datadir-io-utilization")
+	assert.Contains(t, resp.Metrics, "datadir-io-latency")
 }
@@ -173,6 +173,10 @@ func (be *XtrabackupEngine) ExecuteBackup(ctx context.Context, params BackupPara
 	params.Logger.Infof("Executing Backup at %v for keyspace/shard %v/%v on tablet %v, concurrency: %v, compress: %v, incrementalFromPos: %v",
 		params.BackupTime, params.Keyspace, params.Shard, params.TabletAlias, params.Concurrency, backupStorageCompress, params.IncrementalFromPos)
 
+	bh, err := newEncryptedBackupHandle(ctx, bh)
+	if err != nil {
+		return BackupUnusable, vterrors.Wrap(err, "cannot set up backup encryption")
+	}
 	return be.executeFullBackup(ctx, params, bh)
 }
 
@@ -259,6 +263,7 @@ func (be *XtrabackupEngine) executeFullBackup(ctx context.Context, params Backup
 			// xtrabackup backups are always created such that they
 			// are safe to use for upgrades later on.
 			UpgradeSafe: true,
+			Encryption:  backupEncryptionFor(bh),
 		},
 
 		// XtraBackup-specific fields
@@ -474,6 +479,11 @@ func (be *XtrabackupEngine) ExecuteRestore(ctx context.Context, params RestorePa
 		return nil, err
 	}
 
+	bh, err := newDecryptedBackupHandle(ctx, bh, bm.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
 	// mark restore as in progress
 	if err := createStateFile(params.Cnf); err != nil {
 		return nil, err
@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// CutOverWindow is a recurring time range in which a migration is allowed to cut over.
+// Its textual form is `[<days>] <HH:MM>-<HH:MM> [<timezone>]`, where:
+//   - days is `*` or a comma separated list of days and day ranges, using cron's day-of-week
+//     notation: `Sun`..`Sat` or `0`..`6`, e.g. `Mon-Fri` or `Sat,Sun`. It defaults to all days.
+//   - the time range starts and ends at the given times of day. A range that ends before it
+//     starts crosses midnight, and belongs to the day in which it starts.
+//   - timezone is an IANA time zone name, e.g. `America/New_York`. It defaults to UTC.
+type CutOverWindow struct {
+	days     [7]bool
+	start    int // minutes since midnight
+	end      int // minutes since midnight
+	location *time.Location
+}
+
+// CutOverWindows is a list of windows, any of which allows a migration to cut over.
+type CutOverWindows []*CutOverWindow
+
+// ParseCutOverWindows parses a `;` separated list of cut-over windows.
+func ParseCutOverWindows(s string) (CutOverWindows, error) {
+	var windows CutOverWindows
+	for _, spec := range strings.Split(s, ";") {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		window, err := parseCutOverWindow(spec)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+func parseCutOverWindow(spec string) (*CutOverWindow, error) {
+	tokens := strings.Fields(spec)
+	// The time range is the only token with a colon, and tells the days from the timezone.
+	rangeIndex := -1
+	for i, token := range tokens {
+		if strings.Contains(token, ":") {
+			rangeIndex = i
+			break
+		}
+	}
+	if rangeIndex < 0 || rangeIndex > 1 || len(tokens)-rangeIndex > 2 {
+		return nil, fmt.Errorf("invalid cut-over window %q: expected [<days>] <HH:MM>-<HH:MM> [<timezone>]", spec)
+	}
+	window := &CutOverWindow{location: time.UTC}
+	days := "*"
+	if rangeIndex == 1 {
+		days = tokens[0]
+	}
+	if err := window.parseDays(days); err != nil {
+		return nil, fmt.Errorf("invalid cut-over window %q: %w", spec, err)
+	}
+	from, to, ok := strings.Cut(tokens[rangeIndex], "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid cut-over window %q: expected a <HH:MM>-<HH:MM> time range", spec)
+	}
+	var err error
+	if window.start, err = parseTimeOfDay(from); err != nil {
+		return nil, fmt.Errorf("invalid cut-over window %q: %w", spec, err)
+	}
+	if window.end, err = parseTimeOfDay(to); err != nil {
+		return nil, fmt.Errorf("invalid cut-over window %q: %w", spec, err)
+	}
+	if rangeIndex+1 < len(tokens) {
+		if window.location, err = time.LoadLocation(tokens[rangeIndex+1]); err != nil {
+			return nil, fmt.Errorf("invalid cut-over window %q: %w", spec, err)
+		}
+	}
+	return window, nil
+}
+
+// parseDays parses a day-of-week field.
+func (w *CutOverWindow) parseDays(s string) error {
+	if s == "*" {
+		for i := range w.days {
+			w.days[i] = true
+		}
+		return nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, err := parseWeekday(from)
+		if err != nil {
+			return err
+		}
+		last := first
+		if isRange {
+			if last, err = parseWeekday(to); err != nil {
+				return err
+			}
+		}
+		for day := first; ; day = (day + 1) % 7 {
+			w.days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	if day, ok := weekdayNames[strings.ToLower(s)]; ok {
+		return day, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 7 {
+		return 0, fmt.Errorf("invalid day of week %q", s)
+	}
+	// As in cron, both 0 and 7 stand for Sunday.
+	return time.Weekday(n % 7), nil
+}
+
+// parseTimeOfDay parses a HH:MM time into minutes since midnight.
+func parseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// duration returns the length of the window, in minutes.
+func (w *CutOverWindow) duration() int {
+	if w.end > w.start {
+		return w.end - w.start
+	}
+	// The window crosses midnight. A window that ends when it starts lasts a whole day.
+	return w.end - w.start + minutesPerDay
+}
+
+// lastStart returns the latest start of the window that is not after t.
+func (w *CutOverWindow) lastStart(t time.Time) (time.Time, bool) {
+	t = t.In(w.location)
+	for i := 0; i <= 7; i++ {
+		day := t.AddDate(0, 0, -i)
+		start := time.Date(day.Year(), day.Month(), day.Day(), w.start/60, w.start%60, 0, 0, w.location)
+		if w.days[start.Weekday()] && !start.After(t) {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Contains returns true when t is within the window.
+func (w *CutOverWindow) Contains(t time.Time) bool {
+	start, ok := w.lastStart(t)
+	if !ok {
+		return false
+	}
+	return t.Before(start.Add(time.Duration(w.duration()) * time.Minute))
+}
+
+// Next returns the first start of the window after t.
+func (w *CutOverWindow) Next(t time.Time) time.Time {
+	t = t.In(w.location)
+	for i := 0; i <= 7; i++ {
+		day := t.AddDate(0, 0, i)
+		start := time.Date(day.Year(), day.Month(), day.Day(), w.start/60, w.start%60, 0, 0, w.location)
+		if w.days[start.Weekday()] && start.After(t) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+// Contains returns true when t is within any of the windows. An empty list
+// of windows does not restrict cut-overs, and contains any time.
+func (windows CutOverWindows) Contains(t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the earliest time, not before t, in which a cut-over is allowed.
+func (windows CutOverWindows) Next(t time.Time) time.Time {
+	if windows.Contains(t) {
+		return t
+	}
+	var next time.Time
+	for _, w := range windows {
+		if start := w.Next(t); !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return next
+}
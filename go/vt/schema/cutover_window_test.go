@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCutOverWindows(t *testing.T) {
+	tt := []struct {
+		s           string
+		expectError string
+		days        [7]bool
+		start       int
+		end         int
+		location    string
+	}{
+		{
+			s:        "01:00-05:30",
+			days:     [7]bool{true, true, true, true, true, true, true},
+			start:    60,
+			end:      330,
+			location: "UTC",
+		},
+		{
+			s:        "Mon-Fri 22:00-04:00 Europe/Berlin",
+			days:     [7]bool{false, true, true, true, true, true, false},
+			start:    22 * 60,
+			end:      4 * 60,
+			location: "Europe/Berlin",
+		},
+		{
+			s:        "sat,0 00:00-00:00",
+			days:     [7]bool{true, false, false, false, false, false, true},
+			location: "UTC",
+		},
+		{
+			s:        "Fri-Mon 10:00-11:00",
+			days:     [7]bool{true, true, false, false, false, true, true},
+			start:    600,
+			end:      660,
+			location: "UTC",
+		},
+		{
+			s:        "7 10:00-11:00",
+			days:     [7]bool{true, false, false, false, false, false, false},
+			start:    600,
+			end:      660,
+			location: "UTC",
+		},
+		{
+			s:           "Mon",
+			expectError: "expected [<days>] <HH:MM>-<HH:MM> [<timezone>]",
+		},
+		{
+			s:           "Mon 01:00",
+			expectError: "expected a <HH:MM>-<HH:MM> time range",
+		},
+		{
+			s:           "Funday 01:00-02:00",
+			expectError: `invalid day of week "Funday"`,
+		},
+		{
+			s:           "Mon 01:00-02:00 Mars/Olympus",
+			expectError: "unknown time zone Mars/Olympus",
+		},
+		{
+			s:           "Mon 01:00-02:00 UTC extra",
+			expectError: "expected [<days>] <HH:MM>-<HH:MM> [<timezone>]",
+		},
+	}
+	for _, ts := range tt {
+		t.Run(ts.s, func(t *testing.T) {
+			windows, err := ParseCutOverWindows(ts.s)
+			if ts.expectError != "" {
+				assert.ErrorContains(t, err, ts.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, windows, 1)
+			assert.Equal(t, ts.days, windows[0].days)
+			assert.Equal(t, ts.start, windows[0].start)
+			assert.Equal(t, ts.end, windows[0].end)
+			assert.Equal(t, ts.location, windows[0].location.String())
+		})
+	}
+}
+
+func TestCutOverWindowsContains(t *testing.T) {
+	windows, err := ParseCutOverWindows("Mon-Fri 22:00-02:00; Sun 10:00-12:00 America/New_York")
+	require.NoError(t, err)
+
+	// 2024-01-01 is a Monday.
+	tt := []struct {
+		t      time.Time
+		expect bool
+		next   time.Time
+	}{
+		{
+			t:      time.Date(2024, 1, 1, 21, 59, 0, 0, time.UTC),
+			expect: false,
+			next:   time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			t:      time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC),
+			expect: true,
+		},
+		{
+			t:      time.Date(2024, 1, 2, 1, 59, 0, 0, time.UTC),
+			expect: true,
+		},
+		{
+			t:      time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC),
+			expect: false,
+			next:   time.Date(2024, 1, 2, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			// Friday's window ends on Saturday.
+			t:      time.Date(2024, 1, 6, 1, 0, 0, 0, time.UTC),
+			expect: true,
+		},
+		{
+			// There is no window on Saturday night.
+			t:      time.Date(2024, 1, 6, 23, 0, 0, 0, time.UTC),
+			expect: false,
+			next:   time.Date(2024, 1, 7, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			// Sunday 10:30 in New York.
+			t:      time.Date(2024, 1, 7, 15, 30, 0, 0, time.UTC),
+			expect: true,
+		},
+	}
+	for _, ts := range tt {
+		t.Run(ts.t.String(), func(t *testing.T) {
+			assert.Equal(t, ts.expect, windows.Contains(ts.t))
+			next := windows.Next(ts.t)
+			if ts.expect {
+				assert.Equal(t, ts.t, next)
+			} else {
+				assert.True(t, ts.next.Equal(next), "expected %v, got %v", ts.next, next)
+			}
+		})
+	}
+
+	var noWindows CutOverWindows
+	assert.True(t, noWindows.Contains(time.Now()))
+}
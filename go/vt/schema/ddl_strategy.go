@@ -31,6 +31,7 @@ var (
 	cutOverThresholdFlagRegexp  = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, cutOverThresholdFlag))
 	forceCutOverAfterFlagRegexp = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, forceCutOverAfterFlag))
 	retainArtifactsFlagRegexp   = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, retainArtifactsFlag))
+	cutOverWindowFlagRegexp     = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, cutOverWindowFlag))
 )
 
 const (
@@ -48,6 +49,7 @@ const (
 	fastRangeRotationFlag  = "fast-range-rotation"
 	cutOverThresholdFlag   = "cut-over-threshold"
 	forceCutOverAfterFlag  = "force-cut-over-after"
+	cutOverWindowFlag      = "cut-over-window"
 	retainArtifactsFlag    = "retain-artifacts"
 	vreplicationTestSuite  = "vreplication-test-suite"
 	allowForeignKeysFlag   = "unsafe-allow-foreign-keys"
@@ -119,12 +121,19 @@ func ParseDDLStrategy(strategyVariable string) (*DDLStrategySetting, error) {
 	if err != nil {
 		return nil, err
 	}
+	cutOverWindows, err := setting.CutOverWindows()
+	if err != nil {
+		return nil, err
+	}
 	switch setting.Strategy {
 	case DDLStrategyVitess, DDLStrategyOnline:
 	default:
 		if cutoverAfter != 0 {
 			return nil, fmt.Errorf("--force-cut-over-after is only valid in 'vitess' strategy. Found %v value in '%v' strategy", cutoverAfter, setting.Strategy)
 		}
+		if len(cutOverWindows) > 0 {
+			return nil, fmt.Errorf("--cut-over-window is only valid in 'vitess' strategy. Found in '%v' strategy", setting.Strategy)
+		}
 	}
 
 	switch setting.Strategy {
@@ -227,6 +236,15 @@ func isForceCutOverFlag(opt string) (string, bool) {
 	return submatch[1], true
 }
 
+// isCutOverWindowFlag returns true when given option denotes a `--cut-over-window=[...]` flag
+func isCutOverWindowFlag(opt string) (string, bool) {
+	submatch := cutOverWindowFlagRegexp.FindStringSubmatch(opt)
+	if len(submatch) == 0 {
+		return "", false
+	}
+	return submatch[1], true
+}
+
 // isRetainArtifactsFlag returns true when given option denotes a `--retain-artifacts=[...]` flag
 func isRetainArtifactsFlag(opt string) (string, bool) {
 	submatch := retainArtifactsFlagRegexp.FindStringSubmatch(opt)
@@ -272,6 +290,25 @@ func (setting *DDLStrategySetting) ForceCutOverAfter() (d time.Duration, err err
 	return d, err
 }
 
+// CutOverWindows returns the windows indicated by --cut-over-window, in which a migration is
+// allowed to cut over. An empty list means cut-over is allowed at any time.
+func (setting *DDLStrategySetting) CutOverWindows() (windows CutOverWindows, err error) {
+	// We do some ugly manual parsing of --cut-over-window value
+	opts, _ := shlex.Split(setting.Options)
+	for _, opt := range opts {
+		if val, isCutOverWindow := isCutOverWindowFlag(opt); isCutOverWindow {
+			// value is possibly quoted
+			if s, err := strconv.Unquote(val); err == nil {
+				val = s
+			}
+			if val != "" {
+				windows, err = ParseCutOverWindows(val)
+			}
+		}
+	}
+	return windows, err
+}
+
 // RetainArtifactsDuration returns a the duration indicated by --retain-artifacts
 func (setting *DDLStrategySetting) RetainArtifactsDuration() (d time.Duration, err error) {
 	// We do some ugly manual parsing of --retain-artifacts
@@ -316,6 +353,9 @@ func (setting *DDLStrategySetting) RuntimeOptions() []string {
 		if _, ok := isForceCutOverFlag(opt); ok {
 			continue
 		}
+		if _, ok := isCutOverWindowFlag(opt); ok {
+			continue
+		}
 		if _, ok := isRetainArtifactsFlag(opt); ok {
 			continue
 		}
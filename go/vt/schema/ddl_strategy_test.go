@@ -197,6 +197,7 @@ func TestParseDDLStrategy(t *testing.T) {
 		analyzeTable         bool
 		cutOverThreshold     time.Duration
 		forceCutOverAfter    time.Duration
+		cutOverWindows       int
 		expireArtifacts      time.Duration
 		runtimeOptions       string
 		expectError          string
@@ -338,6 +339,32 @@ func TestParseDDLStrategy(t *testing.T) {
 			runtimeOptions:   "",
 			expectError:      "--force-cut-over-after is only valid in 'vitess' strategy",
 		},
+		{
+			strategyVariable: `vitess --cut-over-window="Mon-Fri 01:00-05:00 UTC"`,
+			strategy:         DDLStrategyVitess,
+			options:          `--cut-over-window="Mon-Fri 01:00-05:00 UTC"`,
+			runtimeOptions:   "",
+			cutOverWindows:   1,
+		},
+		{
+			strategyVariable: `vitess --cut-over-window="Sat,Sun 22:00-04:00; 03:00-04:00 America/New_York"`,
+			strategy:         DDLStrategyVitess,
+			options:          `--cut-over-window="Sat,Sun 22:00-04:00; 03:00-04:00 America/New_York"`,
+			runtimeOptions:   "",
+			cutOverWindows:   2,
+		},
+		{
+			strategyVariable: `vitess --cut-over-window="Mon 25:00-26:00"`,
+			strategy:         DDLStrategyVitess,
+			runtimeOptions:   "",
+			expectError:      `invalid time of day "25:00"`,
+		},
+		{
+			strategyVariable: `mysql --cut-over-window="01:00-05:00"`,
+			strategy:         DDLStrategyMySQL,
+			runtimeOptions:   "",
+			expectError:      "--cut-over-window is only valid in 'vitess' strategy",
+		},
 		{
 			strategyVariable: "vitess --retain-artifacts=4m",
 			strategy:         DDLStrategyVitess,
@@ -392,6 +419,9 @@ func TestParseDDLStrategy(t *testing.T) {
 			forceCutOverAfter, err := setting.ForceCutOverAfter()
 			assert.NoError(t, err)
 			assert.Equal(t, ts.forceCutOverAfter, forceCutOverAfter)
+			cutOverWindows, err := setting.CutOverWindows()
+			assert.NoError(t, err)
+			assert.Len(t, cutOverWindows, ts.cutOverWindows)
 
 			runtimeOptions := strings.Join(setting.RuntimeOptions(), " ")
 			assert.Equal(t, ts.runtimeOptions, runtimeOptions)
@@ -78,6 +78,7 @@ CREATE TABLE IF NOT EXISTS schema_migrations
     `last_cutover_attempt_timestamp`  timestamp        NULL DEFAULT NULL,
     `force_cutover`                   tinyint unsigned NOT NULL DEFAULT '0',
     `cutover_threshold_seconds`       int unsigned     NOT NULL DEFAULT '0',
+    `next_cutover_window_timestamp`   timestamp        NULL DEFAULT NULL,
     PRIMARY KEY (`id`),
     UNIQUE KEY `uuid_idx` (`migration_uuid`),
     KEY `keyspace_shard_idx` (`keyspace`(64), `shard`(64)),
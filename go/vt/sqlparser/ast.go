@@ -503,6 +503,18 @@ type (
 		Comments Comments
 	}
 
+	// ShowDeadLetters represents a SHOW VITESS_DEAD_LETTERS statement
+	ShowDeadLetters struct {
+		Table TableName
+		Where *Where
+	}
+
+	// ReplayDeadLetters represents a REPLAY VITESS_DEAD_LETTERS statement
+	ReplayDeadLetters struct {
+		Table TableName
+		Where *Where
+	}
+
 	// RevertMigration represents a REVERT VITESS_MIGRATION statement
 	RevertMigration struct {
 		UUID     string
@@ -791,6 +803,8 @@ func (*AlterTable) iStatement()            {}
 func (*AlterVschema) iStatement()          {}
 func (*AlterMigration) iStatement()        {}
 func (*RevertMigration) iStatement()       {}
+func (*ShowDeadLetters) iStatement()       {}
+func (*ReplayDeadLetters) iStatement()     {}
 func (*ShowMigrationLogs) iStatement()     {}
 func (*ShowThrottledApps) iStatement()     {}
 func (*ShowThrottlerStatus) iStatement()   {}
@@ -421,6 +421,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfRenameTable(in)
 	case *RenameTableName:
 		return CloneRefOfRenameTableName(in)
+	case *ReplayDeadLetters:
+		return CloneRefOfReplayDeadLetters(in)
 	case *RevertMigration:
 		return CloneRefOfRevertMigration(in)
 	case *Rollback:
@@ -451,6 +453,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfShowBasic(in)
 	case *ShowCreate:
 		return CloneRefOfShowCreate(in)
+	case *ShowDeadLetters:
+		return CloneRefOfShowDeadLetters(in)
 	case *ShowFilter:
 		return CloneRefOfShowFilter(in)
 	case *ShowMigrationLogs:
@@ -2699,6 +2703,17 @@ func CloneRefOfRenameTableName(n *RenameTableName) *RenameTableName {
 	return &out
 }
 
+// CloneRefOfReplayDeadLetters creates a deep clone of the input.
+func CloneRefOfReplayDeadLetters(n *ReplayDeadLetters) *ReplayDeadLetters {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Table = CloneTableName(n.Table)
+	out.Where = CloneRefOfWhere(n.Where)
+	return &out
+}
+
 // CloneRefOfRevertMigration creates a deep clone of the input.
 func CloneRefOfRevertMigration(n *RevertMigration) *RevertMigration {
 	if n == nil {
@@ -2861,6 +2876,17 @@ func CloneRefOfShowCreate(n *ShowCreate) *ShowCreate {
 	return &out
 }
 
+// CloneRefOfShowDeadLetters creates a deep clone of the input.
+func CloneRefOfShowDeadLetters(n *ShowDeadLetters) *ShowDeadLetters {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Table = CloneTableName(n.Table)
+	out.Where = CloneRefOfWhere(n.Where)
+	return &out
+}
+
 // CloneRefOfShowFilter creates a deep clone of the input.
 func CloneRefOfShowFilter(n *ShowFilter) *ShowFilter {
 	if n == nil {
@@ -4265,6 +4291,8 @@ func CloneStatement(in Statement) Statement {
 		return CloneRefOfRelease(in)
 	case *RenameTable:
 		return CloneRefOfRenameTable(in)
+	case *ReplayDeadLetters:
+		return CloneRefOfReplayDeadLetters(in)
 	case *RevertMigration:
 		return CloneRefOfRevertMigration(in)
 	case *Rollback:
@@ -4279,6 +4307,8 @@ func CloneStatement(in Statement) Statement {
 		return CloneRefOfSet(in)
 	case *Show:
 		return CloneRefOfShow(in)
+	case *ShowDeadLetters:
+		return CloneRefOfShowDeadLetters(in)
 	case *ShowMigrationLogs:
 		return CloneRefOfShowMigrationLogs(in)
 	case *ShowThrottledApps:
@@ -420,6 +420,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfRenameTable(n, parent)
 	case *RenameTableName:
 		return c.copyOnRewriteRefOfRenameTableName(n, parent)
+	case *ReplayDeadLetters:
+		return c.copyOnRewriteRefOfReplayDeadLetters(n, parent)
 	case *RevertMigration:
 		return c.copyOnRewriteRefOfRevertMigration(n, parent)
 	case *Rollback:
@@ -450,6 +452,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfShowBasic(n, parent)
 	case *ShowCreate:
 		return c.copyOnRewriteRefOfShowCreate(n, parent)
+	case *ShowDeadLetters:
+		return c.copyOnRewriteRefOfShowDeadLetters(n, parent)
 	case *ShowFilter:
 		return c.copyOnRewriteRefOfShowFilter(n, parent)
 	case *ShowMigrationLogs:
@@ -5225,6 +5229,30 @@ func (c *cow) copyOnRewriteRefOfRenameTableName(n *RenameTableName, parent SQLNo
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfReplayDeadLetters(n *ReplayDeadLetters, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Table, changedTable := c.copyOnRewriteTableName(n.Table, n)
+		_Where, changedWhere := c.copyOnRewriteRefOfWhere(n.Where, n)
+		if changedTable || changedWhere {
+			res := *n
+			res.Table, _ = _Table.(TableName)
+			res.Where, _ = _Where.(*Where)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfRevertMigration(n *RevertMigration, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -5579,6 +5607,30 @@ func (c *cow) copyOnRewriteRefOfShowCreate(n *ShowCreate, parent SQLNode) (out S
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfShowDeadLetters(n *ShowDeadLetters, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Table, changedTable := c.copyOnRewriteTableName(n.Table, n)
+		_Where, changedWhere := c.copyOnRewriteRefOfWhere(n.Where, n)
+		if changedTable || changedWhere {
+			res := *n
+			res.Table, _ = _Table.(TableName)
+			res.Where, _ = _Where.(*Where)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfShowFilter(n *ShowFilter, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -7700,6 +7752,8 @@ func (c *cow) copyOnRewriteStatement(n Statement, parent SQLNode) (out SQLNode,
 		return c.copyOnRewriteRefOfRelease(n, parent)
 	case *RenameTable:
 		return c.copyOnRewriteRefOfRenameTable(n, parent)
+	case *ReplayDeadLetters:
+		return c.copyOnRewriteRefOfReplayDeadLetters(n, parent)
 	case *RevertMigration:
 		return c.copyOnRewriteRefOfRevertMigration(n, parent)
 	case *Rollback:
@@ -7714,6 +7768,8 @@ func (c *cow) copyOnRewriteStatement(n Statement, parent SQLNode) (out SQLNode,
 		return c.copyOnRewriteRefOfSet(n, parent)
 	case *Show:
 		return c.copyOnRewriteRefOfShow(n, parent)
+	case *ShowDeadLetters:
+		return c.copyOnRewriteRefOfShowDeadLetters(n, parent)
 	case *ShowMigrationLogs:
 		return c.copyOnRewriteRefOfShowMigrationLogs(n, parent)
 	case *ShowThrottledApps:
@@ -1220,6 +1220,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfRenameTableName(a, b)
+	case *ReplayDeadLetters:
+		b, ok := inB.(*ReplayDeadLetters)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfReplayDeadLetters(a, b)
 	case *RevertMigration:
 		b, ok := inB.(*RevertMigration)
 		if !ok {
@@ -1310,6 +1316,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfShowCreate(a, b)
+	case *ShowDeadLetters:
+		b, ok := inB.(*ShowDeadLetters)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfShowDeadLetters(a, b)
 	case *ShowFilter:
 		b, ok := inB.(*ShowFilter)
 		if !ok {
@@ -4125,6 +4137,18 @@ func (cmp *Comparator) RefOfRenameTableName(a, b *RenameTableName) bool {
 	return cmp.TableName(a.Table, b.Table)
 }
 
+// RefOfReplayDeadLetters does deep equals between the two objects.
+func (cmp *Comparator) RefOfReplayDeadLetters(a, b *ReplayDeadLetters) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.TableName(a.Table, b.Table) &&
+		cmp.RefOfWhere(a.Where, b.Where)
+}
+
 // RefOfRevertMigration does deep equals between the two objects.
 func (cmp *Comparator) RefOfRevertMigration(a, b *RevertMigration) bool {
 	if a == b {
@@ -4320,6 +4344,18 @@ func (cmp *Comparator) RefOfShowCreate(a, b *ShowCreate) bool {
 		cmp.TableName(a.Op, b.Op)
 }
 
+// RefOfShowDeadLetters does deep equals between the two objects.
+func (cmp *Comparator) RefOfShowDeadLetters(a, b *ShowDeadLetters) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.TableName(a.Table, b.Table) &&
+		cmp.RefOfWhere(a.Where, b.Where)
+}
+
 // RefOfShowFilter does deep equals between the two objects.
 func (cmp *Comparator) RefOfShowFilter(a, b *ShowFilter) bool {
 	if a == b {
@@ -7056,6 +7092,12 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfRenameTable(a, b)
+	case *ReplayDeadLetters:
+		b, ok := inB.(*ReplayDeadLetters)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfReplayDeadLetters(a, b)
 	case *RevertMigration:
 		b, ok := inB.(*RevertMigration)
 		if !ok {
@@ -7098,6 +7140,12 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfShow(a, b)
+	case *ShowDeadLetters:
+		b, ok := inB.(*ShowDeadLetters)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfShowDeadLetters(a, b)
 	case *ShowMigrationLogs:
 		b, ok := inB.(*ShowMigrationLogs)
 		if !ok {
@@ -380,6 +380,16 @@ func (node *ShowThrottlerStatus) Format(buf *TrackedBuffer) {
 	buf.astPrintf(node, "show vitess_throttler status")
 }
 
+// Format formats the node.
+func (node *ShowDeadLetters) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "show vitess_dead_letters from %v%v", node.Table, node.Where)
+}
+
+// Format formats the node.
+func (node *ReplayDeadLetters) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "replay vitess_dead_letters from %v%v", node.Table, node.Where)
+}
+
 // Format formats the node.
 func (node *OptLike) Format(buf *TrackedBuffer) {
 	buf.astPrintf(node, "like %v", node.LikeTable)
@@ -524,6 +524,20 @@ func (node *ShowThrottlerStatus) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString("show vitess_throttler status")
 }
 
+// FormatFast formats the node.
+func (node *ShowDeadLetters) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("show vitess_dead_letters from ")
+	node.Table.FormatFast(buf)
+	node.Where.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *ReplayDeadLetters) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("replay vitess_dead_letters from ")
+	node.Table.FormatFast(buf)
+	node.Where.FormatFast(buf)
+}
+
 // FormatFast formats the node.
 func (node *OptLike) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString("like ")
@@ -2367,6 +2367,11 @@ func ContainsAggregation(e SQLNode) bool {
 			// so we don't need to worry about aggregation in the original
 			return false, nil
 		case AggrFunc:
+			if IsWindowFunc(node) {
+				// aggregations used as window functions do not group the rows,
+				// but arguments used by them might
+				return true, nil
+			}
 			hasAggregates = true
 			return false, io.EOF
 		}
@@ -2376,6 +2381,140 @@ func ContainsAggregation(e SQLNode) bool {
 	return hasAggregates
 }
 
+// GetOverClause returns the OVER clause of a function call that is being
+// evaluated as a window function. For all other expressions it returns nil.
+func GetOverClause(e SQLNode) *OverClause {
+	switch node := e.(type) {
+	case *ArgumentLessWindowExpr:
+		return node.OverClause
+	case *FirstOrLastValueExpr:
+		return node.OverClause
+	case *NtileExpr:
+		return node.OverClause
+	case *NTHValueExpr:
+		return node.OverClause
+	case *LagLeadExpr:
+		return node.OverClause
+	case *Count:
+		return node.OverClause
+	case *CountStar:
+		return node.OverClause
+	case *Avg:
+		return node.OverClause
+	case *Max:
+		return node.OverClause
+	case *Min:
+		return node.OverClause
+	case *Sum:
+		return node.OverClause
+	case *BitAnd:
+		return node.OverClause
+	case *BitOr:
+		return node.OverClause
+	case *BitXor:
+		return node.OverClause
+	case *Std:
+		return node.OverClause
+	case *StdDev:
+		return node.OverClause
+	case *StdPop:
+		return node.OverClause
+	case *StdSamp:
+		return node.OverClause
+	case *VarPop:
+		return node.OverClause
+	case *VarSamp:
+		return node.OverClause
+	case *Variance:
+		return node.OverClause
+	case *JSONArrayAgg:
+		return node.OverClause
+	case *JSONObjectAgg:
+		return node.OverClause
+	}
+	return nil
+}
+
+// IsWindowFunc returns true if the expression is a function call evaluated as a window function
+func IsWindowFunc(e SQLNode) bool {
+	return GetOverClause(e) != nil
+}
+
+// ContainsWindowFunc returns true if the expression contains a window function.
+// Subqueries are not inspected, since they have their own window functions.
+func ContainsWindowFunc(e SQLNode) bool {
+	hasWindowFunc := false
+	_ = Walk(func(node SQLNode) (kontinue bool, err error) {
+		switch node.(type) {
+		case *Offset, *Subquery:
+			return false, nil
+		}
+		if IsWindowFunc(node) {
+			hasWindowFunc = true
+			return false, io.EOF
+		}
+		return true, nil
+	}, e)
+	return hasWindowFunc
+}
+
+// ResolveWindowSpec returns the window specification used by an OVER clause,
+// following references to named windows defined in the WINDOW clause of the query.
+// A window that is based on another named window inherits its PARTITION BY and
+// ORDER BY clauses. If a referenced window can't be found, nil is returned.
+func ResolveWindowSpec(over *OverClause, windows NamedWindows) *WindowSpecification {
+	if over == nil {
+		return nil
+	}
+	if over.WindowName.NotEmpty() {
+		return resolveNamedWindow(over.WindowName, windows, 0)
+	}
+	return resolveWindowSpec(over.WindowSpec, windows, 0)
+}
+
+func resolveNamedWindow(name IdentifierCI, windows NamedWindows, depth int) *WindowSpecification {
+	if depth > len(windows) {
+		// circular window references are rejected by MySQL, so we don't need to resolve them
+		return nil
+	}
+	for _, namedWindow := range windows {
+		for _, def := range namedWindow.Windows {
+			if def.Name.Equal(name) {
+				return resolveWindowSpec(def.WindowSpec, windows, depth+1)
+			}
+		}
+	}
+	return nil
+}
+
+func resolveWindowSpec(spec *WindowSpecification, windows NamedWindows, depth int) *WindowSpecification {
+	if spec == nil {
+		return &WindowSpecification{}
+	}
+	if spec.Name.IsEmpty() {
+		return spec
+	}
+	base := resolveNamedWindow(spec.Name, windows, depth)
+	if base == nil {
+		return nil
+	}
+	resolved := &WindowSpecification{
+		PartitionClause: base.PartitionClause,
+		OrderClause:     base.OrderClause,
+		FrameClause:     base.FrameClause,
+	}
+	if spec.PartitionClause != nil {
+		resolved.PartitionClause = spec.PartitionClause
+	}
+	if spec.OrderClause != nil {
+		resolved.OrderClause = spec.OrderClause
+	}
+	if spec.FrameClause != nil {
+		resolved.FrameClause = spec.FrameClause
+	}
+	return resolved
+}
+
 // setFuncArgs sets the arguments for the aggregation function, while checking that there is only one argument
 func setFuncArgs(aggr AggrFunc, exprs []Expr, name string) error {
 	if len(exprs) != 1 {
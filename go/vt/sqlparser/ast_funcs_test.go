@@ -254,3 +254,49 @@ func TestKeyspaceToNonQualifiedTable(t *testing.T) {
 	AddKeyspace(stmt, "ks2")
 	require.Equal(t, "select col, col + (select 1 from ks2.t4) from ks.t join ks2.t2 join (select 1 from ks2.t3) as x where t.id = t2.id and x.id = t.id", String(stmt))
 }
+
+func TestWindowFunctions(t *testing.T) {
+	tcs := []struct {
+		expr          string
+		window        bool
+		containsAggr  bool
+		containsWinFn bool
+	}{
+		{expr: "row_number() over ()", window: true, containsWinFn: true},
+		{expr: "sum(x) over (partition by y)", window: true, containsWinFn: true},
+		{expr: "sum(x)", containsAggr: true},
+		{expr: "sum(sum(x)) over ()", window: true, containsAggr: true, containsWinFn: true},
+		{expr: "1 + lag(x) over (order by y)", containsWinFn: true},
+		{expr: "(select rank() over () from t)"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, err := NewTestParser().ParseExpr(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.window, IsWindowFunc(expr), "IsWindowFunc")
+			assert.Equal(t, tc.containsAggr, ContainsAggregation(expr), "ContainsAggregation")
+			assert.Equal(t, tc.containsWinFn, ContainsWindowFunc(expr), "ContainsWindowFunc")
+		})
+	}
+}
+
+func TestResolveWindowSpec(t *testing.T) {
+	stmt, err := NewTestParser().Parse("select rank() over w, sum(x) over (w order by z), count(*) over (partition by a) from t window w as (partition by y)")
+	require.NoError(t, err)
+	sel := stmt.(*Select)
+
+	var specs []string
+	for _, expr := range sel.SelectExprs.Exprs {
+		over := GetOverClause(expr.(*AliasedExpr).Expr)
+		spec := ResolveWindowSpec(over, sel.Windows)
+		require.NotNil(t, spec)
+		specs = append(specs, String(spec))
+	}
+	assert.Equal(t, []string{
+		" partition by y",
+		" partition by y order by z asc",
+		" partition by a",
+	}, specs)
+
+	assert.Nil(t, ResolveWindowSpec(&OverClause{WindowName: NewIdentifierCI("unknown")}, sel.Windows))
+}
@@ -383,6 +383,8 @@ const (
 	RefOfRenameIndexOldName
 	RefOfRenameIndexNewName
 	RefOfRenameTableNameTable
+	RefOfReplayDeadLettersTable
+	RefOfReplayDeadLettersWhere
 	RefOfRevertMigrationComments
 	RootNodeSQLNode
 	RefOfRowAliasTableName
@@ -411,6 +413,8 @@ const (
 	RefOfShowBasicDbName
 	RefOfShowBasicFilter
 	RefOfShowCreateOp
+	RefOfShowDeadLettersTable
+	RefOfShowDeadLettersWhere
 	RefOfShowFilterFilter
 	RefOfShowMigrationLogsComments
 	RefOfStarExprTableName
@@ -1292,6 +1296,10 @@ func (s ASTStep) DebugString() string {
 		return "(*RenameIndex).NewName"
 	case RefOfRenameTableNameTable:
 		return "(*RenameTableName).Table"
+	case RefOfReplayDeadLettersTable:
+		return "(*ReplayDeadLetters).Table"
+	case RefOfReplayDeadLettersWhere:
+		return "(*ReplayDeadLetters).Where"
 	case RefOfRevertMigrationComments:
 		return "(*RevertMigration).Comments"
 	case RootNodeSQLNode:
@@ -1348,6 +1356,10 @@ func (s ASTStep) DebugString() string {
 		return "(*ShowBasic).Filter"
 	case RefOfShowCreateOp:
 		return "(*ShowCreate).Op"
+	case RefOfShowDeadLettersTable:
+		return "(*ShowDeadLetters).Table"
+	case RefOfShowDeadLettersWhere:
+		return "(*ShowDeadLetters).Where"
 	case RefOfShowFilterFilter:
 		return "(*ShowFilter).Filter"
 	case RefOfShowMigrationLogsComments:
@@ -2462,6 +2474,10 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*RenameIndex).NewName
 		case RefOfRenameTableNameTable:
 			node = node.(*RenameTableName).Table
+		case RefOfReplayDeadLettersTable:
+			node = node.(*ReplayDeadLetters).Table
+		case RefOfReplayDeadLettersWhere:
+			node = node.(*ReplayDeadLetters).Where
 		case RefOfRevertMigrationComments:
 			node = node.(*RevertMigration).Comments
 		case RootNodeSQLNode:
@@ -2524,6 +2540,10 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*ShowBasic).Filter
 		case RefOfShowCreateOp:
 			node = node.(*ShowCreate).Op
+		case RefOfShowDeadLettersTable:
+			node = node.(*ShowDeadLetters).Table
+		case RefOfShowDeadLettersWhere:
+			node = node.(*ShowDeadLetters).Where
 		case RefOfShowFilterFilter:
 			node = node.(*ShowFilter).Filter
 		case RefOfShowMigrationLogsComments:
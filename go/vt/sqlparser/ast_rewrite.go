@@ -421,6 +421,8 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfRenameTable(parent, node, replacer)
 	case *RenameTableName:
 		return a.rewriteRefOfRenameTableName(parent, node, replacer)
+	case *ReplayDeadLetters:
+		return a.rewriteRefOfReplayDeadLetters(parent, node, replacer)
 	case *RevertMigration:
 		return a.rewriteRefOfRevertMigration(parent, node, replacer)
 	case *Rollback:
@@ -451,6 +453,8 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfShowBasic(parent, node, replacer)
 	case *ShowCreate:
 		return a.rewriteRefOfShowCreate(parent, node, replacer)
+	case *ShowDeadLetters:
+		return a.rewriteRefOfShowDeadLetters(parent, node, replacer)
 	case *ShowFilter:
 		return a.rewriteRefOfShowFilter(parent, node, replacer)
 	case *ShowMigrationLogs:
@@ -10196,6 +10200,55 @@ func (a *application) rewriteRefOfRenameTableName(parent SQLNode, node *RenameTa
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfReplayDeadLetters(parent SQLNode, node *ReplayDeadLetters, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfReplayDeadLettersTable))
+	}
+	if !a.rewriteTableName(node, node.Table, func(newNode, parent SQLNode) {
+		parent.(*ReplayDeadLetters).Table = newNode.(TableName)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfReplayDeadLettersWhere))
+	}
+	if !a.rewriteRefOfWhere(node, node.Where, func(newNode, parent SQLNode) {
+		parent.(*ReplayDeadLetters).Where = newNode.(*Where)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfRevertMigration(parent SQLNode, node *RevertMigration, replacer replacerFunc) bool {
 	if node == nil {
@@ -10936,6 +10989,55 @@ func (a *application) rewriteRefOfShowCreate(parent SQLNode, node *ShowCreate, r
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfShowDeadLetters(parent SQLNode, node *ShowDeadLetters, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfShowDeadLettersTable))
+	}
+	if !a.rewriteTableName(node, node.Table, func(newNode, parent SQLNode) {
+		parent.(*ShowDeadLetters).Table = newNode.(TableName)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfShowDeadLettersWhere))
+	}
+	if !a.rewriteRefOfWhere(node, node.Where, func(newNode, parent SQLNode) {
+		parent.(*ShowDeadLetters).Where = newNode.(*Where)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfShowFilter(parent SQLNode, node *ShowFilter, replacer replacerFunc) bool {
 	if node == nil {
@@ -14577,6 +14679,8 @@ func (a *application) rewriteStatement(parent SQLNode, node Statement, replacer
 		return a.rewriteRefOfRelease(parent, node, replacer)
 	case *RenameTable:
 		return a.rewriteRefOfRenameTable(parent, node, replacer)
+	case *ReplayDeadLetters:
+		return a.rewriteRefOfReplayDeadLetters(parent, node, replacer)
 	case *RevertMigration:
 		return a.rewriteRefOfRevertMigration(parent, node, replacer)
 	case *Rollback:
@@ -14591,6 +14695,8 @@ func (a *application) rewriteStatement(parent SQLNode, node Statement, replacer
 		return a.rewriteRefOfSet(parent, node, replacer)
 	case *Show:
 		return a.rewriteRefOfShow(parent, node, replacer)
+	case *ShowDeadLetters:
+		return a.rewriteRefOfShowDeadLetters(parent, node, replacer)
 	case *ShowMigrationLogs:
 		return a.rewriteRefOfShowMigrationLogs(parent, node, replacer)
 	case *ShowThrottledApps:
@@ -420,6 +420,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfRenameTable(in, f)
 	case *RenameTableName:
 		return VisitRefOfRenameTableName(in, f)
+	case *ReplayDeadLetters:
+		return VisitRefOfReplayDeadLetters(in, f)
 	case *RevertMigration:
 		return VisitRefOfRevertMigration(in, f)
 	case *Rollback:
@@ -450,6 +452,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfShowBasic(in, f)
 	case *ShowCreate:
 		return VisitRefOfShowCreate(in, f)
+	case *ShowDeadLetters:
+		return VisitRefOfShowDeadLetters(in, f)
 	case *ShowFilter:
 		return VisitRefOfShowFilter(in, f)
 	case *ShowMigrationLogs:
@@ -3478,6 +3482,21 @@ func VisitRefOfRenameTableName(in *RenameTableName, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfReplayDeadLetters(in *ReplayDeadLetters, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitTableName(in.Table, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfWhere(in.Where, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfRevertMigration(in *RevertMigration, f Visit) error {
 	if in == nil {
 		return nil
@@ -3700,6 +3719,21 @@ func VisitRefOfShowCreate(in *ShowCreate, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfShowDeadLetters(in *ShowDeadLetters, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitTableName(in.Table, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfWhere(in.Where, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfShowFilter(in *ShowFilter, f Visit) error {
 	if in == nil {
 		return nil
@@ -5323,6 +5357,8 @@ func VisitStatement(in Statement, f Visit) error {
 		return VisitRefOfRelease(in, f)
 	case *RenameTable:
 		return VisitRefOfRenameTable(in, f)
+	case *ReplayDeadLetters:
+		return VisitRefOfReplayDeadLetters(in, f)
 	case *RevertMigration:
 		return VisitRefOfRevertMigration(in, f)
 	case *Rollback:
@@ -5337,6 +5373,8 @@ func VisitStatement(in Statement, f Visit) error {
 		return VisitRefOfSet(in, f)
 	case *Show:
 		return VisitRefOfShow(in, f)
+	case *ShowDeadLetters:
+		return VisitRefOfShowDeadLetters(in, f)
 	case *ShowMigrationLogs:
 		return VisitRefOfShowMigrationLogs(in, f)
 	case *ShowThrottledApps:
@@ -3752,6 +3752,20 @@ func (cached *RenameTablePair) CachedSize(alloc bool) int64 {
 	size += cached.ToTable.CachedSize(false)
 	return size
 }
+func (cached *ReplayDeadLetters) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Table vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Table.CachedSize(false)
+	// field Where *vitess.io/vitess/go/vt/sqlparser.Where
+	size += cached.Where.CachedSize(true)
+	return size
+}
 func (cached *RevertMigration) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -3992,6 +4006,20 @@ func (cached *ShowCreate) CachedSize(alloc bool) int64 {
 	size += cached.Op.CachedSize(false)
 	return size
 }
+func (cached *ShowDeadLetters) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Table vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Table.CachedSize(false)
+	// field Where *vitess.io/vitess/go/vt/sqlparser.Where
+	size += cached.Where.CachedSize(true)
+	return size
+}
 func (cached *ShowFilter) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
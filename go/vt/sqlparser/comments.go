@@ -594,11 +594,48 @@ func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
 	return qh, nil
 }
 
+// ApplyQueryAttributes returns the query hints with the ones that the comments
+// of the statement left unset taken from the query attributes a client sent
+// with it. Attributes are matched to directives by name, case-insensitively,
+// e.g. a WORKLOAD_NAME attribute sets the workload.
+func ApplyQueryAttributes(qh QueryHints, attributes map[string]string, stmtType StatementType) (QueryHints, error) {
+	directives := &CommentDirectives{m: make(map[string]string, len(attributes))}
+	for name, value := range attributes {
+		directives.m[strings.ToLower(name)] = value
+	}
+
+	if qh.Priority == "" {
+		priority, err := getPriority(directives)
+		if err != nil {
+			return qh, err
+		}
+		qh.Priority = priority
+	}
+	if !qh.IgnoreMaxMemoryRows {
+		qh.IgnoreMaxMemoryRows = directives.IsSet(DirectiveIgnoreMaxMemoryRows)
+	}
+	if qh.Consolidator == querypb.ExecuteOptions_CONSOLIDATOR_UNSPECIFIED && stmtType == StmtSelect {
+		qh.Consolidator = getConsolidatorDirective(directives)
+	}
+	if qh.Workload == "" {
+		qh.Workload = getWorkload(directives)
+	}
+	if qh.Timeout == nil {
+		qh.Timeout = getQueryTimeout(directives)
+	}
+	return qh, nil
+}
+
 // getConsolidator returns the consolidator option.
 func getConsolidator(stmt Statement, directives *CommentDirectives) querypb.ExecuteOptions_Consolidator {
 	if _, isSelect := stmt.(SelectStatement); !isSelect {
 		return querypb.ExecuteOptions_CONSOLIDATOR_UNSPECIFIED
 	}
+	return getConsolidatorDirective(directives)
+}
+
+// getConsolidatorDirective returns the consolidator option of the directives.
+func getConsolidatorDirective(directives *CommentDirectives) querypb.ExecuteOptions_Consolidator {
 	strv, isSet := directives.GetString(DirectiveConsolidator, "")
 	if !isSet {
 		return querypb.ExecuteOptions_CONSOLIDATOR_UNSPECIFIED
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/ptr"
 	"vitess.io/vitess/go/vt/sysvars"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -547,6 +548,58 @@ func TestGetPriorityFromStatement(t *testing.T) {
 	}
 }
 
+func TestApplyQueryAttributes(t *testing.T) {
+	testCases := []struct {
+		query      string
+		attributes map[string]string
+		expected   QueryHints
+		err        error
+	}{{
+		query:      "select * from users",
+		attributes: map[string]string{"traceparent": "00-abc"},
+	}, {
+		query:      "select * from users",
+		attributes: map[string]string{"workload_name": "reports", "PRIORITY": "10", "Query_Timeout_Ms": "100", "IGNORE_MAX_MEMORY_ROWS": "1", "CONSOLIDATOR": "enabled"},
+		expected: QueryHints{
+			Workload:            "reports",
+			Priority:            "10",
+			Timeout:             ptr.Of(100),
+			IgnoreMaxMemoryRows: true,
+			Consolidator:        querypb.ExecuteOptions_CONSOLIDATOR_ENABLED,
+		},
+	}, {
+		// comments win over attributes
+		query:      "select /*vt+ WORKLOAD_NAME=olap PRIORITY=20 */ * from users",
+		attributes: map[string]string{"WORKLOAD_NAME": "reports", "PRIORITY": "10"},
+		expected:   QueryHints{Workload: "olap", Priority: "20"},
+	}, {
+		// the consolidator is only used for selects
+		query:      "delete from users",
+		attributes: map[string]string{"CONSOLIDATOR": "enabled"},
+	}, {
+		query:      "select * from users",
+		attributes: map[string]string{"PRIORITY": "200"},
+		err:        ErrInvalidPriority,
+	}}
+
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+			qh, err := BuildQueryHints(stmt)
+			require.NoError(t, err)
+			qh, err = ApplyQueryAttributes(qh, tc.attributes, ASTToStatementType(stmt))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, qh)
+		})
+	}
+}
+
 // TestGetMySQLSetVarValue tests the functionality of GetMySQLSetVarValue
 func TestGetMySQLSetVarValue(t *testing.T) {
 	tests := []struct {
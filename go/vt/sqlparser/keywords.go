@@ -548,6 +548,7 @@ var keywords = []keyword{
 	{"repeat", UNUSED},
 	{"repeatable", REPEATABLE},
 	{"replace", REPLACE},
+	{"replay", REPLAY},
 	{"require", UNUSED},
 	{"resignal", UNUSED},
 	{"respect", RESPECT},
@@ -768,6 +769,7 @@ var keywords = []keyword{
 	{"vindexes", VINDEXES},
 	{"view", VIEW},
 	{"vitess", VITESS},
+	{"vitess_dead_letters", VITESS_DEAD_LETTERS},
 	{"vitess_keyspaces", VITESS_KEYSPACES},
 	{"vitess_metadata", VITESS_METADATA},
 	{"vitess_migration", VITESS_MIGRATION},
@@ -180,6 +180,14 @@ func (nz *normalizer) walkDown(node, _ SQLNode) bool {
 	case *AssignmentExpr:
 		nz.err = vterrors.VT12001("Assignment expression")
 		return false
+	case *NtileExpr, *LagLeadExpr, *NTHValueExpr:
+		// The N argument of these window functions has to be a literal,
+		// so the planner can use it when evaluating the window functions at the vtgate level
+		return false
+	case *JSONTableExpr:
+		// The paths and default values of JSON_TABLE have to be literals,
+		// both for MySQL and for the planner when it evaluates JSON_TABLE at the vtgate level
+		return false
 	case *DerivedTable:
 		nz.inDerived++
 	case *Select:
@@ -66,6 +66,11 @@ func TestNormalize(t *testing.T) {
 		outbv: map[string]*querypb.BindVariable{
 			"bv1": sqltypes.StringBindVariable("aa"),
 		},
+	}, {
+		// window function arguments that must be literals
+		in:      "select lag(a, 2) over (order by b), ntile(4) over () from t",
+		outstmt: "select lag(a, 2) over ( order by b asc), ntile(4) over () from t",
+		outbv:   map[string]*querypb.BindVariable{},
 	}, {
 		// int val
 		in:      "select * from t where foobar = 1",
@@ -2522,6 +2522,18 @@ var (
 		input: "show vitess_throttled_apps",
 	}, {
 		input: "show vitess_throttler status",
+	}, {
+		input: "show vitess_dead_letters from msg",
+	}, {
+		input: "show vitess_dead_letters from ks.msg where id in (1, 2)",
+	}, {
+		input: "replay vitess_dead_letters from msg",
+	}, {
+		input:  "REPLAY VITESS_DEAD_LETTERS FROM ks.msg WHERE epoch > 3",
+		output: "replay vitess_dead_letters from ks.msg where epoch > 3",
+	}, {
+		input:  "select replay, vitess_dead_letters from t",
+		output: "select `replay`, `vitess_dead_letters` from t",
 	}, {
 		input: "show warnings",
 	}, {
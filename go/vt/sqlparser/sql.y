@@ -320,7 +320,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 
 // DDL Tokens
 %token <str> CREATE ALTER DROP RENAME ANALYZE ADD FLUSH CHANGE MODIFY DEALLOCATE
-%token <str> REVERT QUERIES
+%token <str> REVERT REPLAY QUERIES
 %token <str> SCHEMA TABLE INDEX VIEW TO IGNORE IF PRIMARY COLUMN SPATIAL FULLTEXT KEY_BLOCK_SIZE CHECK INDEXES
 %token <str> ACTION CASCADE CONSTRAINT FOREIGN NO REFERENCES RESTRICT
 %token <str> SHOW DESCRIBE EXPLAIN DATE ESCAPE REPAIR OPTIMIZE TRUNCATE COALESCE EXCHANGE REBUILD PARTITIONING REMOVE PREPARE EXECUTE
@@ -360,7 +360,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 // SHOW tokens
 %token <str> CODE COLLATION COLUMNS DATABASES ENGINES EVENT EXTENDED FIELDS FULL FUNCTION GTID_EXECUTED
 %token <str> KEYSPACES OPEN PLUGINS PRIVILEGES PROCESSLIST SCHEMAS TABLES TRIGGERS USER
-%token <str> VGTID_EXECUTED VITESS_KEYSPACES VITESS_METADATA VITESS_MIGRATIONS VITESS_REPLICATION_STATUS VITESS_SHARDS VITESS_TABLETS VITESS_TARGET VSCHEMA VITESS_THROTTLED_APPS
+%token <str> VGTID_EXECUTED VITESS_KEYSPACES VITESS_METADATA VITESS_MIGRATIONS VITESS_REPLICATION_STATUS VITESS_SHARDS VITESS_TABLETS VITESS_TARGET VSCHEMA VITESS_THROTTLED_APPS VITESS_DEAD_LETTERS
 
 // SET tokens
 %token <str> NAMES GLOBAL SESSION ISOLATION LEVEL READ WRITE ONLY REPEATABLE COMMITTED UNCOMMITTED SERIALIZABLE
@@ -455,7 +455,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <statement> analyze_statement show_statement use_statement purge_statement other_statement
 %type <statement> begin_statement commit_statement rollback_statement savepoint_statement release_statement load_statement
 %type <statement> lock_statement unlock_statement call_statement
-%type <statement> revert_statement
+%type <statement> revert_statement replay_statement
 %type <strs> comment_opt comment_list
 %type <str> wild_opt check_option_opt cascade_or_local_opt restrict_or_cascade_opt
 %type <explainType> explain_format_opt
@@ -683,6 +683,7 @@ command:
 | unlock_statement
 | call_statement
 | revert_statement
+| replay_statement
 | prepare_statement
 | execute_statement
 | deallocate_statement
@@ -4188,6 +4189,10 @@ show_statement:
   {
     $$ = &ShowThrottledApps{}
   }
+| SHOW VITESS_DEAD_LETTERS FROM table_name where_expression_opt
+  {
+    $$ = &ShowDeadLetters{Table: $4, Where: NewWhere(WhereClause, $5)}
+  }
 | SHOW VITESS_REPLICATION_STATUS like_opt
   {
     $$ = &Show{&ShowBasic{Command: VitessReplicationStatus, Filter: $3}}
@@ -4662,6 +4667,12 @@ revert_statement:
     $$ = &RevertMigration{Comments: Comments($2).Parsed(), UUID: string($4)}
   }
 
+replay_statement:
+  REPLAY VITESS_DEAD_LETTERS FROM table_name where_expression_opt
+  {
+    $$ = &ReplayDeadLetters{Table: $4, Where: NewWhere(WhereClause, $5)}
+  }
+
 flush_statement:
   FLUSH local_opt flush_option_list
   {
@@ -8590,6 +8601,7 @@ non_reserved_keyword:
 | REORGANIZE
 | REPAIR
 | REPEATABLE
+| REPLAY
 | RESTRICT
 | REQUIRE_ROW_FORMAT
 | RESOURCE
@@ -8756,6 +8768,7 @@ non_reserved_keyword:
 | VINDEXES
 | VISIBLE
 | VITESS
+| VITESS_DEAD_LETTERS
 | VITESS_KEYSPACES
 | VITESS_METADATA
 | VITESS_MIGRATION
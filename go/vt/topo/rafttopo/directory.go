@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// ListDir is part of the topo.Conn interface.
+func (s *Server) ListDir(ctx context.Context, dirPath string, full bool) ([]topo.DirEntry, error) {
+	nodePath := path.Join(s.root, dirPath) + "/"
+	if nodePath == "//" {
+		// Special case where s.root is "/", dirPath is empty,
+		// we would end up with "//". in that case, we want "/".
+		nodePath = "/"
+	}
+	files, err := s.list(ctx, nodePath, true /* keysOnly */)
+	if err != nil {
+		return nil, convertError(err, dirPath)
+	}
+	if len(files) == 0 {
+		// No key starts with this prefix, means the directory
+		// doesn't exist.
+		return nil, topo.NewError(topo.NoNode, nodePath)
+	}
+
+	prefixLen := len(nodePath)
+	var result []topo.DirEntry
+	for _, file := range files {
+		// Remove the prefix, base path.
+		p := file.Path[prefixLen:]
+
+		// Keep only the part until the first '/'.
+		t := topo.TypeFile
+		if i := strings.Index(p, "/"); i >= 0 {
+			p = p[:i]
+			t = topo.TypeDirectory
+		}
+
+		// The files are sorted by path, so the files of a directory
+		// are next to each other. A directory is ephemeral if all
+		// its files are.
+		ephemeral := file.SessionId != 0
+		if len(result) > 0 && result[len(result)-1].Name == p {
+			if full && !ephemeral {
+				result[len(result)-1].Ephemeral = false
+			}
+			continue
+		}
+		e := topo.DirEntry{
+			Name: p,
+		}
+		if full {
+			e.Type = t
+			e.Ephemeral = ephemeral
+		}
+		result = append(result, e)
+	}
+
+	// A directory sorts after the files that have its name as a
+	// prefix, followed by a character lower than '/'.
+	slices.SortFunc(result, func(a, b topo.DirEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result, nil
+}
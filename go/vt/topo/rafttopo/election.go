@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"path"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+// NewLeaderParticipation is part of the topo.Server interface
+func (s *Server) NewLeaderParticipation(name, id string) (topo.LeaderParticipation, error) {
+	return &raftLeaderParticipation{
+		s:    s,
+		name: name,
+		id:   id,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}, nil
+}
+
+// raftLeaderParticipation implements topo.LeaderParticipation.
+//
+// We use a directory (in global election path, with the name) with
+// ephemeral files in it, that contains the id. The oldest version
+// wins the election.
+type raftLeaderParticipation struct {
+	// s is our parent raft topo Server
+	s *Server
+
+	// name is the name of this LeaderParticipation
+	name string
+
+	// id is the process's current id.
+	id string
+
+	// stop is a channel closed when Stop is called.
+	stop chan struct{}
+
+	// done is a channel closed when we're done processing the Stop
+	done chan struct{}
+}
+
+// WaitForLeadership is part of the topo.LeaderParticipation interface.
+func (mp *raftLeaderParticipation) WaitForLeadership() (context.Context, error) {
+	// If Stop was already called, mp.done is closed, so we are interrupted.
+	select {
+	case <-mp.done:
+		return nil, topo.NewError(topo.Interrupted, "Leadership")
+	default:
+	}
+
+	electionPath := path.Join(electionsPath, mp.name)
+	var ld topo.LockDescriptor
+
+	// We use a cancelable context here. If stop is closed,
+	// we just cancel that context.
+	lockCtx, lockCancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-mp.s.running:
+			return
+		case <-mp.stop:
+		}
+		if ld != nil {
+			if err := ld.Unlock(context.Background()); err != nil {
+				log.Errorf("failed to unlock electionPath %v: %v", electionPath, err)
+			}
+		}
+		lockCancel()
+		close(mp.done)
+	}()
+
+	// Try to get the primaryship, by getting a lock.
+	var err error
+	ld, err = mp.s.lock(lockCtx, electionPath, mp.id, leaseTTL)
+	if err != nil {
+		// It can be that we were interrupted.
+		return nil, err
+	}
+
+	// We got the lock. Return the lockContext. If Stop() is called,
+	// it will cancel the lockCtx, and cancel the returned context.
+	return lockCtx, nil
+}
+
+// Stop is part of the topo.LeaderParticipation interface
+func (mp *raftLeaderParticipation) Stop() {
+	close(mp.stop)
+	<-mp.done
+}
+
+// GetCurrentLeaderID is part of the topo.LeaderParticipation interface
+func (mp *raftLeaderParticipation) GetCurrentLeaderID(ctx context.Context) (string, error) {
+	electionPath := path.Join(mp.s.root, electionsPath, mp.name)
+
+	leader, err := mp.currentLeader(ctx, electionPath)
+	if err != nil {
+		return "", convertError(err, electionPath)
+	}
+	if leader == nil {
+		// No file starts with this prefix, means nobody is the primary.
+		return "", nil
+	}
+	return string(leader.Contents), nil
+}
+
+// currentLeader returns the oldest file of the election, or nil if there
+// is none.
+func (mp *raftLeaderParticipation) currentLeader(ctx context.Context, electionPath string) (*rafttopopb.File, error) {
+	files, err := mp.s.list(ctx, electionPath+"/", false /* keysOnly */)
+	if err != nil {
+		return nil, err
+	}
+	var leader *rafttopopb.File
+	for _, file := range files {
+		if leader == nil || file.Version < leader.Version {
+			leader = file
+		}
+	}
+	return leader, nil
+}
+
+// WaitForNewLeader is part of the topo.LeaderParticipation interface
+func (mp *raftLeaderParticipation) WaitForNewLeader(ctx context.Context) (<-chan string, error) {
+	electionPath := path.Join(mp.s.root, electionsPath, mp.name)
+
+	notifications := make(chan string, 8)
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Get the current leader
+	initial, err := mp.currentLeader(ctx, electionPath)
+	if err != nil {
+		cancel()
+		return nil, convertError(err, electionPath)
+	}
+	if initial != nil {
+		notifications <- string(initial.Contents)
+	}
+
+	// Create the Watcher.
+	_, watcher, err := mp.s.WatchRecursive(ctx, path.Join(electionsPath, mp.name))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer cancel()
+		defer close(notifications)
+		for {
+			select {
+			case <-mp.s.running:
+				return
+			case <-mp.done:
+				return
+			case <-ctx.Done():
+				return
+			case wd, ok := <-watcher:
+				if !ok || (wd.Err != nil && !topo.IsErrType(wd.Err, topo.NoNode)) {
+					return
+				}
+
+				currentLeader, err := mp.currentLeader(ctx, electionPath)
+				if err != nil || currentLeader == nil {
+					continue
+				}
+				notifications <- string(currentLeader.Contents)
+			}
+		}
+	}()
+
+	return notifications, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// toGRPCError converts an error of a node into a gRPC error, that
+// convertError converts back into a topo error on the client side.
+func toGRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case topo.IsErrType(err, topo.NoNode):
+		return status.Error(codes.NotFound, err.Error())
+	case topo.IsErrType(err, topo.NodeExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case topo.IsErrType(err, topo.BadVersion):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, errNotLeader), errors.Is(err, errStopped):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// convertError converts an error returned by a node into a topo error.
+func convertError(err error, nodePath string) error {
+	if err == nil {
+		return nil
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.NotFound:
+			return topo.NewError(topo.NoNode, nodePath)
+		case codes.AlreadyExists:
+			return topo.NewError(topo.NodeExists, nodePath)
+		case codes.FailedPrecondition:
+			return topo.NewError(topo.BadVersion, nodePath)
+		case codes.Canceled:
+			return topo.NewError(topo.Interrupted, nodePath)
+		case codes.DeadlineExceeded, codes.Unavailable:
+			// Unavailable is returned when there is no leader, or no
+			// node can be reached, timeout sounds reasonable then.
+			return topo.NewError(topo.Timeout, nodePath)
+		case codes.ResourceExhausted:
+			return topo.NewError(topo.ResourceExhausted, nodePath)
+		default:
+			return err
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return topo.NewError(topo.Interrupted, nodePath)
+	case errors.Is(err, context.DeadlineExceeded):
+		return topo.NewError(topo.Timeout, nodePath)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"path"
+
+	"vitess.io/vitess/go/vt/topo"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+// Create is part of the topo.Conn interface.
+func (s *Server) Create(ctx context.Context, filePath string, contents []byte) (topo.Version, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	index, err := s.apply(ctx, &rafttopopb.Command{
+		Type:     rafttopopb.CommandType_CREATE,
+		Path:     nodePath,
+		Contents: contents,
+	})
+	if err != nil {
+		return nil, convertError(err, nodePath)
+	}
+	return RaftVersion(index), nil
+}
+
+// Update is part of the topo.Conn interface.
+func (s *Server) Update(ctx context.Context, filePath string, contents []byte, version topo.Version) (topo.Version, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	cmd := &rafttopopb.Command{
+		Type:     rafttopopb.CommandType_UPDATE,
+		Path:     nodePath,
+		Contents: contents,
+	}
+	if version != nil {
+		cmd.Version = int64(version.(RaftVersion))
+	}
+	index, err := s.apply(ctx, cmd)
+	if err != nil {
+		return nil, convertError(err, nodePath)
+	}
+	return RaftVersion(index), nil
+}
+
+// Get is part of the topo.Conn interface.
+func (s *Server) Get(ctx context.Context, filePath string) ([]byte, topo.Version, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	var resp *rafttopopb.GetResponse
+	err := s.call(ctx, func(client rafttopopb.RaftTopoClient) (err error) {
+		resp, err = client.Get(ctx, &rafttopopb.GetRequest{
+			Path:     nodePath,
+			MinIndex: s.lastIndex.Load(),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, nil, convertError(err, nodePath)
+	}
+	return resp.File.GetContents(), RaftVersion(resp.File.GetVersion()), nil
+}
+
+// GetVersion is part of the topo.Conn interface.
+// The nodes only keep the latest version of the files.
+func (s *Server) GetVersion(ctx context.Context, filePath string, version int64) ([]byte, error) {
+	return nil, topo.NewError(topo.NoImplementation, "GetVersion not supported in raft topo")
+}
+
+// List is part of the topo.Conn interface.
+func (s *Server) List(ctx context.Context, filePathPrefix string) ([]topo.KVInfo, error) {
+	nodePathPrefix := path.Join(s.root, filePathPrefix)
+
+	files, err := s.list(ctx, nodePathPrefix, false /* keysOnly */)
+	if err != nil {
+		return []topo.KVInfo{}, convertError(err, nodePathPrefix)
+	}
+	if len(files) == 0 {
+		return []topo.KVInfo{}, topo.NewError(topo.NoNode, nodePathPrefix)
+	}
+	results := make([]topo.KVInfo, len(files))
+	for n, file := range files {
+		results[n].Key = []byte(file.Path)
+		results[n].Value = file.Contents
+		results[n].Version = RaftVersion(file.Version)
+	}
+	return results, nil
+}
+
+// Delete is part of the topo.Conn interface.
+func (s *Server) Delete(ctx context.Context, filePath string, version topo.Version) error {
+	nodePath := path.Join(s.root, filePath)
+
+	cmd := &rafttopopb.Command{
+		Type: rafttopopb.CommandType_DELETE,
+		Path: nodePath,
+	}
+	if version != nil {
+		cmd.Version = int64(version.(RaftVersion))
+	}
+	if _, err := s.apply(ctx, cmd); err != nil {
+		return convertError(err, nodePath)
+	}
+	return nil
+}
+
+// list returns the files whose path starts with prefix, sorted by path.
+func (s *Server) list(ctx context.Context, prefix string, keysOnly bool) ([]*rafttopopb.File, error) {
+	var resp *rafttopopb.ListResponse
+	err := s.call(ctx, func(client rafttopopb.RaftTopoClient) (err error) {
+		resp, err = client.List(ctx, &rafttopopb.ListRequest{
+			Prefix:   prefix,
+			KeysOnly: keysOnly,
+			MinIndex: s.lastIndex.Load(),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Files, nil
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+var (
+	leaseTTL = 30 * time.Second // This is the default used for all non-named locks
+)
+
+func init() {
+	for _, cmd := range topo.FlagBinaries {
+		servenv.OnParseFor(cmd, registerRaftTopoLockFlags)
+	}
+}
+
+func registerRaftTopoLockFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&leaseTTL, "topo_raft_lease_ttl", leaseTTL, "Session TTL for locks and leader election. The client keeps the session alive while it holds the lock.")
+}
+
+// newSession creates a session with the provided ttl, and keeps it alive
+// until the returned function is called.
+func (s *Server) newSession(ctx context.Context, ttl time.Duration) (int64, context.CancelFunc, error) {
+	id, err := s.apply(ctx, &rafttopopb.Command{
+		Type: rafttopopb.CommandType_CREATE_SESSION,
+		Ttl:  protoutil.DurationToProto(ttl),
+	})
+	if err != nil {
+		return 0, nil, convertError(err, "session")
+	}
+
+	kaCtx, kaCancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.running:
+				return
+			case <-kaCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			ctx, cancel := context.WithTimeout(kaCtx, ttl/3)
+			err := s.keepAlive(ctx, id)
+			cancel()
+			if status.Code(err) == codes.NotFound {
+				// The session expired, there is nothing left
+				// to keep alive.
+				return
+			}
+		}
+	}()
+	return id, kaCancel, nil
+}
+
+// keepAlive renews the ttl of a session.
+func (s *Server) keepAlive(ctx context.Context, id int64) error {
+	return s.call(ctx, func(client rafttopopb.RaftTopoClient) error {
+		_, err := client.KeepAlive(ctx, &rafttopopb.KeepAliveRequest{SessionId: id})
+		return err
+	})
+}
+
+// closeSession closes a session, deleting its files.
+func (s *Server) closeSession(ctx context.Context, id int64) error {
+	_, err := s.apply(ctx, &rafttopopb.Command{
+		Type:      rafttopopb.CommandType_CLOSE_SESSION,
+		SessionId: id,
+	})
+	return err
+}
+
+// waitOnLastVersion waits on the file of the provided directory that has
+// the largest version smaller than the provided version. It returns true
+// only if there is no more other older files.
+func (s *Server) waitOnLastVersion(ctx context.Context, nodePath string, version int64) (bool, error) {
+	// Get the file that is blocking us, if any.
+	files, err := s.list(ctx, nodePath+"/", true /* keysOnly */)
+	if err != nil {
+		return false, convertError(err, nodePath)
+	}
+	var last *rafttopopb.File
+	for _, file := range files {
+		if file.Version < version && (last == nil || file.Version > last.Version) {
+			last = file
+		}
+	}
+	if last == nil {
+		// No older file, we're done waiting.
+		return true, nil
+	}
+
+	// Wait for release on blocking file. Cancel the watch when we
+	// exit this function.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, _, err := s.startWatch(ctx, last.Path, false)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			// It is already gone. There might still be
+			// older files, but not this one.
+			return false, nil
+		}
+		return false, convertError(err, nodePath)
+	}
+	for {
+		resp, _, err := s.recv(ctx, &stream, last.Path, false)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return false, nil
+			}
+			return false, convertError(err, nodePath)
+		}
+		for _, ev := range resp.Events {
+			if ev.Deleted {
+				// There might still be older files,
+				// but not this one.
+				return false, nil
+			}
+		}
+	}
+}
+
+// raftLockDescriptor implements topo.LockDescriptor.
+type raftLockDescriptor struct {
+	s         *Server
+	sessionID int64
+	stop      context.CancelFunc
+}
+
+// TryLock is part of the topo.Conn interface.
+func (s *Server) TryLock(ctx context.Context, dirPath, contents string) (topo.LockDescriptor, error) {
+	// We list all the entries under dirPath
+	entries, err := s.ListDir(ctx, dirPath, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there is a folder '/locks' with some entries in it then we can assume that someone else already has a lock.
+	// Throw error in this case
+	for _, e := range entries {
+		if e.Name == locksPath && e.Type == topo.TypeDirectory && e.Ephemeral {
+			return nil, topo.NewError(topo.NodeExists, fmt.Sprintf("lock already exists at path %s", dirPath))
+		}
+	}
+
+	// everything is good let's acquire the lock.
+	return s.lock(ctx, dirPath, contents, leaseTTL)
+}
+
+// Lock is part of the topo.Conn interface.
+func (s *Server) Lock(ctx context.Context, dirPath, contents string) (topo.LockDescriptor, error) {
+	// We list the directory first to make sure it exists.
+	if _, err := s.ListDir(ctx, dirPath, false /*full*/); err != nil {
+		return nil, err
+	}
+
+	return s.lock(ctx, dirPath, contents, leaseTTL)
+}
+
+// LockWithTTL is part of the topo.Conn interface.
+func (s *Server) LockWithTTL(ctx context.Context, dirPath, contents string, ttl time.Duration) (topo.LockDescriptor, error) {
+	// We list the directory first to make sure it exists.
+	if _, err := s.ListDir(ctx, dirPath, false /*full*/); err != nil {
+		return nil, err
+	}
+
+	return s.lock(ctx, dirPath, contents, ttl)
+}
+
+// LockName is part of the topo.Conn interface.
+func (s *Server) LockName(ctx context.Context, dirPath, contents string) (topo.LockDescriptor, error) {
+	return s.lock(ctx, dirPath, contents, topo.NamedLockTTL)
+}
+
+// lock is used by both Lock() and primary election.
+func (s *Server) lock(ctx context.Context, nodePath, contents string, ttl time.Duration) (topo.LockDescriptor, error) {
+	nodePath = path.Join(s.root, nodePath, locksPath)
+
+	// Create a session, and keep it alive.
+	sessionID, stop, err := s.newSession(ctx, ttl)
+	if err != nil {
+		return nil, err
+	}
+	release := func() {
+		stop()
+		if err := s.closeSession(context.Background(), sessionID); err != nil {
+			log.Warningf("closeSession(%d) failed, may have left a lock file behind in %v: %v", sessionID, nodePath, err)
+		}
+	}
+
+	// Create an ephemeral file in the locks directory. Use the session
+	// ID as the file name, so it's guaranteed unique.
+	key := fmt.Sprintf("%v/%v", nodePath, sessionID)
+	version, err := s.apply(ctx, &rafttopopb.Command{
+		Type:      rafttopopb.CommandType_CREATE,
+		Path:      key,
+		Contents:  []byte(contents),
+		SessionId: sessionID,
+	})
+	if err != nil {
+		// We don't know if the file was created, closing the
+		// session deletes it in any case.
+		release()
+		return nil, convertError(err, key)
+	}
+
+	// Wait until all older files in the locks directory are gone.
+	for {
+		done, err := s.waitOnLastVersion(ctx, nodePath, version)
+		if err != nil {
+			// We had an error waiting on the last file.
+			// Close our session, this will delete the file.
+			release()
+			return nil, err
+		}
+		if done {
+			// No more older files, we're it!
+			return &raftLockDescriptor{
+				s:         s,
+				sessionID: sessionID,
+				stop:      stop,
+			}, nil
+		}
+	}
+}
+
+// Check is part of the topo.LockDescriptor interface.
+// We use KeepAlive to make sure the session is still active and well.
+func (ld *raftLockDescriptor) Check(ctx context.Context) error {
+	if err := ld.s.keepAlive(ctx, ld.sessionID); err != nil {
+		return convertError(err, "session")
+	}
+	return nil
+}
+
+// Unlock is part of the topo.LockDescriptor interface.
+func (ld *raftLockDescriptor) Unlock(ctx context.Context) error {
+	ld.stop()
+	if err := ld.s.closeSession(ctx, ld.sessionID); err != nil {
+		return convertError(err, "session")
+	}
+	return nil
+}
@@ -0,0 +1,543 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+// forwardedKey is the gRPC metadata set on the requests that a node
+// forwards to the leader, so they are not forwarded again.
+const forwardedKey = "rafttopo-forwarded"
+
+var (
+	nodeDataDir           string
+	nodeID                string
+	nodePeers             []string
+	nodeElectionTimeout   = time.Second
+	nodeSnapshotThreshold = int64(10000)
+
+	// runningNode is the node started by StartNode.
+	runningNode *Node
+)
+
+func init() {
+	servenv.OnParseFor("vtctld", registerRaftTopoNodeFlags)
+}
+
+func registerRaftTopoNodeFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&nodeDataDir, "topo_raft_data_dir", nodeDataDir, "Data directory of the embedded raft topo server. Setting it runs a raft topo server node in this vtctld, on its gRPC port.")
+	fs.StringVar(&nodeID, "topo_raft_id", nodeID, "gRPC address of this vtctld in topo_raft_peers, that the other raft topo server nodes use to reach it.")
+	fs.StringSliceVar(&nodePeers, "topo_raft_peers", nodePeers, "Comma-separated list of the gRPC addresses of all the vtctlds running a raft topo server node, including this one.")
+	fs.DurationVar(&nodeElectionTimeout, "topo_raft_election_timeout", nodeElectionTimeout, "Minimum time without hearing from the leader before a raft topo server node starts an election. The leader sends heartbeats every tenth of it.")
+	fs.Int64Var(&nodeSnapshotThreshold, "topo_raft_snapshot_threshold", nodeSnapshotThreshold, "Number of raft log entries after which a raft topo server node compacts its log into a snapshot.")
+}
+
+// Config is the configuration of a Node.
+type Config struct {
+	// ID is the gRPC address of the node, as listed in Peers.
+	ID string
+
+	// Peers are the gRPC addresses of all the nodes of the cluster,
+	// including this one.
+	Peers []string
+
+	// DataDir is the directory where the node persists its state.
+	DataDir string
+
+	// ElectionTimeout is the minimum time without hearing from the
+	// leader before a follower starts an election.
+	ElectionTimeout time.Duration
+
+	// SnapshotThreshold is the number of entries after which the log is
+	// compacted into a snapshot.
+	SnapshotThreshold int64
+
+	// DialOption is used to connect to the other nodes. It defaults to
+	// an insecure connection.
+	DialOption grpc.DialOption
+}
+
+// peer is another node of the cluster.
+type peer struct {
+	addr   string
+	conn   *grpc.ClientConn
+	client rafttopopb.RaftTopoClient
+
+	// The replication state, used when this node is the leader.
+	nextIndex   int64
+	matchIndex  int64
+	lastContact time.Time
+
+	// wake triggers the replication of new entries to the peer.
+	wake chan struct{}
+}
+
+func (p *peer) wakeUp() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Node is a node of the raft topo server cluster. It replicates the
+// topology data with the raft consensus protocol, and serves it to the
+// Server clients through the RaftTopo gRPC service.
+//
+// Writes go through the leader, that forwards them to the other nodes.
+// Reads are served by any node, from the entries it has applied, so the
+// Server clients pass the index of their last write to read their own
+// writes. Like with ZooKeeper, a client may read stale data written by
+// another client.
+type Node struct {
+	rafttopopb.UnimplementedRaftTopoServer
+
+	config Config
+	store  *store
+	peers  []*peer
+
+	// applyMu is held while applying entries to the store, or replacing
+	// it with a snapshot. It is acquired before mu.
+	applyMu sync.Mutex
+
+	// mu protects the raft state below.
+	mu          sync.Mutex
+	storage     *storage
+	state       raftState
+	term        int64
+	votedFor    string
+	commitIndex int64
+	lastApplied int64
+	proposals   map[int64]*proposal
+
+	// electionDeadline is when a follower starts an election.
+	electionDeadline time.Time
+
+	// leaderID is the current leader, if known. leaderCh is closed, and
+	// replaced, when it changes.
+	leaderID string
+	leaderCh chan struct{}
+
+	// leaderStop is closed when the node stops being the leader.
+	// leaderStartIndex is the index of the first entry of its term.
+	leaderStop       chan struct{}
+	leaderStartIndex int64
+
+	// sessionDeadlines are the expiration times of the sessions, kept by
+	// the leader and reset when the leadership changes.
+	sessionDeadlines map[int64]time.Time
+
+	// applyCh wakes up the applier when the commit index advances.
+	applyCh chan struct{}
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewNode opens the data directory of a node, and starts it. It has to be
+// registered to a gRPC server to join the cluster.
+func NewNode(config Config) (*Node, error) {
+	if config.ID == "" {
+		return nil, errors.New("the id of the raft topo server node is required")
+	}
+	if !slices.Contains(config.Peers, config.ID) {
+		return nil, fmt.Errorf("the id of the raft topo server node %v is not one of its peers %v", config.ID, config.Peers)
+	}
+	if config.ElectionTimeout <= 0 {
+		return nil, fmt.Errorf("invalid raft election timeout %v", config.ElectionTimeout)
+	}
+	if config.SnapshotThreshold <= 0 {
+		return nil, fmt.Errorf("invalid raft snapshot threshold %v", config.SnapshotThreshold)
+	}
+	if config.DialOption == nil {
+		dialOption, err := grpcclient.SecureDialOption("", "", "", "", "")
+		if err != nil {
+			return nil, err
+		}
+		config.DialOption = dialOption
+	}
+
+	storage, snapshot, err := openStorage(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	n := &Node{
+		config:    config,
+		store:     newStore(),
+		storage:   storage,
+		term:      storage.hardState.Term,
+		votedFor:  storage.hardState.VotedFor,
+		proposals: make(map[int64]*proposal),
+		leaderCh:  make(chan struct{}),
+		applyCh:   make(chan struct{}, 1),
+		stopCh:    make(chan struct{}),
+	}
+	if snapshot != nil {
+		n.store.restore(snapshot)
+		n.commitIndex = snapshot.LastIndex
+		n.lastApplied = snapshot.LastIndex
+	}
+	for _, addr := range config.Peers {
+		if addr == config.ID || slices.ContainsFunc(n.peers, func(p *peer) bool { return p.addr == addr }) {
+			continue
+		}
+		conn, err := grpcclient.DialContext(context.Background(), addr, grpcclient.FailFast(true), config.DialOption)
+		if err != nil {
+			n.closePeers()
+			storage.close()
+			return nil, err
+		}
+		n.peers = append(n.peers, &peer{
+			addr:   addr,
+			conn:   conn,
+			client: rafttopopb.NewRaftTopoClient(conn),
+			wake:   make(chan struct{}, 1),
+		})
+	}
+	n.resetElectionTimer()
+
+	n.wg.Add(2)
+	go n.runLoop()
+	go n.applyLoop()
+	return n, nil
+}
+
+// Register registers the RaftTopo service of the node on a gRPC server.
+func (n *Node) Register(s *grpc.Server) {
+	rafttopopb.RegisterRaftTopoServer(s, n)
+}
+
+// Close stops the node.
+func (n *Node) Close() {
+	close(n.stopCh)
+	n.wg.Wait()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.state == leader {
+		close(n.leaderStop)
+		n.leaderStop = nil
+	}
+	n.state = follower
+	n.setLeader("")
+	n.failProposals(0, errStopped)
+	n.closePeers()
+	if err := n.storage.close(); err != nil {
+		log.Errorf("rafttopo: cannot close the raft log: %v", err)
+	}
+}
+
+func (n *Node) closePeers() {
+	for _, p := range n.peers {
+		p.conn.Close()
+	}
+}
+
+// runLoop runs the timers of the node.
+func (n *Node) runLoop() {
+	defer n.wg.Done()
+	ticker := time.NewTicker(n.config.ElectionTimeout / 10)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case <-ticker.C:
+		}
+		n.tick()
+		n.expireSessions()
+	}
+}
+
+// expireSessions closes the sessions that were not kept alive for their
+// ttl. Only the leader does it.
+func (n *Node) expireSessions() {
+	n.mu.Lock()
+	if n.state != leader || n.store.applied() < n.leaderStartIndex {
+		n.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	ttls := n.store.sessionTTLs()
+	var expired []int64
+	for id, ttl := range ttls {
+		deadline, ok := n.sessionDeadlines[id]
+		switch {
+		case !ok:
+			n.sessionDeadlines[id] = now.Add(ttl)
+		case now.After(deadline):
+			expired = append(expired, id)
+			// Try again later if closing it fails.
+			n.sessionDeadlines[id] = now.Add(ttl)
+		}
+	}
+	for id := range n.sessionDeadlines {
+		if _, ok := ttls[id]; !ok {
+			delete(n.sessionDeadlines, id)
+		}
+	}
+	n.mu.Unlock()
+
+	for _, id := range expired {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+			defer cancel()
+			log.Infof("rafttopo: closing expired session %v", id)
+			_, err := n.propose(ctx, &rafttopopb.Command{
+				Type:      rafttopopb.CommandType_CLOSE_SESSION,
+				SessionId: id,
+			})
+			if err != nil && !topo.IsErrType(err, topo.NoNode) {
+				log.Warningf("rafttopo: cannot close expired session %v: %v", id, err)
+			}
+		}()
+	}
+}
+
+// waitLeader waits until the leader is known, for a few election
+// timeouts at most.
+func (n *Node) waitLeader(ctx context.Context) (string, chan struct{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*n.config.ElectionTimeout)
+	defer cancel()
+	for {
+		n.mu.Lock()
+		id, ch := n.leaderID, n.leaderCh
+		n.mu.Unlock()
+		if id != "" {
+			return id, ch, nil
+		}
+		select {
+		case <-ch:
+		case <-n.stopCh:
+			return "", nil, errStopped
+		case <-ctx.Done():
+			return "", nil, status.Error(codes.Unavailable, "no raft leader")
+		}
+	}
+}
+
+// onLeader runs local when this node is the leader, or remote with the
+// client of the leader otherwise.
+func (n *Node) onLeader(ctx context.Context, local func() error, remote func(ctx context.Context, client rafttopopb.RaftTopoClient) error) error {
+	for {
+		id, ch, err := n.waitLeader(ctx)
+		if err != nil {
+			return err
+		}
+		if id == n.config.ID {
+			err := local()
+			if !errors.Is(err, errNotLeader) {
+				return err
+			}
+			// We just lost the leadership, wait for the next leader.
+			select {
+			case <-ch:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(forwardedKey)) > 0 {
+			return status.Errorf(codes.Unavailable, "%v is not the raft leader", n.config.ID)
+		}
+		i := slices.IndexFunc(n.peers, func(p *peer) bool { return p.addr == id })
+		if i < 0 {
+			return status.Errorf(codes.Unavailable, "unknown raft leader %v", id)
+		}
+		return remote(metadata.AppendToOutgoingContext(ctx, forwardedKey, n.config.ID), n.peers[i].client)
+	}
+}
+
+// Apply is part of the rafttopopb.RaftTopoServer interface.
+func (n *Node) Apply(ctx context.Context, req *rafttopopb.ApplyRequest) (*rafttopopb.ApplyResponse, error) {
+	var resp *rafttopopb.ApplyResponse
+	err := n.onLeader(ctx, func() error {
+		index, err := n.propose(ctx, req.Command)
+		if err != nil {
+			return err
+		}
+		resp = &rafttopopb.ApplyResponse{Index: index}
+		return nil
+	}, func(ctx context.Context, client rafttopopb.RaftTopoClient) (err error) {
+		resp, err = client.Apply(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return resp, nil
+}
+
+// KeepAlive is part of the rafttopopb.RaftTopoServer interface.
+func (n *Node) KeepAlive(ctx context.Context, req *rafttopopb.KeepAliveRequest) (*rafttopopb.KeepAliveResponse, error) {
+	err := n.onLeader(ctx, func() error {
+		n.mu.Lock()
+		if n.state != leader {
+			n.mu.Unlock()
+			return errNotLeader
+		}
+		startIndex := n.leaderStartIndex
+		n.mu.Unlock()
+
+		// Wait until the sessions of the previous terms are applied.
+		if err := n.store.waitApplied(ctx, startIndex); err != nil {
+			return err
+		}
+		ttl, ok := n.store.sessionTTL(req.SessionId)
+		if !ok {
+			return topo.NewError(topo.NoNode, "session")
+		}
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		if n.state != leader {
+			return errNotLeader
+		}
+		n.sessionDeadlines[req.SessionId] = time.Now().Add(ttl)
+		return nil
+	}, func(ctx context.Context, client rafttopopb.RaftTopoClient) error {
+		_, err := client.KeepAlive(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &rafttopopb.KeepAliveResponse{}, nil
+}
+
+// waitApplied waits until the node applied the entry at index, for a few
+// election timeouts at most, so a client can read its own writes.
+func (n *Node) waitApplied(ctx context.Context, index int64) error {
+	if n.store.applied() >= index {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*n.config.ElectionTimeout)
+	defer cancel()
+	if err := n.store.waitApplied(ctx, index); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return status.Errorf(codes.Unavailable, "%v has not applied index %v yet", n.config.ID, index)
+		}
+		return err
+	}
+	return nil
+}
+
+// Get is part of the rafttopopb.RaftTopoServer interface.
+func (n *Node) Get(ctx context.Context, req *rafttopopb.GetRequest) (*rafttopopb.GetResponse, error) {
+	if err := n.waitApplied(ctx, req.MinIndex); err != nil {
+		return nil, toGRPCError(err)
+	}
+	file, err := n.store.get(req.Path)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &rafttopopb.GetResponse{File: file}, nil
+}
+
+// List is part of the rafttopopb.RaftTopoServer interface.
+func (n *Node) List(ctx context.Context, req *rafttopopb.ListRequest) (*rafttopopb.ListResponse, error) {
+	if err := n.waitApplied(ctx, req.MinIndex); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &rafttopopb.ListResponse{Files: n.store.list(req.Prefix, req.KeysOnly)}, nil
+}
+
+// Watch is part of the rafttopopb.RaftTopoServer interface.
+func (n *Node) Watch(req *rafttopopb.WatchRequest, stream rafttopopb.RaftTopo_WatchServer) error {
+	ctx := stream.Context()
+	if err := n.waitApplied(ctx, req.MinIndex); err != nil {
+		return toGRPCError(err)
+	}
+	initial, w, err := n.store.watch(req.Path, req.Recursive)
+	if err != nil {
+		return toGRPCError(err)
+	}
+	defer n.store.unwatch(w)
+
+	if err := stream.Send(&rafttopopb.WatchResponse{Events: initial}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return toGRPCError(ctx.Err())
+		case <-n.stopCh:
+			return toGRPCError(errStopped)
+		case events, ok := <-w.events:
+			if !ok {
+				return status.Errorf(codes.Unavailable, "watch of %v on %v interrupted", req.Path, n.config.ID)
+			}
+			if err := stream.Send(&rafttopopb.WatchResponse{Events: events}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// StartNode starts the raft topo server node configured by the flags, if
+// any, and registers it on the gRPC server.
+func StartNode(s *grpc.Server) error {
+	if nodeDataDir == "" {
+		return nil
+	}
+	if s == nil {
+		return errors.New("the raft topo server node requires the gRPC server, set --grpc_port")
+	}
+	dialOption, err := grpcclient.SecureDialOption(clientCertPath, clientKeyPath, serverCaPath, "", "")
+	if err != nil {
+		return err
+	}
+	node, err := NewNode(Config{
+		ID:                nodeID,
+		Peers:             nodePeers,
+		DataDir:           nodeDataDir,
+		ElectionTimeout:   nodeElectionTimeout,
+		SnapshotThreshold: nodeSnapshotThreshold,
+		DialOption:        dialOption,
+	})
+	if err != nil {
+		return err
+	}
+	node.Register(s)
+	runningNode = node
+	return nil
+}
+
+// StopNode stops the node started by StartNode, if any.
+func StopNode() {
+	if runningNode != nil {
+		runningNode.Close()
+		runningNode = nil
+	}
+}
@@ -0,0 +1,650 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+// This file implements the raft consensus protocol, as described in
+// "In Search of an Understandable Consensus Algorithm" by Diego Ongaro
+// and John Ousterhout, with the check quorum extension: a leader that
+// does not hear from a majority of the nodes for an election timeout
+// steps down.
+
+const (
+	// maxAppendEntries is the maximum number of entries sent in one
+	// AppendEntries request.
+	maxAppendEntries = 256
+
+	// maxApplyBatch is the maximum number of entries applied at once.
+	maxApplyBatch = 256
+)
+
+var (
+	// errNotLeader is returned when proposing a command on a node that
+	// is not the leader.
+	errNotLeader = errors.New("not the raft leader")
+
+	// errLeadershipLost is returned when the leader loses its leadership
+	// before a proposed command is committed. The command may or may not
+	// be committed by the next leader.
+	errLeadershipLost = errors.New("raft leadership lost before the command was committed")
+
+	// errStopped is returned for the commands pending when the node stops.
+	errStopped = errors.New("raft node stopped")
+)
+
+type raftState int
+
+const (
+	follower raftState = iota
+	candidate
+	leader
+)
+
+func (s raftState) String() string {
+	switch s {
+	case follower:
+		return "follower"
+	case candidate:
+		return "candidate"
+	case leader:
+		return "leader"
+	}
+	return "unknown"
+}
+
+// proposal is a command proposed by the leader, waiting to be applied.
+type proposal struct {
+	term int64
+	done chan error
+}
+
+// quorum returns the number of nodes that make a majority.
+func (n *Node) quorum() int {
+	return (len(n.peers)+1)/2 + 1
+}
+
+// resetElectionTimer picks a new random election deadline, between one
+// and two election timeouts from now. It must be called with mu held.
+func (n *Node) resetElectionTimer() {
+	timeout := n.config.ElectionTimeout
+	n.electionDeadline = time.Now().Add(timeout + rand.N(timeout))
+}
+
+// setLeader records the current leader, and wakes up the goroutines
+// waiting for a leader change. It must be called with mu held.
+func (n *Node) setLeader(id string) {
+	if n.leaderID == id {
+		return
+	}
+	if id != "" {
+		log.Infof("rafttopo: %v is the leader of term %v", id, n.term)
+	}
+	n.leaderID = id
+	close(n.leaderCh)
+	n.leaderCh = make(chan struct{})
+}
+
+// setTerm moves to a new term, and persists it. It must be called with
+// mu held.
+func (n *Node) setTerm(term int64, votedFor string) error {
+	if err := n.storage.setHardState(term, votedFor); err != nil {
+		return err
+	}
+	n.term = term
+	n.votedFor = votedFor
+	return nil
+}
+
+// becomeFollower steps down to follower, moving to term if it is newer
+// than ours. It must be called with mu held.
+func (n *Node) becomeFollower(term int64) error {
+	if n.state == leader {
+		log.Infof("rafttopo: %v stepping down as the leader of term %v", n.config.ID, n.term)
+		close(n.leaderStop)
+		n.leaderStop = nil
+	}
+	n.state = follower
+	if term > n.term {
+		n.setLeader("")
+		if err := n.setTerm(term, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tick runs the timers of the node: it starts an election when the
+// election timeout of a follower expires, and it makes a leader step
+// down when it loses contact with a majority of the nodes.
+func (n *Node) tick() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := time.Now()
+	switch n.state {
+	case follower, candidate:
+		if now.After(n.electionDeadline) {
+			n.startElection()
+		}
+	case leader:
+		active := 1
+		for _, p := range n.peers {
+			if now.Sub(p.lastContact) < n.config.ElectionTimeout {
+				active++
+			}
+		}
+		if active < n.quorum() {
+			log.Warningf("rafttopo: %v lost contact with a majority of the nodes", n.config.ID)
+			if err := n.becomeFollower(n.term); err != nil {
+				log.Errorf("rafttopo: cannot step down: %v", err)
+			}
+			n.setLeader("")
+			n.resetElectionTimer()
+		}
+	}
+}
+
+// startElection becomes a candidate for a new term, and requests the
+// votes of the other nodes. It must be called with mu held.
+func (n *Node) startElection() {
+	if err := n.setTerm(n.term+1, n.config.ID); err != nil {
+		log.Errorf("rafttopo: cannot start an election: %v", err)
+		n.resetElectionTimer()
+		return
+	}
+	n.state = candidate
+	n.setLeader("")
+	n.resetElectionTimer()
+
+	votes := 1
+	if votes >= n.quorum() {
+		n.becomeLeader()
+		return
+	}
+	req := &rafttopopb.RequestVoteRequest{
+		Term:         n.term,
+		CandidateId:  n.config.ID,
+		LastLogIndex: n.storage.lastIndex(),
+		LastLogTerm:  n.storage.lastTerm(),
+	}
+	for _, p := range n.peers {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), n.config.ElectionTimeout)
+			defer cancel()
+			resp, err := p.client.RequestVote(ctx, req)
+			if err != nil {
+				return
+			}
+
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			if resp.Term > n.term {
+				if err := n.becomeFollower(resp.Term); err != nil {
+					log.Errorf("rafttopo: cannot step down: %v", err)
+				}
+				return
+			}
+			if n.state != candidate || n.term != req.Term || !resp.VoteGranted {
+				return
+			}
+			votes++
+			if votes == n.quorum() {
+				n.becomeLeader()
+			}
+		}()
+	}
+}
+
+// becomeLeader takes the leadership of the current term. It appends an
+// empty entry to commit the entries of the previous terms, and starts the
+// replication to the other nodes. It must be called with mu held.
+func (n *Node) becomeLeader() {
+	n.state = leader
+	n.setLeader(n.config.ID)
+	n.leaderStop = make(chan struct{})
+	n.sessionDeadlines = make(map[int64]time.Time)
+
+	now := time.Now()
+	for _, p := range n.peers {
+		p.nextIndex = n.storage.lastIndex() + 1
+		p.matchIndex = 0
+		p.lastContact = now
+		go n.replicate(p, n.term, n.leaderStop)
+	}
+
+	n.leaderStartIndex = n.storage.lastIndex() + 1
+	if _, err := n.appendLocal(&rafttopopb.Command{Type: rafttopopb.CommandType_NOOP}); err != nil {
+		log.Errorf("rafttopo: cannot append to the log: %v", err)
+		if err := n.becomeFollower(n.term); err != nil {
+			log.Errorf("rafttopo: cannot step down: %v", err)
+		}
+		n.setLeader("")
+	}
+}
+
+// appendLocal appends a command to the log of the leader, and returns
+// its index. It must be called with mu held.
+func (n *Node) appendLocal(cmd *rafttopopb.Command) (int64, error) {
+	entry := &rafttopopb.LogEntry{
+		Index:   n.storage.lastIndex() + 1,
+		Term:    n.term,
+		Command: cmd,
+	}
+	if err := n.storage.append(entry); err != nil {
+		return 0, err
+	}
+	n.maybeCommit()
+	for _, p := range n.peers {
+		p.wakeUp()
+	}
+	return entry.Index, nil
+}
+
+// propose commits a command through the log, and returns its index once
+// it is applied, with the result of applying it.
+func (n *Node) propose(ctx context.Context, cmd *rafttopopb.Command) (int64, error) {
+	n.mu.Lock()
+	if n.state != leader {
+		n.mu.Unlock()
+		return 0, errNotLeader
+	}
+	p := &proposal{
+		term: n.term,
+		done: make(chan error, 1),
+	}
+	index, err := n.appendLocal(cmd)
+	if err != nil {
+		n.mu.Unlock()
+		return 0, err
+	}
+	n.proposals[index] = p
+	n.mu.Unlock()
+
+	select {
+	case err := <-p.done:
+		return index, err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// maybeCommit advances the commit index of the leader to the last entry
+// of its term that is stored by a majority of the nodes. It must be
+// called with mu held.
+func (n *Node) maybeCommit() {
+	for index := n.storage.lastIndex(); index > n.commitIndex; index-- {
+		if term, _ := n.storage.term(index); term != n.term {
+			// Entries of previous terms are only committed along
+			// with an entry of the current term.
+			return
+		}
+		count := 1
+		for _, p := range n.peers {
+			if p.matchIndex >= index {
+				count++
+			}
+		}
+		if count >= n.quorum() {
+			n.commit(index)
+			for _, p := range n.peers {
+				p.wakeUp()
+			}
+			return
+		}
+	}
+}
+
+// commit advances the commit index, and wakes up the applier. It must be
+// called with mu held.
+func (n *Node) commit(index int64) {
+	if index <= n.commitIndex {
+		return
+	}
+	n.commitIndex = index
+	select {
+	case n.applyCh <- struct{}{}:
+	default:
+	}
+}
+
+// replicate sends the log of the leader to a peer, until the leadership
+// of term stops.
+func (n *Node) replicate(p *peer, term int64, stop chan struct{}) {
+	heartbeat := time.NewTicker(n.config.ElectionTimeout / 10)
+	defer heartbeat.Stop()
+	for {
+		for n.sendAppendEntries(p, term) {
+		}
+		select {
+		case <-stop:
+			return
+		case <-p.wake:
+		case <-heartbeat.C:
+		}
+	}
+}
+
+// sendAppendEntries sends the next entries, or a heartbeat, to a peer. It
+// returns true when there are more entries to send right away.
+func (n *Node) sendAppendEntries(p *peer, term int64) bool {
+	n.mu.Lock()
+	if n.state != leader || n.term != term {
+		n.mu.Unlock()
+		return false
+	}
+	if p.nextIndex <= n.storage.snapshotIndex {
+		n.mu.Unlock()
+		return n.sendSnapshot(p, term)
+	}
+	prevTerm, _ := n.storage.term(p.nextIndex - 1)
+	req := &rafttopopb.AppendEntriesRequest{
+		Term:         n.term,
+		LeaderId:     n.config.ID,
+		PrevLogIndex: p.nextIndex - 1,
+		PrevLogTerm:  prevTerm,
+		Entries:      n.storage.slice(p.nextIndex, maxAppendEntries),
+		LeaderCommit: n.commitIndex,
+	}
+	n.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.config.ElectionTimeout)
+	defer cancel()
+	resp, err := p.client.AppendEntries(ctx, req)
+	if err != nil {
+		return false
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if resp.Term > n.term {
+		if err := n.becomeFollower(resp.Term); err != nil {
+			log.Errorf("rafttopo: cannot step down: %v", err)
+		}
+		return false
+	}
+	if n.state != leader || n.term != term {
+		return false
+	}
+	p.lastContact = time.Now()
+	if !resp.Success {
+		// Skip back to the end of the log of the peer, or one entry
+		// back if it has conflicting entries.
+		p.nextIndex = max(min(req.PrevLogIndex, resp.LastLogIndex+1), 1)
+		return true
+	}
+	match := req.PrevLogIndex + int64(len(req.Entries))
+	if match > p.matchIndex {
+		p.matchIndex = match
+		n.maybeCommit()
+	}
+	p.nextIndex = max(p.nextIndex, match+1)
+	return p.nextIndex <= n.storage.lastIndex()
+}
+
+// sendSnapshot sends the latest snapshot to a peer that is missing
+// entries compacted out of the log.
+func (n *Node) sendSnapshot(p *peer, term int64) bool {
+	n.mu.Lock()
+	snapshot, err := n.storage.readSnapshot()
+	n.mu.Unlock()
+	if err != nil || snapshot == nil {
+		log.Errorf("rafttopo: cannot read the snapshot to send to %v: %v", p.addr, err)
+		return false
+	}
+	req := &rafttopopb.InstallSnapshotRequest{
+		Term:     term,
+		LeaderId: n.config.ID,
+		Snapshot: snapshot,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*n.config.ElectionTimeout)
+	defer cancel()
+	resp, err := p.client.InstallSnapshot(ctx, req)
+	if err != nil {
+		log.Warningf("rafttopo: cannot send the snapshot to %v: %v", p.addr, err)
+		return false
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if resp.Term > n.term {
+		if err := n.becomeFollower(resp.Term); err != nil {
+			log.Errorf("rafttopo: cannot step down: %v", err)
+		}
+		return false
+	}
+	if n.state != leader || n.term != term {
+		return false
+	}
+	p.lastContact = time.Now()
+	p.matchIndex = max(p.matchIndex, snapshot.LastIndex)
+	p.nextIndex = max(p.nextIndex, snapshot.LastIndex+1)
+	return true
+}
+
+// RequestVote is part of the rafttopopb.RaftTopoServer interface.
+func (n *Node) RequestVote(ctx context.Context, req *rafttopopb.RequestVoteRequest) (*rafttopopb.RequestVoteResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if req.Term > n.term {
+		if err := n.becomeFollower(req.Term); err != nil {
+			return nil, err
+		}
+	}
+	resp := &rafttopopb.RequestVoteResponse{Term: n.term}
+	if req.Term < n.term || (n.votedFor != "" && n.votedFor != req.CandidateId) {
+		return resp, nil
+	}
+	// The candidate must have all the committed entries, so its log
+	// must be at least as up-to-date as ours.
+	lastTerm := n.storage.lastTerm()
+	if req.LastLogTerm < lastTerm || (req.LastLogTerm == lastTerm && req.LastLogIndex < n.storage.lastIndex()) {
+		return resp, nil
+	}
+	if err := n.setTerm(n.term, req.CandidateId); err != nil {
+		return nil, err
+	}
+	n.resetElectionTimer()
+	resp.VoteGranted = true
+	return resp, nil
+}
+
+// AppendEntries is part of the rafttopopb.RaftTopoServer interface.
+func (n *Node) AppendEntries(ctx context.Context, req *rafttopopb.AppendEntriesRequest) (*rafttopopb.AppendEntriesResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	resp := &rafttopopb.AppendEntriesResponse{Term: n.term}
+	if req.Term < n.term {
+		resp.LastLogIndex = n.storage.lastIndex()
+		return resp, nil
+	}
+	if req.Term > n.term || n.state != follower {
+		if err := n.becomeFollower(req.Term); err != nil {
+			return nil, err
+		}
+		resp.Term = n.term
+	}
+	n.setLeader(req.LeaderId)
+	n.resetElectionTimer()
+
+	// Check that our log matches the log of the leader up to the entry
+	// before the new ones. The entries up to the snapshot are committed,
+	// so they match.
+	entries := req.Entries
+	prevIndex := req.PrevLogIndex
+	if prevIndex < n.storage.snapshotIndex {
+		skip := min(n.storage.snapshotIndex-prevIndex, int64(len(entries)))
+		entries = entries[skip:]
+		prevIndex += skip
+	}
+	if prevIndex > n.storage.lastIndex() {
+		resp.LastLogIndex = n.storage.lastIndex()
+		return resp, nil
+	}
+	if prevIndex > n.storage.snapshotIndex {
+		if term, _ := n.storage.term(prevIndex); term != req.PrevLogTerm {
+			resp.LastLogIndex = prevIndex - 1
+			return resp, nil
+		}
+	}
+
+	// Append the new entries, dropping ours from the first conflicting one.
+	for i, entry := range entries {
+		if entry.Index <= n.storage.lastIndex() {
+			if term, _ := n.storage.term(entry.Index); term == entry.Term {
+				continue
+			}
+			if entry.Index <= n.commitIndex {
+				return nil, fmt.Errorf("entry %d from leader %v conflicts with a committed entry", entry.Index, req.LeaderId)
+			}
+			if err := n.storage.truncate(entry.Index); err != nil {
+				return nil, err
+			}
+			n.failProposals(entry.Index, errLeadershipLost)
+		}
+		if err := n.storage.append(entries[i:]...); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	if req.LeaderCommit > n.commitIndex {
+		n.commit(min(req.LeaderCommit, prevIndex+int64(len(entries))))
+	}
+	resp.Success = true
+	resp.LastLogIndex = n.storage.lastIndex()
+	return resp, nil
+}
+
+// InstallSnapshot is part of the rafttopopb.RaftTopoServer interface.
+func (n *Node) InstallSnapshot(ctx context.Context, req *rafttopopb.InstallSnapshotRequest) (*rafttopopb.InstallSnapshotResponse, error) {
+	// Hold the applier, while we replace the state it applies to.
+	n.applyMu.Lock()
+	defer n.applyMu.Unlock()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	resp := &rafttopopb.InstallSnapshotResponse{Term: n.term}
+	if req.Term < n.term {
+		return resp, nil
+	}
+	if req.Term > n.term || n.state != follower {
+		if err := n.becomeFollower(req.Term); err != nil {
+			return nil, err
+		}
+		resp.Term = n.term
+	}
+	n.setLeader(req.LeaderId)
+	n.resetElectionTimer()
+
+	snapshot := req.Snapshot
+	if snapshot == nil || snapshot.LastIndex <= n.lastApplied {
+		return resp, nil
+	}
+	log.Infof("rafttopo: installing the snapshot of index %v from %v", snapshot.LastIndex, req.LeaderId)
+	if err := n.storage.saveSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+	n.store.restore(snapshot)
+	n.lastApplied = snapshot.LastIndex
+	if n.commitIndex < snapshot.LastIndex {
+		n.commitIndex = snapshot.LastIndex
+	}
+	return resp, nil
+}
+
+// failProposals fails the proposals from index on. It must be called
+// with mu held.
+func (n *Node) failProposals(index int64, err error) {
+	for i, p := range n.proposals {
+		if i >= index {
+			p.done <- err
+			delete(n.proposals, i)
+		}
+	}
+}
+
+// applyLoop applies the committed entries to the store.
+func (n *Node) applyLoop() {
+	defer n.wg.Done()
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case <-n.applyCh:
+		}
+		n.applyCommitted()
+	}
+}
+
+func (n *Node) applyCommitted() {
+	n.applyMu.Lock()
+	defer n.applyMu.Unlock()
+	for {
+		n.mu.Lock()
+		count := min(n.commitIndex-n.lastApplied, maxApplyBatch)
+		var entries []*rafttopopb.LogEntry
+		if count > 0 {
+			entries = n.storage.slice(n.lastApplied+1, int(count))
+		}
+		n.mu.Unlock()
+		if len(entries) == 0 {
+			return
+		}
+
+		for _, entry := range entries {
+			err := n.store.apply(entry)
+
+			n.mu.Lock()
+			n.lastApplied = entry.Index
+			if p, ok := n.proposals[entry.Index]; ok {
+				if p.term != entry.Term {
+					err = errLeadershipLost
+				}
+				p.done <- err
+				delete(n.proposals, entry.Index)
+			}
+			n.mu.Unlock()
+		}
+		n.maybeSnapshot()
+	}
+}
+
+// maybeSnapshot compacts the log into a snapshot when enough entries
+// were applied since the last one. It must be called with applyMu held.
+func (n *Node) maybeSnapshot() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.lastApplied-n.storage.snapshotIndex < n.config.SnapshotThreshold {
+		return
+	}
+	if err := n.storage.saveSnapshot(n.store.snapshot()); err != nil {
+		log.Errorf("rafttopo: cannot save a snapshot: %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package rafttopo implements topo.Server with a raft-replicated key-value
+store embedded in a small set of vtctld processes, so no external
+topology service is needed.
+
+Each vtctld started with --topo_raft_data_dir runs a Node, that serves
+the RaftTopo gRPC service on the gRPC port of vtctld. The nodes elect a
+leader, that replicates the changes of the topology data to the others
+through the raft log. The clients use the "raft" topo implementation,
+with the comma-separated list of the gRPC addresses of the nodes as the
+server address.
+
+We follow these conventions within this package:
+
+  - The paths of the files are the keys of the store, there are no
+    directories: a directory exists as long as it has files under it.
+  - The version of a file is the index of the log entry that last changed
+    it. It increases with each change, so the oldest lock file wins.
+  - The ephemeral files of locks and elections belong to a session, that
+    the client keeps alive, and that the leader closes when it expires.
+  - Call convertError(err) on any errors returned from the nodes.
+    Functions defined in this package can be assumed to have already
+    converted errors as necessary.
+*/
+package rafttopo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+var (
+	clientCertPath string
+	clientKeyPath  string
+	serverCaPath   string
+)
+
+// Factory is the raft topo.Factory implementation.
+type Factory struct{}
+
+// HasGlobalReadOnlyCell is part of the topo.Factory interface.
+func (f Factory) HasGlobalReadOnlyCell(serverAddr, root string) bool {
+	return false
+}
+
+// Create is part of the topo.Factory interface.
+func (f Factory) Create(cell, serverAddr, root string) (topo.Conn, error) {
+	return NewServer(serverAddr, root)
+}
+
+// Server is the implementation of topo.Server for the raft topo server.
+type Server struct {
+	// addrs are the gRPC addresses of the nodes.
+	addrs []string
+
+	// root is the root path for this client.
+	root string
+
+	dialOption grpc.DialOption
+
+	// mu protects conns and current.
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+	// current is the index in addrs of the node the requests go to,
+	// until it is unavailable.
+	current int
+
+	// lastIndex is the index of the last write of this client. The
+	// nodes apply it before serving our reads.
+	lastIndex atomic.Int64
+
+	running chan struct{}
+}
+
+func init() {
+	for _, cmd := range topo.FlagBinaries {
+		servenv.OnParseFor(cmd, registerRaftTopoFlags)
+	}
+	topo.RegisterFactory("raft", Factory{})
+}
+
+func registerRaftTopoFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&clientCertPath, "topo_raft_tls_cert", clientCertPath, "path to the client cert to use to connect to the raft topo server nodes, requires topo_raft_tls_key, enables TLS")
+	fs.StringVar(&clientKeyPath, "topo_raft_tls_key", clientKeyPath, "path to the client key to use to connect to the raft topo server nodes, enables TLS")
+	fs.StringVar(&serverCaPath, "topo_raft_tls_ca", serverCaPath, "path to the ca to use to validate the server cert when connecting to the raft topo server nodes")
+}
+
+// Close implements topo.Server.Close.
+// It will nil out the connections, so any attempt to re-use this server
+// will fail.
+func (s *Server) Close() {
+	close(s.running)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+// NewServerWithOpts creates a new server with the provided TLS options.
+func NewServerWithOpts(serverAddr, root, certPath, keyPath, caPath string) (*Server, error) {
+	dialOption, err := grpcclient.SecureDialOption(certPath, keyPath, caPath, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		addrs:      strings.Split(serverAddr, ","),
+		root:       root,
+		dialOption: dialOption,
+		conns:      make(map[string]*grpc.ClientConn),
+		running:    make(chan struct{}),
+	}, nil
+}
+
+// NewServer returns a new rafttopo.Server.
+func NewServer(serverAddr, root string) (*Server, error) {
+	return NewServerWithOpts(serverAddr, root, clientCertPath, clientKeyPath, serverCaPath)
+}
+
+// client returns the client of the current node, and its index.
+func (s *Server) client() (rafttopopb.RaftTopoClient, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conns == nil {
+		return nil, 0, status.Error(codes.Canceled, "topo server closed")
+	}
+	addr := s.addrs[s.current]
+	conn, ok := s.conns[addr]
+	if !ok {
+		var err error
+		conn, err = grpcclient.DialContext(context.Background(), addr, grpcclient.FailFast(true), s.dialOption)
+		if err != nil {
+			return nil, s.current, status.Error(codes.Unavailable, err.Error())
+		}
+		s.conns[addr] = conn
+	}
+	return rafttopopb.NewRaftTopoClient(conn), s.current, nil
+}
+
+// next moves to the node after the one at index, if it is still the
+// current one.
+func (s *Server) next(index int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == index {
+		s.current = (s.current + 1) % len(s.addrs)
+	}
+}
+
+// call runs f with the client of the current node. While the nodes are
+// unavailable, it tries the next ones, for topo.RemoteOperationTimeout
+// at most.
+func (s *Server) call(ctx context.Context, f func(client rafttopopb.RaftTopoClient) error) error {
+	deadline := time.Now().Add(topo.RemoteOperationTimeout)
+	backoff := 10 * time.Millisecond
+	for {
+		client, index, err := s.client()
+		if err == nil {
+			err = f(client)
+		}
+		if status.Code(err) != codes.Unavailable || time.Now().After(deadline) {
+			return err
+		}
+		s.next(index)
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-s.running:
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+		backoff = min(2*backoff, time.Second)
+	}
+}
+
+// apply commits a command, and returns its index.
+func (s *Server) apply(ctx context.Context, cmd *rafttopopb.Command) (int64, error) {
+	var resp *rafttopopb.ApplyResponse
+	err := s.call(ctx, func(client rafttopopb.RaftTopoClient) (err error) {
+		resp, err = client.Apply(ctx, &rafttopopb.ApplyRequest{Command: cmd})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	for {
+		last := s.lastIndex.Load()
+		if resp.Index <= last || s.lastIndex.CompareAndSwap(last, resp.Index) {
+			return resp.Index, nil
+		}
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/test"
+)
+
+// testCluster is a cluster of nodes running in the test process.
+type testCluster struct {
+	t       *testing.T
+	config  Config
+	addrs   []string
+	dirs    []string
+	nodes   []*Node
+	servers []*grpc.Server
+}
+
+// startCluster starts a cluster of count nodes.
+func startCluster(t *testing.T, count int, snapshotThreshold int64) *testCluster {
+	c := &testCluster{
+		t: t,
+		config: Config{
+			ElectionTimeout:   150 * time.Millisecond,
+			SnapshotThreshold: snapshotThreshold,
+		},
+		nodes:   make([]*Node, count),
+		servers: make([]*grpc.Server, count),
+	}
+	listeners := make([]net.Listener, count)
+	for i := range count {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		listeners[i] = listener
+		c.addrs = append(c.addrs, listener.Addr().String())
+		c.dirs = append(c.dirs, t.TempDir())
+	}
+	c.config.Peers = c.addrs
+	for i := range count {
+		c.serve(i, listeners[i])
+	}
+	t.Cleanup(func() {
+		for i := range c.nodes {
+			if c.nodes[i] != nil {
+				c.stopNode(i)
+			}
+		}
+	})
+	return c
+}
+
+func (c *testCluster) serve(i int, listener net.Listener) {
+	config := c.config
+	config.ID = c.addrs[i]
+	config.DataDir = c.dirs[i]
+	node, err := NewNode(config)
+	require.NoError(c.t, err)
+	server := grpc.NewServer()
+	node.Register(server)
+	go server.Serve(listener)
+	c.nodes[i] = node
+	c.servers[i] = server
+}
+
+// stopNode stops a node, keeping its data directory.
+func (c *testCluster) stopNode(i int) {
+	c.servers[i].Stop()
+	c.nodes[i].Close()
+	c.nodes[i] = nil
+}
+
+// restartNode restarts a stopped node on the same address.
+func (c *testCluster) restartNode(i int) {
+	listener, err := net.Listen("tcp", c.addrs[i])
+	require.NoError(c.t, err)
+	c.serve(i, listener)
+}
+
+// leader waits for a node to be the leader, and returns its index.
+func (c *testCluster) leader() int {
+	var index int
+	require.Eventually(c.t, func() bool {
+		for i, node := range c.nodes {
+			if node == nil {
+				continue
+			}
+			node.mu.Lock()
+			isLeader := node.state == leader
+			node.mu.Unlock()
+			if isLeader {
+				index = i
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 10*time.Millisecond)
+	return index
+}
+
+func TestRaftTopo(t *testing.T) {
+	c := startCluster(t, 3, 10000)
+	serverAddr := strings.Join(c.addrs, ",")
+
+	testIndex := 0
+	newServer := func() *topo.Server {
+		// Each test will use its own sub-directories.
+		testRoot := fmt.Sprintf("/test-%v", testIndex)
+		testIndex++
+
+		// Create the server on the new root.
+		ts, err := topo.OpenServer("raft", serverAddr, path.Join(testRoot, topo.GlobalCell))
+		if err != nil {
+			t.Fatalf("OpenServer() failed: %v", err)
+		}
+
+		// Create the CellInfo.
+		if err := ts.CreateCellInfo(context.Background(), test.LocalCellName, &topodatapb.CellInfo{
+			ServerAddress: serverAddr,
+			Root:          path.Join(testRoot, test.LocalCellName),
+		}); err != nil {
+			t.Fatalf("CreateCellInfo() failed: %v", err)
+		}
+
+		return ts
+	}
+
+	// Run the TopoServerTestSuite tests.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	test.TopoServerTestSuite(t, ctx, func() *topo.Server {
+		return newServer()
+	}, []string{})
+}
+
+func TestRaftTopoFailover(t *testing.T) {
+	ctx := context.Background()
+	c := startCluster(t, 3, 10000)
+	s, err := NewServer(strings.Join(c.addrs, ","), "/failover")
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, err = s.Create(ctx, "file", []byte("1"))
+	require.NoError(t, err)
+
+	// Stop the leader, the other nodes elect a new one.
+	old := c.leader()
+	c.stopNode(old)
+	version, err := s.Update(ctx, "file", []byte("2"), nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, old, c.leader())
+
+	// The old leader catches up when it comes back, from its log.
+	c.restartNode(old)
+	require.NoError(t, c.nodes[old].store.waitApplied(ctx, int64(version.(RaftVersion))))
+	file, err := c.nodes[old].store.get("/failover/file")
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(file.Contents))
+}
+
+func TestRaftTopoSnapshot(t *testing.T) {
+	ctx := context.Background()
+	c := startCluster(t, 3, 10)
+	s, err := NewServer(strings.Join(c.addrs, ","), "/snapshot")
+	require.NoError(t, err)
+	defer s.Close()
+
+	// Stop a follower, and write enough for the others to compact
+	// the entries it is missing.
+	follower := (c.leader() + 1) % 3
+	c.stopNode(follower)
+	var version topo.Version
+	for i := range 50 {
+		version, err = s.Update(ctx, fmt.Sprintf("file%d", i%5), []byte(fmt.Sprint(i)), nil)
+		require.NoError(t, err)
+	}
+
+	// The follower gets a snapshot when it comes back.
+	c.restartNode(follower)
+	require.NoError(t, c.nodes[follower].store.waitApplied(ctx, int64(version.(RaftVersion))))
+	files := c.nodes[follower].store.list("/snapshot/", false)
+	require.Len(t, files, 5)
+	assert.Equal(t, "49", string(files[4].Contents))
+	assert.Positive(t, c.nodes[follower].storage.snapshotIndex)
+
+	// And it still has the data after a restart, from its snapshot.
+	c.stopNode(follower)
+	c.restartNode(follower)
+	files = c.nodes[follower].store.list("/snapshot/", false)
+	assert.Len(t, files, 5)
+}
+
+func TestRaftTopoSessionExpiry(t *testing.T) {
+	ctx := context.Background()
+	c := startCluster(t, 3, 10000)
+	serverAddr := strings.Join(c.addrs, ",")
+
+	s1, err := NewServer(serverAddr, "/expiry")
+	require.NoError(t, err)
+	s2, err := NewServer(serverAddr, "/expiry")
+	require.NoError(t, err)
+	defer s2.Close()
+
+	_, err = s1.Create(ctx, "keyspace/file", []byte("contents"))
+	require.NoError(t, err)
+	_, err = s1.LockWithTTL(ctx, "keyspace", "s1", 500*time.Millisecond)
+	require.NoError(t, err)
+
+	// The lock is held as long as s1 keeps its session alive.
+	ctx1, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = s2.Lock(ctx1, "keyspace", "s2")
+	require.True(t, topo.IsErrType(err, topo.Timeout), "unexpected error: %v", err)
+
+	// Once s1 goes away, its session expires and releases the lock.
+	s1.Close()
+	ld, err := s2.Lock(ctx, "keyspace", "s2")
+	require.NoError(t, err)
+	require.NoError(t, ld.Unlock(ctx))
+}
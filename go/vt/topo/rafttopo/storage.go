@@ -0,0 +1,338 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"vitess.io/vitess/go/vt/log"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+// The files of the data directory of a node.
+const (
+	stateFile    = "state"
+	snapshotFile = "snapshot"
+	logFile      = "log"
+)
+
+// storage persists the raft state of a node in its data directory:
+//   - the state file has the HardState, rewritten when it changes.
+//   - the snapshot file has the latest Snapshot of the topology data.
+//   - the log file has the entries that follow the snapshot, each one
+//     prefixed by its length.
+//
+// It keeps the entries in memory, until they are compacted into a snapshot.
+// It is not safe for concurrent use, the raft mutex protects it.
+type storage struct {
+	dir       string
+	hardState *rafttopopb.HardState
+
+	// snapshotIndex and snapshotTerm are the last index and term of the
+	// latest snapshot. The entries start right after it.
+	snapshotIndex int64
+	snapshotTerm  int64
+	entries       []*rafttopopb.LogEntry
+
+	log *os.File
+}
+
+// openStorage opens the storage in dir, creating it if needed. It returns
+// the latest snapshot, if any, to restore the topology data from.
+func openStorage(dir string) (*storage, *rafttopopb.Snapshot, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, nil, err
+	}
+	s := &storage{
+		dir:       dir,
+		hardState: &rafttopopb.HardState{},
+	}
+	data, err := os.ReadFile(filepath.Join(dir, stateFile))
+	switch {
+	case err == nil:
+		if err := s.hardState.UnmarshalVT(data); err != nil {
+			return nil, nil, fmt.Errorf("cannot parse the raft state: %w", err)
+		}
+	case !os.IsNotExist(err):
+		return nil, nil, err
+	}
+	snapshot, err := s.readSnapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	if snapshot != nil {
+		s.snapshotIndex = snapshot.LastIndex
+		s.snapshotTerm = snapshot.LastTerm
+	}
+	if err := s.readLog(); err != nil {
+		return nil, nil, err
+	}
+	return s, snapshot, nil
+}
+
+// readLog loads the entries of the log file, and opens it for appending.
+// A partially written record at the end of the file, left by a crash, is
+// dropped.
+func (s *storage) readLog() error {
+	name := filepath.Join(s.dir, logFile)
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0o640)
+	if err != nil {
+		return err
+	}
+	var valid int64
+	r := bufio.NewReader(f)
+	for {
+		size, err := binary.ReadUvarint(r)
+		if err != nil {
+			break
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			break
+		}
+		entry := &rafttopopb.LogEntry{}
+		if err := entry.UnmarshalVT(data); err != nil {
+			break
+		}
+		valid += int64(len(binary.AppendUvarint(nil, size))) + int64(size)
+		// A crash between saving a snapshot and rewriting the log leaves
+		// entries that are already in the snapshot.
+		if entry.Index <= s.snapshotIndex {
+			continue
+		}
+		if entry.Index != s.lastIndex()+1 {
+			f.Close()
+			return fmt.Errorf("unexpected index %d in the raft log after index %d", entry.Index, s.lastIndex())
+		}
+		s.entries = append(s.entries, entry)
+	}
+	if size, err := f.Seek(0, io.SeekEnd); err == nil && size > valid {
+		log.Warningf("Dropping %d bytes of a partially written record at the end of %s", size-valid, name)
+		if err := f.Truncate(valid); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if _, err := f.Seek(valid, io.SeekStart); err != nil {
+		f.Close()
+		return err
+	}
+	s.log = f
+	return nil
+}
+
+// readSnapshot returns the latest snapshot, or nil if there is none yet.
+func (s *storage) readSnapshot() (*rafttopopb.Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &rafttopopb.Snapshot{}
+	if err := snapshot.UnmarshalVT(data); err != nil {
+		return nil, fmt.Errorf("cannot parse the raft snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// setHardState persists the term and vote of the node.
+func (s *storage) setHardState(term int64, votedFor string) error {
+	hs := &rafttopopb.HardState{Term: term, VotedFor: votedFor}
+	data, err := hs.MarshalVT()
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(s.dir, stateFile), data); err != nil {
+		return err
+	}
+	s.hardState = hs
+	return nil
+}
+
+// firstIndex returns the index of the first entry in the log.
+func (s *storage) firstIndex() int64 {
+	return s.snapshotIndex + 1
+}
+
+// lastIndex returns the index of the last entry in the log, or of the
+// snapshot if the log is empty.
+func (s *storage) lastIndex() int64 {
+	return s.snapshotIndex + int64(len(s.entries))
+}
+
+// lastTerm returns the term of the last entry of the log.
+func (s *storage) lastTerm() int64 {
+	if len(s.entries) == 0 {
+		return s.snapshotTerm
+	}
+	return s.entries[len(s.entries)-1].Term
+}
+
+// term returns the term of the entry at index. It returns false when the
+// entry was compacted away, or does not exist yet.
+func (s *storage) term(index int64) (int64, bool) {
+	switch {
+	case index == s.snapshotIndex:
+		return s.snapshotTerm, true
+	case index < s.snapshotIndex || index > s.lastIndex():
+		return 0, false
+	}
+	return s.entries[index-s.firstIndex()].Term, true
+}
+
+// slice returns a copy of up to max entries starting at index, that is
+// safe to use without holding the raft mutex.
+func (s *storage) slice(index int64, max int) []*rafttopopb.LogEntry {
+	if index < s.firstIndex() || index > s.lastIndex() {
+		return nil
+	}
+	entries := s.entries[index-s.firstIndex():]
+	if len(entries) > max {
+		entries = entries[:max]
+	}
+	return append([]*rafttopopb.LogEntry(nil), entries...)
+}
+
+// append appends entries to the log, and syncs them to disk.
+func (s *storage) append(entries ...*rafttopopb.LogEntry) error {
+	var buf []byte
+	for _, entry := range entries {
+		data, err := entry.MarshalVT()
+		if err != nil {
+			return err
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(data)))
+		buf = append(buf, data...)
+	}
+	if _, err := s.log.Write(buf); err != nil {
+		return err
+	}
+	if err := s.log.Sync(); err != nil {
+		return err
+	}
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+// truncate removes the entries starting at index, that conflict with the
+// log of the leader.
+func (s *storage) truncate(index int64) error {
+	if index < s.firstIndex() || index > s.lastIndex() {
+		return nil
+	}
+	s.entries = s.entries[:index-s.firstIndex()]
+	return s.rewriteLog()
+}
+
+// saveSnapshot persists a snapshot, and compacts the entries it contains
+// out of the log. Entries that follow the snapshot are kept, unless they
+// conflict with it.
+func (s *storage) saveSnapshot(snapshot *rafttopopb.Snapshot) error {
+	if snapshot.LastIndex <= s.snapshotIndex {
+		return nil
+	}
+	data, err := snapshot.MarshalVT()
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(s.dir, snapshotFile), data); err != nil {
+		return err
+	}
+	if term, ok := s.term(snapshot.LastIndex); ok && term == snapshot.LastTerm {
+		s.entries = s.entries[snapshot.LastIndex-s.firstIndex()+1:]
+	} else {
+		s.entries = nil
+	}
+	s.snapshotIndex = snapshot.LastIndex
+	s.snapshotTerm = snapshot.LastTerm
+	return s.rewriteLog()
+}
+
+// rewriteLog replaces the log file with the entries in memory.
+func (s *storage) rewriteLog() error {
+	name := filepath.Join(s.dir, logFile)
+	tmp := name + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	old := s.log
+	s.log = f
+	entries := s.entries
+	s.entries = nil
+	if err := s.append(entries...); err != nil {
+		f.Close()
+		s.log = old
+		s.entries = entries
+		return err
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		f.Close()
+		s.log = old
+		return err
+	}
+	old.Close()
+	return syncDir(s.dir)
+}
+
+// close closes the log file.
+func (s *storage) close() error {
+	return s.log.Close()
+}
+
+// writeFileAtomic replaces the contents of a file, so that a crash leaves
+// either the old or the new contents.
+func writeFileAtomic(name string, data []byte) error {
+	tmp := name + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.Join(err, os.Remove(tmp))
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(name))
+}
+
+// syncDir syncs a directory, to persist the renames of its files.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
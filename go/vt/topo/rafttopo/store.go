@@ -0,0 +1,385 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+// watcherBufferSize is the number of batches of changes a watcher can
+// lag behind, before it is closed.
+const watcherBufferSize = 128
+
+// session is a set of ephemeral files, deleted when the session closes.
+type session struct {
+	ttl   time.Duration
+	files map[string]struct{}
+}
+
+// watcher is a watch of a file, or of the files under a directory.
+type watcher struct {
+	path      string
+	recursive bool
+
+	// events receives the changes. It is closed when the watcher
+	// falls behind, or when the data is restored from a snapshot.
+	events chan []*rafttopopb.WatchEvent
+}
+
+// matches returns true if the watcher is interested in a file.
+func (w *watcher) matches(filePath string) bool {
+	if w.recursive {
+		return strings.HasPrefix(filePath, w.path+"/")
+	}
+	return filePath == w.path
+}
+
+// store is the state machine that the raft log is applied to: the files
+// and sessions of the topology data. Files are never modified in place,
+// so they can be used without holding the mutex once returned.
+type store struct {
+	mu       sync.Mutex
+	files    map[string]*rafttopopb.File
+	sessions map[int64]*session
+
+	// appliedIndex and appliedTerm are the index and term of the last
+	// entry applied to the store. appliedCh is closed, and replaced, when
+	// appliedIndex changes.
+	appliedIndex int64
+	appliedTerm  int64
+	appliedCh    chan struct{}
+
+	watchers map[*watcher]struct{}
+}
+
+func newStore() *store {
+	return &store{
+		files:     make(map[string]*rafttopopb.File),
+		sessions:  make(map[int64]*session),
+		appliedCh: make(chan struct{}),
+		watchers:  make(map[*watcher]struct{}),
+	}
+}
+
+// apply applies an entry of the log. The returned error is the result of
+// the command, and has to be the same on all the nodes, so it only
+// depends on the state of the store.
+func (s *store) apply(entry *rafttopopb.LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.applyCommand(entry.Index, entry.Command)
+	s.appliedIndex = entry.Index
+	s.appliedTerm = entry.Term
+	close(s.appliedCh)
+	s.appliedCh = make(chan struct{})
+	return err
+}
+
+func (s *store) applyCommand(index int64, cmd *rafttopopb.Command) error {
+	if cmd == nil {
+		return nil
+	}
+	switch cmd.Type {
+	case rafttopopb.CommandType_CREATE:
+		if _, ok := s.files[cmd.Path]; ok {
+			return topo.NewError(topo.NodeExists, cmd.Path)
+		}
+		if cmd.SessionId != 0 {
+			sess, ok := s.sessions[cmd.SessionId]
+			if !ok {
+				return topo.NewError(topo.NoNode, "session")
+			}
+			sess.files[cmd.Path] = struct{}{}
+		}
+		s.put(&rafttopopb.File{
+			Path:      cmd.Path,
+			Contents:  cmd.Contents,
+			Version:   index,
+			SessionId: cmd.SessionId,
+		})
+	case rafttopopb.CommandType_UPDATE:
+		file, ok := s.files[cmd.Path]
+		if cmd.Version != 0 {
+			if !ok {
+				return topo.NewError(topo.NoNode, cmd.Path)
+			}
+			if file.Version != cmd.Version {
+				return topo.NewError(topo.BadVersion, cmd.Path)
+			}
+		}
+		updated := &rafttopopb.File{
+			Path:     cmd.Path,
+			Contents: cmd.Contents,
+			Version:  index,
+		}
+		if ok {
+			updated.SessionId = file.SessionId
+		}
+		s.put(updated)
+	case rafttopopb.CommandType_DELETE:
+		file, ok := s.files[cmd.Path]
+		if !ok {
+			return topo.NewError(topo.NoNode, cmd.Path)
+		}
+		if cmd.Version != 0 && file.Version != cmd.Version {
+			return topo.NewError(topo.BadVersion, cmd.Path)
+		}
+		if sess, ok := s.sessions[file.SessionId]; ok {
+			delete(sess.files, file.Path)
+		}
+		s.delete(file)
+	case rafttopopb.CommandType_CREATE_SESSION:
+		ttl, _, err := protoutil.DurationFromProto(cmd.Ttl)
+		if err != nil {
+			return err
+		}
+		s.sessions[index] = &session{
+			ttl:   ttl,
+			files: make(map[string]struct{}),
+		}
+	case rafttopopb.CommandType_CLOSE_SESSION:
+		sess, ok := s.sessions[cmd.SessionId]
+		if !ok {
+			return topo.NewError(topo.NoNode, "session")
+		}
+		delete(s.sessions, cmd.SessionId)
+		paths := make([]string, 0, len(sess.files))
+		for p := range sess.files {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		for _, p := range paths {
+			s.delete(s.files[p])
+		}
+	}
+	return nil
+}
+
+// put stores a file, and notifies the watchers.
+func (s *store) put(file *rafttopopb.File) {
+	s.files[file.Path] = file
+	s.notify(&rafttopopb.WatchEvent{File: file})
+}
+
+// delete removes a file, and notifies the watchers.
+func (s *store) delete(file *rafttopopb.File) {
+	delete(s.files, file.Path)
+	s.notify(&rafttopopb.WatchEvent{File: file, Deleted: true})
+}
+
+// notify sends an event to the interested watchers. A watcher that is
+// too far behind is closed, its client has to watch again.
+func (s *store) notify(event *rafttopopb.WatchEvent) {
+	for w := range s.watchers {
+		if !w.matches(event.File.Path) {
+			continue
+		}
+		select {
+		case w.events <- []*rafttopopb.WatchEvent{event}:
+		default:
+			s.closeWatcher(w)
+		}
+	}
+}
+
+func (s *store) closeWatcher(w *watcher) {
+	delete(s.watchers, w)
+	close(w.events)
+}
+
+// get returns a file, or a NoNode error.
+func (s *store) get(filePath string) (*rafttopopb.File, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, ok := s.files[filePath]
+	if !ok {
+		return nil, topo.NewError(topo.NoNode, filePath)
+	}
+	return file, nil
+}
+
+// list returns the files whose path starts with prefix, sorted by path.
+func (s *store) list(prefix string, keysOnly bool) []*rafttopopb.File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var files []*rafttopopb.File
+	for p, file := range s.files {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		if keysOnly {
+			file = &rafttopopb.File{
+				Path:      file.Path,
+				Version:   file.Version,
+				SessionId: file.SessionId,
+			}
+		}
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// sessionTTL returns the ttl of a session, and false if it is not open.
+func (s *store) sessionTTL(id int64) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok {
+		return 0, false
+	}
+	return sess.ttl, true
+}
+
+// sessionTTLs returns the ttl of the open sessions.
+func (s *store) sessionTTLs() map[int64]time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ttls := make(map[int64]time.Duration, len(s.sessions))
+	for id, sess := range s.sessions {
+		ttls[id] = sess.ttl
+	}
+	return ttls
+}
+
+// applied returns the index of the last applied entry.
+func (s *store) applied() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.appliedIndex
+}
+
+// waitApplied waits until the entry at index is applied.
+func (s *store) waitApplied(ctx context.Context, index int64) error {
+	for {
+		s.mu.Lock()
+		applied, ch := s.appliedIndex, s.appliedCh
+		s.mu.Unlock()
+		if applied >= index {
+			return nil
+		}
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// watch returns the current contents of a file, or of the files under a
+// directory if recursive is set, and a watcher for their changes. The
+// watcher has to be removed with unwatch.
+func (s *store) watch(filePath string, recursive bool) ([]*rafttopopb.WatchEvent, *watcher, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := &watcher{
+		path:      filePath,
+		recursive: recursive,
+		events:    make(chan []*rafttopopb.WatchEvent, watcherBufferSize),
+	}
+	var initial []*rafttopopb.WatchEvent
+	if recursive {
+		for p, file := range s.files {
+			if w.matches(p) {
+				initial = append(initial, &rafttopopb.WatchEvent{File: file})
+			}
+		}
+		sort.Slice(initial, func(i, j int) bool {
+			return initial[i].File.Path < initial[j].File.Path
+		})
+	} else {
+		file, ok := s.files[filePath]
+		if !ok {
+			return nil, nil, topo.NewError(topo.NoNode, filePath)
+		}
+		initial = append(initial, &rafttopopb.WatchEvent{File: file})
+	}
+	s.watchers[w] = struct{}{}
+	return initial, w, nil
+}
+
+// unwatch removes a watcher, if it is still registered.
+func (s *store) unwatch(w *watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.watchers[w]; ok {
+		s.closeWatcher(w)
+	}
+}
+
+// snapshot returns the contents of the store.
+func (s *store) snapshot() *rafttopopb.Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := &rafttopopb.Snapshot{
+		LastIndex: s.appliedIndex,
+		LastTerm:  s.appliedTerm,
+		Files:     make([]*rafttopopb.File, 0, len(s.files)),
+		Sessions:  make([]*rafttopopb.Session, 0, len(s.sessions)),
+	}
+	for _, file := range s.files {
+		snapshot.Files = append(snapshot.Files, file)
+	}
+	for id, sess := range s.sessions {
+		snapshot.Sessions = append(snapshot.Sessions, &rafttopopb.Session{
+			Id:  id,
+			Ttl: protoutil.DurationToProto(sess.ttl),
+		})
+	}
+	return snapshot
+}
+
+// restore replaces the contents of the store with a snapshot. The
+// watchers are closed, as they cannot be told what changed.
+func (s *store) restore(snapshot *rafttopopb.Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files = make(map[string]*rafttopopb.File, len(snapshot.Files))
+	s.sessions = make(map[int64]*session, len(snapshot.Sessions))
+	for _, sess := range snapshot.Sessions {
+		ttl, _, _ := protoutil.DurationFromProto(sess.Ttl)
+		s.sessions[sess.Id] = &session{
+			ttl:   ttl,
+			files: make(map[string]struct{}),
+		}
+	}
+	for _, file := range snapshot.Files {
+		s.files[file.Path] = file
+		if sess, ok := s.sessions[file.SessionId]; ok {
+			sess.files[file.Path] = struct{}{}
+		}
+	}
+	for w := range s.watchers {
+		s.closeWatcher(w)
+	}
+	s.appliedIndex = snapshot.LastIndex
+	s.appliedTerm = snapshot.LastTerm
+	close(s.appliedCh)
+	s.appliedCh = make(chan struct{})
+}
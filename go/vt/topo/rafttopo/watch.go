@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rafttopo
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"vitess.io/vitess/go/vt/topo"
+
+	rafttopopb "vitess.io/vitess/go/vt/proto/rafttopo"
+)
+
+// startWatch starts a watch on a node, and returns the stream with its
+// first response, that has the current contents of the watched files.
+func (s *Server) startWatch(ctx context.Context, nodePath string, recursive bool) (rafttopopb.RaftTopo_WatchClient, *rafttopopb.WatchResponse, error) {
+	var stream rafttopopb.RaftTopo_WatchClient
+	var initial *rafttopopb.WatchResponse
+	err := s.call(ctx, func(client rafttopopb.RaftTopoClient) (err error) {
+		stream, err = client.Watch(ctx, &rafttopopb.WatchRequest{
+			Path:      nodePath,
+			Recursive: recursive,
+			MinIndex:  s.lastIndex.Load(),
+		})
+		if err != nil {
+			return err
+		}
+		initial, err = stream.Recv()
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return stream, initial, nil
+}
+
+// recv returns the next response of a watch. When the node goes away, or
+// the watch falls behind, it watches again on a node, and returns the
+// current contents of the watched files with resumed set.
+func (s *Server) recv(ctx context.Context, stream *rafttopopb.RaftTopo_WatchClient, nodePath string, recursive bool) (resp *rafttopopb.WatchResponse, resumed bool, err error) {
+	resp, err = (*stream).Recv()
+	if status.Code(err) != codes.Unavailable || ctx.Err() != nil {
+		return resp, false, err
+	}
+	select {
+	case <-s.running:
+		return nil, false, err
+	default:
+	}
+	*stream, resp, err = s.startWatch(ctx, nodePath, recursive)
+	return resp, true, err
+}
+
+// Watch is part of the topo.Conn interface.
+func (s *Server) Watch(ctx context.Context, filePath string) (*topo.WatchData, <-chan *topo.WatchData, error) {
+	nodePath := path.Join(s.root, filePath)
+
+	// Create a context, canceled when the watch stops.
+	watchCtx, watchCancel := context.WithCancel(ctx)
+
+	stream, initial, err := s.startWatch(watchCtx, nodePath, false)
+	if err != nil {
+		watchCancel()
+		return nil, nil, convertError(err, nodePath)
+	}
+	if len(initial.Events) != 1 {
+		watchCancel()
+		return nil, nil, topo.NewError(topo.NoNode, nodePath)
+	}
+	file := initial.Events[0].File
+	wd := &topo.WatchData{
+		Contents: file.Contents,
+		Version:  RaftVersion(file.Version),
+	}
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchData, 10)
+	go func() {
+		defer close(notifications)
+		defer watchCancel()
+
+		version := file.Version
+		for {
+			resp, _, err := s.recv(watchCtx, &stream, nodePath, false)
+			if err != nil {
+				select {
+				case <-s.running:
+					return
+				default:
+				}
+				// Final notification, this includes context
+				// cancellation errors, and the deletion of the
+				// file while we were watching again.
+				notifications <- &topo.WatchData{
+					Err: convertError(err, nodePath),
+				}
+				return
+			}
+			for _, ev := range resp.Events {
+				if ev.Deleted {
+					// Node is gone, send a final notice.
+					notifications <- &topo.WatchData{
+						Err: topo.NewError(topo.NoNode, nodePath),
+					}
+					return
+				}
+				if ev.File.Version == version {
+					// We resumed the watch, and the file did
+					// not change.
+					continue
+				}
+				version = ev.File.Version
+				notifications <- &topo.WatchData{
+					Contents: ev.File.Contents,
+					Version:  RaftVersion(ev.File.Version),
+				}
+			}
+		}
+	}()
+
+	return wd, notifications, nil
+}
+
+// WatchRecursive is part of the topo.Conn interface.
+func (s *Server) WatchRecursive(ctx context.Context, dirpath string) ([]*topo.WatchDataRecursive, <-chan *topo.WatchDataRecursive, error) {
+	nodePath := path.Join(s.root, dirpath)
+	nodePath = strings.TrimSuffix(nodePath, "/")
+
+	// Create a context, canceled when the watch stops.
+	watchCtx, watchCancel := context.WithCancel(ctx)
+
+	stream, initial, err := s.startWatch(watchCtx, nodePath, true)
+	if err != nil {
+		watchCancel()
+		return nil, nil, convertError(err, nodePath)
+	}
+
+	// versions are the versions of the files we know of, to tell what
+	// changed when we resume the watch.
+	versions := make(map[string]int64, len(initial.Events))
+	var initialwd []*topo.WatchDataRecursive
+	for _, ev := range initial.Events {
+		versions[ev.File.Path] = ev.File.Version
+		initialwd = append(initialwd, &topo.WatchDataRecursive{
+			Path: ev.File.Path,
+			WatchData: topo.WatchData{
+				Contents: ev.File.Contents,
+				Version:  RaftVersion(ev.File.Version),
+			},
+		})
+	}
+
+	// Create the notifications channel, send updates to it.
+	notifications := make(chan *topo.WatchDataRecursive, 10)
+	go func() {
+		defer close(notifications)
+		defer watchCancel()
+
+		for {
+			resp, resumed, err := s.recv(watchCtx, &stream, nodePath, true)
+			if err != nil {
+				select {
+				case <-s.running:
+					return
+				default:
+				}
+				// Final notification.
+				notifications <- &topo.WatchDataRecursive{
+					WatchData: topo.WatchData{Err: convertError(err, nodePath)},
+				}
+				return
+			}
+			if resumed {
+				// The files we know of that are not in the
+				// current contents were deleted.
+				current := make(map[string]bool, len(resp.Events))
+				for _, ev := range resp.Events {
+					current[ev.File.Path] = true
+				}
+				for p := range versions {
+					if !current[p] {
+						resp.Events = append(resp.Events, &rafttopopb.WatchEvent{
+							File:    &rafttopopb.File{Path: p},
+							Deleted: true,
+						})
+					}
+				}
+			}
+			for _, ev := range resp.Events {
+				if ev.Deleted {
+					delete(versions, ev.File.Path)
+					notifications <- &topo.WatchDataRecursive{
+						Path: ev.File.Path,
+						WatchData: topo.WatchData{
+							Err: topo.NewError(topo.NoNode, nodePath),
+						},
+					}
+					continue
+				}
+				if versions[ev.File.Path] == ev.File.Version {
+					continue
+				}
+				versions[ev.File.Path] = ev.File.Version
+				notifications <- &topo.WatchDataRecursive{
+					Path: ev.File.Path,
+					WatchData: topo.WatchData{
+						Contents: ev.File.Contents,
+						Version:  RaftVersion(ev.File.Version),
+					},
+				}
+			}
+		}
+	}()
+
+	return initialwd, notifications, nil
+}
@@ -892,6 +892,14 @@ func (itmc *internalTabletManagerClient) ApplySchema(ctx context.Context, tablet
 	return t.tm.ApplySchema(ctx, change)
 }
 
+func (itmc *internalTabletManagerClient) AnalyzeSchemaMigration(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.AnalyzeSchemaMigrationRequest) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error) {
+	t, ok := tabletMap[tablet.Alias.Uid]
+	if !ok {
+		return nil, fmt.Errorf("tmclient: cannot find tablet %v", tablet.Alias.Uid)
+	}
+	return t.tm.AnalyzeSchemaMigration(ctx, req)
+}
+
 func (itmc *internalTabletManagerClient) ExecuteQuery(context.Context, *topodatapb.Tablet, *tabletmanagerdatapb.ExecuteQueryRequest) (*querypb.QueryResult, error) {
 	return nil, fmt.Errorf("not implemented in vtcombo")
 }
@@ -47,6 +47,15 @@ func (client *gRPCVtctldClient) AddCellsAlias(ctx context.Context, in *vtctldata
 	return client.c.AddCellsAlias(ctx, in, opts...)
 }
 
+// AnalyzeSchemaMigrations is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) AnalyzeSchemaMigrations(ctx context.Context, in *vtctldatapb.AnalyzeSchemaMigrationsRequest, opts ...grpc.CallOption) (*vtctldatapb.AnalyzeSchemaMigrationsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.AnalyzeSchemaMigrations(ctx, in, opts...)
+}
+
 // ApplyKeyspaceRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, in *vtctldatapb.ApplyKeyspaceRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyKeyspaceRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -1028,6 +1037,15 @@ func (client *gRPCVtctldClient) VDiffDelete(ctx context.Context, in *vtctldatapb
 	return client.c.VDiffDelete(ctx, in, opts...)
 }
 
+// VDiffRepair is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) VDiffRepair(ctx context.Context, in *vtctldatapb.VDiffRepairRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffRepairResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.VDiffRepair(ctx, in, opts...)
+}
+
 // VDiffResume is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) VDiffResume(ctx context.Context, in *vtctldatapb.VDiffResumeRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffResumeResponse, error) {
 	if client.c == nil {
@@ -170,6 +170,73 @@ func (s *VtctldServer) AddCellsAlias(ctx context.Context, req *vtctldatapb.AddCe
 	return &vtctldatapb.AddCellsAliasResponse{}, nil
 }
 
+// AnalyzeSchemaMigrations is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) AnalyzeSchemaMigrations(ctx context.Context, req *vtctldatapb.AnalyzeSchemaMigrationsRequest) (resp *vtctldatapb.AnalyzeSchemaMigrationsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.AnalyzeSchemaMigrations")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("ddl_strategy", req.DdlStrategy)
+
+	if len(req.Sql) == 0 {
+		err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "Sql must be a non-empty array")
+		return nil, err
+	}
+
+	tabletsResp, err := s.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{
+		Keyspace:   req.Keyspace,
+		TabletType: topodatapb.TabletType_PRIMARY,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tablets := tabletsResp.Tablets
+	sort.Slice(tablets, func(i, j int) bool {
+		return tablets[i].Shard < tablets[j].Shard
+	})
+
+	var (
+		wg  sync.WaitGroup
+		rec concurrency.AllErrorRecorder
+		// analyses are ordered by statement, then by shard.
+		analyses = make([]*vtctldatapb.SchemaMigrationAnalysis, len(req.Sql)*len(tablets))
+	)
+	for i, tablet := range tablets {
+		wg.Add(1)
+		go func(i int, tablet *topodatapb.Tablet) {
+			defer wg.Done()
+
+			for j, sql := range req.Sql {
+				analysis, err := s.tmc.AnalyzeSchemaMigration(ctx, tablet, &tabletmanagerdatapb.AnalyzeSchemaMigrationRequest{
+					Sql:         sql,
+					DdlStrategy: req.DdlStrategy,
+				})
+				if err != nil {
+					rec.RecordError(vterrors.Wrapf(err, "AnalyzeSchemaMigration(%s) failed on %s", sql, topoproto.TabletAliasString(tablet.Alias)))
+					return
+				}
+				analyses[j*len(tablets)+i] = &vtctldatapb.SchemaMigrationAnalysis{
+					Sql:         sql,
+					Shard:       tablet.Shard,
+					TabletAlias: tablet.Alias,
+					Analysis:    analysis,
+				}
+			}
+		}(i, tablet)
+	}
+
+	wg.Wait()
+	if rec.HasErrors() {
+		return nil, rec.Error()
+	}
+
+	return &vtctldatapb.AnalyzeSchemaMigrationsResponse{
+		Analyses: analyses,
+	}, nil
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ApplyRoutingRules(ctx context.Context, req *vtctldatapb.ApplyRoutingRulesRequest) (resp *vtctldatapb.ApplyRoutingRulesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyRoutingRules")
@@ -3870,9 +3937,12 @@ func (s *VtctldServer) SetKeyspaceDurabilityPolicy(ctx context.Context, req *vtc
 		return nil, err
 	}
 
-	policyValid := policy.CheckDurabilityPolicyExists(req.DurabilityPolicy)
-	if !policyValid {
-		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "durability policy <%v> is not a valid policy. Please register it as a policy first", req.DurabilityPolicy)
+	if _, policyErr := policy.GetDurabilityPolicy(req.DurabilityPolicy); policyErr != nil {
+		if policy.IsDeclarativeDurabilityPolicy(req.DurabilityPolicy) {
+			err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%v", policyErr)
+		} else {
+			err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "durability policy <%v> is not a valid policy. Please register it as a policy first", req.DurabilityPolicy)
+		}
 		return nil, err
 	}
 
@@ -5480,6 +5550,23 @@ func (s *VtctldServer) VDiffDelete(ctx context.Context, req *vtctldatapb.VDiffDe
 	return resp, err
 }
 
+// VDiffRepair is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) VDiffRepair(ctx context.Context, req *vtctldatapb.VDiffRepairRequest) (resp *vtctldatapb.VDiffRepairResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.VDiffRepair")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.TargetKeyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("uuid", req.Uuid)
+	span.Annotate("shards", req.TargetShards)
+	span.Annotate("apply", req.Apply)
+
+	resp, err = s.ws.VDiffRepair(ctx, req)
+	return resp, err
+}
+
 // VDiffResume is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) VDiffResume(ctx context.Context, req *vtctldatapb.VDiffResumeRequest) (resp *vtctldatapb.VDiffResumeResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.VDiffResume")
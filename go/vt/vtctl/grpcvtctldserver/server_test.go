@@ -239,6 +239,149 @@ func TestAddCellsAlias(t *testing.T) {
 	}
 }
 
+func TestAnalyzeSchemaMigrations(t *testing.T) {
+	t.Parallel()
+
+	tablets := []*topodatapb.Tablet{
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  200,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "80-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  100,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "-80",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+		{
+			Alias: &topodatapb.TabletAlias{
+				Cell: "zone1",
+				Uid:  101,
+			},
+			Keyspace: "testkeyspace",
+			Shard:    "-80",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+	}
+	analysis := &tabletmanagerdatapb.AnalyzeSchemaMigrationResponse{
+		Table:         "t1",
+		UniqueKey:     "PRIMARY",
+		EstimatedRows: 1000,
+	}
+
+	tests := []struct {
+		name      string
+		tmc       *testutil.TabletManagerClient
+		req       *vtctldatapb.AnalyzeSchemaMigrationsRequest
+		expected  *vtctldatapb.AnalyzeSchemaMigrationsResponse
+		shouldErr bool
+	}{
+		{
+			name: "ok",
+			tmc: &testutil.TabletManagerClient{
+				AnalyzeSchemaMigrationResults: map[string]struct {
+					Response *tabletmanagerdatapb.AnalyzeSchemaMigrationResponse
+					Error    error
+				}{
+					"zone1-0000000100": {Response: analysis},
+					"zone1-0000000200": {Response: analysis},
+				},
+			},
+			req: &vtctldatapb.AnalyzeSchemaMigrationsRequest{
+				Keyspace:    "testkeyspace",
+				Sql:         []string{"alter table t1 add column c int", "alter table t1 drop column d"},
+				DdlStrategy: "vitess",
+			},
+			expected: &vtctldatapb.AnalyzeSchemaMigrationsResponse{
+				Analyses: []*vtctldatapb.SchemaMigrationAnalysis{
+					{
+						Sql:         "alter table t1 add column c int",
+						Shard:       "-80",
+						TabletAlias: tablets[1].Alias,
+						Analysis:    analysis,
+					},
+					{
+						Sql:         "alter table t1 add column c int",
+						Shard:       "80-",
+						TabletAlias: tablets[0].Alias,
+						Analysis:    analysis,
+					},
+					{
+						Sql:         "alter table t1 drop column d",
+						Shard:       "-80",
+						TabletAlias: tablets[1].Alias,
+						Analysis:    analysis,
+					},
+					{
+						Sql:         "alter table t1 drop column d",
+						Shard:       "80-",
+						TabletAlias: tablets[0].Alias,
+						Analysis:    analysis,
+					},
+				},
+			},
+		},
+		{
+			name: "tablet error",
+			tmc: &testutil.TabletManagerClient{
+				AnalyzeSchemaMigrationResults: map[string]struct {
+					Response *tabletmanagerdatapb.AnalyzeSchemaMigrationResponse
+					Error    error
+				}{
+					"zone1-0000000100": {Response: analysis},
+					"zone1-0000000200": {Error: assert.AnError},
+				},
+			},
+			req: &vtctldatapb.AnalyzeSchemaMigrationsRequest{
+				Keyspace: "testkeyspace",
+				Sql:      []string{"alter table t1 add column c int"},
+			},
+			shouldErr: true,
+		},
+		{
+			name: "no sql",
+			tmc:  &testutil.TabletManagerClient{},
+			req: &vtctldatapb.AnalyzeSchemaMigrationsRequest{
+				Keyspace: "testkeyspace",
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ts := memorytopo.NewServer(ctx, "zone1")
+			testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+				AlsoSetShardPrimary: true,
+			}, tablets...)
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tt.tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			resp, err := vtctld.AnalyzeSchemaMigrations(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
 func TestApplyRoutingRules(t *testing.T) {
 	t.Parallel()
 
@@ -11457,6 +11600,38 @@ func TestSetKeyspaceDurabilityPolicy(t *testing.T) {
 			},
 			expectedErr: "durability policy <non-existent> is not a valid policy. Please register it as a policy first",
 		},
+		{
+			name: "declarative durability policy",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceDurabilityPolicyRequest{
+				Keyspace:         "ks1",
+				DurabilityPolicy: "declarative:\nsemi_sync:\n- ackers: 2\n  cross_cell: true\n",
+			},
+			expected: &vtctldatapb.SetKeyspaceDurabilityPolicyResponse{
+				Keyspace: &topodatapb.Keyspace{
+					DurabilityPolicy: "declarative:\nsemi_sync:\n- ackers: 2\n  cross_cell: true\n",
+				},
+			},
+		},
+		{
+			name: "invalid declarative durability policy",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceDurabilityPolicyRequest{
+				Keyspace:         "ks1",
+				DurabilityPolicy: "declarative:\nsemi_sync:\n- ackers: -1\n",
+			},
+			expectedErr: "invalid declarative durability policy: semi-sync setup 0: invalid number of ackers -1",
+		},
 	}
 
 	for _, tt := range tests {
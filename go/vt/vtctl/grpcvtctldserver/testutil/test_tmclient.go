@@ -388,6 +388,11 @@ type TabletManagerClient struct {
 	CheckThrottlerDelays map[string]time.Duration
 	// keyed by tablet alias
 	CheckThrottlerResults map[string]*tabletmanagerdatapb.CheckThrottlerResponse
+	// keyed by tablet alias
+	AnalyzeSchemaMigrationResults map[string]struct {
+		Response *tabletmanagerdatapb.AnalyzeSchemaMigrationResponse
+		Error    error
+	}
 }
 
 type backupStreamAdapter struct {
@@ -419,6 +424,24 @@ func (stream *backupStreamAdapter) Send(msg *logutilpb.Event) error {
 	}
 }
 
+// AnalyzeSchemaMigration is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) AnalyzeSchemaMigration(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.AnalyzeSchemaMigrationRequest) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error) {
+	if fake.AnalyzeSchemaMigrationResults == nil {
+		return nil, assert.AnError
+	}
+
+	if tablet.Alias == nil {
+		return nil, assert.AnError
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if result, ok := fake.AnalyzeSchemaMigrationResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("%w: no AnalyzeSchemaMigration result set for tablet %s", assert.AnError, key)
+}
+
 // Backup is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) Backup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.BackupRequest) (logutil.EventStream, error) {
 	if tablet.Type == topodatapb.TabletType_PRIMARY && !req.AllowPrimary {
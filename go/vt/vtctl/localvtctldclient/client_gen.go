@@ -39,6 +39,11 @@ func (client *localVtctldClient) AddCellsAlias(ctx context.Context, in *vtctldat
 	return client.s.AddCellsAlias(ctx, in)
 }
 
+// AnalyzeSchemaMigrations is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) AnalyzeSchemaMigrations(ctx context.Context, in *vtctldatapb.AnalyzeSchemaMigrationsRequest, opts ...grpc.CallOption) (*vtctldatapb.AnalyzeSchemaMigrationsResponse, error) {
+	return client.s.AnalyzeSchemaMigrations(ctx, in)
+}
+
 // ApplyKeyspaceRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, in *vtctldatapb.ApplyKeyspaceRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyKeyspaceRoutingRulesResponse, error) {
 	return client.s.ApplyKeyspaceRoutingRules(ctx, in)
@@ -722,6 +727,11 @@ func (client *localVtctldClient) VDiffDelete(ctx context.Context, in *vtctldatap
 	return client.s.VDiffDelete(ctx, in)
 }
 
+// VDiffRepair is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) VDiffRepair(ctx context.Context, in *vtctldatapb.VDiffRepairRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffRepairResponse, error) {
+	return client.s.VDiffRepair(ctx, in)
+}
+
 // VDiffResume is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) VDiffResume(ctx context.Context, in *vtctldatapb.VDiffResumeRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffResumeResponse, error) {
 	return client.s.VDiffResume(ctx, in)
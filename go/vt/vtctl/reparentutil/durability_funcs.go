@@ -55,7 +55,9 @@ func haveRevokedForTablet(durability policy.Durabler, primaryEligible *topodatap
 	numOfSemiSyncAcksRequired := policy.SemiSyncAckers(durability, primaryEligible)
 
 	// if we have reached enough semi-sync Acking tablets such that the primaryEligible cannot accept a write
-	// we have revoked from the tablet
+	// we have revoked from the tablet.
+	// The number of distinct cells the Acks must come from doesn't count here: MySQL commits a write once it has
+	// enough Acks from any cells, so the unreached tablets of a single cell could still Ack it.
 	return len(allSemiSyncAckers)-len(semiSyncAckersReached) < numOfSemiSyncAcksRequired
 }
 
@@ -88,7 +90,20 @@ func canEstablishForTablet(durability policy.Durabler, primaryEligible *topodata
 	// numOfSemiSyncAcksRequired is the number of semi sync Acks that the primaryEligible tablet requires
 	numOfSemiSyncAcksRequired := policy.SemiSyncAckers(durability, primaryEligible)
 
-	// if we have reached enough semi-sync Acking tablets such that the primaryEligible can accept a write
+	// numOfSemiSyncAckCellsRequired is the number of distinct cells that the semi sync Acks of the primaryEligible tablet must come from
+	numOfSemiSyncAckCellsRequired := policy.SemiSyncAckCells(durability, primaryEligible)
+
+	// if we have reached enough semi-sync Acking tablets, in enough cells, such that the primaryEligible can accept a write
 	// we can safely promote this tablet
-	return len(semiSyncAckersReached) >= numOfSemiSyncAcksRequired
+	return len(semiSyncAckersReached) >= numOfSemiSyncAcksRequired &&
+		len(tabletCells(semiSyncAckersReached)) >= numOfSemiSyncAckCellsRequired
+}
+
+// tabletCells returns the distinct cells of the given tablets
+func tabletCells(tablets []*topodatapb.Tablet) map[string]bool {
+	cells := make(map[string]bool, len(tablets))
+	for _, tablet := range tablets {
+		cells[tablet.Alias.Cell] = true
+	}
+	return cells
 }
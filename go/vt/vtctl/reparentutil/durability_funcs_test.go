@@ -62,6 +62,8 @@ var (
 		},
 		Type: topodatapb.TabletType_RDONLY,
 	}
+	// durabilityTwoCells requires semi-sync acks from replica and rdonly tablets in two different cells.
+	durabilityTwoCells = `declarative:{"semi_sync": [{"ackers": 2, "min_ack_cells": 2, "acker_tablet_types": ["replica", "rdonly"]}]}`
 )
 
 func TestSemiSyncAckersForPrimary(t *testing.T) {
@@ -194,6 +196,18 @@ func Test_haveRevokedForTablet(t *testing.T) {
 				primaryTablet,
 			},
 			revoked: true,
+		}, {
+			// MySQL commits a write with acks from any cells, so the unreached tablets of one cell still count.
+			name:             "acks from two cells - not revoked",
+			durabilityPolicy: durabilityTwoCells,
+			primaryEligible:  primaryTablet,
+			allTablets: []*topodatapb.Tablet{
+				primaryTablet, replicaTablet, replicaCrossCellTablet, rdonlyCrossCellTablet, rdonlyTablet,
+			},
+			tabletsReached: []*topodatapb.Tablet{
+				replicaCrossCellTablet, rdonlyCrossCellTablet,
+			},
+			revoked: false,
 		},
 	}
 	for _, tt := range tests {
@@ -372,6 +386,22 @@ func Test_canEstablishForTablet(t *testing.T) {
 				primaryTablet, replicaCrossCellTablet,
 			},
 			canEstablish: true,
+		}, {
+			name:             "acks from a single cell",
+			durabilityPolicy: durabilityTwoCells,
+			primaryEligible:  primaryTablet,
+			tabletsReached: []*topodatapb.Tablet{
+				primaryTablet, replicaTablet, rdonlyTablet,
+			},
+			canEstablish: false,
+		}, {
+			name:             "acks from two cells",
+			durabilityPolicy: durabilityTwoCells,
+			primaryEligible:  primaryTablet,
+			tabletsReached: []*topodatapb.Tablet{
+				primaryTablet, replicaTablet, rdonlyCrossCellTablet,
+			},
+			canEstablish: true,
 		},
 	}
 	for _, tt := range tests {
@@ -4529,6 +4529,20 @@ func TestEmergencyReparenter_filterValidCandidates(t *testing.T) {
 			tabletsReachable:    []*topodatapb.Tablet{primaryTablet, replicaTablet, rdonlyTablet, rdonlyCrossCellTablet},
 			tabletsTakingBackup: noTabletsTakingBackup,
 			filteredTablets:     nil,
+		}, {
+			name:                "filter establish with acks from a single cell",
+			durability:          `declarative:{"semi_sync": [{"ackers": 2, "min_ack_cells": 2, "acker_tablet_types": ["replica", "rdonly"]}]}`,
+			validTablets:        []*topodatapb.Tablet{primaryTablet, replicaTablet},
+			tabletsReachable:    []*topodatapb.Tablet{primaryTablet, replicaTablet, rdonlyTablet},
+			tabletsTakingBackup: noTabletsTakingBackup,
+			filteredTablets:     nil,
+		}, {
+			name:                "establish with acks from two cells",
+			durability:          `declarative:{"semi_sync": [{"ackers": 2, "min_ack_cells": 2, "acker_tablet_types": ["replica", "rdonly"]}]}`,
+			validTablets:        []*topodatapb.Tablet{primaryTablet, replicaTablet},
+			tabletsReachable:    []*topodatapb.Tablet{primaryTablet, replicaTablet, rdonlyTablet, rdonlyCrossCellTablet},
+			tabletsTakingBackup: noTabletsTakingBackup,
+			filteredTablets:     []*topodatapb.Tablet{primaryTablet, replicaTablet},
 		}, {
 			name:       "filter mixed",
 			durability: policy.DurabilityCrossCell,
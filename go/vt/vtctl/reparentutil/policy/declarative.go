@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/cache"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil/promotionrule"
+	"vitess.io/vitess/go/yaml2"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// DeclarativeDurabilityPolicyPrefix marks a durability policy of a keyspace as a declarative durability
+// policy rather than the name of a registered policy.
+const DeclarativeDurabilityPolicyPrefix = "declarative:"
+
+// DeclarativeDurabilityPolicy is a durability policy that is described by a JSON or YAML document
+// rather than registered by code. The document is stored as the durability policy of the keyspace,
+// after DeclarativeDurabilityPolicyPrefix, in place of the name of a registered policy. For example, the following policy requires semi-sync
+// acks from replicas in two different cells and prefers to promote tablets in the cells of one region:
+//
+//	declarative:
+//	promotion_rules:
+//	- cells: [us_east_1a, us_east_1b]
+//	  tablet_types: [primary, replica]
+//	  rule: prefer
+//	semi_sync:
+//	- ackers: 2
+//	  min_ack_cells: 2
+//	  acker_tablet_types: [replica]
+type DeclarativeDurabilityPolicy struct {
+	// PromotionRules are the promotion rules of tablets. The first rule that matches a tablet applies.
+	// Primary and replica tablets that no rule matches are neutral, other tablets must not be promoted.
+	PromotionRules []*DeclarativePromotionRule `json:"promotion_rules,omitempty"`
+	// SemiSync are the semi-sync setups of primaries. The first setup that matches the primary applies.
+	// A primary that no setup matches has no semi-sync.
+	SemiSync []*DeclarativeSemiSync `json:"semi_sync,omitempty"`
+}
+
+// DeclarativePromotionRule is the promotion rule of the tablets in the given cells and of the given
+// tablet types. Empty cells or tablet types match all cells or tablet types.
+type DeclarativePromotionRule struct {
+	Cells       []string                             `json:"cells,omitempty"`
+	TabletTypes []string                             `json:"tablet_types,omitempty"`
+	Rule        promotionrule.CandidatePromotionRule `json:"rule"`
+
+	tabletTypes []topodatapb.TabletType
+}
+
+// DeclarativeSemiSync is the semi-sync setup of a primary in the given cells, or in any cell when
+// the cells are empty. It requires the given number of acks from replicas in the acker cells and
+// of the acker tablet types. When it is cross cell, only replicas in cells other than the primary's
+// cell send acks.
+type DeclarativeSemiSync struct {
+	PrimaryCells []string `json:"primary_cells,omitempty"`
+	Ackers       int      `json:"ackers"`
+	// MinAckCells is the number of distinct cells the acks must come from. MySQL only counts the acks,
+	// so it is enforced by the reparent operations, which only promote a primary whose reachable
+	// ackers span that many cells.
+	MinAckCells int      `json:"min_ack_cells,omitempty"`
+	AckerCells  []string `json:"acker_cells,omitempty"`
+	// AckerTabletTypes defaults to primary and replica tablets.
+	AckerTabletTypes []string `json:"acker_tablet_types,omitempty"`
+	CrossCell        bool     `json:"cross_cell,omitempty"`
+
+	ackerTabletTypes []topodatapb.TabletType
+}
+
+// declarativePoliciesCacheSize is the number of parsed declarative policies that are cached. The
+// policies of keyspaces that were edited are evicted once they are no longer used.
+const declarativePoliciesCacheSize = 64
+
+// declarativePolicies caches the parsed declarative policies, so that they are not parsed whenever
+// the durability policy of a keyspace is read.
+var declarativePolicies = cache.NewLRUCache[*DeclarativeDurabilityPolicy](declarativePoliciesCacheSize)
+
+// IsDeclarativeDurabilityPolicy returns true when the durability policy is a declarative durability
+// policy, rather than the name of a registered policy.
+func IsDeclarativeDurabilityPolicy(policy string) bool {
+	return strings.HasPrefix(policy, DeclarativeDurabilityPolicyPrefix)
+}
+
+// ParseDeclarativeDurabilityPolicy parses and validates a declarative durability policy, with or
+// without DeclarativeDurabilityPolicyPrefix.
+func ParseDeclarativeDurabilityPolicy(policy string) (*DeclarativeDurabilityPolicy, error) {
+	if d, ok := declarativePolicies.Get(policy); ok {
+		return d, nil
+	}
+
+	d := &DeclarativeDurabilityPolicy{}
+	err := yaml2.Unmarshal([]byte(strings.TrimPrefix(policy, DeclarativeDurabilityPolicyPrefix)), d, func(decoder *json.Decoder) *json.Decoder {
+		decoder.DisallowUnknownFields()
+		return decoder
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid declarative durability policy: %w", err)
+	}
+	if err := d.validate(); err != nil {
+		return nil, fmt.Errorf("invalid declarative durability policy: %w", err)
+	}
+	declarativePolicies.Set(policy, d)
+	return d, nil
+}
+
+func parseTabletTypes(names []string) ([]topodatapb.TabletType, error) {
+	tabletTypes := make([]topodatapb.TabletType, 0, len(names))
+	for _, name := range names {
+		tabletType, err := topoproto.ParseTabletType(name)
+		if err != nil {
+			return nil, err
+		}
+		tabletTypes = append(tabletTypes, tabletType)
+	}
+	return tabletTypes, nil
+}
+
+func (d *DeclarativeDurabilityPolicy) validate() (err error) {
+	for i, rule := range d.PromotionRules {
+		if rule == nil {
+			return fmt.Errorf("promotion rule %d is empty", i)
+		}
+		if _, err := promotionrule.Parse(string(rule.Rule)); err != nil {
+			return fmt.Errorf("promotion rule %d: %w", i, err)
+		}
+		if rule.tabletTypes, err = parseTabletTypes(rule.TabletTypes); err != nil {
+			return fmt.Errorf("promotion rule %d: %w", i, err)
+		}
+	}
+	for i, semiSync := range d.SemiSync {
+		if semiSync == nil {
+			return fmt.Errorf("semi-sync setup %d is empty", i)
+		}
+		if semiSync.Ackers < 0 {
+			return fmt.Errorf("semi-sync setup %d: invalid number of ackers %d", i, semiSync.Ackers)
+		}
+		if semiSync.MinAckCells < 0 || semiSync.MinAckCells > semiSync.Ackers {
+			return fmt.Errorf("semi-sync setup %d: invalid number of ack cells %d for %d ackers", i, semiSync.MinAckCells, semiSync.Ackers)
+		}
+		ackerTabletTypes := semiSync.AckerTabletTypes
+		if len(ackerTabletTypes) == 0 {
+			ackerTabletTypes = []string{"primary", "replica"}
+		}
+		if semiSync.ackerTabletTypes, err = parseTabletTypes(ackerTabletTypes); err != nil {
+			return fmt.Errorf("semi-sync setup %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// matchesCell returns true when the cells are empty or include the cell.
+func matchesCell(cells []string, cell string) bool {
+	return len(cells) == 0 || slices.Contains(cells, cell)
+}
+
+// semiSyncOf returns the semi-sync setup of the given primary, or nil when it has none.
+func (d *DeclarativeDurabilityPolicy) semiSyncOf(primary *topodatapb.Tablet) *DeclarativeSemiSync {
+	for _, semiSync := range d.SemiSync {
+		if matchesCell(semiSync.PrimaryCells, primary.GetAlias().GetCell()) {
+			return semiSync
+		}
+	}
+	return nil
+}
+
+// PromotionRule implements the Durabler interface
+func (d *DeclarativeDurabilityPolicy) PromotionRule(tablet *topodatapb.Tablet) promotionrule.CandidatePromotionRule {
+	for _, rule := range d.PromotionRules {
+		if !matchesCell(rule.Cells, tablet.GetAlias().GetCell()) {
+			continue
+		}
+		if len(rule.tabletTypes) > 0 && !slices.Contains(rule.tabletTypes, tablet.Type) {
+			continue
+		}
+		return rule.Rule
+	}
+	switch tablet.Type {
+	case topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA:
+		return promotionrule.Neutral
+	}
+	return promotionrule.MustNot
+}
+
+// SemiSyncAckers implements the Durabler interface
+func (d *DeclarativeDurabilityPolicy) SemiSyncAckers(tablet *topodatapb.Tablet) int {
+	if semiSync := d.semiSyncOf(tablet); semiSync != nil {
+		return semiSync.Ackers
+	}
+	return 0
+}
+
+// SemiSyncAckCells implements the SemiSyncAckCellsDurabler interface
+func (d *DeclarativeDurabilityPolicy) SemiSyncAckCells(tablet *topodatapb.Tablet) int {
+	if semiSync := d.semiSyncOf(tablet); semiSync != nil {
+		return semiSync.MinAckCells
+	}
+	return 0
+}
+
+// IsReplicaSemiSync implements the Durabler interface
+func (d *DeclarativeDurabilityPolicy) IsReplicaSemiSync(primary, replica *topodatapb.Tablet) bool {
+	semiSync := d.semiSyncOf(primary)
+	if semiSync == nil || semiSync.Ackers == 0 {
+		return false
+	}
+	if !slices.Contains(semiSync.ackerTabletTypes, replica.Type) {
+		return false
+	}
+	if !matchesCell(semiSync.AckerCells, replica.Alias.Cell) {
+		return false
+	}
+	if semiSync.CrossCell && primary.Alias.Cell == replica.Alias.Cell {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtctl/reparentutil/promotionrule"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func newTablet(cell string, uid uint32, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+	return &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: cell,
+			Uid:  uid,
+		},
+		Type: tabletType,
+	}
+}
+
+func TestDeclarativeDurabilityPolicy(t *testing.T) {
+	durability, err := GetDurabilityPolicy(`declarative:
+promotion_rules:
+- cells: [east1, east2]
+  tablet_types: [primary, replica]
+  rule: prefer
+- tablet_types: [rdonly]
+  rule: must_not
+- cells: [west1]
+  rule: prefer_not
+semi_sync:
+- primary_cells: [west1]
+  ackers: 1
+- ackers: 2
+  acker_tablet_types: [replica, rdonly]
+  cross_cell: true
+`)
+	require.NoError(t, err)
+
+	east1Primary := newTablet("east1", 100, topodatapb.TabletType_PRIMARY)
+	east1Replica := newTablet("east1", 101, topodatapb.TabletType_REPLICA)
+	east2Rdonly := newTablet("east2", 200, topodatapb.TabletType_RDONLY)
+	east2Replica := newTablet("east2", 201, topodatapb.TabletType_REPLICA)
+	west1Primary := newTablet("west1", 300, topodatapb.TabletType_PRIMARY)
+	west1Replica := newTablet("west1", 301, topodatapb.TabletType_REPLICA)
+	west2Replica := newTablet("west2", 400, topodatapb.TabletType_REPLICA)
+	west2Spare := newTablet("west2", 401, topodatapb.TabletType_SPARE)
+
+	assert.Equal(t, promotionrule.Prefer, PromotionRule(durability, east1Primary))
+	assert.Equal(t, promotionrule.Prefer, PromotionRule(durability, east1Replica))
+	assert.Equal(t, promotionrule.MustNot, PromotionRule(durability, east2Rdonly))
+	assert.Equal(t, promotionrule.PreferNot, PromotionRule(durability, west1Replica))
+	assert.Equal(t, promotionrule.Neutral, PromotionRule(durability, west2Replica))
+	assert.Equal(t, promotionrule.MustNot, PromotionRule(durability, west2Spare))
+	assert.Equal(t, promotionrule.MustNot, PromotionRule(durability, nil))
+
+	assert.Equal(t, 2, SemiSyncAckers(durability, east1Primary))
+	assert.Equal(t, 1, SemiSyncAckers(durability, west1Primary))
+
+	// Cross cell acks of replica and rdonly tablets.
+	assert.False(t, IsReplicaSemiSync(durability, east1Primary, east1Replica))
+	assert.True(t, IsReplicaSemiSync(durability, east1Primary, east2Rdonly))
+	assert.True(t, IsReplicaSemiSync(durability, east1Primary, east2Replica))
+	assert.True(t, IsReplicaSemiSync(durability, east1Primary, west2Replica))
+	assert.False(t, IsReplicaSemiSync(durability, east1Primary, west2Spare))
+	// Acks of primary and replica tablets in any cell.
+	assert.True(t, IsReplicaSemiSync(durability, west1Primary, west1Replica))
+	assert.True(t, IsReplicaSemiSync(durability, west1Primary, east2Replica))
+	assert.False(t, IsReplicaSemiSync(durability, west1Primary, east2Rdonly))
+	assert.False(t, IsReplicaSemiSync(durability, nil, nil))
+}
+
+func TestDeclarativeDurabilityPolicyAckCells(t *testing.T) {
+	durability, err := GetDurabilityPolicy("declarative:\nsemi_sync:\n- primary_cells: [east1]\n  ackers: 2\n  min_ack_cells: 2\n- ackers: 1\n")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, SemiSyncAckCells(durability, newTablet("east1", 100, topodatapb.TabletType_PRIMARY)))
+	assert.Equal(t, 0, SemiSyncAckCells(durability, newTablet("west1", 100, topodatapb.TabletType_PRIMARY)))
+
+	// Registered policies don't require acks from distinct cells.
+	durability, err = GetDurabilityPolicy(DurabilityCrossCell)
+	require.NoError(t, err)
+	assert.Equal(t, 0, SemiSyncAckCells(durability, newTablet("east1", 100, topodatapb.TabletType_PRIMARY)))
+}
+
+func TestDeclarativeDurabilityPolicyNoSemiSync(t *testing.T) {
+	durability, err := GetDurabilityPolicy(`declarative:{"promotion_rules": [{"cells": ["zone1"], "rule": "prefer"}]}`)
+	require.NoError(t, err)
+
+	primary := newTablet("zone1", 100, topodatapb.TabletType_PRIMARY)
+	replica := newTablet("zone2", 200, topodatapb.TabletType_REPLICA)
+	assert.Equal(t, promotionrule.Prefer, PromotionRule(durability, primary))
+	assert.Equal(t, promotionrule.Neutral, PromotionRule(durability, replica))
+	assert.Equal(t, 0, SemiSyncAckers(durability, primary))
+	assert.False(t, IsReplicaSemiSync(durability, primary, replica))
+}
+
+func TestParseDeclarativeDurabilityPolicy(t *testing.T) {
+	testcases := []struct {
+		name        string
+		policy      string
+		expectedErr string
+	}{
+		{
+			name:        "unknown field",
+			policy:      `{"promotion_rule": []}`,
+			expectedErr: `unknown field "promotion_rule"`,
+		},
+		{
+			name:        "invalid promotion rule",
+			policy:      "promotion_rules:\n- rule: always",
+			expectedErr: "promotion rule 0: Invalid CandidatePromotionRule: always",
+		},
+		{
+			name:        "invalid tablet type",
+			policy:      "promotion_rules:\n- tablet_types: [leader]\n  rule: prefer",
+			expectedErr: "promotion rule 0: unknown TabletType leader",
+		},
+		{
+			name:        "invalid number of ackers",
+			policy:      "semi_sync:\n- ackers: -1",
+			expectedErr: "semi-sync setup 0: invalid number of ackers -1",
+		},
+		{
+			name:        "invalid number of ack cells",
+			policy:      "semi_sync:\n- ackers: 1\n  min_ack_cells: 2",
+			expectedErr: "semi-sync setup 0: invalid number of ack cells 2 for 1 ackers",
+		},
+		{
+			name:        "invalid acker tablet type",
+			policy:      "semi_sync:\n- ackers: 1\n  acker_tablet_types: [follower]",
+			expectedErr: "semi-sync setup 0: unknown TabletType follower",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseDeclarativeDurabilityPolicy(tc.policy)
+			assert.ErrorContains(t, err, tc.expectedErr)
+			assert.False(t, CheckDurabilityPolicyExists(DeclarativeDurabilityPolicyPrefix+tc.policy))
+		})
+	}
+
+	assert.False(t, IsDeclarativeDurabilityPolicy(DurabilitySemiSync))
+	_, err := GetDurabilityPolicy("semi-sync")
+	assert.EqualError(t, err, "durability policy semi-sync not found")
+
+	// Only the prefix marks a declarative durability policy, so registered names may contain colons
+	// and a document without the prefix is not parsed.
+	RegisterDurability("vendor:semi_sync", func() Durabler {
+		return &durabilitySemiSync{}
+	})
+	defer delete(durabilityPolicies, "vendor:semi_sync")
+	assert.False(t, IsDeclarativeDurabilityPolicy("vendor:semi_sync"))
+	durability, err := GetDurabilityPolicy("vendor:semi_sync")
+	require.NoError(t, err)
+	assert.IsType(t, &durabilitySemiSync{}, durability)
+	_, err = GetDurabilityPolicy("semi_sync:\n- ackers: 1")
+	assert.EqualError(t, err, "durability policy semi_sync:\n- ackers: 1 not found")
+}
+
+func TestDeclarativeDurabilityPolicyCache(t *testing.T) {
+	policy := DeclarativeDurabilityPolicyPrefix + "semi_sync:\n- ackers: 1"
+	first, err := ParseDeclarativeDurabilityPolicy(policy)
+	require.NoError(t, err)
+	second, err := ParseDeclarativeDurabilityPolicy(policy)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	// Every edit of a policy is a new document, but the cache keeps a bounded number of them.
+	for ackers := range 2 * declarativePoliciesCacheSize {
+		_, err := ParseDeclarativeDurabilityPolicy(fmt.Sprintf("%ssemi_sync:\n- ackers: %d", DeclarativeDurabilityPolicyPrefix, ackers))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, declarativePoliciesCacheSize, declarativePolicies.Len())
+}
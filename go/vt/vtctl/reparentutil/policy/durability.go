@@ -93,28 +93,47 @@ type Durabler interface {
 	IsReplicaSemiSync(primary, replica *topodatapb.Tablet) bool
 }
 
+// SemiSyncAckCellsDurabler is implemented by the durability policies that also require the semi-sync
+// acks to come from a number of distinct cells.
+type SemiSyncAckCellsDurabler interface {
+	// SemiSyncAckCells represents the number of distinct cells the semi-sync acks must come from for a given tablet if it were to become the PRIMARY instance
+	SemiSyncAckCells(*topodatapb.Tablet) int
+}
+
 func RegisterDurability(name string, newDurablerFunc NewDurabler) {
 	if durabilityPolicies[name] != nil {
 		log.Fatalf("durability policy %v already registered", name)
 	}
+	if IsDeclarativeDurabilityPolicy(name) {
+		log.Fatalf("durability policy %v must not start with %v", name, DeclarativeDurabilityPolicyPrefix)
+	}
 	durabilityPolicies[name] = newDurablerFunc
 }
 
 //=======================================================================
 
-// GetDurabilityPolicy is used to get a new durability policy from the registered policies
+// GetDurabilityPolicy is used to get a new durability policy from the registered policies, or
+// from a declarative durability policy.
 func GetDurabilityPolicy(name string) (Durabler, error) {
 	newDurabilityCreationFunc, found := durabilityPolicies[name]
-	if !found {
-		return nil, fmt.Errorf("durability policy %v not found", name)
+	if found {
+		return newDurabilityCreationFunc(), nil
+	}
+	if IsDeclarativeDurabilityPolicy(name) {
+		durability, err := ParseDeclarativeDurabilityPolicy(name)
+		if err != nil {
+			return nil, err
+		}
+		return durability, nil
 	}
-	return newDurabilityCreationFunc(), nil
+	return nil, fmt.Errorf("durability policy %v not found", name)
 }
 
-// CheckDurabilityPolicyExists is used to check if the durability policy is part of the registered policies
+// CheckDurabilityPolicyExists is used to check if the durability policy is part of the registered policies,
+// or is a valid declarative durability policy.
 func CheckDurabilityPolicyExists(name string) bool {
-	_, found := durabilityPolicies[name]
-	return found
+	_, err := GetDurabilityPolicy(name)
+	return err == nil
 }
 
 // PromotionRule returns the promotion rule for the instance.
@@ -132,6 +151,15 @@ func SemiSyncAckers(durability Durabler, tablet *topodatapb.Tablet) int {
 	return durability.SemiSyncAckers(tablet)
 }
 
+// SemiSyncAckCells returns the number of distinct cells the semi-sync acks of the instance must
+// come from. 0 means any cell.
+func SemiSyncAckCells(durability Durabler, tablet *topodatapb.Tablet) int {
+	if d, ok := durability.(SemiSyncAckCellsDurabler); ok {
+		return d.SemiSyncAckCells(tablet)
+	}
+	return 0
+}
+
 // IsReplicaSemiSync returns the replica semi-sync setting from the tablet record.
 // Prefer using this function if tablet record is available.
 func IsReplicaSemiSync(durability Durabler, primary, replica *topodatapb.Tablet) bool {
@@ -111,3 +111,9 @@ func sortTabletsForReparent(tablets []*topodatapb.Tablet, positions []replicatio
 	sort.Sort(newReparentSorter(tablets, positions, innodbBufferPool, durability))
 	return nil
 }
+
+// SortTabletsForReparent sorts the tablets the same way emergency and planned reparent shard do,
+// so that callers that don't run a reparent, like VTOrc simulating a recovery, can predict its choice.
+func SortTabletsForReparent(tablets []*topodatapb.Tablet, positions []replication.Position, durability policy.Durabler) error {
+	return sortTabletsForReparent(tablets, positions, nil, durability)
+}
@@ -62,8 +62,9 @@ func commandVDiff2(ctx context.Context, wr *wrangler.Wrangler, subFlags *pflag.F
 	maxExtraRowsToCompare := subFlags.Int64("max_extra_rows_to_compare", 1000, "If there are collation differences between the source and target, you can have rows that are identical but simply returned in a different order from MySQL. We will do a second pass to compare the rows for any actual differences in this case and this flag allows you to control the resources used for this operation.")
 
 	autoRetry := subFlags.Bool("auto-retry", true, "Should this vdiff automatically retry and continue in case of recoverable errors")
-	checksum := subFlags.Bool("checksum", false, "Use row-level checksums to compare, not yet implemented")
-	samplePct := subFlags.Int64("sample_pct", 100, "How many rows to sample, not yet implemented")
+	checksum := subFlags.Bool("checksum", false, "Compare checksums of ranges of rows, and only compare the rows of the ranges whose checksums don't match")
+	samplePct := subFlags.Int64("sample_pct", 100, "Percentage of the ranges of rows to compare, which are picked deterministically by primary key")
+	repair := subFlags.Bool("repair", false, "Record a statement that fixes each difference found in the target, which can then be reviewed with the repair action and applied with the apply action")
 	verbose := subFlags.Bool("verbose", false, "Show verbose vdiff output in summaries")
 	wait := subFlags.Bool("wait", false, "When creating or resuming a vdiff, wait for it to finish before exiting")
 	waitUpdateInterval := subFlags.Duration("wait-update-interval", time.Duration(1*time.Minute), "When waiting on a vdiff to finish, check and display the current status this often")
@@ -116,6 +117,7 @@ func commandVDiff2(ctx context.Context, wr *wrangler.Wrangler, subFlags *pflag.F
 			MaxRows:               *maxRows,
 			Checksum:              *checksum,
 			SamplePct:             *samplePct,
+			Repair:                *repair,
 			TimeoutSeconds:        int64(timeout.Seconds()),
 			MaxExtraRowsToCompare: *maxExtraRowsToCompare,
 			UpdateTableStats:      *updateTableStats,
@@ -148,7 +150,7 @@ func commandVDiff2(ctx context.Context, wr *wrangler.Wrangler, subFlags *pflag.F
 				return fmt.Errorf("can only show a specific vdiff, please provide a valid UUID; view all with: VDiff -- %s.%s show all", keyspace, workflowName)
 			}
 		}
-	case vdiff.StopAction, vdiff.ResumeAction:
+	case vdiff.StopAction, vdiff.ResumeAction, vdiff.RepairAction, vdiff.ApplyAction:
 		vdiffUUID, err = uuid.Parse(actionArg)
 		if err != nil {
 			return fmt.Errorf("can only %s a specific vdiff, please provide a valid UUID; view all with: VDiff -- %s.%s show all", action, keyspace, workflowName)
@@ -212,6 +214,8 @@ func commandVDiff2(ctx context.Context, wr *wrangler.Wrangler, subFlags *pflag.F
 			uuidToDisplay = vdiffUUID.String()
 		}
 		displayVDiff2ActionStatusResponse(wr, format, uuidToDisplay, action, vdiff.CompletedState)
+	case vdiff.RepairAction, vdiff.ApplyAction:
+		displayVDiff2RepairResponse(wr, format, output)
 	default:
 		return fmt.Errorf("invalid action %s; %s", action, usage)
 	}
@@ -231,7 +235,12 @@ type vdiffTableSummary struct {
 	MismatchedRows  int64
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
-	LastUpdated     string `json:"LastUpdated,omitempty"`
+	// These are only set for checksum and sampled diffs.
+	MatchingRanges   int64  `json:"MatchingRanges,omitempty"`
+	MismatchedRanges int64  `json:"MismatchedRanges,omitempty"`
+	SkippedRanges    int64  `json:"SkippedRanges,omitempty"`
+	RepairStatements int64  `json:"RepairStatements,omitempty"` // Only set with the repair option.
+	LastUpdated      string `json:"LastUpdated,omitempty"`
 }
 type vdiffSummary struct {
 	Workflow, Keyspace string
@@ -270,6 +279,10 @@ Table {{$table.TableName}}:
 {{if $table.MismatchedRows}}	MismatchedRows:   {{$table.MismatchedRows}}{{end}}
 {{if $table.ExtraRowsSource}}	ExtraRowsSource:  {{$table.ExtraRowsSource}}{{end}}
 {{if $table.ExtraRowsTarget}}	ExtraRowsTarget:  {{$table.ExtraRowsTarget}}{{end}}
+{{if $table.MatchingRanges}}	MatchingRanges:   {{$table.MatchingRanges}}{{end}}
+{{if $table.MismatchedRanges}}	MismatchedRanges: {{$table.MismatchedRanges}}{{end}}
+{{if $table.SkippedRanges}}	SkippedRanges:    {{$table.SkippedRanges}}{{end}}
+{{if $table.RepairStatements}}	RepairStatements: {{$table.RepairStatements}}{{end}}
 {{end}}
  
 Use "--format=json" for more detailed output.
@@ -559,6 +572,10 @@ func buildVDiff2SingleSummary(wr *wrangler.Wrangler, keyspace, workflow, uuid st
 						ts.MatchingRows += dr.MatchingRows
 						ts.ExtraRowsTarget += dr.ExtraRowsTarget
 						ts.ExtraRowsSource += dr.ExtraRowsSource
+						ts.MatchingRanges += dr.MatchingRanges
+						ts.MismatchedRanges += dr.MismatchedRanges
+						ts.SkippedRanges += dr.SkippedRanges
+						ts.RepairStatements += dr.RepairStatements
 					}
 					if _, ok := reports[table]; !ok {
 						reports[table] = make(map[string]vdiff.DiffReport)
@@ -655,6 +672,50 @@ func displayVDiff2ActionStatusResponse(wr *wrangler.Wrangler, format, uuid strin
 	}
 }
 
+// displayVDiff2RepairResponse displays the repair statements of all the
+// shards, which are listed in the order they were recorded on each shard.
+func displayVDiff2RepairResponse(wr *wrangler.Wrangler, format string, output *wrangler.VDiffOutput) {
+	type RepairStatement struct {
+		Shard     string
+		Table     string
+		Statement string
+		AppliedAt string `json:"AppliedAt,omitempty"`
+	}
+	shards := make([]string, 0, len(output.Responses))
+	for shard := range output.Responses {
+		shards = append(shards, shard)
+	}
+	sort.Strings(shards)
+	statements := []*RepairStatement{}
+	for _, shard := range shards {
+		resp := output.Responses[shard]
+		if resp == nil || resp.Output == nil {
+			continue
+		}
+		qr := sqltypes.Proto3ToResult(resp.Output)
+		for _, row := range qr.Named().Rows {
+			statements = append(statements, &RepairStatement{
+				Shard:     shard,
+				Table:     row["table_name"].ToString(),
+				Statement: row["statement"].ToString(),
+				AppliedAt: row["applied_at"].ToString(),
+			})
+		}
+	}
+	if format == "json" {
+		jsonText, _ := json.MarshalIndent(statements, "", "\t")
+		wr.Logger().Printf("%s\n", jsonText)
+		return
+	}
+	for _, stmt := range statements {
+		if stmt.AppliedAt != "" {
+			wr.Logger().Printf("%s: %s; -- applied at %s\n", stmt.Shard, stmt.Statement, stmt.AppliedAt)
+		} else {
+			wr.Logger().Printf("%s: %s;\n", stmt.Shard, stmt.Statement)
+		}
+	}
+}
+
 func buildProgressReport(summary *vdiffSummary, rowsToCompare int64) {
 	report := &vdiff.ProgressReport{}
 	if summary.RowsCompared >= 1 {
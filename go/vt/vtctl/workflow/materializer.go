@@ -200,7 +200,8 @@ func (mz *materializer) generateBinlogSources(ctx context.Context, targetShard *
 
 		for _, ts := range mz.ms.TableSettings {
 			rule := &binlogdatapb.Rule{
-				Match: ts.TargetTable,
+				Match:            ts.TargetTable,
+				ColumnTransforms: ts.ColumnTransforms,
 			}
 
 			if ts.SourceExpression == "" {
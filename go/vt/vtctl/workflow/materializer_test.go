@@ -3229,6 +3229,36 @@ func TestKeyRangesEqualOptimization(t *testing.T) {
 	}
 }
 
+// TestMaterializerColumnTransforms tests that the column transformations of
+// the table settings are passed on to the rules of the binlog sources.
+func TestMaterializerColumnTransforms(t *testing.T) {
+	transforms := map[string]*binlogdatapb.ColumnTransform{
+		"email": {Function: "sha256"},
+		"name":  {Function: "truncate", Length: 1},
+	}
+	mz := &materializer{
+		ms: &vtctldatapb.MaterializeSettings{
+			SourceKeyspace: "sourceks",
+			TargetKeyspace: "targetks",
+			TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+				TargetTable:      "t1",
+				SourceExpression: "select id, email, name from t1",
+				ColumnTransforms: transforms,
+			}},
+		},
+		env: vtenv.NewTestEnv(),
+	}
+	shard := topo.NewShardInfo("sourceks", "0", &topodatapb.Shard{}, nil)
+	blses, err := mz.generateBinlogSources(context.Background(), shard, []*topo.ShardInfo{shard}, true)
+	require.NoError(t, err)
+	require.Len(t, blses, 1)
+	utils.MustMatch(t, []*binlogdatapb.Rule{{
+		Match:            "t1",
+		Filter:           "select id, email, `name` from t1",
+		ColumnTransforms: transforms,
+	}}, blses[0].Filter.Rules)
+}
+
 func TestValidateEmptyTables(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -48,7 +48,12 @@ type TableSummary struct {
 	MismatchedRows  int64
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
-	LastUpdated     string `json:"LastUpdated,omitempty"`
+	// These are only set for checksum and sampled diffs.
+	MatchingRanges   int64  `json:"MatchingRanges,omitempty"`
+	MismatchedRanges int64  `json:"MismatchedRanges,omitempty"`
+	SkippedRanges    int64  `json:"SkippedRanges,omitempty"`
+	RepairStatements int64  `json:"RepairStatements,omitempty"` // Only set with the repair option.
+	LastUpdated      string `json:"LastUpdated,omitempty"`
 }
 
 // Summary aggregates the current state of the vdiff from all shards.
@@ -204,6 +209,10 @@ func BuildSummary(keyspace, workflow, uuid string, resp *vtctldatapb.VDiffShowRe
 						ts.MatchingRows += dr.MatchingRows
 						ts.ExtraRowsTarget += dr.ExtraRowsTarget
 						ts.ExtraRowsSource += dr.ExtraRowsSource
+						ts.MatchingRanges += dr.MatchingRanges
+						ts.MismatchedRanges += dr.MismatchedRanges
+						ts.SkippedRanges += dr.SkippedRanges
+						ts.RepairStatements += dr.RepairStatements
 					}
 					if _, ok := reports[table]; !ok {
 						reports[table] = make(map[string]vdiff.DiffReport)
@@ -357,11 +366,14 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 			Tables:                strings.Join(req.Tables, ","),
 			AutoRetry:             req.AutoRetry,
 			MaxRows:               req.Limit,
+			Checksum:              req.Checksum,
+			SamplePct:             req.SamplePct,
 			TimeoutSeconds:        req.FilteredReplicationWaitTime.Seconds,
 			MaxExtraRowsToCompare: req.MaxExtraRowsToCompare,
 			UpdateTableStats:      req.UpdateTableStats,
 			MaxDiffSeconds:        req.MaxDiffDuration.Seconds,
 			AutoStart:             &autoStart,
+			Repair:                req.Repair,
 		},
 		ReportOptions: &tabletmanagerdatapb.VDiffReportOptions{
 			OnlyPks:                 req.OnlyPKs,
@@ -445,6 +457,64 @@ func (s *Server) VDiffDelete(ctx context.Context, req *vtctldatapb.VDiffDeleteRe
 	return &vtctldatapb.VDiffDeleteResponse{}, nil
 }
 
+// VDiffRepair is part of the vtctlservicepb.VtctldServer interface.
+// It returns the statements that the target primary tablets recorded to
+// fix the differences found by the given VDiff, and runs them on the
+// target when requested.
+func (s *Server) VDiffRepair(ctx context.Context, req *vtctldatapb.VDiffRepairRequest) (*vtctldatapb.VDiffRepairResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.VDiffRepair")
+	defer span.Finish()
+
+	targetShards := req.GetTargetShards()
+
+	span.Annotate("keyspace", req.TargetKeyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("uuid", req.Uuid)
+	span.Annotate("target_shards", targetShards)
+	span.Annotate("apply", req.Apply)
+
+	action := vdiff.RepairAction
+	if req.Apply {
+		action = vdiff.ApplyAction
+	}
+	tabletreq := &tabletmanagerdatapb.VDiffRequest{
+		Keyspace:  req.TargetKeyspace,
+		Workflow:  req.Workflow,
+		Action:    string(action),
+		VdiffUuid: req.Uuid,
+	}
+
+	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targetShards) > 0 {
+		if err := applyTargetShards(ts, targetShards); err != nil {
+			return nil, err
+		}
+	}
+
+	output := &vdiffOutput{
+		responses: make(map[string]*tabletmanagerdatapb.VDiffResponse, len(ts.targets)),
+		err:       nil,
+	}
+	output.err = ts.ForAllTargets(func(target *MigrationTarget) error {
+		resp, err := s.tmc.VDiff(ctx, target.GetPrimary().Tablet, tabletreq)
+		output.mu.Lock()
+		defer output.mu.Unlock()
+		output.responses[target.GetShard().ShardName()] = resp
+		return err
+	})
+	if output.err != nil {
+		s.Logger().Errorf("Error executing vdiff %s action: %v", action, output.err)
+		return nil, output.err
+	}
+	return &vtctldatapb.VDiffRepairResponse{
+		TabletResponses: output.responses,
+	}, nil
+}
+
 // VDiffResume is part of the vtctlservicepb.VtctldServer interface.
 func (s *Server) VDiffResume(ctx context.Context, req *vtctldatapb.VDiffResumeRequest) (*vtctldatapb.VDiffResumeResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "workflow.Server.VDiffResume")
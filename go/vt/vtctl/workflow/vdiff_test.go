@@ -26,6 +26,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -159,10 +160,13 @@ func TestVDiffCreate(t *testing.T) {
 	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
 	defer env.close()
 
+	vdiffUUID := uuid.New().String()
+	autoStart := true
 	tests := []struct {
-		name    string
-		req     *vtctldatapb.VDiffCreateRequest
-		wantErr string
+		name                  string
+		req                   *vtctldatapb.VDiffCreateRequest
+		expectedVDiffRequests map[*topodatapb.Tablet]*vdiffRequestResponse
+		wantErr               string
 	}{
 		{
 			name: "no values",
@@ -177,6 +181,56 @@ func TestVDiffCreate(t *testing.T) {
 				Workflow:       workflowName,
 			},
 		},
+		{
+			name: "checksum and sample percentage",
+			req: &vtctldatapb.VDiffCreateRequest{
+				TargetKeyspace: targetKeyspace.KeyspaceName,
+				Workflow:       workflowName,
+				Uuid:           vdiffUUID,
+				Checksum:       true,
+				SamplePct:      10,
+			},
+			expectedVDiffRequests: map[*topodatapb.Tablet]*vdiffRequestResponse{
+				env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID]: {
+					req: &tabletmanagerdatapb.VDiffRequest{
+						Keyspace:  targetKeyspace.KeyspaceName,
+						Workflow:  workflowName,
+						Action:    string(vdiff.CreateAction),
+						VdiffUuid: vdiffUUID,
+						Options: &tabletmanagerdatapb.VDiffOptions{
+							PickerOptions: &tabletmanagerdatapb.VDiffPickerOptions{},
+							CoreOptions: &tabletmanagerdatapb.VDiffCoreOptions{
+								MaxRows:        math.MaxInt64,
+								Checksum:       true,
+								SamplePct:      10,
+								TimeoutSeconds: int64(DefaultTimeout.Seconds()),
+								AutoStart:      &autoStart,
+							},
+							ReportOptions: &tabletmanagerdatapb.VDiffReportOptions{},
+						},
+					},
+				},
+				env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID+tabletUIDStep]: {
+					req: &tabletmanagerdatapb.VDiffRequest{
+						Keyspace:  targetKeyspace.KeyspaceName,
+						Workflow:  workflowName,
+						Action:    string(vdiff.CreateAction),
+						VdiffUuid: vdiffUUID,
+						Options: &tabletmanagerdatapb.VDiffOptions{
+							PickerOptions: &tabletmanagerdatapb.VDiffPickerOptions{},
+							CoreOptions: &tabletmanagerdatapb.VDiffCoreOptions{
+								MaxRows:        math.MaxInt64,
+								Checksum:       true,
+								SamplePct:      10,
+								TimeoutSeconds: int64(DefaultTimeout.Seconds()),
+								AutoStart:      &autoStart,
+							},
+							ReportOptions: &tabletmanagerdatapb.VDiffReportOptions{},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -186,6 +240,9 @@ func TestVDiffCreate(t *testing.T) {
 					result: &querypb.QueryResult{},
 				})
 			}
+			for tab, vdr := range tt.expectedVDiffRequests {
+				env.tmc.expectVDiffRequest(tab, vdr)
+			}
 			got, err := env.ws.VDiffCreate(ctx, tt.req)
 			if tt.wantErr != "" {
 				require.EqualError(t, err, tt.wantErr)
@@ -196,6 +253,7 @@ func TestVDiffCreate(t *testing.T) {
 			// Ensure that we always use a valid UUID.
 			err = uuid.Validate(got.UUID)
 			require.NoError(t, err)
+			env.tmc.confirmVDiffRequests(t)
 		})
 	}
 }
@@ -489,3 +547,126 @@ func TestVDiffDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestVDiffRepair(t *testing.T) {
+	ctx := context.Background()
+	sourceKeyspace := &testKeyspace{
+		KeyspaceName: "sourceks",
+		ShardNames:   []string{"0"},
+	}
+	targetKeyspace := &testKeyspace{
+		KeyspaceName: "targetks",
+		ShardNames:   []string{"-80", "80-"},
+	}
+	workflow := "testwf"
+	uuid := uuid.New().String()
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+
+	env.tmc.strict = true
+	output := sqltypes.ResultToProto3(sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+		"id|table_name|statement|applied_at",
+		"int64|varbinary|blob|timestamp"),
+		"1|t1|delete from t1 where id = 1|NULL",
+	))
+
+	tests := []struct {
+		name                  string
+		req                   *vtctldatapb.VDiffRepairRequest
+		expectedVDiffRequests map[*topodatapb.Tablet]*vdiffRequestResponse
+		wantShards            []string
+		wantErr               string
+	}{
+		{
+			name: "list on all shards",
+			req: &vtctldatapb.VDiffRepairRequest{
+				TargetKeyspace: targetKeyspace.KeyspaceName,
+				Workflow:       workflow,
+				Uuid:           uuid,
+			},
+			expectedVDiffRequests: map[*topodatapb.Tablet]*vdiffRequestResponse{
+				env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID]: {
+					req: &tabletmanagerdatapb.VDiffRequest{
+						Keyspace:  targetKeyspace.KeyspaceName,
+						Workflow:  workflow,
+						Action:    string(vdiff.RepairAction),
+						VdiffUuid: uuid,
+					},
+					res: &tabletmanagerdatapb.VDiffResponse{Output: output},
+				},
+				env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID+tabletUIDStep]: {
+					req: &tabletmanagerdatapb.VDiffRequest{
+						Keyspace:  targetKeyspace.KeyspaceName,
+						Workflow:  workflow,
+						Action:    string(vdiff.RepairAction),
+						VdiffUuid: uuid,
+					},
+					res: &tabletmanagerdatapb.VDiffResponse{Output: output},
+				},
+			},
+			wantShards: []string{"-80", "80-"},
+		},
+		{
+			name: "apply on first shard",
+			req: &vtctldatapb.VDiffRepairRequest{
+				TargetKeyspace: targetKeyspace.KeyspaceName,
+				TargetShards:   targetKeyspace.ShardNames[:1],
+				Workflow:       workflow,
+				Uuid:           uuid,
+				Apply:          true,
+			},
+			expectedVDiffRequests: map[*topodatapb.Tablet]*vdiffRequestResponse{
+				env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID]: {
+					req: &tabletmanagerdatapb.VDiffRequest{
+						Keyspace:  targetKeyspace.KeyspaceName,
+						Workflow:  workflow,
+						Action:    string(vdiff.ApplyAction),
+						VdiffUuid: uuid,
+					},
+					res: &tabletmanagerdatapb.VDiffResponse{Output: output},
+				},
+			},
+			wantShards: []string{"-80"},
+		},
+		{
+			name: "apply fails",
+			req: &vtctldatapb.VDiffRepairRequest{
+				TargetKeyspace: targetKeyspace.KeyspaceName,
+				TargetShards:   targetKeyspace.ShardNames[:1],
+				Workflow:       workflow,
+				Uuid:           uuid,
+				Apply:          true,
+			},
+			expectedVDiffRequests: map[*topodatapb.Tablet]*vdiffRequestResponse{
+				env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID]: {
+					req: &tabletmanagerdatapb.VDiffRequest{
+						Keyspace:  targetKeyspace.KeyspaceName,
+						Workflow:  workflow,
+						Action:    string(vdiff.ApplyAction),
+						VdiffUuid: uuid,
+					},
+					err: fmt.Errorf("workflow %s.%s has streams in the Running state", targetKeyspace.KeyspaceName, workflow),
+				},
+			},
+			wantErr: fmt.Sprintf("workflow %s.%s has streams in the Running state", targetKeyspace.KeyspaceName, workflow),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for tab, vdr := range tt.expectedVDiffRequests {
+				env.tmc.expectVDiffRequest(tab, vdr)
+			}
+			got, err := env.ws.VDiffRepair(ctx, tt.req)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, got.TabletResponses, len(tt.wantShards))
+				for _, shard := range tt.wantShards {
+					require.Equal(t, output, got.TabletResponses[shard].Output)
+				}
+			}
+			env.tmc.confirmVDiffRequests(t)
+		})
+	}
+}
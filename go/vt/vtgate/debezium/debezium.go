@@ -0,0 +1,316 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package debezium converts the events of a VTGate VStream into change events
+// that use the Debezium envelope: before and after images of the row, the
+// operation, the source of the change and, optionally, the schema of the
+// message. Every record carries the VGTID from which the stream can be resumed
+// once the transaction of the record has been delivered.
+package debezium
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// Connector is the name of the connector in the source of the change events.
+	Connector = "vitess"
+
+	// The operations of the change events.
+	OpCreate = "c"
+	OpUpdate = "u"
+	OpDelete = "d"
+	OpRead   = "r"
+)
+
+// Config configures a Converter.
+type Config struct {
+	// ServerName is the logical name of the source, which prefixes the topics
+	// of the records, as the Debezium topic.prefix does.
+	ServerName string
+	// IncludeSchema adds the schema of the key and the value to the messages,
+	// as the Kafka Connect JSON converter does with schemas.enable.
+	IncludeSchema bool
+}
+
+// Message is a key or a value, in the format of the Kafka Connect JSON converter.
+type Message struct {
+	Schema  *Schema `json:"schema,omitempty"`
+	Payload any     `json:"payload"`
+}
+
+// Envelope is the payload of the value of a change event.
+type Envelope struct {
+	Before map[string]any `json:"before"`
+	After  map[string]any `json:"after"`
+	Source *Source        `json:"source"`
+	Op     string         `json:"op"`
+	TsMs   int64          `json:"ts_ms"`
+}
+
+// Source describes where a change event comes from.
+type Source struct {
+	Connector string `json:"connector"`
+	Name      string `json:"name"`
+	TsMs      int64  `json:"ts_ms"`
+	Snapshot  string `json:"snapshot"`
+	Db        string `json:"db"`
+	Keyspace  string `json:"keyspace"`
+	Table     string `json:"table"`
+	Shard     string `json:"shard"`
+	Gtid      string `json:"gtid"`
+	// Vgtid is the JSON encoding of the VGTID after the transaction of the event.
+	Vgtid string `json:"vgtid"`
+}
+
+// Record is a change event.
+type Record struct {
+	// Topic is <server name>.<keyspace>.<table>.
+	Topic string
+	// Key holds the primary key columns of the row, and is nil when the
+	// table has no primary key.
+	Key   *Message
+	Value *Message
+	// Offset is the VGTID after the transaction of the record.
+	Offset *binlogdatapb.VGtid
+	// Committed is set on the last record of a transaction: once it has been
+	// delivered, the stream can be resumed from its Offset.
+	Committed bool
+}
+
+// Envelope returns the payload of the value of the record.
+func (r *Record) Envelope() *Envelope {
+	return r.Value.Payload.(*Envelope)
+}
+
+// table is the latest FIELD event of a table.
+type table struct {
+	keyspace, name string
+	fields         []*querypb.Field
+	// pks are the indexes of the primary key fields.
+	pks         []int
+	keySchema   *Schema
+	valueSchema *Schema
+}
+
+// Converter converts the event batches of a VStream into records. It expects
+// the events of a shard in the order the VStream sends them, and isn't safe
+// for concurrent use.
+type Converter struct {
+	cfg Config
+	now func() time.Time
+
+	// tables are keyed by the keyspace qualified table names of the VStream.
+	tables map[string]*table
+	// pending are the records of the open transactions, and vgtids the latest
+	// VGTIDs, both keyed by keyspace/shard.
+	pending map[string][]*Record
+	vgtids  map[string]*binlogdatapb.VGtid
+}
+
+// NewConverter returns a Converter for the given config.
+func NewConverter(cfg Config) *Converter {
+	return &Converter{
+		cfg:     cfg,
+		now:     time.Now,
+		tables:  make(map[string]*table),
+		pending: make(map[string][]*Record),
+		vgtids:  make(map[string]*binlogdatapb.VGtid),
+	}
+}
+
+// Convert converts a batch of events. The records of a transaction are
+// returned with the batch that contains its COMMIT event.
+func (c *Converter) Convert(events []*binlogdatapb.VEvent) ([]*Record, error) {
+	var records []*Record
+	for _, ev := range events {
+		shard := ev.Keyspace + "/" + ev.Shard
+		switch ev.Type {
+		case binlogdatapb.VEventType_FIELD:
+			c.setFields(ev.FieldEvent)
+		case binlogdatapb.VEventType_ROW:
+			pending, err := c.convertRows(ev)
+			if err != nil {
+				return nil, err
+			}
+			c.pending[shard] = append(c.pending[shard], pending...)
+		case binlogdatapb.VEventType_VGTID:
+			c.vgtids[shard] = ev.Vgtid
+		case binlogdatapb.VEventType_COMMIT:
+			committed, err := c.commit(shard)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, committed...)
+		}
+	}
+	return records, nil
+}
+
+func (c *Converter) setFields(fe *binlogdatapb.FieldEvent) {
+	t := &table{
+		keyspace: fe.Keyspace,
+		name:     fe.TableName,
+		fields:   fe.Fields,
+	}
+	// VTGate qualifies the table names with the keyspace.
+	if ks, name, ok := strings.Cut(fe.TableName, "."); ok {
+		t.keyspace, t.name = ks, name
+	}
+	for i, field := range fe.Fields {
+		if field.Flags&uint32(querypb.MySqlFlag_PRI_KEY_FLAG) != 0 {
+			t.pks = append(t.pks, i)
+		}
+	}
+	if c.cfg.IncludeSchema {
+		t.keySchema, t.valueSchema = c.schemas(t)
+	}
+	c.tables[fe.TableName] = t
+}
+
+func (c *Converter) convertRows(ev *binlogdatapb.VEvent) ([]*Record, error) {
+	t, ok := c.tables[ev.RowEvent.TableName]
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no FIELD event was received for table %s", ev.RowEvent.TableName)
+	}
+	var records []*Record
+	for _, change := range ev.RowEvent.RowChanges {
+		envelope := &Envelope{
+			Source: &Source{
+				Connector: Connector,
+				Name:      c.cfg.ServerName,
+				TsMs:      ev.Timestamp * 1000,
+				Snapshot:  "false",
+				Db:        t.keyspace,
+				Keyspace:  t.keyspace,
+				Table:     t.name,
+				Shard:     ev.Shard,
+			},
+			TsMs: c.now().UnixMilli(),
+		}
+		var keyRow []sqltypes.Value
+		switch {
+		case change.Before == nil && ev.Timestamp == 0:
+			// The rows of the copy phase are the only ones without a timestamp.
+			envelope.Op = OpRead
+			envelope.Source.Snapshot = "true"
+		case change.Before == nil:
+			envelope.Op = OpCreate
+		case change.After == nil:
+			envelope.Op = OpDelete
+		default:
+			envelope.Op = OpUpdate
+		}
+		if change.Before != nil {
+			keyRow = sqltypes.MakeRowTrusted(t.fields, change.Before)
+			envelope.Before = t.payload(keyRow, nil)
+		}
+		if change.After != nil {
+			keyRow = sqltypes.MakeRowTrusted(t.fields, change.After)
+			envelope.After = t.payload(keyRow, nil)
+		}
+		record := &Record{
+			Topic: strings.Join([]string{c.cfg.ServerName, t.keyspace, t.name}, "."),
+			Value: &Message{Schema: t.valueSchema, Payload: envelope},
+		}
+		if len(t.pks) > 0 {
+			record.Key = &Message{Schema: t.keySchema, Payload: t.payload(keyRow, t.pks)}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// payload returns the columns of the row, limited to the given indexes when
+// there are some.
+func (t *table) payload(row []sqltypes.Value, indexes []int) map[string]any {
+	payload := make(map[string]any)
+	if indexes == nil {
+		for i, field := range t.fields {
+			payload[field.Name] = value(field, row[i])
+		}
+		return payload
+	}
+	for _, i := range indexes {
+		payload[t.fields[i].Name] = value(t.fields[i], row[i])
+	}
+	return payload
+}
+
+// commit stamps the pending records of a shard with the VGTID of their
+// transaction.
+func (c *Converter) commit(key string) ([]*Record, error) {
+	records := c.pending[key]
+	delete(c.pending, key)
+	if len(records) == 0 {
+		return nil, nil
+	}
+	vgtid := c.vgtids[key]
+	if vgtid == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no VGTID event was received for the transaction on shard %s", key)
+	}
+	encoded, err := json2.MarshalPB(vgtid)
+	if err != nil {
+		return nil, err
+	}
+	var gtid string
+	for _, sgtid := range vgtid.ShardGtids {
+		if sgtid.Keyspace+"/"+sgtid.Shard == key {
+			gtid = sgtid.Gtid
+		}
+	}
+	for _, record := range records {
+		record.Offset = vgtid
+		source := record.Envelope().Source
+		source.Gtid = gtid
+		source.Vgtid = string(encoded)
+	}
+	records[len(records)-1].Committed = true
+	return records, nil
+}
+
+// value returns the JSON representation of a column value.
+func value(field *querypb.Field, v sqltypes.Value) any {
+	switch {
+	case v.IsNull():
+		return nil
+	case v.IsIntegral() || v.IsFloat():
+		return json.Number(v.RawStr())
+	case isBinary(field):
+		// Binary values are encoded in base64, as the JSON converter does.
+		return v.Raw()
+	default:
+		return v.ToString()
+	}
+}
+
+func isBinary(field *querypb.Field) bool {
+	switch field.Type {
+	case sqltypes.Binary, sqltypes.VarBinary, sqltypes.Blob, sqltypes.Bit, sqltypes.Geometry:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debezium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var testFields = []*querypb.Field{
+	{Name: "id", Type: sqltypes.Int64, Flags: uint32(querypb.MySqlFlag_PRI_KEY_FLAG | querypb.MySqlFlag_NOT_NULL_FLAG)},
+	{Name: "name", Type: sqltypes.VarChar},
+	{Name: "data", Type: sqltypes.VarBinary},
+}
+
+func testRow(id int64, name string) *querypb.Row {
+	return sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(id), sqltypes.NewVarChar(name), sqltypes.NewVarBinary("\x01")})
+}
+
+func testVGtid(gtid string) *binlogdatapb.VGtid {
+	return &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "-80", Gtid: gtid}}}
+}
+
+// testTransaction returns the events of a transaction, as VTGate sends them.
+func testTransaction(ts int64, gtid string, changes ...*binlogdatapb.RowChange) []*binlogdatapb.VEvent {
+	events := []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_BEGIN, Keyspace: "ks", Shard: "-80"},
+		{Type: binlogdatapb.VEventType_FIELD, Keyspace: "ks", Shard: "-80", FieldEvent: &binlogdatapb.FieldEvent{
+			TableName: "ks.t1",
+			Fields:    testFields,
+			Keyspace:  "ks",
+			Shard:     "-80",
+		}},
+		{Type: binlogdatapb.VEventType_ROW, Keyspace: "ks", Shard: "-80", Timestamp: ts, RowEvent: &binlogdatapb.RowEvent{
+			TableName:  "ks.t1",
+			RowChanges: changes,
+			Keyspace:   "ks",
+			Shard:      "-80",
+		}},
+		{Type: binlogdatapb.VEventType_VGTID, Keyspace: "ks", Shard: "-80", Vgtid: testVGtid(gtid)},
+		{Type: binlogdatapb.VEventType_COMMIT, Keyspace: "ks", Shard: "-80"},
+	}
+	return events
+}
+
+func TestConvert(t *testing.T) {
+	conv := NewConverter(Config{ServerName: "vt"})
+	conv.now = func() time.Time { return time.UnixMilli(1700000000123) }
+
+	events := testTransaction(1700000000, "MySQL56/a:1-2",
+		&binlogdatapb.RowChange{After: testRow(1, "a")},
+		&binlogdatapb.RowChange{Before: testRow(1, "a"), After: testRow(1, "b")},
+		&binlogdatapb.RowChange{Before: testRow(1, "b")},
+	)
+	// The records are only returned with the COMMIT event.
+	records, err := conv.Convert(events[:4])
+	require.NoError(t, err)
+	require.Empty(t, records)
+	records, err = conv.Convert(events[4:])
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	var ops []string
+	for i, record := range records {
+		require.Equal(t, "vt.ks.t1", record.Topic)
+		require.Equal(t, map[string]any{"id": json.Number("1")}, record.Key.Payload)
+		utils.MustMatch(t, testVGtid("MySQL56/a:1-2"), record.Offset)
+		require.Equal(t, i == 2, record.Committed)
+		ops = append(ops, record.Envelope().Op)
+	}
+	require.Equal(t, []string{OpCreate, OpUpdate, OpDelete}, ops)
+
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+	require.NoError(t, sink.Write(context.Background(), records[1:2]))
+	require.JSONEq(t, `{
+		"payload": {
+			"before": {"id": 1, "name": "a", "data": "AQ=="},
+			"after": {"id": 1, "name": "b", "data": "AQ=="},
+			"source": {
+				"connector": "vitess",
+				"name": "vt",
+				"ts_ms": 1700000000000,
+				"snapshot": "false",
+				"db": "ks",
+				"keyspace": "ks",
+				"table": "t1",
+				"shard": "-80",
+				"gtid": "MySQL56/a:1-2",
+				"vgtid": "{\"shardGtids\":[{\"keyspace\":\"ks\",\"shard\":\"-80\",\"gtid\":\"MySQL56/a:1-2\"}]}"
+			},
+			"op": "u",
+			"ts_ms": 1700000000123
+		}
+	}`, buf.String())
+
+	// The rows of the copy phase are snapshot reads.
+	records, err = conv.Convert(testTransaction(0, "MySQL56/a:1-2", &binlogdatapb.RowChange{After: testRow(2, "c")}))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, OpRead, records[0].Envelope().Op)
+	require.Equal(t, "true", records[0].Envelope().Source.Snapshot)
+
+	// Rows need the fields of their table.
+	_, err = NewConverter(Config{}).Convert(events[2:3])
+	require.ErrorContains(t, err, "no FIELD event was received for table ks.t1")
+}
+
+func TestConvertSchema(t *testing.T) {
+	conv := NewConverter(Config{ServerName: "vt", IncludeSchema: true})
+	records, err := conv.Convert(testTransaction(1700000000, "MySQL56/a:1", &binlogdatapb.RowChange{After: testRow(1, "a")}))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	require.Equal(t, &Schema{
+		Type:   "struct",
+		Name:   "vt.ks.t1.Key",
+		Fields: []*Schema{{Type: "int64", Field: "id"}},
+	}, records[0].Key.Schema)
+	valueSchema := records[0].Value.Schema
+	require.Equal(t, "vt.ks.t1.Envelope", valueSchema.Name)
+	require.Equal(t, []*Schema{
+		{Type: "int64", Field: "id"},
+		{Type: "string", Optional: true, Field: "name"},
+		{Type: "bytes", Optional: true, Field: "data"},
+	}, valueSchema.Fields[0].Fields)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debezium
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// Schema is a Kafka Connect schema.
+type Schema struct {
+	Type     string    `json:"type"`
+	Optional bool      `json:"optional"`
+	Name     string    `json:"name,omitempty"`
+	Field    string    `json:"field,omitempty"`
+	Fields   []*Schema `json:"fields,omitempty"`
+}
+
+// sourceSchema is the schema of Source.
+var sourceSchema = &Schema{
+	Type:  "struct",
+	Name:  "io.debezium.connector.vitess.Source",
+	Field: "source",
+	Fields: []*Schema{
+		{Type: "string", Field: "connector"},
+		{Type: "string", Field: "name"},
+		{Type: "int64", Field: "ts_ms"},
+		{Type: "string", Optional: true, Field: "snapshot"},
+		{Type: "string", Field: "db"},
+		{Type: "string", Field: "keyspace"},
+		{Type: "string", Field: "table"},
+		{Type: "string", Field: "shard"},
+		{Type: "string", Optional: true, Field: "gtid"},
+		{Type: "string", Field: "vgtid"},
+	},
+}
+
+// schemas returns the schemas of the key and the value of the records of a table.
+func (c *Converter) schemas(t *table) (*Schema, *Schema) {
+	prefix := strings.Join([]string{c.cfg.ServerName, t.keyspace, t.name}, ".")
+	var keySchema *Schema
+	if len(t.pks) > 0 {
+		keySchema = &Schema{Type: "struct", Name: prefix + ".Key"}
+		for _, i := range t.pks {
+			keySchema.Fields = append(keySchema.Fields, fieldSchema(t.fields[i]))
+		}
+	}
+	row := func(field string) *Schema {
+		s := &Schema{Type: "struct", Optional: true, Name: prefix + ".Value", Field: field}
+		for _, f := range t.fields {
+			s.Fields = append(s.Fields, fieldSchema(f))
+		}
+		return s
+	}
+	valueSchema := &Schema{
+		Type: "struct",
+		Name: prefix + ".Envelope",
+		Fields: []*Schema{
+			row("before"),
+			row("after"),
+			sourceSchema,
+			{Type: "string", Field: "op"},
+			{Type: "int64", Optional: true, Field: "ts_ms"},
+		},
+	}
+	return keySchema, valueSchema
+}
+
+// fieldSchema returns the schema of a column. Decimals and temporal values
+// are represented as strings, which keeps their precision.
+func fieldSchema(field *querypb.Field) *Schema {
+	s := &Schema{
+		Type:     "string",
+		Optional: field.Flags&uint32(querypb.MySqlFlag_NOT_NULL_FLAG) == 0,
+		Field:    field.Name,
+	}
+	switch field.Type {
+	case sqltypes.Int8, sqltypes.Uint8, sqltypes.Int16:
+		s.Type = "int16"
+	case sqltypes.Uint16, sqltypes.Int24, sqltypes.Uint24, sqltypes.Int32, sqltypes.Year:
+		s.Type = "int32"
+	case sqltypes.Uint32, sqltypes.Int64, sqltypes.Uint64:
+		s.Type = "int64"
+	case sqltypes.Float32:
+		s.Type = "float"
+	case sqltypes.Float64:
+		s.Type = "double"
+	case sqltypes.Binary, sqltypes.VarBinary, sqltypes.Blob, sqltypes.Bit, sqltypes.Geometry:
+		s.Type = "bytes"
+	case sqltypes.TypeJSON:
+		s.Name = "io.debezium.data.Json"
+	case sqltypes.Enum:
+		s.Name = "io.debezium.data.Enum"
+	case sqltypes.Set:
+		s.Name = "io.debezium.data.EnumSet"
+	}
+	return s
+}
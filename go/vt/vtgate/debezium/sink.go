@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debezium
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Sink delivers records.
+type Sink interface {
+	// Write delivers the records in order. The stream is only resumed
+	// after a transaction once Write has returned its last record.
+	Write(ctx context.Context, records []*Record) error
+	// Close flushes and releases the resources of the sink.
+	Close() error
+}
+
+// writerSink writes the values of the records as JSON lines.
+type writerSink struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+// NewWriterSink returns a Sink that writes the value of every record to w,
+// one JSON document per line.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w, enc: json.NewEncoder(w)}
+}
+
+func (s *writerSink) Write(ctx context.Context, records []*Record) error {
+	for _, record := range records {
+		if err := s.enc.Encode(record.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *writerSink) Close() error {
+	return nil
+}
+
+// fileSink writes the values of the records in one file per topic.
+type fileSink struct {
+	dir   string
+	files map[string]*os.File
+}
+
+// NewFileSink returns a Sink that appends the value of every record, as a
+// JSON line, to the <topic>.json file of dir.
+func NewFileSink(dir string) (Sink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileSink{dir: dir, files: make(map[string]*os.File)}, nil
+}
+
+func (s *fileSink) Write(ctx context.Context, records []*Record) error {
+	written := make(map[*os.File]bool)
+	for _, record := range records {
+		f, ok := s.files[record.Topic]
+		if !ok {
+			var err error
+			f, err = os.OpenFile(filepath.Join(s.dir, record.Topic+".json"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+			if err != nil {
+				return err
+			}
+			s.files[record.Topic] = f
+		}
+		data, err := json.Marshal(record.Value)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			return err
+		}
+		written[f] = true
+	}
+	// The records must be durable before the stream moves past them.
+	for f := range written {
+		if err := f.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	var firstErr error
+	for _, f := range s.files {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// MemorySink keeps the records in memory. It stands in for a message broker
+// in tests.
+type MemorySink struct {
+	mu      sync.Mutex
+	records []*Record
+	closed  bool
+}
+
+// NewMemorySink returns an empty MemorySink.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Write(ctx context.Context, records []*Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, records...)
+	return nil
+}
+
+func (s *MemorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// Records returns the records that were written so far.
+func (s *MemorySink) Records() []*Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Record(nil), s.records...)
+}
+
+// Closed returns whether the sink was closed.
+func (s *MemorySink) Closed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
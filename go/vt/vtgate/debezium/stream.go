@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debezium
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/vtgate/vtgateconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+)
+
+// OffsetStore persists the VGTID from which a stream is resumed.
+type OffsetStore interface {
+	// Load returns the saved VGTID, or nil if there is none.
+	Load() (*binlogdatapb.VGtid, error)
+	// Save replaces the saved VGTID.
+	Save(vgtid *binlogdatapb.VGtid) error
+}
+
+// FileOffsetStore saves the VGTID as JSON in a file.
+type FileOffsetStore struct {
+	Path string
+}
+
+// Load is part of the OffsetStore interface.
+func (s *FileOffsetStore) Load() (*binlogdatapb.VGtid, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	vgtid := &binlogdatapb.VGtid{}
+	if err := json2.UnmarshalPB(data, vgtid); err != nil {
+		return nil, err
+	}
+	return vgtid, nil
+}
+
+// Save is part of the OffsetStore interface. The file is replaced atomically,
+// so that a crash leaves either the previous offset or the new one.
+func (s *FileOffsetStore) Save(vgtid *binlogdatapb.VGtid) error {
+	data, err := json2.MarshalPB(vgtid)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// Stream converts the events of the reader and writes the records to the
+// sink until the stream ends or fails. The offset of every transaction is
+// saved in offsets, when set, once its records have been written. It returns
+// nil when the stream ends with io.EOF.
+func Stream(ctx context.Context, reader vtgateconn.VStreamReader, conv *Converter, sink Sink, offsets OffsetStore) error {
+	for {
+		events, err := reader.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		records, err := conv.Convert(events)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			continue
+		}
+		if err := sink.Write(ctx, records); err != nil {
+			return err
+		}
+		if offsets == nil {
+			continue
+		}
+		var offset *binlogdatapb.VGtid
+		for _, record := range records {
+			if record.Committed {
+				offset = record.Offset
+			}
+		}
+		if offset != nil {
+			if err := offsets.Save(offset); err != nil {
+				return err
+			}
+		}
+	}
+}
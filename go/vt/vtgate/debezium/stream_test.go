@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debezium
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+)
+
+type fakeReader struct {
+	batches [][]*binlogdatapb.VEvent
+}
+
+func (r *fakeReader) Recv() ([]*binlogdatapb.VEvent, error) {
+	if len(r.batches) == 0 {
+		return nil, io.EOF
+	}
+	batch := r.batches[0]
+	r.batches = r.batches[1:]
+	return batch, nil
+}
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	offsets := &FileOffsetStore{Path: filepath.Join(dir, "offset.json")}
+	vgtid, err := offsets.Load()
+	require.NoError(t, err)
+	require.Nil(t, vgtid)
+
+	first := testTransaction(1700000000, "MySQL56/a:1", &binlogdatapb.RowChange{After: testRow(1, "a")})
+	second := testTransaction(1700000001, "MySQL56/a:1-2", &binlogdatapb.RowChange{After: testRow(2, "b")})
+	reader := &fakeReader{batches: [][]*binlogdatapb.VEvent{first, second[:3]}}
+	sink := NewMemorySink()
+	require.NoError(t, Stream(ctx, reader, NewConverter(Config{ServerName: "vt"}), sink, offsets))
+	require.Len(t, sink.Records(), 1)
+	// The open transaction isn't part of the offset.
+	vgtid, err = offsets.Load()
+	require.NoError(t, err)
+	utils.MustMatch(t, testVGtid("MySQL56/a:1"), vgtid)
+
+	fileSink, err := NewFileSink(filepath.Join(dir, "out"))
+	require.NoError(t, err)
+	reader = &fakeReader{batches: [][]*binlogdatapb.VEvent{second}}
+	require.NoError(t, Stream(ctx, reader, NewConverter(Config{ServerName: "vt"}), fileSink, offsets))
+	require.NoError(t, fileSink.Close())
+	vgtid, err = offsets.Load()
+	require.NoError(t, err)
+	utils.MustMatch(t, testVGtid("MySQL56/a:1-2"), vgtid)
+
+	data, err := os.ReadFile(filepath.Join(dir, "out", "vt.ks.t1.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"after":{"data":"AQ==","id":2,"name":"b"}`)
+}
@@ -561,6 +561,87 @@ func (cached *InsertSelect) CachedSize(alloc bool) int64 {
 }
 
 //go:nocheckptr
+func (cached *JSONTable) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(80)
+	}
+	// field Doc vitess.io/vitess/go/vt/vtgate/evalengine.Expr
+	if cc, ok := cached.Doc.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Path *vitess.io/vitess/go/vt/vtgate/engine.JSONTablePath
+	size += cached.Path.CachedSize(true)
+	// field Fields []*vitess.io/vitess/go/vt/proto/query.Field
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Fields)) * int64(8))
+		for _, elem := range cached.Fields {
+			size += elem.CachedSize(true)
+		}
+	}
+	// field Cols []int
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Cols)) * int64(8))
+	}
+	return size
+}
+func (cached *JSONTableColumn) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(80)
+	}
+	// field Path *vitess.io/vitess/go/mysql/json.Path
+	size += cached.Path.CachedSize(true)
+	// field OnEmpty vitess.io/vitess/go/vt/vtgate/engine.JSONTableOnResponse
+	size += cached.OnEmpty.CachedSize(false)
+	// field OnError vitess.io/vitess/go/vt/vtgate/engine.JSONTableOnResponse
+	size += cached.OnError.CachedSize(false)
+	return size
+}
+func (cached *JSONTableOnResponse) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(24)
+	}
+	// field Default string
+	size += hack.RuntimeAllocSize(int64(len(cached.Default)))
+	return size
+}
+func (cached *JSONTablePath) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field Path *vitess.io/vitess/go/mysql/json.Path
+	size += cached.Path.CachedSize(true)
+	// field Columns []*vitess.io/vitess/go/vt/vtgate/engine.JSONTableColumn
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Columns)) * int64(8))
+		for _, elem := range cached.Columns {
+			size += elem.CachedSize(true)
+		}
+	}
+	// field Nested []*vitess.io/vitess/go/vt/vtgate/engine.JSONTablePath
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Nested)) * int64(8))
+		for _, elem := range cached.Nested {
+			size += elem.CachedSize(true)
+		}
+	}
+	return size
+}
 func (cached *Join) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1615,6 +1696,73 @@ func (cached *VitessMetadata) CachedSize(alloc bool) int64 {
 	size += hack.RuntimeAllocSize(int64(len(cached.Value)))
 	return size
 }
+func (cached *Window) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(112)
+	}
+	// field PartitionBy []*vitess.io/vitess/go/vt/vtgate/engine.GroupByParams
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.PartitionBy)) * int64(8))
+		for _, elem := range cached.PartitionBy {
+			size += elem.CachedSize(true)
+		}
+	}
+	// field OrderBy []*vitess.io/vitess/go/vt/vtgate/engine.GroupByParams
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.OrderBy)) * int64(8))
+		for _, elem := range cached.OrderBy {
+			size += elem.CachedSize(true)
+		}
+	}
+	// field Functions []*vitess.io/vitess/go/vt/vtgate/engine.WindowFunc
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Functions)) * int64(8))
+		for _, elem := range cached.Functions {
+			size += elem.CachedSize(true)
+		}
+	}
+	// field Cols []int
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Cols)) * int64(8))
+	}
+	// field Input vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Input.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+func (cached *WindowFrame) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	return size
+}
+func (cached *WindowFunc) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(112)
+	}
+	// field Frame *vitess.io/vitess/go/vt/vtgate/engine.WindowFrame
+	size += cached.Frame.CachedSize(true)
+	// field Type vitess.io/vitess/go/vt/vtgate/evalengine.Type
+	size += cached.Type.CachedSize(false)
+	// field CollationEnv *vitess.io/vitess/go/mysql/collations.Environment
+	size += cached.CollationEnv.CachedSize(true)
+	// field Alias string
+	size += hack.RuntimeAllocSize(int64(len(cached.Alias)))
+	return size
+}
 func (cached *percentBasedMirror) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
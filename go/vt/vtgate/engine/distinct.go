@@ -19,6 +19,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"io"
 	"sync"
 
 	"vitess.io/vitess/go/mysql/collations"
@@ -67,6 +68,18 @@ func (pt *probeTable) exists(inputRow sqltypes.Row) (sqltypes.Row, error) {
 	return inputRow, nil
 }
 
+// spill writes a row that was not seen yet to its partition file.
+func (pt *probeTable) spill(files *spillPartitionFiles, inputRow sqltypes.Row) error {
+	code, err := pt.hashCodeForRow(inputRow)
+	if err != nil {
+		return err
+	}
+	if _, found := pt.seenRows[code]; found {
+		return nil
+	}
+	return files.write(code, inputRow)
+}
+
 func (pt *probeTable) hashCodeForRow(inputRow sqltypes.Row) (vthash.Hash, error) {
 	hasher := vthash.New()
 	for i, checkCol := range pt.checkCols {
@@ -134,6 +147,13 @@ func (d *Distinct) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 	var mu sync.Mutex
 
 	pt := newProbeTable(d.CheckCols, vcursor.Environment().CollationEnv())
+	// files are the partitions of the rows spilled to disk, once the rows
+	// seen exceed the max memory rows. The rows seen until then are still
+	// filtered out, and the others are made distinct partition by partition.
+	var files *spillPartitionFiles
+	defer func() {
+		files.close()
+	}()
 	err := vcursor.StreamExecutePrimitive(ctx, d.Source, bindVars, wantfields, func(input *sqltypes.Result) error {
 		result := &sqltypes.Result{
 			Fields:   input.Fields,
@@ -142,6 +162,12 @@ func (d *Distinct) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 		mu.Lock()
 		defer mu.Unlock()
 		for _, row := range input.Rows {
+			if files != nil {
+				if err := pt.spill(files, row); err != nil {
+					return err
+				}
+				continue
+			}
 			appendRow, err := pt.exists(row)
 			if err != nil {
 				return err
@@ -150,10 +176,95 @@ func (d *Distinct) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 				result.Rows = append(result.Rows, appendRow)
 			}
 		}
+		if files == nil && vcursor.ExceedsMaxMemoryRows(len(pt.seenRows)) {
+			if spiller := vcursor.Spiller(); spiller != nil {
+				spills.Add("Distinct", 1)
+				var err error
+				if files, err = spiller.newPartitionFiles("Distinct", 0); err != nil {
+					return err
+				}
+			}
+		}
 		return callback(result.Truncate(len(d.CheckCols)))
 	})
+	if err != nil || files == nil {
+		return err
+	}
 
-	return err
+	return d.streamPartitions(vcursor, files, callback)
+}
+
+// streamPartitions sends the distinct rows of each partition spilled to disk.
+func (d *Distinct) streamPartitions(vcursor VCursor, files *spillPartitionFiles, callback func(*sqltypes.Result) error) error {
+	for _, file := range files.files {
+		if err := d.streamPartition(vcursor, file, files.level, callback); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamPartition sends the distinct rows of a partition spilled to disk. If
+// the rows seen exceed the max memory rows, the others are split again in the
+// partitions of the next level, as the rows of the source are.
+func (d *Distinct) streamPartition(vcursor VCursor, file *spillFile, level int, callback func(*sqltypes.Result) error) error {
+	pt := newProbeTable(d.CheckCols, vcursor.Environment().CollationEnv())
+	var files *spillPartitionFiles
+	defer func() {
+		files.close()
+	}()
+	reader, err := file.reader()
+	if err != nil {
+		return err
+	}
+	result := &sqltypes.Result{}
+	for {
+		row, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if files != nil {
+			if err := pt.spill(files, row); err != nil {
+				return err
+			}
+			continue
+		}
+		appendRow, err := pt.exists(row)
+		if err != nil {
+			return err
+		}
+		if appendRow == nil {
+			continue
+		}
+		result.Rows = append(result.Rows, appendRow)
+		if vcursor.ExceedsMaxMemoryRows(len(pt.seenRows)) {
+			if level == spillMaxLevel {
+				return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+			}
+			if files, err = vcursor.Spiller().newPartitionFiles("Distinct", level+1); err != nil {
+				return err
+			}
+		}
+		if len(result.Rows) == spillBatchSize {
+			if err := callback(result.Truncate(len(d.CheckCols))); err != nil {
+				return err
+			}
+			result = &sqltypes.Result{}
+		}
+	}
+	file.close()
+	if len(result.Rows) != 0 {
+		if err := callback(result.Truncate(len(d.CheckCols))); err != nil {
+			return err
+		}
+	}
+	if files == nil {
+		return nil
+	}
+	return d.streamPartitions(vcursor, files, callback)
 }
 
 // RouteType implements the Primitive interface
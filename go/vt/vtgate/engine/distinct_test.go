@@ -27,6 +27,7 @@ import (
 
 	"vitess.io/vitess/go/test/utils"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -184,6 +185,65 @@ func TestDistinctStreamAsync(t *testing.T) {
 [VARCHAR("a") INT64(1) INT64(1) VARCHAR("t")]]`, qr.Rows))
 }
 
+func TestDistinctSpill(t *testing.T) {
+	spiller := withTestSpiller(t, 2)
+
+	fields := sqltypes.MakeTestFields("id|name", "int64|varchar")
+	distinct := &Distinct{
+		Source: &fakePrimitive{
+			results: []*sqltypes.Result{sqltypes.MakeTestResult(fields,
+				"1|a",
+				"2|b",
+				"1|a",
+				"3|c",
+				"2|b",
+				"4|null",
+				"3|c",
+				"1|a",
+				"4|null",
+				"5|e",
+			)},
+		},
+		CheckCols: []CheckCol{
+			{Col: 0, Type: evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID)},
+			{Col: 1, Type: evalengine.NewType(sqltypes.VarChar, collations.CollationUtf8mb4ID)},
+		},
+	}
+
+	result, err := wrapStreamExecute(distinct, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	expectResultAnyOrder(t, result, sqltypes.MakeTestResult(fields,
+		"1|a",
+		"2|b",
+		"3|c",
+		"4|null",
+		"5|e",
+	))
+	assert.Zero(t, spiller.Used())
+}
+
+func TestDistinctSpillSplitsPartitions(t *testing.T) {
+	spiller := withTestSpiller(t, 2)
+
+	fields := sqltypes.MakeTestFields("id", "int64")
+	var rows, expected []string
+	for i := range 100 {
+		rows = append(rows, fmt.Sprintf("%d", i), fmt.Sprintf("%d", i/2))
+		expected = append(expected, fmt.Sprintf("%d", i))
+	}
+	distinct := &Distinct{
+		Source: &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, rows...)}},
+		CheckCols: []CheckCol{
+			{Col: 0, Type: evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID)},
+		},
+	}
+
+	result, err := wrapStreamExecute(distinct, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	expectResultAnyOrder(t, result, sqltypes.MakeTestResult(fields, expected...))
+	assert.Zero(t, spiller.Used())
+}
+
 func TestWeightStringFallBack(t *testing.T) {
 	offsetOne := 1
 	checkCols := []CheckCol{{
@@ -49,6 +49,7 @@ import (
 var (
 	testMaxMemoryRows       = 100
 	testIgnoreMaxMemoryRows = false
+	testSpiller             *Spiller
 )
 
 var (
@@ -361,6 +362,10 @@ func (t *noopVCursor) ExceedsMaxMemoryRows(numRows int) bool {
 	return !testIgnoreMaxMemoryRows && numRows > testMaxMemoryRows
 }
 
+func (t *noopVCursor) Spiller() *Spiller {
+	return testSpiller
+}
+
 func (t *noopVCursor) GetKeyspace() string {
 	return "test_ks"
 }
@@ -19,6 +19,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -74,6 +75,7 @@ type (
 
 	hashJoinProbeTable struct {
 		innerMap map[vthash.Hash]*probeTableEntry
+		rows     int
 
 		coll           collations.ID
 		typ            querypb.Type
@@ -136,6 +138,12 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 	// build the probe table from the LHS result
 	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
 	var lfields []*querypb.Field
+	// lhsFiles are the partitions of the LHS rows spilled to disk, when
+	// they exceed the max memory rows.
+	var lhsFiles *spillPartitionFiles
+	defer func() {
+		lhsFiles.close()
+	}()
 	var mu sync.Mutex
 	err := vcursor.StreamExecutePrimitive(ctx, hj.Left, bindVars, wantfields, func(result *sqltypes.Result) error {
 		mu.Lock()
@@ -144,16 +152,36 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 			lfields = result.Fields
 		}
 		for _, current := range result.Rows {
+			if lhsFiles != nil {
+				hash, err := pt.hash(current[pt.lhsKey])
+				if err != nil {
+					return err
+				}
+				if err := lhsFiles.write(hash, current); err != nil {
+					return err
+				}
+				continue
+			}
 			err := pt.addLeftRow(current)
 			if err != nil {
 				return err
 			}
 		}
+		if lhsFiles == nil && vcursor.ExceedsMaxMemoryRows(pt.rows) {
+			if spiller := vcursor.Spiller(); spiller != nil {
+				var err error
+				lhsFiles, err = pt.spill(spiller)
+				return err
+			}
+		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
+	if lhsFiles != nil {
+		return hj.streamSpilled(ctx, vcursor, bindVars, wantfields, lfields, lhsFiles, callback)
+	}
 
 	var sendFields atomic.Bool
 	sendFields.Store(wantfields)
@@ -201,6 +229,168 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 	return nil
 }
 
+// streamSpilled joins the LHS rows spilled to disk with the RHS. The RHS
+// rows are spilled too, to the same partitions as the LHS rows that they
+// can match, and then each partition is joined in memory.
+func (hj *HashJoin) streamSpilled(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, lfields []*querypb.Field, lhsFiles *spillPartitionFiles, callback func(*sqltypes.Result) error) error {
+	rhsFiles, err := vcursor.Spiller().newPartitionFiles("HashJoin", 0)
+	if err != nil {
+		return err
+	}
+	defer rhsFiles.close()
+
+	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	var rfields []*querypb.Field
+	var mu sync.Mutex
+	err = vcursor.StreamExecutePrimitive(ctx, hj.Right, bindVars, wantfields, func(result *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(rfields) == 0 && len(result.Fields) != 0 {
+			rfields = result.Fields
+		}
+		for _, current := range result.Rows {
+			val := current[pt.rhsKey]
+			if val.IsNull() {
+				// NULL never matches, there is no need to spill it.
+				continue
+			}
+			hash, err := pt.hash(val)
+			if err != nil {
+				return err
+			}
+			if err := rhsFiles.write(hash, current); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if wantfields {
+		if len(rfields) == 0 {
+			rres, err := hj.Right.GetFields(ctx, vcursor, bindVars)
+			if err != nil {
+				return err
+			}
+			rfields = rres.Fields
+		}
+		if err := callback(&sqltypes.Result{Fields: joinFields(lfields, rfields, hj.Cols)}); err != nil {
+			return err
+		}
+	}
+
+	return hj.joinPartitions(vcursor, lhsFiles, rhsFiles, callback)
+}
+
+// joinPartitions joins each partition of the LHS rows with the same
+// partition of the RHS rows.
+func (hj *HashJoin) joinPartitions(vcursor VCursor, lhsFiles, rhsFiles *spillPartitionFiles, callback func(*sqltypes.Result) error) error {
+	for i := range lhsFiles.files {
+		if err := hj.joinPartition(vcursor, lhsFiles.files[i], rhsFiles.files[i], lhsFiles.level, callback); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinPartition joins a partition of the LHS rows, that it loads in a probe
+// table, with the same partition of the RHS rows. If the LHS rows exceed the
+// max memory rows, both partitions are split again in the next level.
+func (hj *HashJoin) joinPartition(vcursor VCursor, lhs, rhs *spillFile, level int, callback func(*sqltypes.Result) error) error {
+	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	reader, err := lhs.reader()
+	if err != nil {
+		return err
+	}
+	for {
+		row, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := pt.addLeftRow(row); err != nil {
+			return err
+		}
+		if vcursor.ExceedsMaxMemoryRows(pt.rows) {
+			return hj.splitPartition(vcursor, lhs, rhs, level+1, callback)
+		}
+	}
+	lhs.close()
+
+	reader, err = rhs.reader()
+	if err != nil {
+		return err
+	}
+	var rows []sqltypes.Row
+	for {
+		row, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		results, err := pt.get(row)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, results...)
+		if len(rows) >= spillBatchSize {
+			if err := callback(&sqltypes.Result{Rows: rows}); err != nil {
+				return err
+			}
+			rows = nil
+		}
+	}
+	rhs.close()
+
+	if hj.Opcode == LeftJoin {
+		rows = append(rows, pt.notFetched()...)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return callback(&sqltypes.Result{Rows: rows})
+}
+
+// splitPartition splits a partition of the LHS rows, and the same partition
+// of the RHS rows, in the partitions of the given level, and joins them.
+func (hj *HashJoin) splitPartition(vcursor VCursor, lhs, rhs *spillFile, level int, callback func(*sqltypes.Result) error) error {
+	if level > spillMaxLevel {
+		return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+	}
+	spiller := vcursor.Spiller()
+	lhsFiles, err := spiller.newPartitionFiles("HashJoin", level)
+	if err != nil {
+		return err
+	}
+	defer lhsFiles.close()
+	rhsFiles, err := spiller.newPartitionFiles("HashJoin", level)
+	if err != nil {
+		return err
+	}
+	defer rhsFiles.close()
+
+	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	err = lhsFiles.split(lhs, func(row sqltypes.Row) (vthash.Hash, error) {
+		return pt.hash(row[pt.lhsKey])
+	})
+	if err != nil {
+		return err
+	}
+	err = rhsFiles.split(rhs, func(row sqltypes.Row) (vthash.Hash, error) {
+		return pt.hash(row[pt.rhsKey])
+	})
+	if err != nil {
+		return err
+	}
+	return hj.joinPartitions(vcursor, lhsFiles, rhsFiles, callback)
+}
+
 // RouteType implements the Primitive interface
 func (hj *HashJoin) RouteType() string {
 	return "HashJoin"
@@ -286,6 +476,7 @@ func (pt *hashJoinProbeTable) addLeftRow(r sqltypes.Row) error {
 		row:  r,
 		next: pt.innerMap[hash],
 	}
+	pt.rows++
 
 	return nil
 }
@@ -320,6 +511,25 @@ func (pt *hashJoinProbeTable) get(rrow sqltypes.Row) (result []sqltypes.Row, err
 	return
 }
 
+// spill writes the rows of the probe table to partition files, and empties it.
+func (pt *hashJoinProbeTable) spill(spiller *Spiller) (*spillPartitionFiles, error) {
+	spills.Add("HashJoin", 1)
+	files, err := spiller.newPartitionFiles("HashJoin", 0)
+	if err != nil {
+		return nil, err
+	}
+	for hash, e := range pt.innerMap {
+		for ; e != nil; e = e.next {
+			if err := files.write(hash, e.row); err != nil {
+				return files, err
+			}
+		}
+	}
+	pt.innerMap = map[vthash.Hash]*probeTableEntry{}
+	pt.rows = 0
+	return files, nil
+}
+
 func (pt *hashJoinProbeTable) notFetched() (rows []sqltypes.Row) {
 	for _, e := range pt.innerMap {
 		for ; e != nil; e = e.next {
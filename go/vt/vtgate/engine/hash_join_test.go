@@ -18,8 +18,10 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/collations"
@@ -159,6 +161,15 @@ func TestHashJoinVariations(t *testing.T) {
 			require.NoError(t, err)
 			expectResultAnyOrder(t, r, expected)
 		})
+		t.Run("Spilling "+tc.name, func(t *testing.T) {
+			spiller := withTestSpiller(t, 1)
+			jn.Left = first()
+			jn.Right = last()
+			r, err := wrapStreamExecute(jn, &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			expectResultAnyOrder(t, r, expected)
+			assert.Zero(t, spiller.Used())
+		})
 	}
 }
 
@@ -172,3 +183,59 @@ func typeForOffset(i int) evalengine.Type {
 		panic(i)
 	}
 }
+
+func TestHashJoinSpillSplitsPartitions(t *testing.T) {
+	spiller := withTestSpiller(t, 2)
+
+	fields := sqltypes.MakeTestFields("id", "int64")
+	var lhs, rhs, expected []string
+	for i := range 100 {
+		lhs = append(lhs, fmt.Sprintf("%d", i))
+		if i%2 == 0 {
+			rhs = append(rhs, fmt.Sprintf("%d", i))
+			expected = append(expected, fmt.Sprintf("%d|%d", i, i))
+		}
+	}
+	jn := &HashJoin{
+		Opcode:         InnerJoin,
+		Left:           &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, lhs...)}},
+		Right:          &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, rhs...)}},
+		Cols:           []int{-1, 1},
+		LHSKey:         0,
+		RHSKey:         0,
+		Collation:      collations.CollationBinaryID,
+		ComparisonType: sqltypes.Int64,
+		CollationEnv:   collations.MySQL8(),
+	}
+
+	r, err := wrapStreamExecute(jn, &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+	require.NoError(t, err)
+	expectResultAnyOrder(t, r, sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|id", "int64|int64"), expected...))
+	assert.Zero(t, spiller.Used())
+}
+
+func TestHashJoinSpillSkewedKey(t *testing.T) {
+	spiller := withTestSpiller(t, 2)
+
+	// The rows with the same key cannot be split in smaller partitions.
+	fields := sqltypes.MakeTestFields("id|val", "int64|varchar")
+	jn := &HashJoin{
+		Opcode: InnerJoin,
+		Left: &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields,
+			"1|a", "1|b", "1|c", "1|d", "1|e",
+		)}},
+		Right: &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields,
+			"1|f",
+		)}},
+		Cols:           []int{-1, -2, 2},
+		LHSKey:         0,
+		RHSKey:         0,
+		Collation:      collations.CollationBinaryID,
+		ComparisonType: sqltypes.Int64,
+		CollationEnv:   collations.MySQL8(),
+	}
+
+	_, err := wrapStreamExecute(jn, &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+	require.EqualError(t, err, "in-memory row count exceeded allowed limit of 2")
+	assert.Zero(t, spiller.Used())
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"strconv"
+
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var _ Primitive = (*JSONTable)(nil)
+
+type (
+	// JSONTable is a primitive that evaluates a JSON_TABLE table function in vtgate.
+	// It is used when the document comes from a different route than the one
+	// the rest of the query is sent to, typically on the RHS of a join.
+	JSONTable struct {
+		// JSONTable does not take inputs
+		noInputs
+
+		// JSONTable does not need to work inside a tx
+		noTxNeeded
+
+		// Doc is the expression producing the JSON document
+		Doc evalengine.Expr
+
+		// Path is the row path. One row is produced for every value it matches.
+		Path *JSONTablePath
+
+		// Fields contains the field info for all columns declared in the COLUMNS clause,
+		// in declaration order, including the columns of nested paths
+		Fields []*querypb.Field
+
+		// Cols are the offsets into Fields that this primitive returns
+		Cols []int
+	}
+
+	// JSONTablePath is a path in a JSON_TABLE together with the columns
+	// that are extracted from each of the values it matches
+	JSONTablePath struct {
+		Path    *json.Path
+		Columns []*JSONTableColumn
+		Nested  []*JSONTablePath
+	}
+
+	// JSONTableColumn describes how a single JSON_TABLE column is produced
+	JSONTableColumn struct {
+		// Offset is the position of the column in JSONTable.Fields
+		Offset int
+		Kind   JSONTableColumnKind
+		Path   *json.Path
+
+		OnEmpty JSONTableOnResponse
+		OnError JSONTableOnResponse
+	}
+
+	// JSONTableOnResponse is the ON EMPTY or ON ERROR behaviour of a column
+	JSONTableOnResponse struct {
+		Response JSONTableResponse
+		// Default is the JSON text used when Response is JSONTableDefault
+		Default string
+	}
+
+	// JSONTableResponse is what a column produces when its value is missing or invalid
+	JSONTableResponse int
+
+	// JSONTableColumnKind is the kind of column declared in a JSON_TABLE
+	JSONTableColumnKind int
+)
+
+const (
+	// JSONTablePathColumn is a regular column, extracted using a path
+	JSONTablePathColumn JSONTableColumnKind = iota
+	// JSONTableOrdinality is a FOR ORDINALITY column
+	JSONTableOrdinality
+	// JSONTableExists is an EXISTS PATH column
+	JSONTableExists
+)
+
+const (
+	// JSONTableNull is the default, and produces NULL
+	JSONTableNull JSONTableResponse = iota
+	// JSONTableError fails the query
+	JSONTableError
+	// JSONTableDefault produces the configured default value
+	JSONTableDefault
+)
+
+// RouteType returns a description of the query routing type used by the primitive
+func (jt *JSONTable) RouteType() string {
+	return "JSONTable"
+}
+
+// GetKeyspaceName specifies the Keyspace that this primitive routes to.
+func (jt *JSONTable) GetKeyspaceName() string {
+	return ""
+}
+
+// GetTableName specifies the table that this primitive routes to.
+func (jt *JSONTable) GetTableName() string {
+	return ""
+}
+
+// TryExecute performs a non-streaming exec.
+func (jt *JSONTable) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	rows, err := jt.rows(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{Fields: jt.fields(), Rows: rows}, nil
+}
+
+// TryStreamExecute performs a streaming exec.
+func (jt *JSONTable) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	r, err := jt.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	if err := callback(r.Metadata()); err != nil {
+		return err
+	}
+	return callback(&sqltypes.Result{Rows: r.Rows})
+}
+
+// GetFields fetches the field info.
+func (jt *JSONTable) GetFields(context.Context, VCursor, map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return &sqltypes.Result{Fields: jt.fields()}, nil
+}
+
+func (jt *JSONTable) fields() []*querypb.Field {
+	return slice.Map(jt.Cols, func(col int) *querypb.Field {
+		return jt.Fields[col]
+	})
+}
+
+func (jt *JSONTable) rows(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([][]sqltypes.Value, error) {
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	res, err := env.Evaluate(jt.Doc)
+	if err != nil {
+		return nil, err
+	}
+	value := res.Value(vcursor.ConnCollation())
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	var doc *json.Value
+	switch {
+	case value.Type() == sqltypes.TypeJSON, value.IsText(), value.IsBinary():
+		var p json.Parser
+		doc, err = p.ParseBytes(value.Raw())
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid JSON text in argument 1 to function json_table: %v", err)
+		}
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Incorrect type for argument 1 in function json_table.")
+	}
+
+	template := make([]sqltypes.Value, len(jt.Fields))
+	for i := range template {
+		template[i] = sqltypes.NULL
+	}
+
+	var full [][]sqltypes.Value
+	full, err = jt.Path.appendRows(full, doc, template, jt.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]sqltypes.Value, 0, len(full))
+	for _, row := range full {
+		rows = append(rows, slice.Map(jt.Cols, func(col int) sqltypes.Value {
+			return row[col]
+		}))
+	}
+	return rows, nil
+}
+
+// appendRows produces the rows for all values matched by this path in the context value.
+// Every row starts from a copy of the template, which carries the values produced by the parent paths.
+// Sibling nested paths do not multiply each other - their rows are produced one after the other,
+// with the columns belonging to the other siblings set to NULL.
+func (p *JSONTablePath) appendRows(rows [][]sqltypes.Value, ctx *json.Value, template []sqltypes.Value, fields []*querypb.Field) ([][]sqltypes.Value, error) {
+	var matches []*json.Value
+	p.Path.Match(ctx, true, func(v *json.Value) {
+		matches = append(matches, v)
+	})
+
+	for idx, match := range matches {
+		row := append([]sqltypes.Value(nil), template...)
+		for _, col := range p.Columns {
+			val, err := col.evaluate(match, idx+1, fields[col.Offset])
+			if err != nil {
+				return nil, err
+			}
+			row[col.Offset] = val
+		}
+
+		before := len(rows)
+		for _, nested := range p.Nested {
+			var err error
+			rows, err = nested.appendRows(rows, match, row, fields)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(rows) == before {
+			// no nested path produced anything, so this row stands on its own
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (col *JSONTableColumn) evaluate(ctx *json.Value, ordinality int, field *querypb.Field) (sqltypes.Value, error) {
+	switch col.Kind {
+	case JSONTableOrdinality:
+		return sqltypes.NewValue(field.Type, strconv.AppendInt(nil, int64(ordinality), 10))
+	case JSONTableExists:
+		exists := []byte("0")
+		col.Path.Match(ctx, true, func(*json.Value) {
+			exists = []byte("1")
+		})
+		return sqltypes.NewValue(field.Type, exists)
+	}
+
+	var matches []*json.Value
+	col.Path.Match(ctx, true, func(v *json.Value) {
+		matches = append(matches, v)
+	})
+
+	switch len(matches) {
+	case 0:
+		return col.OnEmpty.respond(field, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Missing value for JSON_TABLE column '%s'", field.Name))
+	case 1:
+		val, err := jsonTableCast(matches[0], field)
+		if err != nil {
+			return col.OnError.respond(field, err)
+		}
+		return val, nil
+	default:
+		return col.OnError.respond(field, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Can't store an array or an object in the scalar column '%s' of JSON_TABLE '%s'", field.Name, field.Table))
+	}
+}
+
+func (r JSONTableOnResponse) respond(field *querypb.Field, err error) (sqltypes.Value, error) {
+	switch r.Response {
+	case JSONTableError:
+		return sqltypes.NULL, err
+	case JSONTableDefault:
+		var p json.Parser
+		def, perr := p.Parse(r.Default)
+		if perr != nil {
+			def = json.NewString(r.Default)
+		}
+		return jsonTableCast(def, field)
+	default:
+		return sqltypes.NULL, nil
+	}
+}
+
+// jsonTableCast converts a JSON value into the type declared for a JSON_TABLE column
+func jsonTableCast(v *json.Value, field *querypb.Field) (sqltypes.Value, error) {
+	if field.Type == sqltypes.TypeJSON {
+		return sqltypes.MakeTrusted(sqltypes.TypeJSON, v.MarshalTo(nil)), nil
+	}
+
+	var raw []byte
+	switch v.Type() {
+	case json.TypeNull:
+		return sqltypes.NULL, nil
+	case json.TypeObject, json.TypeArray:
+		return sqltypes.NULL, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Can't store an array or an object in the scalar column '%s' of JSON_TABLE '%s'", field.Name, field.Table)
+	case json.TypeBoolean:
+		b, _ := v.Bool()
+		switch {
+		case sqltypes.IsNumber(field.Type) && b:
+			raw = []byte("1")
+		case sqltypes.IsNumber(field.Type):
+			raw = []byte("0")
+		default:
+			raw = v.MarshalTo(nil)
+		}
+	case json.TypeNumber:
+		raw = v.MarshalTo(nil)
+	default:
+		raw = v.ToUnencodedBytes()
+	}
+
+	val, err := sqltypes.NewValue(field.Type, raw)
+	if err != nil {
+		return sqltypes.NULL, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid value for JSON_TABLE column '%s': %v", field.Name, err)
+	}
+	return val, nil
+}
+
+func (jt *JSONTable) description() PrimitiveDescription {
+	other := map[string]any{
+		"Doc":     sqlparser.String(jt.Doc),
+		"Path":    jt.Path.Path.String(),
+		"Columns": slice.Map(jt.fields(), func(f *querypb.Field) string { return f.Name }),
+	}
+	return PrimitiveDescription{
+		OperatorType: "JSONTable",
+		Other:        other,
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+func jsonTablePath(t *testing.T, path string) *json.Path {
+	var p json.PathParser
+	jp, err := p.ParseBytes([]byte(path))
+	require.NoError(t, err)
+	return jp
+}
+
+// newTestJSONTable builds the equivalent of:
+//
+//	JSON_TABLE(:doc, '$[*]' COLUMNS(
+//		id FOR ORDINALITY,
+//		a INT PATH '$.a' DEFAULT '0' ON ERROR,
+//		b VARCHAR(10) PATH '$.b' DEFAULT '"none"' ON EMPTY,
+//		has_c INT EXISTS PATH '$.c',
+//		NESTED PATH '$.c[*]' COLUMNS(c INT PATH '$')))
+func newTestJSONTable(t *testing.T) *JSONTable {
+	return &JSONTable{
+		Doc: evalengine.NewBindVar("doc", evalengine.NewType(sqltypes.VarChar, collations.CollationUtf8mb4ID)),
+		Path: &JSONTablePath{
+			Path: jsonTablePath(t, "$[*]"),
+			Columns: []*JSONTableColumn{
+				{Offset: 0, Kind: JSONTableOrdinality},
+				{Offset: 1, Path: jsonTablePath(t, "$.a"), OnError: JSONTableOnResponse{Response: JSONTableDefault, Default: "0"}},
+				{Offset: 2, Path: jsonTablePath(t, "$.b"), OnEmpty: JSONTableOnResponse{Response: JSONTableDefault, Default: `"none"`}},
+				{Offset: 3, Kind: JSONTableExists, Path: jsonTablePath(t, "$.c")},
+			},
+			Nested: []*JSONTablePath{{
+				Path: jsonTablePath(t, "$.c[*]"),
+				Columns: []*JSONTableColumn{
+					{Offset: 4, Path: jsonTablePath(t, "$")},
+				},
+			}},
+		},
+		Fields: []*querypb.Field{
+			{Name: "id", Type: sqltypes.Uint32},
+			{Name: "a", Type: sqltypes.Int32},
+			{Name: "b", Type: sqltypes.VarChar},
+			{Name: "has_c", Type: sqltypes.Int32},
+			{Name: "c", Type: sqltypes.Int32},
+		},
+		Cols: []int{0, 1, 2, 3, 4},
+	}
+}
+
+func TestJSONTableExecute(t *testing.T) {
+	jt := newTestJSONTable(t)
+	bv := map[string]*querypb.BindVariable{
+		"doc": sqltypes.StringBindVariable(`[{"a": 1, "b": "x", "c": [10, 20]}, {"a": "nope", "c": []}, {"b": "y"}]`),
+	}
+
+	result, err := jt.TryExecute(context.Background(), &noopVCursor{}, bv, true)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|a|b|has_c|c",
+			"uint32|int32|varchar|int32|int32",
+		),
+		"1|1|x|1|10",
+		"1|1|x|1|20",
+		"2|0|none|1|null",
+		"3|null|y|0|null",
+	)
+	utils.MustMatch(t, wantResult, result)
+
+	// only a subset of the columns, in a different order
+	jt.Cols = []int{4, 0}
+	result, err = wrapStreamExecute(jt, &noopVCursor{}, bv, true)
+	require.NoError(t, err)
+
+	wantResult = sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"c|id",
+			"int32|uint32",
+		),
+		"10|1",
+		"20|1",
+		"null|2",
+		"null|3",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestJSONTableErrors(t *testing.T) {
+	jt := newTestJSONTable(t)
+	jt.Path.Columns[2].OnEmpty = JSONTableOnResponse{Response: JSONTableError}
+
+	bv := map[string]*querypb.BindVariable{
+		"doc": sqltypes.StringBindVariable(`[{"a": 1}]`),
+	}
+	_, err := jt.TryExecute(context.Background(), &noopVCursor{}, bv, true)
+	require.EqualError(t, err, "Missing value for JSON_TABLE column 'b'")
+
+	bv["doc"] = sqltypes.StringBindVariable(`[{"a": [1, 2]}]`)
+	jt.Path.Columns[1].OnError = JSONTableOnResponse{Response: JSONTableError}
+	_, err = jt.TryExecute(context.Background(), &noopVCursor{}, bv, true)
+	require.ErrorContains(t, err, "Can't store an array or an object in the scalar column 'a' of JSON_TABLE")
+
+	bv["doc"] = sqltypes.StringBindVariable(`[{"a": 1`)
+	_, err = jt.TryExecute(context.Background(), &noopVCursor{}, bv, true)
+	require.ErrorContains(t, err, "Invalid JSON text in argument 1 to function json_table")
+
+	bv["doc"] = sqltypes.NullBindVariable
+	result, err := jt.TryExecute(context.Background(), &noopVCursor{}, bv, true)
+	require.NoError(t, err)
+	require.Empty(t, result.Rows)
+}
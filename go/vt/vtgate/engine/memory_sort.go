@@ -19,6 +19,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"reflect"
 	"strconv"
@@ -98,6 +99,15 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 		Limit:   count,
 	}
 
+	// runs are the sorted runs of rows spilled to disk, when the rows
+	// exceed the max memory rows.
+	var runs []*spillFile
+	defer func() {
+		for _, run := range runs {
+			run.close()
+		}
+	}()
+
 	var mu sync.Mutex
 	err = vcursor.StreamExecutePrimitive(ctx, ms.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
 		mu.Lock()
@@ -111,14 +121,96 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 			sorter.Push(row)
 		}
 		if vcursor.ExceedsMaxMemoryRows(sorter.Len()) {
-			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+			spiller := vcursor.Spiller()
+			if spiller == nil {
+				return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+			}
+			if len(runs) == 0 {
+				spills.Add("MemorySort", 1)
+			}
+			run, err := spiller.newFile("MemorySort")
+			if err != nil {
+				return err
+			}
+			runs = append(runs, run)
+			for _, row := range sorter.Sorted() {
+				if err := run.write(row); err != nil {
+					return err
+				}
+			}
+			sorter = &evalengine.Sorter{
+				Compare: ms.OrderBy,
+				Limit:   count,
+			}
 		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
-	return cb(&sqltypes.Result{Rows: sorter.Sorted()})
+	if len(runs) == 0 {
+		return cb(&sqltypes.Result{Rows: sorter.Sorted()})
+	}
+	return ms.mergeRuns(runs, sorter.Sorted(), count, cb)
+}
+
+// mergeRuns sends up to count rows of the sorted runs spilled to disk and
+// of the sorted rows left in memory, in order.
+func (ms *MemorySort) mergeRuns(runs []*spillFile, rows []sqltypes.Row, count int, callback func(*sqltypes.Result) error) error {
+	merge := &evalengine.Merger{Compare: ms.OrderBy}
+	readers := make([]*spillReader, len(runs))
+	for i, run := range runs {
+		reader, err := run.reader()
+		if err != nil {
+			return err
+		}
+		readers[i] = reader
+		row, err := reader.next()
+		if err != nil {
+			return err
+		}
+		merge.Push(row, i)
+	}
+	// The rows left in memory are the last source of the merge.
+	memory := len(runs)
+	if len(rows) > 0 {
+		merge.Push(rows[0], memory)
+		rows = rows[1:]
+	}
+	merge.Init()
+
+	var batch []sqltypes.Row
+	for merge.Len() > 0 && count > 0 {
+		row, source := merge.Pop()
+		batch = append(batch, row)
+		count--
+		if len(batch) == spillBatchSize {
+			if err := callback(&sqltypes.Result{Rows: batch}); err != nil {
+				return err
+			}
+			batch = nil
+		}
+
+		if source == memory {
+			if len(rows) > 0 {
+				merge.Push(rows[0], memory)
+				rows = rows[1:]
+			}
+			continue
+		}
+		next, err := readers[source].next()
+		switch {
+		case err == io.EOF:
+		case err != nil:
+			return err
+		default:
+			merge.Push(next, source)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return callback(&sqltypes.Result{Rows: batch})
 }
 
 // GetFields satisfies the Primitive interface.
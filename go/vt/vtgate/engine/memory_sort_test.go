@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/collations"
@@ -611,6 +612,66 @@ func TestMemorySortMaxMemoryRows(t *testing.T) {
 	}
 }
 
+func TestMemorySortSpill(t *testing.T) {
+	spiller := withTestSpiller(t, 2)
+
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varbinary|decimal",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|5",
+			"b|2",
+			"c|7",
+			"d|1",
+			"e|null",
+			"f|3",
+			"g|6",
+			"h|4",
+		)},
+	}
+
+	ms := &MemorySort{
+		OrderBy: []evalengine.OrderByParams{{
+			WeightStringCol: -1,
+			Col:             1,
+		}},
+		Input: fp,
+	}
+
+	result, err := wrapStreamExecute(ms, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	expectResult(t, result, sqltypes.MakeTestResult(
+		fields,
+		"e|null",
+		"d|1",
+		"b|2",
+		"f|3",
+		"h|4",
+		"a|5",
+		"g|6",
+		"c|7",
+	))
+	assert.Zero(t, spiller.Used())
+
+	fp.rewind()
+	ms.UpperLimit = evalengine.NewBindVar("__upper_limit", evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID))
+	bv := map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(5)}
+	result, err = wrapStreamExecute(ms, &noopVCursor{}, bv, true)
+	require.NoError(t, err)
+	expectResult(t, result, sqltypes.MakeTestResult(
+		fields,
+		"e|null",
+		"d|1",
+		"b|2",
+		"f|3",
+		"h|4",
+	))
+	assert.Zero(t, spiller.Used())
+}
+
 func TestMemorySortExecuteNoVarChar(t *testing.T) {
 	fields := sqltypes.MakeTestFields(
 		"c1|c2",
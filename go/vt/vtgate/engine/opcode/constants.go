@@ -200,3 +200,85 @@ func (code AggregateOpcode) IsDistinct() bool {
 		return false
 	}
 }
+
+// WindowOpcode is the opcode for window functions evaluated by vtgate.
+type WindowOpcode int
+
+// These constants list the possible window function opcodes.
+const (
+	WindowUnassigned = WindowOpcode(iota)
+	WindowRowNumber
+	WindowRank
+	WindowDenseRank
+	WindowPercentRank
+	WindowCumeDist
+	WindowNtile
+	WindowLag
+	WindowLead
+	WindowFirstValue
+	WindowLastValue
+	WindowNthValue
+	// WindowAggregate is used for aggregate functions used with an OVER clause.
+	// The aggregation itself is described by an AggregateOpcode
+	WindowAggregate
+	_NumOfWindowOpCodes // This line must be last of the opcodes!
+)
+
+var WindowName = map[WindowOpcode]string{
+	WindowRowNumber:   "row_number",
+	WindowRank:        "rank",
+	WindowDenseRank:   "dense_rank",
+	WindowPercentRank: "percent_rank",
+	WindowCumeDist:    "cume_dist",
+	WindowNtile:       "ntile",
+	WindowLag:         "lag",
+	WindowLead:        "lead",
+	WindowFirstValue:  "first_value",
+	WindowLastValue:   "last_value",
+	WindowNthValue:    "nth_value",
+	WindowAggregate:   "aggregate",
+}
+
+func (code WindowOpcode) String() string {
+	name := WindowName[code]
+	if name == "" {
+		name = "ERROR"
+	}
+	return name
+}
+
+// MarshalJSON serializes the WindowOpcode as a JSON string.
+// It's used for testing and diagnostics.
+func (code WindowOpcode) MarshalJSON() ([]byte, error) {
+	return ([]byte)(fmt.Sprintf("\"%s\"", code.String())), nil
+}
+
+// SQLType returns the type produced by the window function, given the type of its argument
+func (code WindowOpcode) SQLType(typ querypb.Type) querypb.Type {
+	switch code {
+	case WindowUnassigned:
+		return sqltypes.Null
+	case WindowRowNumber, WindowRank, WindowDenseRank, WindowNtile:
+		return sqltypes.Uint64
+	case WindowPercentRank, WindowCumeDist:
+		return sqltypes.Float64
+	case WindowLag, WindowLead, WindowFirstValue, WindowLastValue, WindowNthValue:
+		return typ
+	case WindowAggregate:
+		// the type depends on the aggregation used, and is resolved with the AggregateOpcode
+		return typ
+	default:
+		panic(code.String()) // we have a unit test checking we never reach here
+	}
+}
+
+// IsFrameAware returns true if the result of the window function depends on the window frame.
+// Ranking and navigation functions like LAG and LEAD always work on the whole partition.
+func (code WindowOpcode) IsFrameAware() bool {
+	switch code {
+	case WindowFirstValue, WindowLastValue, WindowNthValue, WindowAggregate:
+		return true
+	default:
+		return false
+	}
+}
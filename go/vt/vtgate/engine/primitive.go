@@ -56,6 +56,11 @@ type (
 		// if the max memory rows override directive is set to true
 		ExceedsMaxMemoryRows(numRows int) bool
 
+		// Spiller returns the Spiller that the primitives use to write
+		// their intermediate results to disk when they exceed the max
+		// memory rows, or nil if spilling is disabled.
+		Spiller() *Spiller
+
 		Execute(ctx context.Context, method string, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error)
 		AutocommitApproval() bool
 
@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"sync/atomic"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vthash"
+)
+
+// spillPartitions is the number of partitions that the hash based primitives
+// split their input in when they spill it to disk.
+const spillPartitions = 16
+
+// spillMaxLevel is the number of times that a spilled partition which still
+// exceeds the max memory rows is split again, by another byte of the hash
+// codes of its rows, before failing with the max memory rows error.
+const spillMaxLevel = 3
+
+// spillBatchSize is the number of rows read back from disk that are sent
+// in a single result.
+const spillBatchSize = 1000
+
+var (
+	spillBytes = stats.NewCountersWithSingleLabel("VtgateSpillBytes", "Bytes of intermediate results spilled to disk", "Operator")
+	spills     = stats.NewCountersWithSingleLabel("VtgateSpills", "Number of queries that spilled intermediate results to disk", "Operator")
+	spillUsage = stats.NewGauge("VtgateSpillDiskUsage", "Bytes of intermediate results currently spilled to disk")
+)
+
+// Spiller lets the primitives that hold full result sets in memory write
+// them to temporary files when they exceed the max memory rows. All the
+// queries of a vtgate share its disk quota.
+type Spiller struct {
+	dir   string
+	quota int64
+	used  atomic.Int64
+}
+
+// NewSpiller returns a Spiller that writes to dir, or to the default
+// directory for temporary files if it is empty. It returns nil, which
+// disables spilling, if quota is not positive.
+func NewSpiller(dir string, quota int64) *Spiller {
+	if quota <= 0 {
+		return nil
+	}
+	return &Spiller{dir: dir, quota: quota}
+}
+
+// Used returns the number of bytes currently spilled to disk.
+func (s *Spiller) Used() int64 {
+	return s.used.Load()
+}
+
+func (s *Spiller) reserve(n int64) error {
+	if s.used.Add(n) > s.quota {
+		s.used.Add(-n)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "spill disk quota of %d bytes exceeded", s.quota)
+	}
+	spillUsage.Add(n)
+	return nil
+}
+
+func (s *Spiller) release(n int64) {
+	s.used.Add(-n)
+	spillUsage.Add(-n)
+}
+
+// spillFile is a temporary file of rows. It is removed as soon as it is
+// created, so that it does not outlive the vtgate process.
+type spillFile struct {
+	spiller  *Spiller
+	operator string
+
+	f    *os.File
+	w    *bufio.Writer
+	buf  []byte
+	size int64
+}
+
+func (s *Spiller) newFile(operator string) (*spillFile, error) {
+	f, err := os.CreateTemp(s.dir, "vtgate-spill-")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(f.Name()); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &spillFile{
+		spiller:  s,
+		operator: operator,
+		f:        f,
+		w:        bufio.NewWriter(f),
+	}, nil
+}
+
+// write appends a row to the file. Each value is written as its type and
+// its length, followed by its raw bytes.
+func (sf *spillFile) write(row sqltypes.Row) error {
+	buf := binary.AppendUvarint(sf.buf[:0], uint64(len(row)))
+	for _, val := range row {
+		buf = binary.AppendUvarint(buf, uint64(val.Type()))
+		buf = binary.AppendUvarint(buf, uint64(len(val.Raw())))
+		buf = append(buf, val.Raw()...)
+	}
+	sf.buf = buf
+	if err := sf.spiller.reserve(int64(len(buf))); err != nil {
+		return err
+	}
+	sf.size += int64(len(buf))
+	spillBytes.Add(sf.operator, int64(len(buf)))
+	_, err := sf.w.Write(buf)
+	return err
+}
+
+// reader flushes the file, and returns a reader of its rows from the start.
+func (sf *spillFile) reader() (*spillReader, error) {
+	if err := sf.w.Flush(); err != nil {
+		return nil, err
+	}
+	if _, err := sf.f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return &spillReader{r: bufio.NewReader(sf.f)}, nil
+}
+
+// close closes the file, and releases its space from the disk quota.
+func (sf *spillFile) close() {
+	sf.f.Close()
+	sf.spiller.release(sf.size)
+	sf.size = 0
+}
+
+type spillReader struct {
+	r *bufio.Reader
+}
+
+// next returns the next row of the file, or io.EOF when there is none.
+func (sr *spillReader) next() (sqltypes.Row, error) {
+	n, err := binary.ReadUvarint(sr.r)
+	if err != nil {
+		return nil, err
+	}
+	row := make(sqltypes.Row, n)
+	for i := range row {
+		typ, err := binary.ReadUvarint(sr.r)
+		if err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		size, err := binary.ReadUvarint(sr.r)
+		if err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(sr.r, raw); err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		row[i] = sqltypes.MakeTrusted(querypb.Type(typ), raw)
+	}
+	return row, nil
+}
+
+// spillPartitionFiles are the files that a hash based primitive splits its
+// spilled rows in, by the hash code of their key. The rows of a partition
+// that does not fit in memory are split again in the partitions of the next
+// level, which use another byte of the hash code.
+type spillPartitionFiles struct {
+	level int
+	files []*spillFile
+}
+
+func (s *Spiller) newPartitionFiles(operator string, level int) (*spillPartitionFiles, error) {
+	files := &spillPartitionFiles{
+		level: level,
+		files: make([]*spillFile, 0, spillPartitions),
+	}
+	for range spillPartitions {
+		sf, err := s.newFile(operator)
+		if err != nil {
+			files.close()
+			return nil, err
+		}
+		files.files = append(files.files, sf)
+	}
+	return files, nil
+}
+
+func (files *spillPartitionFiles) write(hash vthash.Hash, row sqltypes.Row) error {
+	return files.files[int(hash[files.level])%len(files.files)].write(row)
+}
+
+// split writes the rows of file to the partitions, and closes it.
+func (files *spillPartitionFiles) split(file *spillFile, hash func(sqltypes.Row) (vthash.Hash, error)) error {
+	reader, err := file.reader()
+	if err != nil {
+		return err
+	}
+	for {
+		row, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		code, err := hash(row)
+		if err != nil {
+			return err
+		}
+		if err := files.write(code, row); err != nil {
+			return err
+		}
+	}
+	file.close()
+	return nil
+}
+
+func (files *spillPartitionFiles) close() {
+	if files == nil {
+		return
+	}
+	for _, sf := range files.files {
+		sf.close()
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// withTestSpiller makes the test vcursors spill to a temporary directory
+// when they hold more than maxMemoryRows rows.
+func withTestSpiller(t *testing.T, maxMemoryRows int) *Spiller {
+	saveMax, saveSpiller := testMaxMemoryRows, testSpiller
+	testMaxMemoryRows = maxMemoryRows
+	testSpiller = NewSpiller(t.TempDir(), 1<<20)
+	t.Cleanup(func() {
+		testMaxMemoryRows, testSpiller = saveMax, saveSpiller
+	})
+	return testSpiller
+}
+
+func TestSpillFile(t *testing.T) {
+	spiller := NewSpiller(t.TempDir(), 1<<20)
+	sf, err := spiller.newFile("test")
+	require.NoError(t, err)
+
+	rows := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("a|b|c|d", "int64|varchar|decimal|varbinary"),
+		"1|foo|1.5|",
+		"-2|null|null|bar",
+	).Rows
+	rows = append(rows, sqltypes.Row{})
+	for _, row := range rows {
+		require.NoError(t, sf.write(row))
+	}
+	assert.Positive(t, spiller.Used())
+
+	reader, err := sf.reader()
+	require.NoError(t, err)
+	for _, want := range rows {
+		row, err := reader.next()
+		require.NoError(t, err)
+		assert.Equal(t, want, row)
+	}
+	_, err = reader.next()
+	assert.Equal(t, io.EOF, err)
+
+	sf.close()
+	assert.Zero(t, spiller.Used())
+}
+
+func TestSpillerQuota(t *testing.T) {
+	assert.Nil(t, NewSpiller("", 0))
+
+	spiller := NewSpiller(t.TempDir(), 20)
+	sf, err := spiller.newFile("test")
+	require.NoError(t, err)
+	defer sf.close()
+
+	row := sqltypes.Row{sqltypes.NewVarChar("0123456789")}
+	require.NoError(t, sf.write(row))
+	err = sf.write(row)
+	require.ErrorContains(t, err, "spill disk quota of 20 bytes exceeded")
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.EqualValues(t, 14, spiller.Used())
+}
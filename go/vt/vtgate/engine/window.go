@@ -0,0 +1,639 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var _ Primitive = (*Window)(nil)
+
+type (
+	// Window is a primitive that evaluates window functions in vtgate.
+	// It expects the rows coming from its input to be sorted by the partitioning keys,
+	// followed by the ordering keys of the window. A single partition is buffered
+	// in memory at a time.
+	Window struct {
+		// PartitionBy are the keys that split the input into partitions
+		PartitionBy []*GroupByParams
+
+		// OrderBy are the keys used to decide which rows in a partition are peers.
+		// The input must already be sorted by these keys.
+		OrderBy []*GroupByParams
+
+		// Functions are the window functions evaluated over every partition
+		Functions []*WindowFunc
+
+		// Cols defines the output columns. A non-negative value is an offset into the
+		// input row, and a negative value -n is the result of Functions[n-1]
+		Cols []int
+
+		Input Primitive
+	}
+
+	// WindowFunc describes a single window function evaluated by the Window primitive
+	WindowFunc struct {
+		Opcode opcode.WindowOpcode
+
+		// AggrOpcode is the aggregation to use when Opcode is WindowAggregate
+		AggrOpcode opcode.AggregateOpcode
+
+		// Col is the offset of the argument of the function, or -1 if it has none
+		Col int
+
+		// N is the constant argument used by NTILE, LAG, LEAD and NTH_VALUE
+		N int64
+
+		// DefaultCol is the offset of the default value of LAG and LEAD, or -1 if there is none
+		DefaultCol int
+
+		// Frame is the window frame used by frame aware functions.
+		// When nil, the default MySQL frame is used
+		Frame *WindowFrame
+
+		Type         evalengine.Type
+		CollationEnv *collations.Environment
+		Alias        string
+	}
+
+	// WindowFrame describes the set of rows in a partition that a frame aware window function works on
+	WindowFrame struct {
+		// Rows is true for ROWS frames. Otherwise, the frame is a RANGE frame,
+		// where CURRENT ROW includes all the peers of the current row
+		Rows       bool
+		Start, End WindowFrameBound
+	}
+
+	// WindowFrameBound is the start or end of a WindowFrame
+	WindowFrameBound struct {
+		Type WindowFrameBoundType
+		// Offset is used by N PRECEDING and N FOLLOWING bounds
+		Offset int64
+	}
+
+	// WindowFrameBoundType is the type of WindowFrameBound
+	WindowFrameBoundType int
+
+	// windowPeers keeps track of which rows in a partition are peers of each other
+	windowPeers struct {
+		// group is the peer group of every row in the partition
+		group []int
+		// start and end are the offsets of the first and last row of every peer group
+		start, end []int
+	}
+)
+
+const (
+	FrameUnboundedPreceding WindowFrameBoundType = iota
+	FramePreceding
+	FrameCurrentRow
+	FrameFollowing
+	FrameUnboundedFollowing
+)
+
+// RouteType implements the Primitive interface
+func (w *Window) RouteType() string {
+	return w.Input.RouteType()
+}
+
+// GetKeyspaceName implements the Primitive interface
+func (w *Window) GetKeyspaceName() string {
+	return w.Input.GetKeyspaceName()
+}
+
+// GetTableName implements the Primitive interface
+func (w *Window) GetTableName() string {
+	return w.Input.GetTableName()
+}
+
+// TryExecute implements the Primitive interface
+func (w *Window) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool) (*sqltypes.Result, error) {
+	input, err := vcursor.ExecutePrimitive(
+		ctx,
+		w.Input,
+		bindVars,
+		true, /*wantFields - we need the input fields types to correctly calculate the output types*/
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &sqltypes.Result{
+		Fields: w.fields(input.Fields),
+		Rows:   make([]sqltypes.Row, 0, len(input.Rows)),
+	}
+
+	var partition []sqltypes.Row
+	for _, row := range input.Rows {
+		if len(partition) > 0 {
+			same, err := sameKeys(w.PartitionBy, partition[0], row)
+			if err != nil {
+				return nil, err
+			}
+			if !same {
+				rows, err := w.evaluatePartition(partition, input.Fields)
+				if err != nil {
+					return nil, err
+				}
+				out.Rows = append(out.Rows, rows...)
+				partition = nil
+			}
+		}
+		partition = append(partition, row)
+		if vcursor.ExceedsMaxMemoryRows(len(partition)) {
+			return nil, fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+		}
+	}
+
+	if len(partition) > 0 {
+		rows, err := w.evaluatePartition(partition, input.Fields)
+		if err != nil {
+			return nil, err
+		}
+		out.Rows = append(out.Rows, rows...)
+	}
+
+	return out, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (w *Window) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
+	var mu sync.Mutex
+	var fields []*querypb.Field
+	var partition []sqltypes.Row
+
+	flush := func() error {
+		rows, err := w.evaluatePartition(partition, fields)
+		if err != nil {
+			return err
+		}
+		partition = nil
+		return callback(&sqltypes.Result{Rows: rows})
+	}
+
+	visitor := func(qr *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if fields == nil && len(qr.Fields) > 0 {
+			fields = qr.Fields
+			if err := callback(&sqltypes.Result{Fields: w.fields(fields)}); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range qr.Rows {
+			if len(partition) > 0 {
+				same, err := sameKeys(w.PartitionBy, partition[0], row)
+				if err != nil {
+					return err
+				}
+				if !same {
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+			}
+			partition = append(partition, row)
+			if vcursor.ExceedsMaxMemoryRows(len(partition)) {
+				return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+			}
+		}
+		return nil
+	}
+
+	/* we need the input fields types to correctly calculate the output types */
+	err := vcursor.StreamExecutePrimitive(ctx, w.Input, bindVars, true, visitor)
+	if err != nil {
+		return err
+	}
+
+	if len(partition) > 0 {
+		return flush()
+	}
+	return nil
+}
+
+// GetFields implements the Primitive interface
+func (w *Window) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	qr, err := w.Input.GetFields(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{Fields: w.fields(qr.Fields)}, nil
+}
+
+// NeedsTransaction implements the Primitive interface
+func (w *Window) NeedsTransaction() bool {
+	return w.Input.NeedsTransaction()
+}
+
+// Inputs implements the Primitive interface
+func (w *Window) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{w.Input}, nil
+}
+
+func (w *Window) description() PrimitiveDescription {
+	other := map[string]any{
+		"Functions": GenericJoin(w.Functions, func(i any) string { return i.(*WindowFunc).String() }),
+		"Columns":   strings.Trim(strings.Join(strings.Fields(fmt.Sprint(w.Cols)), ","), "[]"),
+	}
+	if len(w.PartitionBy) > 0 {
+		other["PartitionBy"] = GenericJoin(w.PartitionBy, groupByParamsToString)
+	}
+	if len(w.OrderBy) > 0 {
+		other["OrderBy"] = GenericJoin(w.OrderBy, groupByParamsToString)
+	}
+	return PrimitiveDescription{
+		OperatorType: "Window",
+		Other:        other,
+	}
+}
+
+func (w *Window) fields(input []*querypb.Field) []*querypb.Field {
+	if input == nil {
+		return nil
+	}
+	fields := make([]*querypb.Field, 0, len(w.Cols))
+	for _, col := range w.Cols {
+		if col >= 0 {
+			fields = append(fields, input[col])
+			continue
+		}
+		fields = append(fields, w.Functions[-col-1].field(input))
+	}
+	return fields
+}
+
+func (w *Window) evaluatePartition(partition []sqltypes.Row, fields []*querypb.Field) ([]sqltypes.Row, error) {
+	peers, err := findPeers(w.OrderBy, partition)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]sqltypes.Value, len(w.Functions))
+	for i, fn := range w.Functions {
+		results[i], err = fn.evaluate(partition, peers, fields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]sqltypes.Row, 0, len(partition))
+	for r, row := range partition {
+		outRow := make(sqltypes.Row, len(w.Cols))
+		for c, col := range w.Cols {
+			if col >= 0 {
+				outRow[c] = row[col]
+			} else {
+				outRow[c] = results[-col-1][r]
+			}
+		}
+		out = append(out, outRow)
+	}
+	return out, nil
+}
+
+// findPeers groups the rows of a partition into groups of rows that are equal according to the ORDER BY keys.
+// Without ORDER BY keys, all the rows in the partition are peers.
+func findPeers(orderBy []*GroupByParams, partition []sqltypes.Row) (*windowPeers, error) {
+	peers := &windowPeers{
+		group: make([]int, len(partition)),
+	}
+	for i, row := range partition {
+		newGroup := i == 0
+		if !newGroup {
+			same, err := sameKeys(orderBy, partition[peers.start[len(peers.start)-1]], row)
+			if err != nil {
+				return nil, err
+			}
+			newGroup = !same
+		}
+		if newGroup {
+			if i > 0 {
+				peers.end = append(peers.end, i-1)
+			}
+			peers.start = append(peers.start, i)
+		}
+		peers.group[i] = len(peers.start) - 1
+	}
+	if len(partition) > 0 {
+		peers.end = append(peers.end, len(partition)-1)
+	}
+	return peers, nil
+}
+
+// sameKeys returns true if both rows have the same values for all the keys
+func sameKeys(keys []*GroupByParams, a, b sqltypes.Row) (bool, error) {
+	for _, key := range keys {
+		v1, v2 := a[key.KeyCol], b[key.KeyCol]
+		if v1.TinyWeightCmp(v2) != 0 {
+			return false, nil
+		}
+		cmp, err := evalengine.NullsafeCompare(v1, v2, key.CollationEnv, key.Type.Collation(), key.Type.Values())
+		if err != nil {
+			_, isCollationErr := err.(evalengine.UnsupportedCollationError)
+			if !isCollationErr || key.WeightStringCol == -1 {
+				return false, err
+			}
+			cmp, err = evalengine.NullsafeCompare(a[key.WeightStringCol], b[key.WeightStringCol], key.CollationEnv, key.Type.Collation(), key.Type.Values())
+			if err != nil {
+				return false, err
+			}
+		}
+		if cmp != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (wf *WindowFunc) inputType(fields []*querypb.Field) querypb.Type {
+	if wf.Col < 0 || wf.Col >= len(fields) {
+		return sqltypes.Null
+	}
+	return fields[wf.Col].Type
+}
+
+func (wf *WindowFunc) field(input []*querypb.Field) *querypb.Field {
+	inputType := wf.inputType(input)
+	if wf.Opcode == opcode.WindowAggregate {
+		return &querypb.Field{Name: wf.Alias, Type: wf.AggrOpcode.SQLType(inputType)}
+	}
+	if wf.Col >= 0 && wf.Opcode.SQLType(inputType) == inputType {
+		// functions returning values from other rows use the same field as their argument
+		field := input[wf.Col].CloneVT()
+		field.Name = wf.Alias
+		return field
+	}
+	return &querypb.Field{Name: wf.Alias, Type: wf.Opcode.SQLType(inputType)}
+}
+
+func (wf *WindowFunc) evaluate(partition []sqltypes.Row, peers *windowPeers, fields []*querypb.Field) ([]sqltypes.Value, error) {
+	n := len(partition)
+	result := make([]sqltypes.Value, n)
+
+	switch wf.Opcode {
+	case opcode.WindowRowNumber:
+		for i := range partition {
+			result[i] = sqltypes.NewUint64(uint64(i + 1))
+		}
+	case opcode.WindowRank:
+		for i := range partition {
+			result[i] = sqltypes.NewUint64(uint64(peers.start[peers.group[i]] + 1))
+		}
+	case opcode.WindowDenseRank:
+		for i := range partition {
+			result[i] = sqltypes.NewUint64(uint64(peers.group[i] + 1))
+		}
+	case opcode.WindowPercentRank:
+		for i := range partition {
+			if n == 1 {
+				result[i] = sqltypes.NewFloat64(0)
+				continue
+			}
+			rank := peers.start[peers.group[i]]
+			result[i] = sqltypes.NewFloat64(float64(rank) / float64(n-1))
+		}
+	case opcode.WindowCumeDist:
+		for i := range partition {
+			last := peers.end[peers.group[i]]
+			result[i] = sqltypes.NewFloat64(float64(last+1) / float64(n))
+		}
+	case opcode.WindowNtile:
+		if wf.N <= 0 {
+			return nil, vterrors.VT03025("ntile")
+		}
+		buckets := int(wf.N)
+		size, rest := n/buckets, n%buckets
+		for i := range partition {
+			// the first `rest` buckets get one extra row each
+			var bucket int
+			if i < rest*(size+1) {
+				bucket = i / (size + 1)
+			} else {
+				bucket = rest + (i-rest*(size+1))/size
+			}
+			result[i] = sqltypes.NewUint64(uint64(bucket + 1))
+		}
+	case opcode.WindowLag, opcode.WindowLead:
+		offset := int(wf.N)
+		if wf.Opcode == opcode.WindowLag {
+			offset = -offset
+		}
+		for i, row := range partition {
+			j := i + offset
+			switch {
+			case j >= 0 && j < n:
+				result[i] = partition[j][wf.Col]
+			case wf.DefaultCol >= 0:
+				result[i] = row[wf.DefaultCol]
+			default:
+				result[i] = sqltypes.NULL
+			}
+		}
+	case opcode.WindowFirstValue, opcode.WindowLastValue, opcode.WindowNthValue:
+		for i := range partition {
+			start, end := wf.frame(i, n, peers)
+			var pick int
+			switch wf.Opcode {
+			case opcode.WindowFirstValue:
+				pick = start
+			case opcode.WindowLastValue:
+				pick = end
+			default:
+				pick = start + int(wf.N) - 1
+			}
+			if start > end || pick > end {
+				result[i] = sqltypes.NULL
+				continue
+			}
+			result[i] = partition[pick][wf.Col]
+		}
+	case opcode.WindowAggregate:
+		return wf.evaluateAggregate(partition, peers, fields)
+	default:
+		return nil, vterrors.VT13001(fmt.Sprintf("unexpected window function opcode: %s", wf.Opcode.String()))
+	}
+	return result, nil
+}
+
+// evaluateAggregate calculates the aggregation over the frame of every row in the partition.
+// As long as the start of the frame stays the same and the end of the frame keeps moving forward,
+// the aggregation is calculated incrementally.
+func (wf *WindowFunc) evaluateAggregate(partition []sqltypes.Row, peers *windowPeers, fields []*querypb.Field) ([]sqltypes.Value, error) {
+	agg, err := wf.newAggregator(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(partition)
+	result := make([]sqltypes.Value, n)
+	aggStart, aggEnd := 0, -1
+	for i := range partition {
+		start, end := wf.frame(i, n, peers)
+		if start != aggStart || end < aggEnd {
+			agg.reset()
+			aggStart, aggEnd = start, start-1
+		}
+		for ; aggEnd < end; aggEnd++ {
+			if err := agg.add(partition[aggEnd+1]); err != nil {
+				return nil, err
+			}
+		}
+		result[i] = agg.finish()
+	}
+	return result, nil
+}
+
+func (wf *WindowFunc) newAggregator(fields []*querypb.Field) (aggregator, error) {
+	noDistinct := aggregatorDistinct{column: -1}
+	sourceType := wf.inputType(fields)
+	switch wf.AggrOpcode {
+	case opcode.AggregateCountStar:
+		return &aggregatorCountStar{}, nil
+	case opcode.AggregateCount:
+		return &aggregatorCount{from: wf.Col, distinct: noDistinct}, nil
+	case opcode.AggregateSum:
+		return &aggregatorSum{from: wf.Col, sum: evalengine.NewAggregationSum(sourceType), distinct: noDistinct}, nil
+	case opcode.AggregateMin:
+		return &aggregatorMin{
+			aggregatorMinMax{
+				from:   wf.Col,
+				minmax: evalengine.NewAggregationMinMax(sourceType, wf.CollationEnv, wf.Type.Collation(), wf.Type.Values()),
+			},
+		}, nil
+	case opcode.AggregateMax:
+		return &aggregatorMax{
+			aggregatorMinMax{
+				from:   wf.Col,
+				minmax: evalengine.NewAggregationMinMax(sourceType, wf.CollationEnv, wf.Type.Collation(), wf.Type.Values()),
+			},
+		}, nil
+	default:
+		return nil, vterrors.VT12001(fmt.Sprintf("window aggregation '%s' in vtgate", wf.AggrOpcode.String()))
+	}
+}
+
+// frame returns the first and last offset of the rows in the frame of the given row.
+// If the frame is empty, start will be larger than end.
+func (wf *WindowFunc) frame(row, n int, peers *windowPeers) (start, end int) {
+	if wf.Frame == nil {
+		// the default frame goes from the start of the partition to the last peer of the current row.
+		// without ORDER BY, all the rows in the partition are peers
+		return 0, peers.end[peers.group[row]]
+	}
+
+	start = wf.Frame.Start.position(row, n, wf.Frame.Rows, peers, true)
+	end = wf.Frame.End.position(row, n, wf.Frame.Rows, peers, false)
+	return max(start, 0), min(end, n-1)
+}
+
+func (b WindowFrameBound) position(row, n int, rows bool, peers *windowPeers, isStart bool) int {
+	switch b.Type {
+	case FrameUnboundedPreceding:
+		if isStart {
+			return 0
+		}
+		return -1
+	case FrameUnboundedFollowing:
+		if isStart {
+			return n
+		}
+		return n - 1
+	case FramePreceding:
+		return row - int(b.Offset)
+	case FrameFollowing:
+		return row + int(b.Offset)
+	default:
+		if rows {
+			return row
+		}
+		if isStart {
+			return peers.start[peers.group[row]]
+		}
+		return peers.end[peers.group[row]]
+	}
+}
+
+func (b WindowFrameBound) String() string {
+	switch b.Type {
+	case FrameUnboundedPreceding:
+		return "unbounded preceding"
+	case FramePreceding:
+		return strconv.FormatInt(b.Offset, 10) + " preceding"
+	case FrameFollowing:
+		return strconv.FormatInt(b.Offset, 10) + " following"
+	case FrameUnboundedFollowing:
+		return "unbounded following"
+	default:
+		return "current row"
+	}
+}
+
+func (f *WindowFrame) String() string {
+	unit := "range"
+	if f.Rows {
+		unit = "rows"
+	}
+	return fmt.Sprintf("%s between %s and %s", unit, f.Start.String(), f.End.String())
+}
+
+// String returns a string. Used for plan descriptions
+func (wf *WindowFunc) String() string {
+	var out string
+	switch wf.Opcode {
+	case opcode.WindowAggregate:
+		arg := "*"
+		if wf.Col >= 0 {
+			arg = strconv.Itoa(wf.Col)
+		}
+		out = fmt.Sprintf("%s(%s)", wf.AggrOpcode.String(), arg)
+	case opcode.WindowNtile:
+		out = fmt.Sprintf("ntile(%d)", wf.N)
+	case opcode.WindowLag, opcode.WindowLead:
+		out = fmt.Sprintf("%s(%d, %d", wf.Opcode.String(), wf.Col, wf.N)
+		if wf.DefaultCol >= 0 {
+			out += fmt.Sprintf(", %d", wf.DefaultCol)
+		}
+		out += ")"
+	case opcode.WindowNthValue:
+		out = fmt.Sprintf("nth_value(%d, %d)", wf.Col, wf.N)
+	case opcode.WindowFirstValue, opcode.WindowLastValue:
+		out = fmt.Sprintf("%s(%d)", wf.Opcode.String(), wf.Col)
+	default:
+		out = wf.Opcode.String() + "()"
+	}
+	if wf.Frame != nil {
+		out += " " + wf.Frame.String()
+	}
+	if wf.Alias != "" {
+		out += " AS " + wf.Alias
+	}
+	return out
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+func windowTestInput() *fakePrimitive {
+	return &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"a|b|c",
+				"varchar|int64|int64",
+			),
+			"a|1|10",
+			"a|2|20",
+			"a|2|30",
+			"b|1|5",
+		)},
+	}
+}
+
+func windowPartitionKey() *GroupByParams {
+	return &GroupByParams{
+		KeyCol:          0,
+		WeightStringCol: -1,
+		Type:            evalengine.NewType(sqltypes.VarChar, collations.CollationUtf8mb4ID),
+		CollationEnv:    collations.MySQL8(),
+	}
+}
+
+func TestWindowExecute(t *testing.T) {
+	w := &Window{
+		PartitionBy: []*GroupByParams{windowPartitionKey()},
+		OrderBy:     []*GroupByParams{{KeyCol: 1, WeightStringCol: -1}},
+		Functions: []*WindowFunc{
+			{Opcode: WindowRowNumber, Col: -1, DefaultCol: -1, Alias: "row_number"},
+			{Opcode: WindowRank, Col: -1, DefaultCol: -1, Alias: "rank"},
+			{Opcode: WindowDenseRank, Col: -1, DefaultCol: -1, Alias: "dense_rank"},
+			{Opcode: WindowAggregate, AggrOpcode: AggregateSum, Col: 2, DefaultCol: -1, Alias: "sum", CollationEnv: collations.MySQL8()},
+			{Opcode: WindowLag, Col: 2, N: 1, DefaultCol: -1, Alias: "lag"},
+		},
+		Cols:  []int{0, 1, -1, -2, -3, -4, -5},
+		Input: windowTestInput(),
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"a|b|row_number|rank|dense_rank|sum|lag",
+			"varchar|int64|uint64|uint64|uint64|decimal|int64",
+		),
+		"a|1|1|1|1|10|null",
+		"a|2|2|2|2|60|10",
+		"a|2|3|2|2|60|20",
+		"b|1|1|1|1|5|null",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestWindowFrames(t *testing.T) {
+	w := &Window{
+		PartitionBy: []*GroupByParams{windowPartitionKey()},
+		OrderBy:     []*GroupByParams{{KeyCol: 1, WeightStringCol: -1}},
+		Functions: []*WindowFunc{
+			{
+				Opcode:     WindowAggregate,
+				AggrOpcode: AggregateSum,
+				Col:        2,
+				DefaultCol: -1,
+				Frame: &WindowFrame{
+					Rows:  true,
+					Start: WindowFrameBound{Type: FramePreceding, Offset: 1},
+					End:   WindowFrameBound{Type: FrameCurrentRow},
+				},
+				Alias:        "moving_sum",
+				CollationEnv: collations.MySQL8(),
+			},
+			{
+				Opcode:     WindowLastValue,
+				Col:        2,
+				DefaultCol: -1,
+				Frame: &WindowFrame{
+					Start: WindowFrameBound{Type: FrameUnboundedPreceding},
+					End:   WindowFrameBound{Type: FrameUnboundedFollowing},
+				},
+				Alias: "last",
+			},
+			{Opcode: WindowNtile, Col: -1, N: 2, DefaultCol: -1, Alias: "ntile"},
+		},
+		Cols:  []int{0, -1, -2, -3},
+		Input: windowTestInput(),
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"a|moving_sum|last|ntile",
+			"varchar|decimal|int64|uint64",
+		),
+		"a|10|30|1",
+		"a|30|30|1",
+		"a|50|30|2",
+		"b|5|5|1",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestWindowStreamExecute(t *testing.T) {
+	w := &Window{
+		PartitionBy: []*GroupByParams{windowPartitionKey()},
+		Functions: []*WindowFunc{
+			{Opcode: WindowAggregate, AggrOpcode: AggregateCountStar, Col: -1, DefaultCol: -1, Alias: "count(*)"},
+		},
+		Cols:  []int{0, 2, -1},
+		Input: windowTestInput(),
+	}
+
+	result, err := wrapStreamExecute(w, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"a|c|count(*)",
+			"varchar|int64|int64",
+		),
+		"a|10|3",
+		"a|20|3",
+		"a|30|3",
+		"b|5|1",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestWindowMaxMemoryRows(t *testing.T) {
+	saveMax := testMaxMemoryRows
+	defer func() {
+		testMaxMemoryRows = saveMax
+	}()
+
+	testCases := []struct {
+		maxMemoryRows int
+		err           string
+	}{
+		// Only the rows of a partition are held in memory, not the whole input.
+		{3, ""},
+		{2, "in-memory row count exceeded allowed limit of 2"},
+	}
+	for _, test := range testCases {
+		testMaxMemoryRows = test.maxMemoryRows
+		newWindow := func() *Window {
+			return &Window{
+				PartitionBy: []*GroupByParams{windowPartitionKey()},
+				Functions: []*WindowFunc{
+					{Opcode: WindowAggregate, AggrOpcode: AggregateCountStar, Col: -1, DefaultCol: -1, Alias: "count(*)"},
+				},
+				Cols:  []int{0, -1},
+				Input: windowTestInput(),
+			}
+		}
+
+		_, err := newWindow().TryExecute(context.Background(), &noopVCursor{}, nil, false)
+		if test.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, test.err)
+		}
+
+		_, err = wrapStreamExecute(newWindow(), &noopVCursor{}, nil, true)
+		if test.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, test.err)
+		}
+	}
+}
@@ -44,6 +44,7 @@ import (
 	"vitess.io/vitess/go/mysql/datetime"
 	"vitess.io/vitess/go/mysql/decimal"
 	"vitess.io/vitess/go/mysql/fastparse"
+	"vitess.io/vitess/go/mysql/format"
 	"vitess.io/vitess/go/mysql/hex"
 	"vitess.io/vitess/go/mysql/icuregex"
 	"vitess.io/vitess/go/mysql/json"
@@ -2294,6 +2295,90 @@ func (asm *assembler) Fn_JSON_KEYS(jp *json.Path) {
 	}
 }
 
+func (asm *assembler) Fn_JSON_MODIFY(fname string, t json.Transformation, args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		res, err := builtin_JSON_MODIFY(fname, t, env.vm.stack[env.vm.sp-args:env.vm.sp])
+		if err != nil {
+			env.vm.err = err
+			return 0
+		}
+		env.vm.stack[env.vm.sp-args] = res
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN %s (SP-%d)...(SP-1)", fname, args)
+}
+
+func (asm *assembler) Fn_JSON_MERGE_PATCH(args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		res, err := builtin_JSON_MERGE_PATCH(env.vm.stack[env.vm.sp-args : env.vm.sp])
+		if err != nil {
+			env.vm.err = err
+			return 0
+		}
+		env.vm.stack[env.vm.sp-args] = res
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN JSON_MERGE_PATCH (SP-%d)...(SP-1)", args)
+}
+
+func (asm *assembler) Fn_JSON_MERGE_PRESERVE(fname string, args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		res, err := builtin_JSON_MERGE_PRESERVE(fname, env.vm.stack[env.vm.sp-args:env.vm.sp])
+		if err != nil {
+			env.vm.err = err
+			return 0
+		}
+		env.vm.stack[env.vm.sp-args] = res
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN %s (SP-%d)...(SP-1)", fname, args)
+}
+
+func (asm *assembler) Fn_JSON_CONTAINS(args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		res, err := builtin_JSON_CONTAINS(env.vm.stack[env.vm.sp-args : env.vm.sp])
+		if err != nil {
+			env.vm.err = err
+			return 0
+		}
+		env.vm.stack[env.vm.sp-args] = res
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN JSON_CONTAINS (SP-%d)...(SP-1)", args)
+}
+
+func (asm *assembler) Fn_JSON_SEARCH(args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		res, err := builtin_JSON_SEARCH(env.vm.stack[env.vm.sp-args : env.vm.sp])
+		if err != nil {
+			env.vm.err = err
+			return 0
+		}
+		env.vm.stack[env.vm.sp-args] = res
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN JSON_SEARCH (SP-%d)...(SP-1)", args)
+}
+
+func (asm *assembler) Fn_JSON_OVERLAPS() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		res, err := builtin_JSON_OVERLAPS(env.vm.stack[env.vm.sp-2], env.vm.stack[env.vm.sp-1])
+		if err != nil {
+			env.vm.err = err
+			return 0
+		}
+		env.vm.stack[env.vm.sp-2] = res
+		env.vm.sp--
+		return 1
+	}, "FN JSON_OVERLAPS (SP-2), (SP-1)")
+}
+
 func (asm *assembler) Fn_JSON_OBJECT(args int) {
 	asm.adjustStack(-(args - 1))
 	asm.emit(func(env *ExpressionEnv) int {
@@ -3052,6 +3137,95 @@ func (asm *assembler) Fn_SUBSTRING3(tt sqltypes.Type, cs types.Charset, col coll
 	}, "FN SUBSTRING VARCHAR(SP-3) INT64(SP-2) INT64(SP-1)")
 }
 
+func (asm *assembler) Fn_SUBSTRING_INDEX(cs types.Charset, col collations.TypedCollation) {
+	asm.adjustStack(-2)
+	asm.emit(func(env *ExpressionEnv) int {
+		str := env.vm.stack[env.vm.sp-3].(*evalBytes)
+		delim := env.vm.stack[env.vm.sp-2].(*evalBytes)
+		count := substringIndexCount(env.vm.stack[env.vm.sp-1])
+
+		res := substringIndex(cs, str.bytes, delim.bytes, count)
+		env.vm.stack[env.vm.sp-3] = env.vm.arena.newEvalRaw(res, str.SQLType(), col)
+		env.vm.sp -= 2
+		return 1
+	}, "FN SUBSTRING_INDEX VARCHAR(SP-3) VARCHAR(SP-2) INT64(SP-1)")
+}
+
+func (asm *assembler) Fn_FORMAT(col collations.TypedCollation, args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		num := env.vm.stack[env.vm.sp-args]
+		dec := env.vm.stack[env.vm.sp-args+1].(*evalInt64)
+
+		locale := format.LocaleEnUS
+		if args > 2 {
+			locale = formatLocale(env.vm.stack[env.vm.sp-1])
+		}
+
+		res := formatNumber(num, dec.i, locale)
+		env.vm.stack[env.vm.sp-args] = env.vm.arena.newEvalText(res, col)
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN FORMAT NUMERIC(SP-%d) INT64(SP-%d)...", args, args-1)
+}
+
+func (asm *assembler) Fn_SOUNDEX(col collations.TypedCollation) {
+	cs := colldata.Lookup(col.Collation).Charset()
+	asm.emit(func(env *ExpressionEnv) int {
+		str := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalText(soundex(cs, str.bytes), col)
+		return 1
+	}, "FN SOUNDEX VARCHAR(SP-1)")
+}
+
+func (asm *assembler) Fn_EXPORT_SET(tt sqltypes.Type, tc collations.TypedCollation, args int) {
+	comma := charset.Collapse(nil, []rune{','}, colldata.Lookup(tc.Collation).Charset())
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		bits := env.vm.stack[env.vm.sp-args].(*evalInt64)
+		on := env.vm.stack[env.vm.sp-args+1].(*evalBytes)
+		off := env.vm.stack[env.vm.sp-args+2].(*evalBytes)
+
+		sep := comma
+		if args > 3 {
+			sep = env.vm.stack[env.vm.sp-args+3].(*evalBytes).bytes
+		}
+		n := 64
+		if args > 4 {
+			n = exportSetBits(env.vm.stack[env.vm.sp-1].(*evalInt64).i)
+		}
+
+		res := exportSet(uint64(bits.i), on.bytes, off.bytes, sep, n)
+		if !validMaxLength(int64(len(res)), 1) {
+			env.vm.stack[env.vm.sp-args] = nil
+		} else {
+			env.vm.stack[env.vm.sp-args] = env.vm.arena.newEvalRaw(res, tt, tc)
+		}
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN EXPORT_SET INT64(SP-%d) VARCHAR(SP-%d)...VARCHAR(SP-1)", args, args-1)
+}
+
+func (asm *assembler) Fn_MAKE_SET(tt sqltypes.Type, tc collations.TypedCollation, args int) {
+	comma := charset.Collapse(nil, []rune{','}, colldata.Lookup(tc.Collation).Charset())
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		bits := env.vm.stack[env.vm.sp-args].(*evalInt64)
+
+		strs := make([]*evalBytes, args-1)
+		for i := range strs {
+			if str, ok := env.vm.stack[env.vm.sp-args+1+i].(*evalBytes); ok {
+				strs[i] = str
+			}
+		}
+
+		res := makeSet(uint64(bits.i), strs, comma)
+		env.vm.stack[env.vm.sp-args] = env.vm.arena.newEvalRaw(res, tt, tc)
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN MAKE_SET INT64(SP-%d) VARCHAR(SP-%d)...VARCHAR(SP-1)", args, args-1)
+}
+
 func (asm *assembler) Fn_TO_BASE64(t sqltypes.Type, col collations.TypedCollation) {
 	asm.emit(func(env *ExpressionEnv) int {
 		str := env.vm.stack[env.vm.sp-1].(*evalBytes)
@@ -4388,6 +4562,67 @@ func (asm *assembler) Fn_PERIOD_DIFF() {
 	}, "FN PERIOD_DIFF INT64(SP-2) INT64(SP-1)")
 }
 
+func (asm *assembler) Fn_STR_TO_DATE() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		str := env.vm.stack[env.vm.sp-2].(*evalBytes)
+		format := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		env.vm.stack[env.vm.sp-2] = strToDate(str, format, env.sqlmode.AllowZeroDate())
+		env.vm.sp--
+		return 1
+	}, "FN STR_TO_DATE VARBINARY(SP-2), VARBINARY(SP-1)")
+}
+
+func (asm *assembler) Fn_DATEDIFF() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		if env.vm.stack[env.vm.sp-2] == nil || env.vm.stack[env.vm.sp-1] == nil {
+			env.vm.stack[env.vm.sp-2] = nil
+			env.vm.sp--
+			return 1
+		}
+		l := env.vm.stack[env.vm.sp-2].(*evalTemporal)
+		r := env.vm.stack[env.vm.sp-1].(*evalTemporal)
+		env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalInt64(dateDiff(l, r))
+		env.vm.sp--
+		return 1
+	}, "FN DATEDIFF DATE(SP-2), DATE(SP-1)")
+}
+
+func (asm *assembler) Fn_TIMEDIFF() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-2] = timeDiff(env.vm.stack[env.vm.sp-2], env.vm.stack[env.vm.sp-1], env.now)
+		env.vm.sp--
+		return 1
+	}, "FN TIMEDIFF (SP-2), (SP-1)")
+}
+
+func (asm *assembler) Fn_TIMESTAMPDIFF(unit datetime.IntervalType) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		if env.vm.stack[env.vm.sp-2] == nil || env.vm.stack[env.vm.sp-1] == nil {
+			env.vm.stack[env.vm.sp-2] = nil
+			env.vm.sp--
+			return 1
+		}
+		l := env.vm.stack[env.vm.sp-2].(*evalTemporal)
+		r := env.vm.stack[env.vm.sp-1].(*evalTemporal)
+		env.vm.stack[env.vm.sp-2] = timestampDiff(l, r, unit)
+		env.vm.sp--
+		return 1
+	}, "FN TIMESTAMPDIFF DATETIME(SP-2), DATETIME(SP-1)")
+}
+
+func (asm *assembler) Fn_ADDTIME(sub bool, col collations.TypedCollation) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-2] = addTime(env.vm.stack[env.vm.sp-2], env.vm.stack[env.vm.sp-1], sub, env.now, col)
+		env.vm.sp--
+		return 1
+	}, "FN ADDTIME (SP-2), (SP-1)")
+}
+
 func (asm *assembler) Interval(l int) {
 	asm.adjustStack(-l)
 	asm.emit(func(env *ExpressionEnv) int {
@@ -761,6 +761,186 @@ func TestCompilerSingle(t *testing.T) {
 			expression: `WEEK(timestamp '2024-01-01 10:34:58', 1)`,
 			result:     `INT64(1)`,
 		},
+		{
+			expression: `STR_TO_DATE('May 1, 2013', '%M %d,%Y')`,
+			result:     `DATE("2013-05-01")`,
+		},
+		{
+			expression: `STR_TO_DATE('2013-05-01 10:11:12.345', '%Y-%m-%d %H:%i:%s.%f')`,
+			result:     `DATETIME("2013-05-01 10:11:12.345000")`,
+		},
+		{
+			expression: `STR_TO_DATE('a09:30:17', '%h:%i:%s')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `DATEDIFF('2010-11-30 23:59:59', '2010-12-31')`,
+			result:     `INT64(-31)`,
+		},
+		{
+			expression: `TIMEDIFF('2008-12-31 23:59:59.000001', '2008-12-30 01:01:01.000002')`,
+			result:     `TIME("46:58:57.999999")`,
+		},
+		{
+			expression: `TIMESTAMPDIFF(MINUTE, '2003-02-01', '2003-05-01 12:05:55')`,
+			result:     `INT64(128885)`,
+		},
+		{
+			expression: `SUBTIME('2007-12-31 23:59:59.999999', '1 1:1:1.000002')`,
+			result:     `VARCHAR("2007-12-30 22:58:58.999997")`,
+		},
+		{
+			expression: `ADDTIME(timestamp '2007-12-31 23:59:59', '01:00:00')`,
+			result:     `DATETIME("2008-01-01 00:59:59")`,
+		},
+		{
+			expression: `JSON_SET('{ "a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 10, \"b\": [2, 3], \"c\": \"[true, false]\"}")`,
+		},
+		{
+			expression: `JSON_INSERT('{ "a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 1, \"b\": [2, 3], \"c\": \"[true, false]\"}")`,
+		},
+		{
+			expression: `JSON_REPLACE('{ "a": 1, "b": [2, 3]}', '$.a', 10, '$.c', '[true, false]')`,
+			result:     `JSON("{\"a\": 10, \"b\": [2, 3]}")`,
+		},
+		{
+			expression: `JSON_SET('[1, 2]', '$[5]', 3, '$[last-5]', 0)`,
+			result:     `JSON("[0, 1, 2, 3]")`,
+		},
+		{
+			expression: `JSON_SET('"x"', '$[1]', CAST('{}' AS JSON), '$[1].a', 1)`,
+			result:     `JSON("[\"x\", {\"a\": 1}]")`,
+		},
+		{
+			expression: `JSON_REMOVE('["a", ["b", "c"], "d"]', '$[1]')`,
+			result:     `JSON("[\"a\", \"d\"]")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"a": 3, "c": 4}', '{"a": 5, "d": 6}')`,
+			result:     `JSON("{\"a\": 5, \"b\": 2, \"c\": 4, \"d\": 6}")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"b": null}')`,
+			result:     `JSON("{\"a\": 1}")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH(NULL, '[1]')`,
+			result:     `JSON("[1]")`,
+		},
+		{
+			expression: `JSON_MERGE_PRESERVE('{"a": 1, "b": 2}', '{"a": 3, "c": 4}', '{"a": 5, "d": 6}')`,
+			result:     `JSON("{\"a\": [1, 3, 5], \"b\": 2, \"c\": 4, \"d\": 6}")`,
+		},
+		{
+			expression: `JSON_MERGE_PRESERVE('[1, 2]', '{"id": 47}')`,
+			result:     `JSON("[1, 2, {\"id\": 47}]")`,
+		},
+		{
+			expression: `JSON_CONTAINS('{"a": 1, "b": 2, "c": {"d": 4}}', '{"d": 4}', '$.c')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_CONTAINS('{"a": 1, "b": 2, "c": {"d": 4}}', '1', '$.b')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `JSON_CONTAINS('[1, [2, 3]]', '[[2], 1.0]')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]', 'all', 'abc')`,
+			result:     `JSON("[\"$[0]\", \"$[2].x\"]")`,
+		},
+		{
+			expression: `JSON_SEARCH('["abc", [{"k": "10"}, "def"], {"x": "abc"}, {"y": "bcd"}]', 'one', '%b%', NULL, '$[3]')`,
+			result:     `JSON("\"$[3].y\"")`,
+		},
+		{
+			expression: `JSON_OVERLAPS('[1, 3, 5, 7]', '[2, 5, 7]')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('[[1, 2], [3, 4], 5]', '[1, [2, 3], [4, 5]]')`,
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `JSON_OVERLAPS('{"a": 1, "b": 10, "d": 10}', '{"c": 1, "e": 10, "f": 1, "d": 10}')`,
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `SUBSTRING_INDEX('www.mysql.com', '.', 2)`,
+			result:     `VARCHAR("www.mysql")`,
+		},
+		{
+			expression: `SUBSTRING_INDEX('www.mysql.com', '.', -2)`,
+			result:     `VARCHAR("mysql.com")`,
+		},
+		{
+			expression: `SUBSTRING_INDEX('aaaaa', 'aa', -2)`,
+			result:     `VARCHAR("aaa")`,
+		},
+		{
+			expression: `SUBSTRING_INDEX('中文,测试,中文', ',', -1)`,
+			result:     `VARCHAR("中文")`,
+		},
+		{
+			expression: `FORMAT(12332.123456, 4)`,
+			result:     `VARCHAR("12,332.1235")`,
+		},
+		{
+			expression: `FORMAT(12332.2, 2, 'de_DE')`,
+			result:     `VARCHAR("12.332,20")`,
+		},
+		{
+			expression: `FORMAT(-1234567.891, 0)`,
+			result:     `VARCHAR("-1,234,568")`,
+		},
+		{
+			expression: `FORMAT(123456789.5e0, 1, 'en_IN')`,
+			result:     `VARCHAR("12,34,56,789.5")`,
+		},
+		{
+			expression: `FORMAT(2.5e0, 0)`,
+			result:     `VARCHAR("2")`,
+		},
+		{
+			expression: `FORMAT(2.5, 0)`,
+			result:     `VARCHAR("3")`,
+		},
+		{
+			expression: `SOUNDEX('Hello')`,
+			result:     `VARCHAR("H400")`,
+		},
+		{
+			expression: `SOUNDEX('Quadratically')`,
+			result:     `VARCHAR("Q36324")`,
+		},
+		{
+			expression: `SOUNDEX('123')`,
+			result:     `VARCHAR("")`,
+		},
+		{
+			expression: `EXPORT_SET(5, 'Y', 'N', ',', 4)`,
+			result:     `VARCHAR("Y,N,Y,N")`,
+		},
+		{
+			expression: `EXPORT_SET(6, '1', '0', ',', 10)`,
+			result:     `VARCHAR("0,1,1,0,0,0,0,0,0,0")`,
+		},
+		{
+			expression: `MAKE_SET(1 | 4, 'hello', 'nice', 'world')`,
+			result:     `VARCHAR("hello,world")`,
+		},
+		{
+			expression: `MAKE_SET(1 | 4, 'hello', 'nice', NULL, 'world')`,
+			result:     `VARCHAR("hello")`,
+		},
+		{
+			expression: `MAKE_SET(0, 'a', 'b', 'c')`,
+			result:     `VARCHAR("")`,
+		},
 		{
 			expression: `column0 + 1`,
 			values:     []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Enum, []byte("foo"))},
@@ -17,7 +17,9 @@ limitations under the License.
 package evalengine
 
 import (
+	"vitess.io/vitess/go/hack"
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/colldata"
 	"vitess.io/vitess/go/mysql/json"
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/sqltypes"
@@ -57,6 +59,31 @@ type (
 	builtinJSONKeys struct {
 		CallExpr
 	}
+
+	builtinJSONModify struct {
+		CallExpr
+		t json.Transformation
+	}
+
+	builtinJSONMergePatch struct {
+		CallExpr
+	}
+
+	builtinJSONMergePreserve struct {
+		CallExpr
+	}
+
+	builtinJSONContains struct {
+		CallExpr
+	}
+
+	builtinJSONSearch struct {
+		CallExpr
+	}
+
+	builtinJSONOverlaps struct {
+		CallExpr
+	}
 )
 
 var _ IR = (*builtinJSONExtract)(nil)
@@ -67,8 +94,14 @@ var _ IR = (*builtinJSONDepth)(nil)
 var _ IR = (*builtinJSONLength)(nil)
 var _ IR = (*builtinJSONContainsPath)(nil)
 var _ IR = (*builtinJSONKeys)(nil)
+var _ IR = (*builtinJSONModify)(nil)
+var _ IR = (*builtinJSONMergePatch)(nil)
+var _ IR = (*builtinJSONMergePreserve)(nil)
+var _ IR = (*builtinJSONContains)(nil)
+var _ IR = (*builtinJSONSearch)(nil)
+var _ IR = (*builtinJSONOverlaps)(nil)
 
-var errInvalidPathForTransform = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In this situation, path expressions may not contain the * and ** tokens or an array range.")
+var errInvalidPathForTransform = json.ErrInvalidPathForTransform
 
 func (call *builtinJSONExtract) eval(env *ExpressionEnv) (eval, error) {
 	args, err := call.args(env)
@@ -511,3 +544,448 @@ func (call *builtinJSONKeys) compile(c *compiler) (ctype, error) {
 	c.asm.Fn_JSON_KEYS(jp)
 	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
 }
+
+func (call *builtinJSONModify) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MODIFY(call.Method, call.t, args)
+}
+
+// builtin_JSON_MODIFY implements JSON_SET, JSON_INSERT, JSON_REPLACE and
+// JSON_REMOVE. The first argument is the document, followed by pairs of
+// paths and values, or by paths only for JSON_REMOVE.
+func builtin_JSON_MODIFY(fname string, t json.Transformation, args []eval) (eval, error) {
+	if args[0] == nil {
+		return nil, nil
+	}
+	doc, err := intoJSON(fname, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	step := 2
+	if t == json.Remove {
+		step = 1
+	}
+	paths := make([]*json.Path, 0, len(args)/step)
+	values := make([]*json.Value, 0, len(args)/step)
+	for i := 1; i < len(args); i += step {
+		if args[i] == nil {
+			return nil, nil
+		}
+		path, err := intoJSONPath(args[i])
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+
+		if t != json.Remove {
+			value, err := argToJSON(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+	}
+
+	res, err := json.ApplyTransform(t, doc, paths, values)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (call *builtinJSONModify) compile(c *compiler) (ctype, error) {
+	for i, arg := range call.Arguments {
+		ct, err := arg.compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+		// the values are converted like in JSON_ARRAY and JSON_OBJECT
+		if call.t != json.Remove && i > 0 && i%2 == 0 {
+			_, err = c.compileArgToJSON(ct, 1)
+			if err != nil {
+				return ctype{}, err
+			}
+		}
+	}
+	c.asm.Fn_JSON_MODIFY(call.Method, call.t, len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+func (call *builtinJSONMergePatch) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MERGE_PATCH(args)
+}
+
+func builtin_JSON_MERGE_PATCH(args []eval) (eval, error) {
+	var doc *json.Value
+	var null bool
+	for i, arg := range args {
+		if arg == nil {
+			// A later patch that is not an object can still give
+			// a result, so we cannot return NULL yet.
+			null = true
+			continue
+		}
+		patch, err := intoJSON("JSON_MERGE_PATCH", arg)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case i == 0:
+			doc = patch
+		case patch.Type() != json.TypeObject:
+			// The result of merging a patch that is not an object is
+			// the patch itself, even when the document is NULL.
+			doc = patch
+			null = false
+		case !null:
+			doc = json.MergePatch(doc, patch)
+		}
+	}
+	if null {
+		return nil, nil
+	}
+	return doc, nil
+}
+
+func (call *builtinJSONMergePatch) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_MERGE_PATCH(len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+func (call *builtinJSONMergePreserve) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MERGE_PRESERVE(call.Method, args)
+}
+
+func builtin_JSON_MERGE_PRESERVE(fname string, args []eval) (eval, error) {
+	var doc *json.Value
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+		j, err := intoJSON(fname, arg)
+		if err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			doc = j
+		} else {
+			doc = json.MergePreserve(doc, j)
+		}
+	}
+	return doc, nil
+}
+
+func (call *builtinJSONMergePreserve) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_MERGE_PRESERVE(call.Method, len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+func (call *builtinJSONContains) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_CONTAINS(args)
+}
+
+func builtin_JSON_CONTAINS(args []eval) (eval, error) {
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+	target, err := intoJSON("JSON_CONTAINS", args[0])
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := intoJSON("JSON_CONTAINS", args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 3 {
+		path, err := intoJSONPath(args[2])
+		if err != nil {
+			return nil, err
+		}
+		if path.ContainsWildcards() {
+			return nil, errInvalidPathForTransform
+		}
+		var match *json.Value
+		path.Match(target, true, func(value *json.Value) {
+			match = value
+		})
+		if match == nil {
+			return nil, nil
+		}
+		target = match
+	}
+
+	contains, err := jsonContains(target, candidate)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(contains), nil
+}
+
+// jsonContains returns whether the candidate is contained in the target:
+// scalars are contained in equal scalars, objects are contained in objects
+// that have all their keys with values that contain theirs, and values are
+// contained in arrays when all of the items of the candidate, or the
+// candidate itself if it's not an array, are contained in one of the items
+// of the array. Only equal scalars are considered for scalars.
+func jsonContains(target, candidate *json.Value) (bool, error) {
+	switch target.Type() {
+	case json.TypeObject:
+		cobj, ok := candidate.Object()
+		if !ok {
+			return false, nil
+		}
+		tobj, _ := target.Object()
+		for _, key := range cobj.Keys() {
+			value := tobj.Get(key)
+			if value == nil {
+				return false, nil
+			}
+			contains, err := jsonContains(value, cobj.Get(key))
+			if err != nil || !contains {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case json.TypeArray:
+		tary, _ := target.Array()
+		cary, ok := candidate.Array()
+		if !ok {
+			cary = []*json.Value{candidate}
+		}
+		for _, c := range cary {
+			var found bool
+			for _, t := range tary {
+				var err error
+				switch c.Type() {
+				case json.TypeObject, json.TypeArray:
+					found, err = jsonContains(t, c)
+				default:
+					found, err = jsonEqual(t, c)
+				}
+				if err != nil {
+					return false, err
+				}
+				if found {
+					break
+				}
+			}
+			if !found {
+				return false, nil
+			}
+		}
+		return true, nil
+
+	default:
+		return jsonEqual(target, candidate)
+	}
+}
+
+func jsonEqual(a, b *json.Value) (bool, error) {
+	cmp, err := compareJSONValue(a, b)
+	return cmp == 0, err
+}
+
+func (call *builtinJSONContains) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_CONTAINS(len(call.Arguments))
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagIsBoolean | flagNullable}, nil
+}
+
+func (call *builtinJSONSearch) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_SEARCH(args)
+}
+
+var errIncorrectEscape = vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongArguments, "Incorrect arguments to ESCAPE")
+
+// builtin_JSON_SEARCH takes the document, 'one' or 'all', the search string
+// and then, optionally, the escape character and the paths to search.
+func builtin_JSON_SEARCH(args []eval) (eval, error) {
+	for i, arg := range args {
+		// the escape character can be NULL
+		if arg == nil && i != 3 {
+			return nil, nil
+		}
+	}
+	doc, err := intoJSON("JSON_SEARCH", args[0])
+	if err != nil {
+		return nil, err
+	}
+	match, err := intoOneOrAll("JSON_SEARCH", evalToBinary(args[1]).string())
+	if err != nil {
+		return nil, err
+	}
+	search, err := evalToVarchar(args[2], collationJSON.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var escape rune
+	if len(args) > 3 && args[3] != nil {
+		esc, err := evalToVarchar(args[3], collationJSON.Collation, true)
+		if err != nil {
+			return nil, err
+		}
+		switch r := []rune(esc.string()); len(r) {
+		case 0:
+		case 1:
+			escape = r[0]
+		default:
+			return nil, errIncorrectEscape
+		}
+	}
+
+	var paths []*json.Path
+	if len(args) > 4 {
+		paths = make([]*json.Path, 0, len(args)-4)
+		for _, arg := range args[4:] {
+			path, err := intoJSONPath(arg)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, path)
+		}
+	}
+
+	wc := colldata.Lookup(collationJSON.Collation).Wildcard(search.bytes, 0, 0, escape)
+	found := json.Search(doc, paths, match == jsonMatchOne, func(s string) bool {
+		return wc.Match(hack.StringBytes(s))
+	})
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return json.NewString(found[0]), nil
+	default:
+		ary := make([]*json.Value, 0, len(found))
+		for _, f := range found {
+			ary = append(ary, json.NewString(f))
+		}
+		return json.NewArray(ary), nil
+	}
+}
+
+func (call *builtinJSONSearch) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_SEARCH(len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
+}
+
+func (call *builtinJSONOverlaps) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_OVERLAPS(args[0], args[1])
+}
+
+func builtin_JSON_OVERLAPS(arg1, arg2 eval) (eval, error) {
+	if arg1 == nil || arg2 == nil {
+		return nil, nil
+	}
+	a, err := intoJSON("JSON_OVERLAPS", arg1)
+	if err != nil {
+		return nil, err
+	}
+	b, err := intoJSON("JSON_OVERLAPS", arg2)
+	if err != nil {
+		return nil, err
+	}
+	overlaps, err := jsonOverlaps(a, b)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(overlaps), nil
+}
+
+// jsonOverlaps returns whether two documents have an item of an array, or
+// a key and value of an object, in common. A value that is compared with
+// an array is handled like an array with only that value in it.
+func jsonOverlaps(a, b *json.Value) (bool, error) {
+	aary, aok := a.Array()
+	bary, bok := b.Array()
+	if aok || bok {
+		if !aok {
+			aary = []*json.Value{a}
+		}
+		if !bok {
+			bary = []*json.Value{b}
+		}
+		for _, x := range aary {
+			for _, y := range bary {
+				if eq, err := jsonEqual(x, y); err != nil || eq {
+					return eq, err
+				}
+			}
+		}
+		return false, nil
+	}
+
+	aobj, aok := a.Object()
+	bobj, bok := b.Object()
+	if aok && bok {
+		for _, key := range aobj.Keys() {
+			value := bobj.Get(key)
+			if value == nil {
+				continue
+			}
+			if eq, err := jsonEqual(aobj.Get(key), value); err != nil || eq {
+				return eq, err
+			}
+		}
+		return false, nil
+	}
+	return jsonEqual(a, b)
+}
+
+func (call *builtinJSONOverlaps) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_OVERLAPS()
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagIsBoolean | flagNullable}, nil
+}
@@ -19,11 +19,13 @@ package evalengine
 import (
 	"bytes"
 	"math"
+	"strconv"
 
 	"vitess.io/vitess/go/mysql/capabilities"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/collations/charset"
 	"vitess.io/vitess/go/mysql/collations/colldata"
+	"vitess.io/vitess/go/mysql/format"
 	"vitess.io/vitess/go/sqltypes"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -150,6 +152,31 @@ type (
 		CallExpr
 		collate collations.ID
 	}
+
+	builtinSubstringIndex struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinFormat struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinSoundex struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinExportSet struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinMakeSet struct {
+		CallExpr
+		collate collations.ID
+	}
 )
 
 var _ IR = (*builtinField)(nil)
@@ -176,6 +203,11 @@ var _ IR = (*builtinRepeat)(nil)
 var _ IR = (*builtinConcat)(nil)
 var _ IR = (*builtinConcatWs)(nil)
 var _ IR = (*builtinReplace)(nil)
+var _ IR = (*builtinSubstringIndex)(nil)
+var _ IR = (*builtinFormat)(nil)
+var _ IR = (*builtinSoundex)(nil)
+var _ IR = (*builtinExportSet)(nil)
+var _ IR = (*builtinMakeSet)(nil)
 
 func fieldSQLType(arg sqltypes.Type, tt sqltypes.Type) sqltypes.Type {
 	if sqltypes.IsNull(arg) {
@@ -2192,3 +2224,577 @@ func replace(str, from, to []byte) []byte {
 	end += copy(out[end:], str[start:])
 	return out[0:end]
 }
+
+// substringIndex returns the part of str before count occurrences of
+// delim, or after them when count is negative. Like in MySQL, delim is
+// matched byte by byte, but only at character boundaries when the
+// charset has multibyte characters.
+func substringIndex(cs charset.Charset, str, delim []byte, count int64) []byte {
+	if len(str) == 0 || len(delim) == 0 || count == 0 {
+		return nil
+	}
+
+	if cs.MaxWidth() == 1 {
+		if count > 0 {
+			for offset := 0; ; offset += len(delim) {
+				pos := bytes.Index(str[offset:], delim)
+				if pos < 0 {
+					return str
+				}
+				offset += pos
+				if count--; count == 0 {
+					return str[:offset]
+				}
+			}
+		}
+		for offset := len(str); offset > 0; {
+			offset = bytes.LastIndex(str[:offset], delim)
+			if offset < 0 {
+				return str
+			}
+			if count++; count == 0 {
+				return str[offset+len(delim):]
+			}
+		}
+		return str
+	}
+
+	// index returns the position of the nth occurrence of delim, or the
+	// number of occurrences when there are fewer than n.
+	index := func(n int64) (int, int64) {
+		var found int64
+		for pos := 0; pos <= len(str)-len(delim); {
+			if bytes.HasPrefix(str[pos:], delim) {
+				if found++; found == n {
+					return pos, found
+				}
+				pos += len(delim)
+				continue
+			}
+			_, size := cs.DecodeRune(str[pos:])
+			pos += max(size, 1)
+		}
+		return -1, found
+	}
+
+	if count > 0 {
+		pos, _ := index(count)
+		if pos < 0 {
+			return str
+		}
+		return str[:pos]
+	}
+
+	_, total := index(-1)
+	count += total + 1
+	if count <= 0 {
+		return str
+	}
+	pos, _ := index(count)
+	return str[pos+len(delim):]
+}
+
+func (call *builtinSubstringIndex) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil || args[1] == nil || args[2] == nil {
+		return nil, nil
+	}
+
+	str, ok := args[0].(*evalBytes)
+	if !ok {
+		str, err = evalToVarchar(args[0], call.collate, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	delim, err := evalToVarchar(args[1], str.col.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	count := substringIndexCount(args[2])
+	cs := colldata.Lookup(str.col.Collation).Charset()
+	return newEvalRaw(str.SQLType(), substringIndex(cs, str.bytes, delim.bytes, count), str.col), nil
+}
+
+// substringIndexCount returns the count argument of SUBSTRING_INDEX.
+// Unsigned counts that don't fit in an int64 are clamped, like in MySQL.
+func substringIndexCount(e eval) int64 {
+	if u, ok := e.(*evalUint64); ok && u.u > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return evalToInt64(e).i
+}
+
+func (call *builtinSubstringIndex) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	delim, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	count, err := call.Arguments[2].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck3(str, delim, count)
+
+	col := str.Col
+	if !str.isTextual() {
+		c.asm.Convert_xce(3, sqltypes.VarChar, c.collation)
+		col = typedCoercionCollation(sqltypes.VarChar, c.collation)
+	}
+
+	fromCharset := colldata.Lookup(delim.Col.Collation).Charset()
+	toCharset := colldata.Lookup(col.Collation).Charset()
+	if !delim.isTextual() || (fromCharset != toCharset && !toCharset.IsSuperset(fromCharset)) {
+		c.asm.Convert_xce(2, sqltypes.VarChar, col.Collation)
+	}
+
+	c.asm.Fn_SUBSTRING_INDEX(colldata.Lookup(col.Collation).Charset(), col)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarChar, Col: col, Flag: flagNullable}, nil
+}
+
+// formatMaxDecimals is the maximum number of decimals of FORMAT().
+const formatMaxDecimals = 30
+
+// formatNumber formats num with dec decimals for FORMAT(). Integers and
+// decimals are rounded half away from zero, and all the other values
+// are formatted as floats, which are rounded half to even.
+func formatNumber(num eval, dec int64, locale *format.Locale) []byte {
+	dec = max(0, min(dec, formatMaxDecimals))
+
+	var buf []byte
+	switch num := num.(type) {
+	case *evalInt64, *evalUint64, *evalDecimal:
+		buf = []byte(evalToDecimal(num, 0, 0).dec.StringFixed(int32(dec)))
+	default:
+		f, _ := evalToFloat(num)
+		v := f.f
+		if scaled := v * math.Pow10(int(dec)); !math.IsInf(scaled, 0) {
+			v = math.RoundToEven(scaled) / math.Pow10(int(dec))
+		}
+		buf = strconv.AppendFloat(nil, v, 'f', int(dec), 64)
+	}
+	return locale.AppendNumber(nil, buf, int(dec))
+}
+
+// formatLocale returns the locale of FORMAT() with the given name. MySQL
+// uses en_US for locales that are NULL or that it doesn't know about.
+func formatLocale(name eval) *format.Locale {
+	if name, ok := name.(*evalBytes); ok {
+		if locale := format.LookupLocale(name.string()); locale != nil {
+			return locale
+		}
+	}
+	return format.LocaleEnUS
+}
+
+func (call *builtinFormat) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil || args[1] == nil {
+		return nil, nil
+	}
+
+	locale := format.LocaleEnUS
+	if len(args) > 2 {
+		locale = formatLocale(args[2])
+	}
+
+	res := formatNumber(args[0], evalToInt64(args[1]).i, locale)
+	return newEvalText(res, typedCoercionCollation(sqltypes.VarChar, call.collate)), nil
+}
+
+func (call *builtinFormat) compile(c *compiler) (ctype, error) {
+	num, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	skip1 := c.compileNullCheckArg(num, 0)
+
+	dec, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	skip2 := c.compileNullCheckArg(dec, 1)
+
+	if len(call.Arguments) > 2 {
+		_, err = call.Arguments[2].compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+	}
+
+	args := len(call.Arguments)
+	switch num.Type {
+	case sqltypes.Int64, sqltypes.Uint64, sqltypes.Decimal:
+	default:
+		c.compileToFloat(num, args)
+	}
+	_ = c.compileToInt64(dec, args-1)
+
+	col := typedCoercionCollation(sqltypes.VarChar, call.collate)
+	c.asm.Fn_FORMAT(col, args)
+	c.asm.jumpDestination(skip1, skip2)
+	return ctype{Type: sqltypes.VarChar, Col: col, Flag: flagNullable}, nil
+}
+
+// soundexCodes has the Soundex codes of the letters from A to Z. The
+// vowels, H, W and Y have no code, and are skipped.
+const soundexCodes = "01230120022455012623010202"
+
+func soundexUpper(r rune) rune {
+	if 'a' <= r && r <= 'z' {
+		return r - 'a' + 'A'
+	}
+	return r
+}
+
+// soundexIsAlpha returns whether r is a letter for SOUNDEX(). Like in
+// MySQL, all the characters from U+00C0 are letters.
+func soundexIsAlpha(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || r >= 0xC0
+}
+
+func soundexCode(r rune) rune {
+	r = soundexUpper(r)
+	if r < 'A' || r > 'Z' {
+		return '0'
+	}
+	return rune(soundexCodes[r-'A'])
+}
+
+// soundex returns the Soundex string of str, the way MySQL computes it:
+// the first letter is kept, and it is followed by the codes of all the
+// other letters, not only the first three of them.
+func soundex(cs charset.Charset, str []byte) []byte {
+	var out []rune
+	var last rune
+	for {
+		r, size := cs.DecodeRune(str)
+		if r == charset.RuneError && size < 2 {
+			return nil
+		}
+		str = str[size:]
+		if soundexIsAlpha(r) {
+			last = soundexCode(r)
+			out = append(out, soundexUpper(r))
+			break
+		}
+	}
+
+	chars := 1
+	for len(str) > 0 {
+		r, size := cs.DecodeRune(str)
+		if r == charset.RuneError && size < 2 {
+			break
+		}
+		str = str[size:]
+		if !soundexIsAlpha(r) {
+			continue
+		}
+		if code := soundexCode(r); code != '0' && code != last {
+			out = append(out, code)
+			last = code
+			chars++
+		}
+	}
+
+	for ; chars < 4; chars++ {
+		out = append(out, '0')
+	}
+	return charset.Collapse(nil, out, cs)
+}
+
+func (call *builtinSoundex) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	str, ok := arg.(*evalBytes)
+	if !ok {
+		str, err = evalToVarchar(arg, call.collate, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cs := colldata.Lookup(str.col.Collation).Charset()
+	return newEvalText(soundex(cs, str.bytes), str.col), nil
+}
+
+func (call *builtinSoundex) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(str)
+
+	col := str.Col
+	if !str.isTextual() {
+		c.asm.Convert_xce(1, sqltypes.VarChar, c.collation)
+		col = typedCoercionCollation(sqltypes.VarChar, c.collation)
+	}
+
+	c.asm.Fn_SOUNDEX(col)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarChar, Col: col, Flag: str.Flag}, nil
+}
+
+// exportSetBits returns the number of bits used by EXPORT_SET(). Like in
+// MySQL, negative numbers and numbers above 64 use all the 64 bits.
+func exportSetBits(n int64) int {
+	if n < 0 || n > 64 {
+		return 64
+	}
+	return int(n)
+}
+
+func exportSet(bits uint64, on, off, sep []byte, n int) []byte {
+	var buf []byte
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf = append(buf, sep...)
+		}
+		if bits&(1<<i) != 0 {
+			buf = append(buf, on...)
+		} else {
+			buf = append(buf, off...)
+		}
+	}
+	return buf
+}
+
+// stringArgsCollation returns the type and the collation of the result
+// of a function that is made of its string arguments.
+func stringArgsCollation(args []eval, collate collations.ID, env *collations.Environment) (sqltypes.Type, collations.TypedCollation, error) {
+	var ca collationAggregation
+	tt := sqltypes.VarChar
+	for _, arg := range args {
+		if arg == nil {
+			// NULL arguments are ignorable, like when compiling.
+			if err := ca.add(collationNull, env); err != nil {
+				return 0, collations.TypedCollation{}, err
+			}
+			continue
+		}
+		tt = concatSQLType(arg.SQLType(), tt)
+		if err := ca.add(evalCollation(arg), env); err != nil {
+			return 0, collations.TypedCollation{}, err
+		}
+	}
+
+	tc := ca.result()
+	// If we only had numbers, we instead fall back to the default
+	// collation instead of using the numeric collation.
+	if tc.Coercibility == collations.CoerceNumeric {
+		tc = typedCoercionCollation(tt, collate)
+	}
+	return tt, tc, nil
+}
+
+// compileStringArgsCollation is the compiled version of stringArgsCollation.
+func (c *compiler) compileStringArgsCollation(args []ctype, collate collations.ID) (sqltypes.Type, collations.TypedCollation, error) {
+	var ca collationAggregation
+	tt := sqltypes.VarChar
+	for _, arg := range args {
+		tt = concatSQLType(arg.Type, tt)
+		if err := ca.add(arg.Col, c.env.CollationEnv()); err != nil {
+			return 0, collations.TypedCollation{}, err
+		}
+	}
+
+	tc := ca.result()
+	if tc.Coercibility == collations.CoerceNumeric {
+		tc = typedCoercionCollation(tt, collate)
+	}
+	return tt, tc, nil
+}
+
+// compileConvertStringArg converts the string argument at the given
+// offset of the stack to the collation of the result.
+func (c *compiler) compileConvertStringArg(arg ctype, offset int, tc collations.TypedCollation) {
+	switch arg.Type {
+	case sqltypes.VarBinary, sqltypes.Binary, sqltypes.Blob:
+		if tc.Collation != collations.CollationBinaryID {
+			c.asm.Convert_xce(offset, arg.Type, tc.Collation)
+		}
+	case sqltypes.VarChar, sqltypes.Char, sqltypes.Text:
+		fromCharset := colldata.Lookup(arg.Col.Collation).Charset()
+		toCharset := colldata.Lookup(tc.Collation).Charset()
+		if fromCharset != toCharset && !toCharset.IsSuperset(fromCharset) {
+			c.asm.Convert_xce(offset, arg.Type, tc.Collation)
+		}
+	default:
+		c.asm.Convert_xce(offset, arg.Type, tc.Collation)
+	}
+}
+
+func (call *builtinExportSet) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	strs := args[1:min(len(args), 4)]
+	tt, tc, err := stringArgsCollation(strs, call.collate, env.collationEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([][]byte, 0, 3)
+	for _, str := range strs {
+		b, err := evalToVarchar(str, tc.Collation, true)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, b.bytes)
+	}
+	if len(converted) < 3 {
+		converted = append(converted, charset.Collapse(nil, []rune{','}, colldata.Lookup(tc.Collation).Charset()))
+	}
+
+	n := 64
+	if len(args) > 4 {
+		n = exportSetBits(evalToInt64(args[4]).i)
+	}
+
+	res := exportSet(uint64(evalToInt64(args[0]).i), converted[0], converted[1], converted[2], n)
+	if !validMaxLength(int64(len(res)), 1) {
+		return nil, nil
+	}
+	return newEvalRaw(tt, res, tc), nil
+}
+
+func (call *builtinExportSet) compile(c *compiler) (ctype, error) {
+	args := make([]ctype, len(call.Arguments))
+	skips := make([]*jump, 0, len(call.Arguments))
+	for i, arg := range call.Arguments {
+		var err error
+		args[i], err = arg.compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+		skips = append(skips, c.compileNullCheckArg(args[i], i))
+	}
+
+	strs := args[1:min(len(args), 4)]
+	tt, tc, err := c.compileStringArgsCollation(strs, call.collate)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	_ = c.compileToInt64(args[0], len(args))
+	for i, str := range strs {
+		c.compileConvertStringArg(str, len(args)-1-i, tc)
+	}
+	if len(args) > 4 {
+		_ = c.compileToInt64(args[4], 1)
+	}
+
+	c.asm.Fn_EXPORT_SET(tt, tc, len(args))
+	c.asm.jumpDestination(skips...)
+	return ctype{Type: tt, Col: tc, Flag: flagNullable}, nil
+}
+
+func makeSet(bits uint64, strs []*evalBytes, sep []byte) []byte {
+	var buf []byte
+	first := true
+	for i := 0; i < len(strs) && bits>>i != 0; i++ {
+		if bits&(1<<i) == 0 || strs[i] == nil {
+			continue
+		}
+		if !first {
+			buf = append(buf, sep...)
+		}
+		first = false
+		buf = append(buf, strs[i].bytes...)
+	}
+	return buf
+}
+
+func (call *builtinMakeSet) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	tt, tc, err := stringArgsCollation(args[1:], call.collate, env.collationEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	bits := uint64(evalToInt64(args[0]).i)
+	strs := make([]*evalBytes, len(args)-1)
+	for i, arg := range args[1:] {
+		if arg == nil || bits&(1<<i) == 0 {
+			continue
+		}
+		strs[i], err = evalToVarchar(arg, tc.Collation, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sep := charset.Collapse(nil, []rune{','}, colldata.Lookup(tc.Collation).Charset())
+	return newEvalRaw(tt, makeSet(bits, strs, sep), tc), nil
+}
+
+func (call *builtinMakeSet) compile(c *compiler) (ctype, error) {
+	args := make([]ctype, len(call.Arguments))
+
+	var skip *jump
+	for i, arg := range call.Arguments {
+		var err error
+		args[i], err = arg.compile(c)
+		if err != nil {
+			return ctype{}, err
+		}
+		if i == 0 {
+			skip = c.compileNullCheck1(args[i])
+		}
+	}
+
+	tt, tc, err := c.compileStringArgsCollation(args[1:], call.collate)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	_ = c.compileToInt64(args[0], len(args))
+	for i, arg := range args[1:] {
+		offset := len(args) - (i + 1)
+		skip := c.compileNullCheckOffset(arg, offset)
+		c.compileConvertStringArg(arg, offset, tc)
+		c.asm.jumpDestination(skip)
+	}
+
+	c.asm.Fn_MAKE_SET(tt, tc, len(args))
+	c.asm.jumpDestination(skip)
+	return ctype{Type: tt, Col: tc, Flag: args[0].Flag}, nil
+}
@@ -191,6 +191,29 @@ type (
 		unit    datetime.IntervalType
 		collate collations.ID
 	}
+
+	builtinStrToDate struct {
+		CallExpr
+	}
+
+	builtinDateDiff struct {
+		CallExpr
+	}
+
+	builtinTimeDiff struct {
+		CallExpr
+	}
+
+	builtinTimestampDiff struct {
+		CallExpr
+		unit datetime.IntervalType
+	}
+
+	builtinAddTime struct {
+		CallExpr
+		sub     bool
+		collate collations.ID
+	}
 )
 
 var _ IR = (*builtinNow)(nil)
@@ -227,6 +250,12 @@ var _ IR = (*builtinYear)(nil)
 var _ IR = (*builtinYearWeek)(nil)
 var _ IR = (*builtinPeriodAdd)(nil)
 var _ IR = (*builtinPeriodDiff)(nil)
+var _ IR = (*builtinDateMath)(nil)
+var _ IR = (*builtinStrToDate)(nil)
+var _ IR = (*builtinDateDiff)(nil)
+var _ IR = (*builtinTimeDiff)(nil)
+var _ IR = (*builtinTimestampDiff)(nil)
+var _ IR = (*builtinAddTime)(nil)
 
 func (call *builtinNow) eval(env *ExpressionEnv) (eval, error) {
 	now := env.time(call.utc)
@@ -2149,3 +2178,430 @@ func (call *builtinDateMath) compile(c *compiler) (ctype, error) {
 	}
 	return ret, nil
 }
+
+// strToDateType returns the type and the precision of the result of
+// STR_TO_DATE, which depend on the parts that its format can set.
+func strToDateType(format string) (sqltypes.Type, int) {
+	date, time, frac := datetime.StrToDateParts(format)
+	prec := 0
+	if frac {
+		prec = datetime.DefaultPrecision
+	}
+	switch {
+	case date && !time:
+		return sqltypes.Date, 0
+	case time && !date:
+		return sqltypes.Time, prec
+	default:
+		return sqltypes.Datetime, prec
+	}
+}
+
+func strToDate(str, format *evalBytes, allowZero bool) eval {
+	f := format.string()
+	dt, ok := datetime.StrToDate(f, str.string())
+	if !ok {
+		return nil
+	}
+
+	tt, prec := strToDateType(f)
+	if tt != sqltypes.Time && !allowZero && (dt.Date.Year() == 0 || dt.Date.Month() == 0 || dt.Date.Day() == 0) {
+		return nil
+	}
+	switch tt {
+	case sqltypes.Date:
+		return newEvalDate(dt.Date, true)
+	case sqltypes.Time:
+		return newEvalTime(dt.Time, prec)
+	default:
+		return newEvalDateTime(dt, prec, true)
+	}
+}
+
+func (b *builtinStrToDate) eval(env *ExpressionEnv) (eval, error) {
+	str, format, err := b.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if str == nil || format == nil {
+		return nil, nil
+	}
+	return strToDate(evalToBinary(str), evalToBinary(format), env.sqlmode.AllowZeroDate()), nil
+}
+
+func (call *builtinStrToDate) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	format, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(str, format)
+
+	switch str.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(2, sqltypes.VarBinary, nil)
+	}
+
+	switch format.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	// Without a constant format, the type of the result can only be known
+	// at runtime. MySQL uses DATETIME(6) in that case.
+	tt, prec := sqltypes.Datetime, datetime.DefaultPrecision
+	if lit, ok := call.Arguments[1].(*Literal); ok && lit.inner != nil {
+		tt, prec = strToDateType(evalToBinary(lit.inner).string())
+	}
+
+	c.asm.Fn_STR_TO_DATE()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: tt, Size: int32(prec), Col: collationBinary, Flag: str.Flag | format.Flag | flagNullable}, nil
+}
+
+func dateDiff(left, right *evalTemporal) int64 {
+	l := datetime.MysqlDayNumber(left.dt.Date.Year(), left.dt.Date.Month(), left.dt.Date.Day())
+	r := datetime.MysqlDayNumber(right.dt.Date.Year(), right.dt.Date.Month(), right.dt.Date.Day())
+	return int64(l - r)
+}
+
+func (b *builtinDateDiff) eval(env *ExpressionEnv) (eval, error) {
+	left, right, err := b.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	l := evalToDate(left, env.now, false)
+	r := evalToDate(right, env.now, false)
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	return newEvalInt64(dateDiff(l, r)), nil
+}
+
+func (call *builtinDateDiff) compile(c *compiler) (ctype, error) {
+	left, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	right, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(left, right)
+
+	switch left.Type {
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+	default:
+		c.asm.Convert_xD(2, false)
+	}
+
+	switch right.Type {
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+	default:
+		c.asm.Convert_xD(1, false)
+	}
+
+	c.asm.Fn_DATEDIFF()
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: left.Flag | right.Flag | flagNullable}, nil
+}
+
+const microsecondsPerDay = 24 * 3600 * 1000000
+
+// temporalMicroseconds returns a TIME as a signed number of microseconds,
+// and any other temporal value as the number of microseconds since the
+// start of MySQL's day numbers.
+func temporalMicroseconds(t *evalTemporal) int64 {
+	us := t.dt.Time.ToDuration().Microseconds()
+	if t.SQLType() == sqltypes.Time {
+		return us
+	}
+	days := datetime.MysqlDayNumber(t.dt.Date.Year(), t.dt.Date.Month(), t.dt.Date.Day())
+	return int64(days)*microsecondsPerDay + us
+}
+
+// evalToTimeOrDateTime converts an argument of TIMEDIFF or ADDTIME into a TIME,
+// or into a DATETIME when the argument has a date, like MySQL does when reading
+// these arguments as times.
+func evalToTimeOrDateTime(e eval, now time.Time) *evalTemporal {
+	switch e := e.(type) {
+	case *evalTemporal:
+		if e.SQLType() == sqltypes.Time {
+			return e
+		}
+		return e.toDateTime(int(e.prec), now)
+	case *evalBytes:
+		if dt, l, ok := datetime.ParseDateTime(e.string(), -1); ok {
+			return newEvalDateTime(dt, l, true)
+		}
+		if t, l, state := datetime.ParseTime(e.string(), -1); state == datetime.TimeOK {
+			return newEvalTime(t, l)
+		}
+	case evalNumeric:
+		if _, ok := datetime.ParseTimeInt64(e.toInt64().i); !ok {
+			if dt := evalToDateTime(e, -1, now, true); dt != nil {
+				return dt
+			}
+		}
+		return evalToTime(e, -1)
+	}
+	return nil
+}
+
+func timeDiff(left, right eval, now time.Time) eval {
+	l := evalToTimeOrDateTime(left, now)
+	r := evalToTimeOrDateTime(right, now)
+	if l == nil || r == nil || l.SQLType() != r.SQLType() {
+		return nil
+	}
+	us := temporalMicroseconds(l) - temporalMicroseconds(r)
+	return newEvalTime(datetime.NewTimeFromSeconds(decimal.New(us, -6)), int(max(l.prec, r.prec)))
+}
+
+func (b *builtinTimeDiff) eval(env *ExpressionEnv) (eval, error) {
+	left, right, err := b.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	return timeDiff(left, right, env.now), nil
+}
+
+// timePrecision returns the precision of a TIME computed from an argument
+// of the given type.
+func timePrecision(ct ctype) int32 {
+	switch ct.Type {
+	case sqltypes.Time, sqltypes.Datetime, sqltypes.Timestamp:
+		return ct.Size
+	case sqltypes.Date, sqltypes.Int64, sqltypes.Uint64:
+		return 0
+	case sqltypes.Decimal:
+		return min(ct.Scale, maxTimePrec)
+	default:
+		return maxTimePrec
+	}
+}
+
+func (call *builtinTimeDiff) compile(c *compiler) (ctype, error) {
+	left, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	right, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(left, right)
+	c.asm.Fn_TIMEDIFF()
+	c.asm.jumpDestination(skip)
+
+	prec := max(timePrecision(left), timePrecision(right))
+	return ctype{Type: sqltypes.Time, Size: prec, Col: collationBinary, Flag: left.Flag | right.Flag | flagNullable}, nil
+}
+
+func timestampDiff(left, right *evalTemporal, unit datetime.IntervalType) eval {
+	if left.dt.Date.IsZero() || right.dt.Date.IsZero() {
+		return nil
+	}
+
+	us := temporalMicroseconds(right) - temporalMicroseconds(left)
+	switch unit {
+	case datetime.IntervalYear, datetime.IntervalQuarter, datetime.IntervalMonth:
+		beg, end := left.dt, right.dt
+		sign := 1
+		if us < 0 {
+			beg, end = end, beg
+			sign = -1
+		}
+
+		// Only full months count, so the day and the time of the end
+		// must not be before the ones of the beginning.
+		before := end.Date.Month() < beg.Date.Month() || (end.Date.Month() == beg.Date.Month() && end.Date.Day() < beg.Date.Day())
+		years := end.Date.Year() - beg.Date.Year()
+		if before {
+			years--
+		}
+		months := 12 * years
+		if before {
+			months += 12 - (beg.Date.Month() - end.Date.Month())
+		} else {
+			months += end.Date.Month() - beg.Date.Month()
+		}
+		if end.Date.Day() < beg.Date.Day() || (end.Date.Day() == beg.Date.Day() && end.Time.Compare(beg.Time) < 0) {
+			months--
+		}
+
+		switch unit {
+		case datetime.IntervalYear:
+			return newEvalInt64(int64(months / 12 * sign))
+		case datetime.IntervalQuarter:
+			return newEvalInt64(int64(months / 3 * sign))
+		default:
+			return newEvalInt64(int64(months * sign))
+		}
+	case datetime.IntervalWeek:
+		return newEvalInt64(us / (7 * microsecondsPerDay))
+	case datetime.IntervalDay:
+		return newEvalInt64(us / microsecondsPerDay)
+	case datetime.IntervalHour:
+		return newEvalInt64(us / (3600 * 1000000))
+	case datetime.IntervalMinute:
+		return newEvalInt64(us / (60 * 1000000))
+	case datetime.IntervalSecond:
+		return newEvalInt64(us / 1000000)
+	case datetime.IntervalMicrosecond:
+		return newEvalInt64(us)
+	default:
+		panic("unexpected IntervalType")
+	}
+}
+
+func (b *builtinTimestampDiff) eval(env *ExpressionEnv) (eval, error) {
+	left, right, err := b.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	l := evalToDateTime(left, -1, env.now, false)
+	r := evalToDateTime(right, -1, env.now, false)
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	return timestampDiff(l, r, b.unit), nil
+}
+
+func (call *builtinTimestampDiff) compile(c *compiler) (ctype, error) {
+	left, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	right, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(left, right)
+
+	switch left.Type {
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+	default:
+		c.asm.Convert_xDT(2, -1, false)
+	}
+
+	switch right.Type {
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+	default:
+		c.asm.Convert_xDT(1, -1, false)
+	}
+
+	c.asm.Fn_TIMESTAMPDIFF(call.unit)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: left.Flag | right.Flag | flagNullable}, nil
+}
+
+// addTime adds (or subtracts) a TIME to a TIME or a DATETIME. Like in MySQL,
+// the result is a string unless the first argument has a temporal type.
+func addTime(left, right eval, sub bool, now time.Time, col collations.TypedCollation) eval {
+	l := evalToTimeOrDateTime(left, now)
+	if l == nil {
+		return nil
+	}
+
+	var r *evalTemporal
+	if tt, ok := right.(*evalTemporal); ok {
+		r = tt.toTime(-1)
+	} else {
+		r = evalToTimeOrDateTime(right, now)
+	}
+	if r == nil || r.SQLType() != sqltypes.Time {
+		return nil
+	}
+
+	delta := temporalMicroseconds(r)
+	if sub {
+		delta = -delta
+	}
+	us := temporalMicroseconds(l) + delta
+	prec := int(max(l.prec, r.prec))
+
+	var res *evalTemporal
+	if l.SQLType() == sqltypes.Time {
+		res = newEvalTime(datetime.NewTimeFromSeconds(decimal.New(us, -6)), prec)
+	} else {
+		if us < 0 {
+			return nil
+		}
+		d := datetime.DateFromDayNumber(int(us / microsecondsPerDay))
+		if d.Day() == 0 {
+			return nil
+		}
+		t := datetime.NewTimeFromSeconds(decimal.New(us%microsecondsPerDay, -6))
+		res = newEvalDateTime(datetime.DateTime{Date: d, Time: t}, prec, true)
+	}
+
+	if _, ok := left.(*evalTemporal); ok {
+		return res
+	}
+	// The string only has fractional seconds when there are some.
+	res.prec = 0
+	if res.dt.Time.Nanosecond() != 0 {
+		res.prec = datetime.DefaultPrecision
+	}
+	return newEvalText(res.ToRawBytes(), col)
+}
+
+func (b *builtinAddTime) eval(env *ExpressionEnv) (eval, error) {
+	left, right, err := b.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	return addTime(left, right, b.sub, env.now, typedCoercionCollation(sqltypes.VarChar, b.collate)), nil
+}
+
+func (call *builtinAddTime) compile(c *compiler) (ctype, error) {
+	left, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	right, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(left, right)
+
+	ret := ctype{Col: collationBinary, Flag: left.Flag | right.Flag | flagNullable}
+	switch left.Type {
+	case sqltypes.Time:
+		ret.Type = sqltypes.Time
+		ret.Size = max(left.Size, timePrecision(right))
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+		ret.Type = sqltypes.Datetime
+		ret.Size = max(left.Size, timePrecision(right))
+	default:
+		ret.Type = sqltypes.VarChar
+		ret.Col = typedCoercionCollation(sqltypes.VarChar, c.collation)
+	}
+
+	c.asm.Fn_ADDTIME(call.sub, ret.Col)
+	c.asm.jumpDestination(skip)
+	return ret, nil
+}
@@ -33,6 +33,9 @@ var Cases = []TestCase{
 	{Run: JSONPathOperations},
 	{Run: JSONArray},
 	{Run: JSONObject},
+	{Run: JSONModify},
+	{Run: JSONMerge},
+	{Run: JSONSearch},
 	{Run: CharsetConversionOperators},
 	{Run: CaseExprWithPredicate},
 	{Run: CaseExprWithValue},
@@ -86,6 +89,11 @@ var Cases = []TestCase{
 	{Run: FnSubstr},
 	{Run: FnLocate},
 	{Run: FnReplace},
+	{Run: FnSubstringIndex},
+	{Run: FnFormat},
+	{Run: FnSoundex},
+	{Run: FnExportSet},
+	{Run: FnMakeSet},
 	{Run: FnConcat},
 	{Run: FnConcatWs},
 	{Run: FnChar},
@@ -157,6 +165,11 @@ var Cases = []TestCase{
 	{Run: FnYearWeek},
 	{Run: FnPeriodAdd},
 	{Run: FnPeriodDiff},
+	{Run: FnStrToDate},
+	{Run: FnDateDiff},
+	{Run: FnTimeDiff},
+	{Run: FnTimestampDiff},
+	{Run: FnAddTime},
 	{Run: FnInetAton},
 	{Run: FnInetNtoa},
 	{Run: FnInet6Aton},
@@ -185,6 +198,8 @@ func JSONPathOperations(yield Query) {
 			yield(fmt.Sprintf("JSON_CONTAINS_PATH('%s', 'one', '%s')", obj, path1), nil, false)
 			yield(fmt.Sprintf("JSON_CONTAINS_PATH('%s', 'all', '%s')", obj, path1), nil, false)
 			yield(fmt.Sprintf("JSON_KEYS('%s', '%s')", obj, path1), nil, false)
+			yield(fmt.Sprintf("JSON_CONTAINS('%s', '1', '%s')", obj, path1), nil, false)
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', 'foo', NULL, '%s')", obj, path1), nil, false)
 
 			for _, path2 := range inputJSONPaths {
 				yield(fmt.Sprintf("JSON_EXTRACT('%s', '%s', '%s')", obj, path1, path2), nil, false)
@@ -195,6 +210,63 @@ func JSONPathOperations(yield Query) {
 	}
 }
 
+func JSONModify(yield Query) {
+	for _, obj := range inputJSONObjects {
+		for _, fn := range []string{"JSON_SET", "JSON_INSERT", "JSON_REPLACE"} {
+			for _, path1 := range inputJSONPaths {
+				yield(fmt.Sprintf("%s('%s', '%s', 'x')", fn, obj, path1), nil, false)
+				yield(fmt.Sprintf("%s('%s', '%s', NULL, '$[last-5]', JSON_ARRAY(1, 2))", fn, obj, path1), nil, false)
+			}
+			yield(fmt.Sprintf("%s('%s', '$[5]', 1, '$[5]', 2)", fn, obj), nil, false)
+			yield(fmt.Sprintf("%s('%s', '$.a[1]', 1.5, '$.b[0][0]', CAST('{}' AS JSON))", fn, obj), nil, false)
+			yield(fmt.Sprintf("%s('%s', NULL, 1)", fn, obj), nil, false)
+		}
+		for _, path1 := range inputJSONPaths {
+			yield(fmt.Sprintf("JSON_REMOVE('%s', '%s')", obj, path1), nil, false)
+			yield(fmt.Sprintf("JSON_REMOVE('%s', '$[0]', '%s')", obj, path1), nil, false)
+		}
+		yield(fmt.Sprintf("JSON_REMOVE('%s', '$[last]', '$.a')", obj), nil, false)
+	}
+	yield("JSON_SET(NULL, '$', 1)", nil, false)
+	yield("JSON_SET('1', '$', '2')", nil, false)
+	yield("JSON_SET(1, '$', 2)", nil, false)
+	yield("JSON_REMOVE(NULL, '$[0]')", nil, false)
+}
+
+func JSONMerge(yield Query) {
+	docs := []string{`'1'`, `'"a"'`, `'null'`, `'{}'`, `'[]'`, `'{"a": null, "c": 1}'`, `NULL`}
+	for _, obj := range inputJSONObjects {
+		docs = append(docs, fmt.Sprintf("'%s'", obj))
+	}
+	for _, a := range docs {
+		for _, b := range docs {
+			yield(fmt.Sprintf("JSON_MERGE_PATCH(%s, %s)", a, b), nil, false)
+			yield(fmt.Sprintf("JSON_MERGE_PRESERVE(%s, %s)", a, b), nil, false)
+			yield(fmt.Sprintf("JSON_CONTAINS(%s, %s)", a, b), nil, false)
+			yield(fmt.Sprintf("JSON_OVERLAPS(%s, %s)", a, b), nil, false)
+		}
+		yield(fmt.Sprintf("JSON_MERGE_PATCH(%s, NULL, '{\"b\": 2}')", a), nil, false)
+		yield(fmt.Sprintf("JSON_MERGE_PATCH(%s, NULL, '[1]')", a), nil, false)
+		yield(fmt.Sprintf("JSON_MERGE(%s, '{\"b\": 2}', '[1]')", a), nil, false)
+	}
+}
+
+func JSONSearch(yield Query) {
+	for _, obj := range inputJSONObjects {
+		for _, search := range []string{"'foo'", "'%2%'", "'1__'", "'a'", "'%'", "NULL", "123"} {
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'one', %s)", obj, search), nil, false)
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', %s)", obj, search), nil, false)
+		}
+		yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', 'f|%%', '|')", obj), nil, false)
+		yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', 'foo', '', '$', '$.b')", obj), nil, false)
+		yield(fmt.Sprintf("JSON_SEARCH('%s', 'many', 'foo')", obj), nil, false)
+	}
+	yield(`JSON_SEARCH('["a%b", "ab"]', 'all', 'a\\%b')`, nil, false)
+	yield(`JSON_SEARCH('["a%b", "ab"]', 'all', 'a|%b', '|')`, nil, false)
+	yield(`JSON_SEARCH('["a%b", "ab"]', 'all', 'a%b', 'ab')`, nil, false)
+	yield(`JSON_SEARCH('{"a b": "x"}', 'one', 'x')`, nil, false)
+}
+
 func JSONArray(yield Query) {
 	for _, a := range inputJSONPrimitives {
 		yield(fmt.Sprintf("JSON_ARRAY(%s)", a), nil, false)
@@ -1717,6 +1789,163 @@ func FnReplace(yield Query) {
 	}
 }
 
+func FnSubstringIndex(yield Query) {
+	cases := []string{
+		`SUBSTRING_INDEX('www.mysql.com', '.', 2)`,
+		`SUBSTRING_INDEX('www.mysql.com', '.', -2)`,
+		`SUBSTRING_INDEX('www.mysql.com', '.', 0)`,
+		`SUBSTRING_INDEX('www.mysql.com', '.', 10)`,
+		`SUBSTRING_INDEX('www.mysql.com', '.', -10)`,
+		`SUBSTRING_INDEX('www.mysql.com', '', 1)`,
+		`SUBSTRING_INDEX('aaaa', 'aa', 1)`,
+		`SUBSTRING_INDEX('aaaa', 'aa', -1)`,
+		`SUBSTRING_INDEX('aaaaa', 'aa', -2)`,
+		// The delimiter is matched byte by byte, so it is case sensitive.
+		`SUBSTRING_INDEX('fooBARfoobar', 'bar', 1)`,
+		`SUBSTRING_INDEX('straße ß strasse', 'ß', 2)`,
+		`SUBSTRING_INDEX('straße ß strasse', 'ß', -1)`,
+		`SUBSTRING_INDEX('中文,测试,中文', ',', 2)`,
+		`SUBSTRING_INDEX('中文,测试,中文', '文', -1)`,
+		`SUBSTRING_INDEX(_latin1 'fooÿbar', _utf8mb4 'ÿ', 1)`,
+		`SUBSTRING_INDEX('a,b,c', ',', 18446744073709551615)`,
+		`SUBSTRING_INDEX('a,b,c', ',', -9223372036854775808)`,
+		`SUBSTRING_INDEX(1234.5678, '.', 1)`,
+		`SUBSTRING_INDEX(_binary 'a.b.c', '.', -1)`,
+	}
+
+	for _, q := range cases {
+		yield(q, nil, false)
+	}
+
+	for _, str := range inputStrings {
+		for _, delim := range []string{"NULL", "''", "'a'", "'1'", "'å'", "'文'"} {
+			for _, count := range []string{"NULL", "1", "-1", "2", "-2"} {
+				yield(fmt.Sprintf("SUBSTRING_INDEX(%s, %s, %s)", str, delim, count), nil, false)
+			}
+		}
+	}
+}
+
+func FnFormat(yield Query) {
+	cases := []string{
+		`FORMAT(12332.123456, 4)`,
+		`FORMAT(12332.1, 4)`,
+		`FORMAT(12332.2, 0)`,
+		`FORMAT(12332.2, 2, 'de_DE')`,
+		`FORMAT(1234567.891, 2, 'de_CH')`,
+		`FORMAT(1234567.891, 2, 'fr_FR')`,
+		`FORMAT(123456789.5, 1, 'en_IN')`,
+		`FORMAT(1234567.891, 2, 'EN_us')`,
+		`FORMAT(1234567.891, 2, 'xx_XX')`,
+		`FORMAT(1234567.891, 2, NULL)`,
+		`FORMAT(-1234567.891, 2)`,
+		`FORMAT(-123, 2)`,
+		`FORMAT(0.5, 0)`,
+		`FORMAT(2.5e0, 0)`,
+		`FORMAT(-0.5, 0)`,
+		`FORMAT(1.005, 2)`,
+		`FORMAT(1e30, 2)`,
+		`FORMAT(12345.678, 40)`,
+		`FORMAT(12345.678, -1)`,
+		`FORMAT('12345.678', 1)`,
+		`FORMAT(DATE '2022-10-11', 2)`,
+	}
+
+	for _, q := range cases {
+		yield(q, nil, false)
+	}
+
+	for _, num := range inputBitwise {
+		for _, dec := range []string{"NULL", "0", "2", "'3'"} {
+			yield(fmt.Sprintf("FORMAT(%s, %s)", num, dec), nil, false)
+		}
+	}
+}
+
+func FnSoundex(yield Query) {
+	cases := []string{
+		`SOUNDEX('Hello')`,
+		`SOUNDEX('Quadratically')`,
+		`SOUNDEX('Robert')`,
+		`SOUNDEX('Rupert')`,
+		`SOUNDEX('Tymczak')`,
+		`SOUNDEX('Pfister')`,
+		`SOUNDEX('Ashcraft')`,
+		`SOUNDEX('  123 lee')`,
+		`SOUNDEX('123')`,
+		`SOUNDEX('Åsa')`,
+		`SOUNDEX('élan')`,
+		`SOUNDEX(_latin1 'Müller')`,
+	}
+
+	for _, q := range cases {
+		yield(q, nil, false)
+	}
+
+	for _, str := range inputStrings {
+		yield(fmt.Sprintf("SOUNDEX(%s)", str), nil, false)
+	}
+}
+
+func FnExportSet(yield Query) {
+	cases := []string{
+		`EXPORT_SET(5, 'Y', 'N', ',', 4)`,
+		`EXPORT_SET(6, '1', '0', ',', 10)`,
+		`EXPORT_SET(6, '1', '0', '', 10)`,
+		`EXPORT_SET(6, '1', '0')`,
+		`EXPORT_SET(6, 'on', 'off', '|')`,
+		`EXPORT_SET(-1, '1', '0', '', 100)`,
+		`EXPORT_SET(5, '1', '0', '', -1)`,
+		`EXPORT_SET(5, '1', '0', '', 0)`,
+		`EXPORT_SET(18446744073709551615, 'x', '', '', 64)`,
+		`EXPORT_SET(5, 'ÿ', _latin1 'n', ',', 4)`,
+		`EXPORT_SET(5, 1, 0, 2, 4)`,
+	}
+
+	for _, q := range cases {
+		yield(q, nil, false)
+	}
+
+	for _, bits := range inputBitwise {
+		yield(fmt.Sprintf("EXPORT_SET(%s, 'Y', 'N', ',', 8)", bits), nil, false)
+	}
+
+	for _, str := range inputStrings {
+		yield(fmt.Sprintf("EXPORT_SET(5, %s, 'N', ',', 4)", str), nil, false)
+		yield(fmt.Sprintf("EXPORT_SET(5, 'Y', %s)", str), nil, false)
+		yield(fmt.Sprintf("EXPORT_SET(5, 'Y', 'N', %s, 4)", str), nil, false)
+		yield(fmt.Sprintf("EXPORT_SET(5, 'Y', 'N', ',', %s)", str), nil, false)
+	}
+}
+
+func FnMakeSet(yield Query) {
+	cases := []string{
+		`MAKE_SET(1, 'a', 'b', 'c')`,
+		`MAKE_SET(1 | 4, 'hello', 'nice', 'world')`,
+		`MAKE_SET(1 | 4, 'hello', 'nice', NULL, 'world')`,
+		`MAKE_SET(0, 'a', 'b', 'c')`,
+		`MAKE_SET(-1, 'a', 'b', 'c')`,
+		`MAKE_SET(NULL, 'a', 'b', 'c')`,
+		`MAKE_SET(3, 'ÿ', _latin1 'b')`,
+		`MAKE_SET(3, 1, 2.5)`,
+		`MAKE_SET(18446744073709551615, 'a', 'b')`,
+	}
+
+	for _, q := range cases {
+		yield(q, nil, false)
+	}
+
+	for _, bits := range inputBitwise {
+		yield(fmt.Sprintf("MAKE_SET(%s, 'a', 'b', 'c', 'd')", bits), nil, false)
+	}
+
+	for _, str1 := range inputStrings {
+		for _, str2 := range inputStrings {
+			yield(fmt.Sprintf("MAKE_SET(3, %s, %s)", str1, str2), nil, false)
+		}
+	}
+}
+
 func FnConcat(yield Query) {
 	for _, str := range inputStrings {
 		yield(fmt.Sprintf("CONCAT(%s)", str), nil, false)
@@ -2289,6 +2518,158 @@ func FnPeriodDiff(yield Query) {
 	}
 }
 
+func FnStrToDate(yield Query) {
+	inputs := []struct {
+		str, format string
+	}{
+		{"'01,5,2013'", "'%d,%m,%Y'"},
+		{"'May 1, 2013'", "'%M %d,%Y'"},
+		{"'a09:30:17'", "'a%h:%i:%s'"},
+		{"'a09:30:17'", "'%h:%i:%s'"},
+		{"'09:30:17a'", "'%h:%i:%s'"},
+		{"'abc'", "'abc'"},
+		{"'9'", "'%m'"},
+		{"'9'", "'%s'"},
+		{"'00/00/0000'", "'%m/%d/%Y'"},
+		{"'04/31/2004'", "'%m/%d/%Y'"},
+		{"'2004-02-29'", "'%Y-%m-%d'"},
+		{"'2003-02-29'", "'%Y-%m-%d'"},
+		{"'1-1-1'", "'%Y-%m-%d'"},
+		{"'70-1-1'", "'%y-%m-%d'"},
+		{"'69-1-1'", "'%y-%m-%d'"},
+		{"'2013-05-01 10:11:12.345'", "'%Y-%m-%d %H:%i:%s.%f'"},
+		{"'10:11:12.345678'", "'%H:%i:%s.%f'"},
+		{"'10:11:12 PM'", "'%r'"},
+		{"'12:11:12 AM'", "'%r'"},
+		{"'13:11:12 PM'", "'%r'"},
+		{"'22:11:12'", "'%T'"},
+		{"'1st May 2013'", "'%D %b %Y'"},
+		{"'Tuesday 2013 18'", "'%W %Y %U'"},
+		{"'Tue 2013 18'", "'%a %x %v'"},
+		{"'Tue 2013 18'", "'%a %X %V'"},
+		{"'2 2013 18'", "'%w %Y %u'"},
+		{"'2013 032'", "'%Y %j'"},
+		{"'  2013 - 05 - 01'", "'%Y-%m-%d'"},
+		{"'2013/05/01'", "'%Y%.%m%.%d'"},
+		{"'2013abc05'", "'%Y%@%m'"},
+		{"'2013 12345 05 01'", "'%Y %# %m %d'"},
+		{"'2013-05-01 trailing'", "'%Y-%m-%d'"},
+		{"'10.20.30'", "'%k.%i.%S'"},
+		{"'10.20.30'", "'%l.%i.%S %p'"},
+		{"'2013-05-01'", "'%Y-%m-%d %H'"},
+		{"20130501", "'%Y%m%d'"},
+		{"'2013-05-01'", "NULL"},
+		{"NULL", "'%Y-%m-%d'"},
+	}
+
+	for _, in := range inputs {
+		yield(fmt.Sprintf("STR_TO_DATE(%s, %s)", in.str, in.format), nil, false)
+	}
+
+	var buf strings.Builder
+	for _, f := range dateFormats {
+		buf.WriteByte('%')
+		buf.WriteByte(f.c)
+		buf.WriteByte(' ')
+		format := buf.String()
+		for _, d := range inputConversions {
+			yield(fmt.Sprintf("STR_TO_DATE(DATE_FORMAT(%s, %q), %q)", d, format, format), nil, false)
+		}
+		buf.Reset()
+	}
+}
+
+var inputTemporalDiffs = []string{
+	"NULL", "0", "20000101", "20000101103458", "103458.123456",
+	"'2000-01-01'", "'2000-01-01 12:34:58'", "'2000-01-01 12:34:58.123'", "'10:04:58'", "'101:34:58'", "'-5 10:34:58'", "'foobar'",
+	"date '2000-01-01'", "date '2024-02-29'",
+	"time '10:04:58'", "time '-10:04:58.1'", "time '838:59:59'",
+	"timestamp '2000-01-01 10:34:58'", "timestamp '2000-01-01 10:34:58.123456'", "timestamp '2024-12-30 10:34:58'",
+}
+
+func FnDateDiff(yield Query) {
+	for _, d1 := range inputConversions {
+		for _, d2 := range inputTemporalDiffs {
+			yield(fmt.Sprintf("DATEDIFF(%s, %s)", d1, d2), nil, false)
+		}
+	}
+
+	mysqlDocSamples := []string{
+		`DATEDIFF('2007-12-31 23:59:59','2007-12-30')`,
+		`DATEDIFF('2010-11-30 23:59:59','2010-12-31')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+}
+
+func FnTimeDiff(yield Query) {
+	for _, d1 := range inputConversions {
+		for _, d2 := range inputTemporalDiffs {
+			yield(fmt.Sprintf("TIMEDIFF(%s, %s)", d1, d2), nil, false)
+		}
+	}
+
+	mysqlDocSamples := []string{
+		`TIMEDIFF('2000-01-01 00:00:00', '2000-01-01 00:00:00.000001')`,
+		`TIMEDIFF('2008-12-31 23:59:59.000001', '2008-12-30 01:01:01.000002')`,
+		`TIMEDIFF('2000-01-01 00:00:00', '2001-01-01 00:00:00')`,
+		`TIMEDIFF(time '-838:59:59', time '838:59:59')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+}
+
+func FnTimestampDiff(yield Query) {
+	units := []string{"MICROSECOND", "SECOND", "MINUTE", "HOUR", "DAY", "WEEK", "MONTH", "QUARTER", "YEAR"}
+	for _, unit := range units {
+		for _, d1 := range inputTemporalDiffs {
+			for _, d2 := range inputTemporalDiffs {
+				yield(fmt.Sprintf("TIMESTAMPDIFF(%s, %s, %s)", unit, d1, d2), nil, false)
+			}
+		}
+	}
+
+	mysqlDocSamples := []string{
+		`TIMESTAMPDIFF(MONTH,'2003-02-01','2003-05-01')`,
+		`TIMESTAMPDIFF(YEAR,'2002-05-01','2001-01-01')`,
+		`TIMESTAMPDIFF(MINUTE,'2003-02-01','2003-05-01 12:05:55')`,
+		`TIMESTAMPDIFF(MONTH,'2024-01-31','2024-02-29')`,
+		`TIMESTAMPDIFF(MONTH,'2024-01-31 10:00:00','2024-02-29 09:00:00')`,
+		`TIMESTAMPDIFF(QUARTER,'2024-05-31 10:00:00','2023-02-28 11:00:00')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+}
+
+func FnAddTime(yield Query) {
+	for _, fn := range []string{"ADDTIME", "SUBTIME"} {
+		for _, d1 := range inputConversions {
+			for _, d2 := range inputTemporalDiffs {
+				yield(fmt.Sprintf("%s(%s, %s)", fn, d1, d2), nil, false)
+			}
+		}
+	}
+
+	mysqlDocSamples := []string{
+		`ADDTIME('2007-12-31 23:59:59.999999', '1 1:1:1.000002')`,
+		`ADDTIME('01:00:00.999999', '02:00:00.999998')`,
+		`SUBTIME('2007-12-31 23:59:59.999999','1 1:1:1.000002')`,
+		`SUBTIME('01:00:00.999999', '02:00:00.999998')`,
+		`ADDTIME(time '838:00:00', '02:00:00')`,
+		`SUBTIME(timestamp '0001-01-01 00:00:00', '02:00:00')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+}
+
 func FnInetAton(yield Query) {
 	for _, d := range ipInputs {
 		yield(fmt.Sprintf("INET_ATON(%s)", d), nil, false)
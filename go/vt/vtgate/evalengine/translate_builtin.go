@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/json"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -542,6 +543,26 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 		default:
 			return nil, argError(method)
 		}
+	case "str_to_date":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinStrToDate{CallExpr: call}, nil
+	case "datediff":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinDateDiff{CallExpr: call}, nil
+	case "timediff":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinTimeDiff{CallExpr: call}, nil
+	case "addtime", "subtime":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinAddTime{CallExpr: call, sub: method == "subtime", collate: ast.cfg.Collation}, nil
 	case "inet_aton":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -662,6 +683,31 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinReplace{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "substring_index":
+		if len(args) != 3 {
+			return nil, argError(method)
+		}
+		return &builtinSubstringIndex{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "format":
+		if len(args) != 2 && len(args) != 3 {
+			return nil, argError(method)
+		}
+		return &builtinFormat{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "soundex":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinSoundex{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "export_set":
+		if len(args) < 3 || len(args) > 5 {
+			return nil, argError(method)
+		}
+		return &builtinExportSet{CallExpr: call, collate: ast.cfg.Collation}, nil
+	case "make_set":
+		if len(args) < 2 {
+			return nil, argError(method)
+		}
+		return &builtinMakeSet{CallExpr: call, collate: ast.cfg.Collation}, nil
 	case "last_insert_id":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -787,6 +833,93 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			Method:    "JSON_KEYS",
 		}}, nil
 
+	case *sqlparser.JSONValueModifierExpr:
+		var t json.Transformation
+		switch call.Type {
+		case sqlparser.JSONSetType:
+			t = json.Set
+		case sqlparser.JSONInsertType:
+			t = json.Insert
+		case sqlparser.JSONReplaceType:
+			t = json.Replace
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+		exprs := []sqlparser.Expr{call.JSONDoc}
+		for _, param := range call.Params {
+			exprs = append(exprs, param.Key, param.Value)
+		}
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONModify{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    strings.ToUpper(call.Type.ToString()),
+		}, t: t}, nil
+
+	case *sqlparser.JSONRemoveExpr:
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.PathList...))
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONModify{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_REMOVE",
+		}, t: json.Remove}, nil
+
+	case *sqlparser.JSONValueMergeExpr:
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.JSONDocList...))
+		if err != nil {
+			return nil, err
+		}
+		cexpr := CallExpr{
+			Arguments: args,
+			Method:    strings.ToUpper(call.Type.ToString()),
+		}
+		if call.Type == sqlparser.JSONMergePatchType {
+			return &builtinJSONMergePatch{CallExpr: cexpr}, nil
+		}
+		return &builtinJSONMergePreserve{CallExpr: cexpr}, nil
+
+	case *sqlparser.JSONContainsExpr:
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.Target, call.Candidate}, call.PathList...))
+		if err != nil {
+			return nil, err
+		}
+		if len(args) > 3 {
+			return nil, argError("JSON_CONTAINS")
+		}
+		return &builtinJSONContains{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_CONTAINS",
+		}}, nil
+
+	case *sqlparser.JSONSearchExpr:
+		exprs := []sqlparser.Expr{call.JSONDoc, call.OneOrAll, call.SearchStr}
+		if call.EscapeChar != nil {
+			exprs = append(exprs, call.EscapeChar)
+		}
+		exprs = append(exprs, call.PathList...)
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONSearch{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_SEARCH",
+		}}, nil
+
+	case *sqlparser.JSONOverlapsExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.JSONDoc1, call.JSONDoc2})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONOverlaps{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_OVERLAPS",
+		}}, nil
+
 	case *sqlparser.CurTimeFuncExpr:
 		if call.Fsp > 6 {
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Too-big precision %d specified for '%s'. Maximum is 6.", call.Fsp, call.Name.String())
@@ -908,6 +1041,25 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			collate:  ast.cfg.Collation,
 		}, nil
 
+	case *sqlparser.TimestampDiffExpr:
+		var err error
+		args := make([]IR, 2)
+
+		args[0], err = ast.translateExpr(call.Expr1)
+		if err != nil {
+			return nil, err
+		}
+		args[1], err = ast.translateExpr(call.Expr2)
+		if err != nil {
+			return nil, err
+		}
+
+		cexpr := CallExpr{Arguments: args, Method: "TIMESTAMPDIFF"}
+		return &builtinTimestampDiff{
+			CallExpr: cexpr,
+			unit:     call.Unit,
+		}, nil
+
 	case *sqlparser.RegexpLikeExpr:
 		input, err := ast.translateExpr(call.Expr)
 		if err != nil {
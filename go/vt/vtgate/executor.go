@@ -247,6 +247,7 @@ func (e *Executor) Execute(
 	defer span.Finish()
 
 	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars, streamlog.GetQueryLogConfig())
+	logStats.QueryAttributes = queryAttributesFromContext(ctx)
 	stmtType, result, err := e.execute(ctx, mysqlCtx, safeSession, sql, bindVars, prepared, logStats)
 	logStats.Error = err
 	if result == nil {
@@ -310,6 +311,7 @@ func (e *Executor) StreamExecute(
 	defer span.Finish()
 
 	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars, streamlog.GetQueryLogConfig())
+	logStats.QueryAttributes = queryAttributesFromContext(ctx)
 	srr := &streaminResultReceiver{callback: callback}
 	var err error
 
@@ -1155,7 +1157,9 @@ func (e *Executor) fetchOrCreatePlan(
 	}
 
 	// Apply query hints
-	e.applyQueryHints(vcursor, plan)
+	if err := e.applyQueryHints(ctx, vcursor, plan); err != nil {
+		return nil, nil, nil, err
+	}
 
 	logStats.SQL = comments.Leading + plan.Original + comments.Trailing
 	logStats.BindVariables = sqltypes.CopyBindVariables(bindVars)
@@ -1200,14 +1204,40 @@ func shouldOptimizePlan(preparedPlan, isExecutePath bool, plan *engine.Plan) boo
 		(plan.Type == engine.PlanJoinOp || plan.Type == engine.PlanComplex)
 }
 
-// applyQueryHints applies query hints to the vcursor
-func (e *Executor) applyQueryHints(vcursor *econtext.VCursorImpl, plan *engine.Plan) {
+// applyQueryHints applies query hints to the vcursor, the ones the query
+// comments didn't set can come from the query attributes of the client.
+func (e *Executor) applyQueryHints(ctx context.Context, vcursor *econtext.VCursorImpl, plan *engine.Plan) error {
 	qh := plan.QueryHints
+	if attributes := queryAttributesFromContext(ctx); len(attributes) > 0 {
+		var err error
+		qh, err = sqlparser.ApplyQueryAttributes(qh, attributes, plan.QueryType)
+		if err != nil {
+			return err
+		}
+	}
 	vcursor.SetIgnoreMaxMemoryRows(qh.IgnoreMaxMemoryRows)
 	vcursor.SetConsolidator(qh.Consolidator)
 	vcursor.SetWorkloadName(qh.Workload)
 	vcursor.SetPriority(qh.Priority)
 	vcursor.SetExecQueryTimeout(qh.Timeout)
+	return nil
+}
+
+type queryAttributesKey struct{}
+
+// withQueryAttributes returns a context with the query attributes
+// a MySQL client sent with its query.
+func withQueryAttributes(ctx context.Context, attributes map[string]string) context.Context {
+	if len(attributes) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, queryAttributesKey{}, attributes)
+}
+
+// queryAttributesFromContext returns the query attributes of the context, if any.
+func queryAttributesFromContext(ctx context.Context) map[string]string {
+	attributes, _ := ctx.Value(queryAttributesKey{}).(map[string]string)
+	return attributes
 }
 
 func (e *Executor) getCachedOrBuildPlan(
@@ -1538,6 +1568,7 @@ func (e *Executor) initVConfig(warnOnShardedOnly bool, pv plancontext.PlannerVer
 
 		QueryTimeout:  queryTimeout,
 		MaxMemoryRows: maxMemoryRows,
+		Spiller:       engine.NewSpiller(spillDir, spillDiskQuota),
 
 		SetVarEnabled:      sysVarSetEnabled,
 		EnableViews:        enableViews,
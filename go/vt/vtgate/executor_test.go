@@ -1837,6 +1837,34 @@ func TestGetPlanPriority(t *testing.T) {
 
 }
 
+func TestQueryAttributes(t *testing.T) {
+	executor, sbc1, _, _, ctx := createExecutorEnv(t)
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	logChan := executor.queryLogger.Subscribe("Test")
+	defer executor.queryLogger.Unsubscribe(logChan)
+
+	// Attributes named after directives are used as such, and all of them are logged.
+	attributes := map[string]string{"workload_name": "reports", "PRIORITY": "10", "traceparent": "00-abc"}
+	_, err := executorExec(withQueryAttributes(ctx, attributes), executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, sbc1.Options)
+	assert.Equal(t, "reports", sbc1.Options[len(sbc1.Options)-1].WorkloadName)
+	assert.Equal(t, "10", sbc1.Options[len(sbc1.Options)-1].Priority)
+	logStats := getQueryLog(logChan)
+	require.NotNil(t, logStats)
+	assert.Equal(t, attributes, logStats.QueryAttributes)
+
+	// The query comments take precedence.
+	_, err = executorExec(withQueryAttributes(ctx, attributes), executor, session, "select /*vt+ WORKLOAD_NAME=olap */ id from user where id = 1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "olap", sbc1.Options[len(sbc1.Options)-1].WorkloadName)
+	assert.Equal(t, "10", sbc1.Options[len(sbc1.Options)-1].Priority)
+
+	_, err = executorExec(withQueryAttributes(ctx, map[string]string{"PRIORITY": "200"}), executor, session, "select id from user where id = 1", nil)
+	assert.ErrorIs(t, err, sqlparser.ErrInvalidPriority)
+}
+
 func TestPassthroughDDL(t *testing.T) {
 	executor, sbc1, sbc2, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
 	session := &vtgatepb.Session{
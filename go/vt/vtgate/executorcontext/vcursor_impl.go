@@ -79,6 +79,7 @@ type (
 		Collation collations.ID
 
 		MaxMemoryRows      int
+		Spiller            *engine.Spiller
 		EnableShardRouting bool
 		DefaultTabletType  topodatapb.TabletType
 		QueryTimeout       int
@@ -384,6 +385,11 @@ func (vc *VCursorImpl) ExceedsMaxMemoryRows(numRows int) bool {
 	return !vc.ignoreMaxMemoryRows && numRows > vc.config.MaxMemoryRows
 }
 
+// Spiller returns the Spiller of the vtgate, or nil if spilling is disabled.
+func (vc *VCursorImpl) Spiller() *engine.Spiller {
+	return vc.config.Spiller
+}
+
 // SetIgnoreMaxMemoryRows sets the ignoreMaxMemoryRows value.
 func (vc *VCursorImpl) SetIgnoreMaxMemoryRows(ignoreMaxMemoryRows bool) {
 	vc.ignoreMaxMemoryRows = ignoreMaxMemoryRows
@@ -53,6 +53,7 @@ func init() {
 		"vtclient",
 		"vtcombo",
 		"vtctl",
+		"vtdebezium",
 		"vttestserver",
 	} {
 		servenv.OnParseFor(cmd, registerFlags)
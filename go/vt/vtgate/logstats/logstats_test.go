@@ -185,6 +185,28 @@ func TestLogStatsRowThreshold(t *testing.T) {
 	assert.Empty(t, got)
 }
 
+func TestLogStatsQueryAttributes(t *testing.T) {
+	logStats := NewLogStats(context.Background(), "test", "sql1", "", nil, streamlog.NewQueryLogConfigForTest())
+	logStats.StartTime = time.Date(2017, time.January, 1, 1, 2, 3, 0, time.UTC)
+	logStats.EndTime = time.Date(2017, time.January, 1, 1, 2, 4, 1234, time.UTC)
+	logStats.QueryAttributes = map[string]string{"traceparent": "00-abc", "attempt": "2"}
+	params := map[string][]string{"full": {}}
+
+	// The query attributes are only logged when asked for.
+	got := testFormat(t, logStats, params)
+	assert.NotContains(t, got, "traceparent")
+
+	logStats.Config.QueryAttributes = true
+	got = testFormat(t, logStats, params)
+	assert.True(t, strings.HasSuffix(got, "\t{\"attempt\": \"2\", \"traceparent\": \"00-abc\"}\n"), got)
+
+	logStats.Config.Format = streamlog.QueryLogFormatJSON
+	got = testFormat(t, logStats, params)
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(got), &parsed))
+	assert.Equal(t, map[string]any{"traceparent": "00-abc", "attempt": "2"}, parsed["QueryAttributes"])
+}
+
 func TestLogStatsContextHTML(t *testing.T) {
 	html := "HtmlContext"
 	callInfo := &fakecallinfo.FakeCallInfo{
@@ -180,6 +180,10 @@ func createInstructionFor(ctx context.Context, query string, stmt sqlparser.Stat
 		return buildShowThrottledAppsPlan(query, vschema)
 	case *sqlparser.ShowThrottlerStatus:
 		return buildShowThrottlerStatusPlan(query, vschema)
+	case *sqlparser.ShowDeadLetters:
+		return buildDeadLettersPlan("SHOW VITESS_DEAD_LETTERS", stmt, &stmt.Table, vschema)
+	case *sqlparser.ReplayDeadLetters:
+		return buildDeadLettersPlan("REPLAY VITESS_DEAD_LETTERS", stmt, &stmt.Table, vschema)
 	case *sqlparser.AlterVschema:
 		return buildVSchemaDDLPlan(stmt, vschema)
 	case *sqlparser.Use:
@@ -22,10 +22,14 @@ import (
 	"strconv"
 	"strings"
 
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/json"
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/sysvars"
+	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
@@ -59,6 +63,8 @@ func recursiveTransform(ctx *plancontext.PlanningContext, op operators.Operator)
 		return transformUnionPlan(ctx, op)
 	case *operators.Vindex:
 		return transformVindexPlan(ctx, op)
+	case *operators.JSONTable:
+		return transformJSONTable(ctx, op)
 	case *operators.SubQuery:
 		return transformSubQuery(ctx, op)
 	case *operators.Filter:
@@ -73,6 +79,8 @@ func recursiveTransform(ctx *plancontext.PlanningContext, op operators.Operator)
 		return transformOrdering(ctx, op)
 	case *operators.Aggregator:
 		return transformAggregator(ctx, op)
+	case *operators.Window:
+		return transformWindow(ctx, op)
 	case *operators.Distinct:
 		return transformDistinct(ctx, op)
 	case *operators.FkCascade:
@@ -383,6 +391,55 @@ func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggrega
 	}, nil
 }
 
+func transformWindow(ctx *plancontext.PlanningContext, op *operators.Window) (engine.Primitive, error) {
+	src, err := transformToPrimitive(ctx, op.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	collationEnv := ctx.VSchema.Environment().CollationEnv()
+	keys := func(in []operators.WindowKey) []*engine.GroupByParams {
+		var out []*engine.GroupByParams
+		for _, key := range in {
+			typ, _ := ctx.TypeForExpr(key.Expr)
+			out = append(out, &engine.GroupByParams{
+				KeyCol:          key.Offset,
+				WeightStringCol: key.WSOffset,
+				Expr:            key.Expr,
+				Type:            typ,
+				CollationEnv:    collationEnv,
+			})
+		}
+		return out
+	}
+
+	var functions []*engine.WindowFunc
+	for _, fn := range op.Functions {
+		wf := &engine.WindowFunc{
+			Opcode:       fn.OpCode,
+			AggrOpcode:   fn.AggrOpCode,
+			Col:          fn.ArgOffset,
+			N:            fn.N,
+			DefaultCol:   fn.DefaultOffset,
+			Frame:        fn.Frame,
+			CollationEnv: collationEnv,
+			Alias:        sqlparser.String(fn.Original),
+		}
+		if fn.Arg != nil {
+			wf.Type, _ = ctx.TypeForExpr(fn.Arg)
+		}
+		functions = append(functions, wf)
+	}
+
+	return &engine.Window{
+		PartitionBy: keys(op.Partition),
+		OrderBy:     keys(op.Order),
+		Functions:   functions,
+		Cols:        op.Offsets,
+		Input:       src,
+	}, nil
+}
+
 func transformDistinct(ctx *plancontext.PlanningContext, op *operators.Distinct) (engine.Primitive, error) {
 	src, err := transformToPrimitive(ctx, op.Source)
 	if err != nil {
@@ -1021,6 +1078,168 @@ func transformVindexPlan(ctx *plancontext.PlanningContext, op *operators.Vindex)
 	return prim, nil
 }
 
+func transformJSONTable(ctx *plancontext.PlanningContext, op *operators.JSONTable) (engine.Primitive, error) {
+	cfg := &evalengine.Config{
+		Collation:   ctx.SemTable.Collation,
+		ResolveType: ctx.TypeForExpr,
+		Environment: ctx.VSchema.Environment(),
+	}
+	doc, err := evalengine.Translate(op.Doc, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	jtb := &jsonTableBuilder{
+		env:     ctx.VSchema.Environment(),
+		table:   op.Expr.Alias.String(),
+		offsets: map[string]int{},
+	}
+	path, err := jtb.buildPath(op.Expr.Filter, op.Expr.Columns)
+	if err != nil {
+		return nil, err
+	}
+	prim := &engine.JSONTable{
+		Doc:    doc,
+		Path:   path,
+		Fields: jtb.fields,
+	}
+
+	// if all the columns needed are columns of the JSON_TABLE, we can return them directly
+	allColumns := true
+	for _, ae := range op.Columns {
+		col, ok := ae.Expr.(*sqlparser.ColName)
+		if !ok {
+			allColumns = false
+			break
+		}
+		prim.Cols = append(prim.Cols, jtb.offsets[col.Name.Lowered()])
+	}
+	if allColumns {
+		return prim, nil
+	}
+
+	// otherwise, we produce all the declared columns and evaluate the expressions on top of them
+	prim.Cols = make([]int, len(jtb.fields))
+	for i := range prim.Cols {
+		prim.Cols[i] = i
+	}
+	cfg.ResolveColumn = func(col *sqlparser.ColName) (int, error) {
+		offset, ok := jtb.offsets[col.Name.Lowered()]
+		if !ok {
+			return 0, vterrors.VT13001(fmt.Sprintf("column %s not found in JSON_TABLE %s", sqlparser.String(col), jtb.table))
+		}
+		return offset, nil
+	}
+	proj := &engine.Projection{Input: prim}
+	for _, ae := range op.Columns {
+		expr, err := evalengine.Translate(ae.Expr, cfg)
+		if err != nil {
+			return nil, err
+		}
+		proj.Cols = append(proj.Cols, ae.ColumnName())
+		proj.Exprs = append(proj.Exprs, expr)
+	}
+	return proj, nil
+}
+
+// jsonTableBuilder turns the COLUMNS clause of a JSON_TABLE into the paths and fields used by engine.JSONTable
+type jsonTableBuilder struct {
+	env     *vtenv.Environment
+	table   string
+	fields  []*querypb.Field
+	offsets map[string]int
+}
+
+func (jtb *jsonTableBuilder) buildPath(pathExpr sqlparser.Expr, columns []*sqlparser.JtColumnDefinition) (*engine.JSONTablePath, error) {
+	path, err := jsonTablePath(pathExpr)
+	if err != nil {
+		return nil, err
+	}
+	result := &engine.JSONTablePath{Path: path}
+	for _, def := range columns {
+		switch {
+		case def.JtOrdinal != nil:
+			result.Columns = append(result.Columns, &engine.JSONTableColumn{
+				Offset: jtb.addField(def.JtOrdinal.Name, sqltypes.Uint32),
+				Kind:   engine.JSONTableOrdinality,
+			})
+		case def.JtPath != nil:
+			col, err := jtb.buildColumn(def.JtPath)
+			if err != nil {
+				return nil, err
+			}
+			result.Columns = append(result.Columns, col)
+		case def.JtNestedPath != nil:
+			nested, err := jtb.buildPath(def.JtNestedPath.Path, def.JtNestedPath.Columns)
+			if err != nil {
+				return nil, err
+			}
+			result.Nested = append(result.Nested, nested)
+		}
+	}
+	return result, nil
+}
+
+func (jtb *jsonTableBuilder) buildColumn(def *sqlparser.JtPathColDef) (*engine.JSONTableColumn, error) {
+	path, err := jsonTablePath(def.Path)
+	if err != nil {
+		return nil, err
+	}
+	col := &engine.JSONTableColumn{
+		Offset: jtb.addField(def.Name, def.Type.SQLType()),
+		Path:   path,
+	}
+	if def.JtColExists {
+		col.Kind = engine.JSONTableExists
+	}
+	if col.OnEmpty, err = jsonTableOnResponse(def.EmptyOnResponse); err != nil {
+		return nil, err
+	}
+	if col.OnError, err = jsonTableOnResponse(def.ErrorOnResponse); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+func (jtb *jsonTableBuilder) addField(name sqlparser.IdentifierCI, typ sqltypes.Type) int {
+	coll := collations.CollationForType(typ, jtb.env.CollationEnv().DefaultConnectionCharset())
+	t := evalengine.NewType(typ, coll)
+	field := t.ToField(name.String())
+	field.Table = jtb.table
+
+	offset := len(jtb.fields)
+	jtb.fields = append(jtb.fields, field)
+	jtb.offsets[name.Lowered()] = offset
+	return offset
+}
+
+func jsonTablePath(expr sqlparser.Expr) (*json.Path, error) {
+	lit, ok := expr.(*sqlparser.Literal)
+	if !ok || lit.Type != sqlparser.StrVal {
+		return nil, vterrors.VT12001(fmt.Sprintf("JSON_TABLE path that is not a string literal: %s", sqlparser.String(expr)))
+	}
+	var p json.PathParser
+	return p.ParseBytes([]byte(lit.Val))
+}
+
+func jsonTableOnResponse(r *sqlparser.JtOnResponse) (engine.JSONTableOnResponse, error) {
+	if r == nil {
+		return engine.JSONTableOnResponse{}, nil
+	}
+	switch r.ResponseType {
+	case sqlparser.ErrorJSONType:
+		return engine.JSONTableOnResponse{Response: engine.JSONTableError}, nil
+	case sqlparser.DefaultJSONType:
+		lit, ok := r.Expr.(*sqlparser.Literal)
+		if !ok {
+			return engine.JSONTableOnResponse{}, vterrors.VT12001(fmt.Sprintf("JSON_TABLE default value that is not a literal: %s", sqlparser.String(r.Expr)))
+		}
+		return engine.JSONTableOnResponse{Response: engine.JSONTableDefault, Default: lit.Val}, nil
+	default:
+		return engine.JSONTableOnResponse{}, nil
+	}
+}
+
 func transformRecurseCTE(ctx *plancontext.PlanningContext, op *operators.RecurseCTE) (engine.Primitive, error) {
 	seed, err := transformToPrimitive(ctx, op.Seed())
 	if err != nil {
@@ -376,18 +376,29 @@ func (ts *tableSorter) Len() int {
 
 // Less implements the Sort interface
 func (ts *tableSorter) Less(i, j int) bool {
-	lhs := ts.sel.From[i]
-	rhs := ts.sel.From[j]
-	left, ok := lhs.(*sqlparser.AliasedTableExpr)
+	left, ok := ts.tableSetFor(ts.sel.From[i])
 	if !ok {
 		return i < j
 	}
-	right, ok := rhs.(*sqlparser.AliasedTableExpr)
+	right, ok := ts.tableSetFor(ts.sel.From[j])
 	if !ok {
 		return i < j
 	}
 
-	return ts.tbl.TableSetFor(left).TableOffset() < ts.tbl.TableSetFor(right).TableOffset()
+	return left.TableOffset() < right.TableOffset()
+}
+
+func (ts *tableSorter) tableSetFor(expr sqlparser.TableExpr) (semantics.TableSet, bool) {
+	switch expr := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		return ts.tbl.TableSetFor(expr), true
+	case *sqlparser.JSONTableExpr:
+		// JSON_TABLE can only reference tables that come before it,
+		// and it always gets a higher table offset than those tables
+		return ts.tbl.TableSetForJSONTable(expr), true
+	default:
+		return semantics.EmptyTableSet(), false
+	}
 }
 
 // Swap implements the Sort interface
@@ -414,6 +425,7 @@ func stripDownQuery(from, to sqlparser.TableStatement) {
 		toNode.Distinct = node.Distinct
 		toNode.GroupBy = node.GroupBy
 		toNode.Having = node.Having
+		toNode.Windows = node.Windows
 		toNode.OrderBy = node.OrderBy
 		toNode.Comments = node.Comments
 		toNode.Limit = node.Limit
@@ -439,6 +451,8 @@ func buildQuery(op Operator, qb *queryBuilder) {
 	switch op := op.(type) {
 	case *Table:
 		buildTable(op, qb)
+	case *JSONTable:
+		buildJSONTable(op, qb)
 	case *Projection:
 		buildProjection(op, qb)
 	case *ApplyJoin:
@@ -584,6 +598,17 @@ func buildTable(op *Table, qb *queryBuilder) {
 	}
 }
 
+func buildJSONTable(op *JSONTable, qb *queryBuilder) {
+	if qb.stmt == nil {
+		qb.stmt = &sqlparser.Select{}
+	}
+	qb.stmt.(FromStatement).SetFrom(append(qb.stmt.(FromStatement).GetFrom(), op.Expr))
+	qb.tableNames = append(qb.tableNames, op.Expr.Alias.String())
+	for _, col := range op.Columns {
+		qb.addProjection(col)
+	}
+}
+
 func buildProjection(op *Projection, qb *queryBuilder) {
 	buildQuery(op.Source, qb)
 
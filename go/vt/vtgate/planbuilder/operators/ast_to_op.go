@@ -242,6 +242,8 @@ func getOperatorFromTableExpr(ctx *plancontext.PlanningContext, tableExpr sqlpar
 		return getOperatorFromJoinTableExpr(ctx, tableExpr)
 	case *sqlparser.ParenTableExpr:
 		return crossJoin(ctx, tableExpr.Exprs)
+	case *sqlparser.JSONTableExpr:
+		return newJSONTable(ctx, tableExpr)
 	default:
 		panic(vterrors.VT13001(fmt.Sprintf("unable to use: %T table type", tableExpr)))
 	}
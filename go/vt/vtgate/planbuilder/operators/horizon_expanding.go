@@ -86,7 +86,22 @@ func expandSelectHorizon(ctx *plancontext.PlanningContext, horizon *Horizon, sel
 		}
 	}
 
+	var window *Window
+	if qp.HasWindow && !canPushWindowFunctions(ctx, sel, horizon.src()) {
+		if qp.NeedsAggregation() {
+			panic(vterrors.VT12001("window functions together with aggregation across shards"))
+		}
+		window = newWindow(ctx, sel, horizon.src())
+		horizon.Source = window
+	}
+
 	op := createProjectionFromSelect(ctx, horizon)
+	if qp.HasWindow {
+		rewriteWindowFunctions(ctx, op, sel.Windows, window != nil)
+	}
+	if window != nil {
+		extracted = append(extracted, "Window")
+	}
 	if qp.HasAggr {
 		extracted = append(extracted, "Aggregation")
 	} else {
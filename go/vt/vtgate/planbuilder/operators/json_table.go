@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"fmt"
+	"slices"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/semantics"
+)
+
+// JSONTable represents a JSON_TABLE table function in the FROM clause.
+// When the document can be produced by the same route as the rest of the query,
+// the table function ends up inside the route and is sent to MySQL.
+// Otherwise, it is evaluated at the vtgate level, typically on the RHS of an ApplyJoin,
+// with the columns it needs from the LHS sent over as bind variables.
+type JSONTable struct {
+	nullaryOperator
+
+	TableID semantics.TableSet
+	Expr    *sqlparser.JSONTableExpr
+
+	// Doc is the document expression. It starts out as the expression in the AST,
+	// but columns coming from the LHS of a join are replaced with arguments when
+	// the table function has to be evaluated at the vtgate level
+	Doc sqlparser.Expr
+
+	Columns []*sqlparser.AliasedExpr
+}
+
+func newJSONTable(ctx *plancontext.PlanningContext, expr *sqlparser.JSONTableExpr) Operator {
+	jt := &JSONTable{
+		TableID: ctx.SemTable.TableSetForJSONTable(expr),
+		Expr:    expr,
+		Doc:     expr.Expr,
+	}
+
+	if !ctx.SemTable.RecursiveDeps(expr.Expr).IsEmpty() {
+		return jt
+	}
+
+	// the document does not depend on any table, so this can be evaluated anywhere
+	return &Route{
+		unaryOperator: newUnaryOp(jt),
+		Routing:       &DualRouting{},
+	}
+}
+
+// Clone implements the Operator interface
+func (jt *JSONTable) Clone([]Operator) Operator {
+	clone := *jt
+	clone.Columns = slices.Clone(jt.Columns)
+	return &clone
+}
+
+func (jt *JSONTable) introducesTableID() semantics.TableSet {
+	return jt.TableID
+}
+
+func (jt *JSONTable) AddPredicate(_ *plancontext.PlanningContext, expr sqlparser.Expr) Operator {
+	return newFilter(jt, expr)
+}
+
+func (jt *JSONTable) AddColumn(ctx *plancontext.PlanningContext, reuse bool, _ bool, ae *sqlparser.AliasedExpr) int {
+	if reuse {
+		if offset := jt.FindCol(ctx, ae.Expr, true); offset > -1 {
+			return offset
+		}
+	}
+	jt.Columns = append(jt.Columns, ae)
+	return len(jt.Columns) - 1
+}
+
+func (jt *JSONTable) AddWSColumn(ctx *plancontext.PlanningContext, offset int, underRoute bool) int {
+	if offset >= len(jt.Columns) || offset < 0 {
+		panic(vterrors.VT13001(fmt.Sprintf("offset [%d] out of range [%d]", offset, len(jt.Columns))))
+	}
+	return jt.AddColumn(ctx, true, false, aeWrap(weightStringFor(jt.Columns[offset].Expr)))
+}
+
+func (jt *JSONTable) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, _ bool) int {
+	return slices.IndexFunc(jt.Columns, func(ae *sqlparser.AliasedExpr) bool {
+		return ctx.SemTable.EqualsExprWithDeps(expr, ae.Expr)
+	})
+}
+
+func (jt *JSONTable) GetColumns(*plancontext.PlanningContext) []*sqlparser.AliasedExpr {
+	return jt.Columns
+}
+
+func (jt *JSONTable) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.SelectExpr {
+	return transformColumnsToSelectExprs(ctx, jt)
+}
+
+func (jt *JSONTable) GetOrdering(*plancontext.PlanningContext) []OrderBy {
+	return nil
+}
+
+func (jt *JSONTable) ShortDescription() string {
+	return fmt.Sprintf("%s AS %s", sqlparser.String(jt.Doc), jt.Expr.Alias.String())
+}
+
+// bindLHSColumns replaces the columns coming from the LHS of the join with arguments,
+// so the document can be evaluated at the vtgate level
+func (jt *JSONTable) bindLHSColumns(ctx *plancontext.PlanningContext, aj *ApplyJoin) {
+	lhs := TableID(aj.LHS)
+	jt.Doc = sqlparser.CopyOnRewrite(jt.Doc, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+		col, ok := cursor.Node().(*sqlparser.ColName)
+		if !ok || !ctx.SemTable.RecursiveDeps(col).IsSolvedBy(lhs) {
+			return
+		}
+		cursor.Replace(sqlparser.NewArgument(aj.findOrAddColNameBindVarName(ctx, col)))
+	}, ctx.SemTable.CopySemanticInfo).(sqlparser.Expr)
+}
+
+// jsonTableFor returns the JSON_TABLE at the bottom of op,
+// if op only consists of filters on top of it
+func jsonTableFor(op Operator) *JSONTable {
+	for {
+		switch o := op.(type) {
+		case *JSONTable:
+			return o
+		case *Filter:
+			op = o.Source
+		default:
+			return nil
+		}
+	}
+}
+
+// tryMergeJSONTable merges a JSON_TABLE into the route on its LHS,
+// when that route produces all the columns the document depends on
+func tryMergeJSONTable(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredicates []sqlparser.Expr, joinType sqlparser.JoinType) *Route {
+	jt := jsonTableFor(rhs)
+	if jt == nil {
+		return nil
+	}
+	route, ok := lhs.(*Route)
+	if !ok || !ctx.SemTable.RecursiveDeps(jt.Doc).IsSolvedBy(TableID(route)) {
+		return nil
+	}
+
+	aj := NewApplyJoin(ctx, route.Source, rhs, ctx.SemTable.AndExpressions(joinPredicates...), joinType, false)
+	for _, column := range aj.JoinPredicates.columns {
+		if column.JoinPredicateID != nil {
+			ctx.PredTracker.Set(*column.JoinPredicateID, column.Original)
+		}
+	}
+	return &Route{
+		unaryOperator: newUnaryOp(aj),
+		MergedWith:    route.MergedWith,
+		Routing:       route.Routing,
+		Conditions:    route.Conditions,
+	}
+}
@@ -65,6 +65,8 @@ func mustFetchFromInput(ctx *plancontext.PlanningContext, e sqlparser.SQLNode) b
 	switch fun := e.(type) {
 	case *sqlparser.ColName, sqlparser.AggrFunc:
 		return true
+	case *sqlparser.ArgumentLessWindowExpr, *sqlparser.FirstOrLastValueExpr, *sqlparser.NtileExpr, *sqlparser.NTHValueExpr, *sqlparser.LagLeadExpr:
+		return true
 	case *sqlparser.FuncExpr:
 		return fun.Name.EqualsAnyString(ctx.VSchema.GetAggregateUDFs())
 	default:
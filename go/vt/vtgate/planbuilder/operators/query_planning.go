@@ -276,6 +276,7 @@ func pushOrExpandHorizon(ctx *plancontext.PlanningContext, in *Horizon) (Operato
 	hasHaving := isSel && sel.Having != nil
 
 	canPush := isRoute &&
+		(!qp.HasWindow || canPushWindowFunctions(ctx, sel, rb)) &&
 		!hasHaving &&
 		!needsOrdering &&
 		!qp.NeedsAggregation() &&
@@ -557,6 +558,9 @@ func setUpperLimit(in *Limit) (Operator, *ApplyResult) {
 		case *Join, *ApplyJoin, *SubQueryContainer, *SubQuery:
 			// we can't push limits down on either side
 			return SkipChildren
+		case *Window:
+			// window functions need to see all the rows of a partition
+			return SkipChildren
 		case *Aggregator:
 			if len(op.Grouping) > 0 {
 				// we can't push limits down if we have a group by
@@ -42,6 +42,7 @@ type (
 		// If you change the contents here, please update the toString() method
 		SelectExprs  []SelectExpr
 		HasAggr      bool
+		HasWindow    bool
 		Distinct     bool
 		WithRollup   bool
 		groupByExprs []GroupBy
@@ -165,6 +166,7 @@ func createQPFromSelect(ctx *plancontext.PlanningContext, sel *sqlparser.Select)
 	}
 
 	qp.addSelectExpressions(ctx, sel)
+	qp.HasWindow = sqlparser.ContainsWindowFunc(sel.SelectExprs) || sqlparser.ContainsWindowFunc(sel.OrderBy)
 	qp.addGroupBy(ctx, sel.GroupBy)
 	qp.addOrderBy(ctx, sel.OrderBy)
 	if !qp.HasAggr && sel.Having != nil {
@@ -296,6 +296,10 @@ func mergeOrJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredic
 		return newPlan, Rewrote("merge routes into single operator")
 	}
 
+	if newPlan := tryMergeJSONTable(ctx, lhs, rhs, joinPredicates, joinType); newPlan != nil {
+		return newPlan, Rewrote("merge JSON_TABLE into the route producing its document")
+	}
+
 	if len(joinPredicates) > 0 && requiresSwitchingSides(ctx, rhs) {
 		if !joinType.IsCommutative() || requiresSwitchingSides(ctx, lhs) {
 			// we can't switch sides, so let's see if we can use a HashJoin to solve it
@@ -318,6 +322,9 @@ func mergeOrJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredic
 	for _, pred := range joinPredicates {
 		join.AddJoinPredicate(ctx, pred, true)
 	}
+	if jt := jsonTableFor(join.RHS); jt != nil {
+		jt.bindLHSColumns(ctx, join)
+	}
 
 	return join, Rewrote("logical join to applyJoin ")
 }
@@ -43,6 +43,11 @@ func isMergeable(ctx *plancontext.PlanningContext, query sqlparser.TableStatemen
 
 	switch node := query.(type) {
 	case *sqlparser.Select:
+		if !canPushWindowFunctions(ctx, node, op) {
+			// window functions that can't be evaluated on a single shard have to run on the vtgate
+			return false
+		}
+
 		if node.GroupBy != nil && len(node.GroupBy.Exprs) > 0 {
 			// iff we are grouping, we need to check that we can perform the grouping inside a single shard, and we check that
 			// by checking that one of the grouping expressions used is a unique single column vindex.
@@ -0,0 +1,488 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+)
+
+type (
+	// Window evaluates window functions at the vtgate level. It is used when the rows of a
+	// window partition can live on different shards. The input is sorted by the PARTITION BY and
+	// ORDER BY expressions of the window, and the window functions are evaluated one partition at a time.
+	Window struct {
+		unaryOperator
+
+		// Partition and Order are shared by all the window functions evaluated by this operator
+		Partition []WindowKey
+		Order     []WindowKey
+
+		Functions []*WindowFunc
+
+		// Columns are the columns produced by this operator.
+		Columns []*sqlparser.AliasedExpr
+		// Offsets has, for every column, the offset of the column in the input,
+		// or -(n+1) if the column is the result of Functions[n]
+		Offsets []int
+
+		windows       sqlparser.NamedWindows
+		partitionSpec *sqlparser.WindowSpecification
+	}
+
+	// WindowKey is a PARTITION BY or ORDER BY expression of a window
+	WindowKey struct {
+		Expr     sqlparser.Expr
+		Offset   int
+		WSOffset int
+	}
+
+	// WindowFunc is a single window function evaluated by the Window operator
+	WindowFunc struct {
+		Original   sqlparser.Expr
+		OpCode     opcode.WindowOpcode
+		AggrOpCode opcode.AggregateOpcode
+
+		// Arg and Default are the argument and the default value of the function, if it has them
+		Arg, Default sqlparser.Expr
+		N            int64
+		Frame        *engine.WindowFrame
+
+		ArgOffset, DefaultOffset int
+	}
+)
+
+// canPushWindowFunctions returns true if the window functions in the query can be evaluated by the
+// underlying MySQL. That is the case when every partition is guaranteed to live on a single shard,
+// which is the case when the PARTITION BY contains a column with a unique vindex.
+func canPushWindowFunctions(ctx *plancontext.PlanningContext, sel *sqlparser.Select, op Operator) bool {
+	if rb, isRoute := op.(*Route); isRoute && rb.IsSingleShard() {
+		return true
+	}
+
+	canPush := true
+	forEachWindowFunc(sel, func(e sqlparser.Expr) {
+		spec := sqlparser.ResolveWindowSpec(sqlparser.GetOverClause(e), sel.Windows)
+		if spec == nil || !slices.ContainsFunc(spec.PartitionClause, func(expr sqlparser.Expr) bool {
+			vindex := findColumnVindex(ctx, op, expr)
+			return vindex != nil && vindex.IsUnique()
+		}) {
+			canPush = false
+		}
+	})
+	return canPush
+}
+
+// forEachWindowFunc calls the given function with all the window functions used in the SELECT and ORDER BY
+// clauses of the query. Subqueries are not inspected, since they are planned separately.
+func forEachWindowFunc(sel *sqlparser.Select, f func(sqlparser.Expr)) {
+	visit := func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Subquery:
+			return false, nil
+		case sqlparser.Expr:
+			if sqlparser.IsWindowFunc(node) {
+				f(node)
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	_ = sqlparser.Walk(visit, sel.SelectExprs, sel.OrderBy)
+}
+
+// newWindow creates the Window operator needed to evaluate the window functions of the query,
+// together with the Ordering that sorts the input in partition order
+func newWindow(ctx *plancontext.PlanningContext, sel *sqlparser.Select, src Operator) *Window {
+	w := &Window{windows: sel.Windows}
+	var spec *sqlparser.WindowSpecification
+	forEachWindowFunc(sel, func(e sqlparser.Expr) {
+		if spec == nil {
+			spec = w.resolveSpec(e)
+		}
+	})
+	if spec == nil {
+		panic(vterrors.VT13001("expected window functions in the query"))
+	}
+
+	var order []OrderBy
+	for _, expr := range spec.PartitionClause {
+		w.Partition = append(w.Partition, WindowKey{Expr: expr, Offset: -1, WSOffset: -1})
+		order = append(order, OrderBy{
+			Inner:          &sqlparser.Order{Expr: expr, Direction: sqlparser.AscOrder},
+			SimplifiedExpr: expr,
+		})
+	}
+	for _, by := range spec.OrderClause {
+		w.Order = append(w.Order, WindowKey{Expr: by.Expr, Offset: -1, WSOffset: -1})
+		order = append(order, OrderBy{Inner: by, SimplifiedExpr: by.Expr})
+	}
+	w.partitionSpec = spec
+
+	// all the window functions are checked up front, so unsupported queries fail early
+	forEachWindowFunc(sel, func(e sqlparser.Expr) {
+		if avg, isAvg := e.(*sqlparser.Avg); isAvg {
+			// AVG is evaluated as SUM / COUNT, see splitAvgWindowFunctions
+			w.addWindowFunc(ctx, &sqlparser.Sum{Arg: avg.Arg, OverClause: avg.OverClause})
+			w.addWindowFunc(ctx, &sqlparser.Count{Args: []sqlparser.Expr{avg.Arg}, OverClause: avg.OverClause})
+			return
+		}
+		w.addWindowFunc(ctx, e)
+	})
+
+	if len(order) > 0 {
+		src = newOrdering(src, order)
+	}
+	w.Source = src
+	return w
+}
+
+// resolveSpec returns the window specification used by the window function
+func (w *Window) resolveSpec(e sqlparser.Expr) *sqlparser.WindowSpecification {
+	over := sqlparser.GetOverClause(e)
+	spec := sqlparser.ResolveWindowSpec(over, w.windows)
+	if spec == nil {
+		panic(vterrors.VT03012(fmt.Sprintf("window '%s' is not defined", over.WindowName.String())))
+	}
+	return spec
+}
+
+// addWindowFunc adds a new window function to the operator and returns its index
+func (w *Window) addWindowFunc(ctx *plancontext.PlanningContext, e sqlparser.Expr) int {
+	for idx, fn := range w.Functions {
+		if ctx.SemTable.EqualsExprWithDeps(fn.Original, e) {
+			return idx
+		}
+	}
+
+	spec := w.resolveSpec(e)
+	if !sqlparser.Equals.SliceOfExpr(spec.PartitionClause, w.partitionSpec.PartitionClause) ||
+		!sqlparser.Equals.OrderBy(spec.OrderClause, w.partitionSpec.OrderClause) {
+		panic(vterrors.VT12001("window functions using different PARTITION BY or ORDER BY on a sharded keyspace"))
+	}
+
+	fn := &WindowFunc{
+		Original:      e,
+		ArgOffset:     -1,
+		DefaultOffset: -1,
+	}
+
+	switch node := e.(type) {
+	case *sqlparser.ArgumentLessWindowExpr:
+		switch node.Type {
+		case sqlparser.RowNumberExprType:
+			fn.OpCode = opcode.WindowRowNumber
+		case sqlparser.RankExprType:
+			fn.OpCode = opcode.WindowRank
+		case sqlparser.DenseRankExprType:
+			fn.OpCode = opcode.WindowDenseRank
+		case sqlparser.PercentRankExprType:
+			fn.OpCode = opcode.WindowPercentRank
+		case sqlparser.CumeDistExprType:
+			fn.OpCode = opcode.WindowCumeDist
+		}
+	case *sqlparser.NtileExpr:
+		fn.OpCode = opcode.WindowNtile
+		fn.N = windowLiteralArg("NTILE", node.N, 0)
+	case *sqlparser.LagLeadExpr:
+		fn.OpCode = opcode.WindowLag
+		if node.Type == sqlparser.LeadExprType {
+			fn.OpCode = opcode.WindowLead
+		}
+		fn.Arg = node.Expr
+		fn.Default = node.Default
+		fn.N = windowLiteralArg(fn.OpCode.String(), node.N, 1)
+	case *sqlparser.FirstOrLastValueExpr:
+		fn.OpCode = opcode.WindowFirstValue
+		if node.Type == sqlparser.LastValueExprType {
+			fn.OpCode = opcode.WindowLastValue
+		}
+		fn.Arg = node.Expr
+	case *sqlparser.NTHValueExpr:
+		if node.FromFirstLastClause != nil && node.FromFirstLastClause.Type == sqlparser.FromLastType {
+			panic(vterrors.VT12001("NTH_VALUE with FROM LAST"))
+		}
+		fn.OpCode = opcode.WindowNthValue
+		fn.Arg = node.Expr
+		fn.N = windowLiteralArg("NTH_VALUE", node.N, 0)
+	case sqlparser.AggrFunc:
+		code, ok := opcode.SupportedAggregates[node.AggrName()]
+		if _, isCountStar := node.(*sqlparser.CountStar); isCountStar {
+			code = opcode.AggregateCountStar
+		}
+		switch {
+		case !ok:
+			panic(vterrors.VT12001(fmt.Sprintf("window function '%s' on a sharded keyspace", node.AggrName())))
+		case sqlparser.IsDistinct(node):
+			panic(vterrors.VT12001(fmt.Sprintf("'%s' with DISTINCT used as a window function", node.AggrName())))
+		}
+		switch code {
+		case opcode.AggregateCount, opcode.AggregateCountStar, opcode.AggregateSum, opcode.AggregateMin, opcode.AggregateMax:
+		default:
+			// AVG is split into SUM and COUNT before we get here
+			panic(vterrors.VT12001(fmt.Sprintf("window function '%s' on a sharded keyspace", node.AggrName())))
+		}
+		fn.OpCode = opcode.WindowAggregate
+		fn.AggrOpCode = code
+		fn.Arg = node.GetArg()
+	default:
+		panic(vterrors.VT13001(fmt.Sprintf("unexpected window function: %s", sqlparser.String(e))))
+	}
+
+	if fn.OpCode.IsFrameAware() && spec.FrameClause != nil {
+		fn.Frame = newWindowFrame(spec.FrameClause)
+	}
+
+	w.Functions = append(w.Functions, fn)
+	return len(w.Functions) - 1
+}
+
+// windowLiteralArg returns the value of the integer literal used as an argument to a window function
+func windowLiteralArg(name string, expr sqlparser.Expr, defaultValue int64) int64 {
+	if expr == nil {
+		return defaultValue
+	}
+	lit, ok := expr.(*sqlparser.Literal)
+	if !ok || lit.Type != sqlparser.IntVal {
+		panic(vterrors.VT12001(fmt.Sprintf("%s with a non-literal argument on a sharded keyspace", strings.ToUpper(name))))
+	}
+	n, err := strconv.ParseInt(lit.Val, 10, 64)
+	if err != nil {
+		panic(vterrors.VT03025(name))
+	}
+	return n
+}
+
+func newWindowFrame(frame *sqlparser.FrameClause) *engine.WindowFrame {
+	rows := frame.Unit == sqlparser.FrameRowsType
+	point := func(p *sqlparser.FramePoint) engine.WindowFrameBound {
+		switch p.Type {
+		case sqlparser.UnboundedPrecedingType:
+			return engine.WindowFrameBound{Type: engine.FrameUnboundedPreceding}
+		case sqlparser.UnboundedFollowingType:
+			return engine.WindowFrameBound{Type: engine.FrameUnboundedFollowing}
+		case sqlparser.CurrentRowType:
+			return engine.WindowFrameBound{Type: engine.FrameCurrentRow}
+		}
+		if !rows {
+			panic(vterrors.VT12001("RANGE frames with offsets on a sharded keyspace"))
+		}
+		typ := engine.FramePreceding
+		if p.Type == sqlparser.ExprFollowingType {
+			typ = engine.FrameFollowing
+		}
+		return engine.WindowFrameBound{Type: typ, Offset: windowLiteralArg("frame", p.Expr, 0)}
+	}
+
+	out := &engine.WindowFrame{
+		Rows:  rows,
+		Start: point(frame.Start),
+		End:   engine.WindowFrameBound{Type: engine.FrameCurrentRow},
+	}
+	if frame.End != nil {
+		out.End = point(frame.End)
+	}
+	return out
+}
+
+// splitAvgWindowFunctions rewrites AVG window functions into a division between SUM and COUNT
+// over the same window, so they can be evaluated incrementally
+func splitAvgWindowFunctions(ctx *plancontext.PlanningContext, expr sqlparser.Expr) sqlparser.Expr {
+	return sqlparser.CopyOnRewrite(expr, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+		avg, ok := cursor.Node().(*sqlparser.Avg)
+		if !ok || avg.OverClause == nil {
+			return
+		}
+		cursor.Replace(&sqlparser.BinaryExpr{
+			Operator: sqlparser.DivOp,
+			Left:     &sqlparser.Sum{Arg: avg.Arg, OverClause: avg.OverClause},
+			Right:    &sqlparser.Count{Args: []sqlparser.Expr{avg.Arg}, OverClause: avg.OverClause},
+		})
+	}, ctx.SemTable.CopySemanticInfo).(sqlparser.Expr)
+}
+
+func (w *Window) Clone(inputs []Operator) Operator {
+	klone := *w
+	klone.Source = inputs[0]
+	klone.Partition = slices.Clone(w.Partition)
+	klone.Order = slices.Clone(w.Order)
+	klone.Functions = slice.Map(w.Functions, func(fn *WindowFunc) *WindowFunc {
+		c := *fn
+		return &c
+	})
+	klone.Columns = slices.Clone(w.Columns)
+	klone.Offsets = slices.Clone(w.Offsets)
+	return &klone
+}
+
+func (w *Window) AddPredicate(ctx *plancontext.PlanningContext, expr sqlparser.Expr) Operator {
+	// predicates can't be pushed through window functions, since they would change the rows in the partitions
+	return newFilter(w, expr)
+}
+
+func (w *Window) AddColumn(ctx *plancontext.PlanningContext, reuse bool, gb bool, expr *sqlparser.AliasedExpr) int {
+	if reuse {
+		if offset := w.FindCol(ctx, expr.Expr, false); offset >= 0 {
+			return offset
+		}
+	}
+
+	if sqlparser.IsWindowFunc(expr.Expr) {
+		idx := w.addWindowFunc(ctx, expr.Expr)
+		w.Columns = append(w.Columns, expr)
+		w.Offsets = append(w.Offsets, -(idx + 1))
+		return len(w.Columns) - 1
+	}
+
+	offset := w.Source.AddColumn(ctx, reuse, gb, expr)
+	if reuse {
+		if idx := slices.Index(w.Offsets, offset); idx >= 0 {
+			return idx
+		}
+	}
+	w.Columns = append(w.Columns, expr)
+	w.Offsets = append(w.Offsets, offset)
+	return len(w.Columns) - 1
+}
+
+func (w *Window) AddWSColumn(ctx *plancontext.PlanningContext, offset int, underRoute bool) int {
+	if w.Offsets[offset] < 0 {
+		panic(vterrors.VT12001("weight_string of the result of a window function"))
+	}
+	wsOffset := w.Source.AddWSColumn(ctx, w.Offsets[offset], underRoute)
+	if idx := slices.Index(w.Offsets, wsOffset); idx >= 0 {
+		return idx
+	}
+	w.Columns = append(w.Columns, aeWrap(weightStringFor(w.Columns[offset].Expr)))
+	w.Offsets = append(w.Offsets, wsOffset)
+	return len(w.Columns) - 1
+}
+
+func (w *Window) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, underRoute bool) int {
+	for idx, col := range w.Columns {
+		if ctx.SemTable.EqualsExprWithDeps(col.Expr, expr) {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (w *Window) GetColumns(*plancontext.PlanningContext) []*sqlparser.AliasedExpr {
+	return w.Columns
+}
+
+func (w *Window) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.SelectExpr {
+	return transformColumnsToSelectExprs(ctx, w)
+}
+
+func (w *Window) GetOrdering(ctx *plancontext.PlanningContext) []OrderBy {
+	// the rows are produced in the same order as they are received
+	return w.Source.GetOrdering(ctx)
+}
+
+func (w *Window) planOffsets(ctx *plancontext.PlanningContext) Operator {
+	planKeys := func(keys []WindowKey) {
+		for i, key := range keys {
+			keys[i].Offset = w.Source.AddColumn(ctx, true, false, aeWrap(key.Expr))
+			if ctx.NeedsWeightString(key.Expr) {
+				keys[i].WSOffset = w.Source.AddWSColumn(ctx, keys[i].Offset, false)
+			}
+		}
+	}
+	planKeys(w.Partition)
+	planKeys(w.Order)
+
+	for _, fn := range w.Functions {
+		if fn.Arg != nil {
+			fn.ArgOffset = w.Source.AddColumn(ctx, true, false, aeWrap(fn.Arg))
+		}
+		if fn.Default != nil {
+			fn.DefaultOffset = w.Source.AddColumn(ctx, true, false, aeWrap(fn.Default))
+		}
+	}
+	return nil
+}
+
+func (w *Window) ShortDescription() string {
+	keys := func(keys []WindowKey) string {
+		return strings.Join(slice.Map(keys, func(k WindowKey) string {
+			return sqlparser.String(k.Expr)
+		}), ", ")
+	}
+	fns := slice.Map(w.Functions, func(fn *WindowFunc) string {
+		return sqlparser.String(fn.Original)
+	})
+
+	out := strings.Join(fns, ", ")
+	if len(w.Partition) > 0 {
+		out += " partition by " + keys(w.Partition)
+	}
+	if len(w.Order) > 0 {
+		out += " order by " + keys(w.Order)
+	}
+	return out
+}
+
+// rewriteWindowFunctions prepares the window functions in the projection for evaluation.
+// When the Window operator is used, AVG is split into SUM and COUNT. Otherwise, the projection
+// is pushed to the shards, and references to named windows are replaced with the window definitions,
+// since the WINDOW clause is not part of the query we send down.
+func rewriteWindowFunctions(ctx *plancontext.PlanningContext, op Operator, windows sqlparser.NamedWindows, onVTGate bool) {
+	proj, ok := op.(*Projection)
+	if !ok {
+		return
+	}
+	ap, err := proj.GetAliasedProjections()
+	if err != nil {
+		if onVTGate {
+			panic(err)
+		}
+		return
+	}
+	for _, pe := range ap {
+		if onVTGate {
+			pe.EvalExpr = splitAvgWindowFunctions(ctx, pe.EvalExpr)
+		} else if len(windows) > 0 {
+			pe.EvalExpr = inlineNamedWindows(ctx, pe.EvalExpr, windows)
+		}
+	}
+}
+
+// inlineNamedWindows replaces references to named windows with the window definitions
+func inlineNamedWindows(ctx *plancontext.PlanningContext, expr sqlparser.Expr, windows sqlparser.NamedWindows) sqlparser.Expr {
+	return sqlparser.CopyOnRewrite(expr, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+		over, ok := cursor.Node().(*sqlparser.OverClause)
+		if !ok {
+			return
+		}
+		spec := sqlparser.ResolveWindowSpec(over, windows)
+		if spec == nil {
+			// MySQL will complain about the unknown window
+			return
+		}
+		cursor.Replace(&sqlparser.OverClause{WindowSpec: spec})
+	}, ctx.SemTable.CopySemanticInfo).(sqlparser.Expr)
+}
@@ -267,7 +267,8 @@ func (ctx *PlanningContext) NeedsWeightString(e sqlparser.Expr) bool {
 func (ctx *PlanningContext) IsAggr(e sqlparser.SQLNode) bool {
 	switch node := e.(type) {
 	case sqlparser.AggrFunc:
-		return true
+		// aggregate functions used as window functions do not aggregate rows
+		return !sqlparser.IsWindowFunc(node)
 	case *sqlparser.FuncExpr:
 		return node.Name.EqualsAnyString(ctx.VSchema.GetAggregateUDFs())
 	}
@@ -283,6 +284,9 @@ func (ctx *PlanningContext) ContainsAggr(e sqlparser.SQLNode) (hasAggr bool) {
 			// so we don't need to worry about aggregation in the original
 			return false, nil
 		case sqlparser.AggrFunc:
+			if sqlparser.IsWindowFunc(node) {
+				return true, nil
+			}
 			hasAggr = true
 			return false, io.EOF
 		case *sqlparser.Subquery:
@@ -78,6 +78,8 @@ var (
 	mysqlDrainOnTerm         bool
 
 	mysqlServerFlushDelay = 100 * time.Millisecond
+
+	mysqlServerCompressionAlgorithms []string
 )
 
 func registerPluginFlags(fs *pflag.FlagSet) {
@@ -104,6 +106,7 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&mysqlServerFlushDelay, "mysql_server_flush_delay", mysqlServerFlushDelay, "Delay after which buffered response will be flushed to the client.")
 	fs.StringVar(&mysqlDefaultWorkloadName, "mysql_default_workload", mysqlDefaultWorkloadName, "Default session workload (OLTP, OLAP, DBA)")
 	fs.BoolVar(&mysqlDrainOnTerm, "mysql-server-drain-onterm", mysqlDrainOnTerm, "If set, the server waits for --onterm_timeout for already connected clients to complete their in flight work")
+	fs.StringSliceVar(&mysqlServerCompressionAlgorithms, "mysql-server-compression-algorithms", mysqlServerCompressionAlgorithms, "Comma separated list of protocol compression algorithms clients of the TCP listener can use: zlib, zstd. Compression is disabled if empty.")
 }
 
 // vtgateHandler implements the Listener interface.
@@ -149,6 +152,22 @@ func (vh *vtgateHandler) ComResetConnection(c *mysql.Conn) {
 	}
 }
 
+// ComChangeUser rolls back the session of the previous user, releases
+// its reserved connections and starts a new session. The caller identity
+// is taken from the connection, so it is now the new user's.
+func (vh *vtgateHandler) ComChangeUser(c *mysql.Conn) {
+	ctx := context.Background()
+	session := vh.session(c)
+	if session.InTransaction {
+		defer vh.busyConnections.Add(-1)
+	}
+	err := vh.vtg.CloseSession(ctx, session)
+	if err != nil {
+		log.Errorf("Error happened in transaction rollback: %v", err)
+	}
+	c.ClientData = nil
+}
+
 func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
 	// Rollback if there is an ongoing transaction. Ignore error.
 	defer func() {
@@ -228,6 +247,7 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 	defer span.Finish()
 
 	ctx = callinfo.MysqlCallInfo(ctx, c)
+	ctx = withQueryAttributes(ctx, c.QueryAttributes())
 
 	// Fill in the ImmediateCallerID with the UserData returned by
 	// the AuthServer plugin for that user. If nothing was
@@ -333,6 +353,7 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 	}
 
 	ctx = callinfo.MysqlCallInfo(ctx, c)
+	ctx = withQueryAttributes(ctx, c.QueryAttributes())
 
 	// Fill in the ImmediateCallerID with the UserData returned by
 	// the AuthServer plugin for that user. If nothing was
@@ -356,7 +377,9 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 		}
 	}()
 
-	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
+	// Cursors are fetched from the stream, so we don't hold the whole
+	// result in memory.
+	if session.Options.Workload == querypb.ExecuteOptions_OLAP || prepare.CursorType != mysql.CursorTypeNoCursor {
 		_, err := vh.vtg.StreamExecute(ctx, vh, session, prepare.PrepareStmt, prepare.BindVars, callback)
 		if err != nil {
 			return sqlerror.NewSQLErrorFromError(err)
@@ -550,6 +573,9 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 			_ = initTLSConfig(context.Background(), srv, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlServerRequireSecureTransport, tlsVersion)
 		}
 		srv.tcpListener.AllowClearTextWithoutTLS.Store(mysqlAllowClearTextWithoutTLS)
+		if err := srv.tcpListener.SetCompressionAlgorithms(mysqlServerCompressionAlgorithms); err != nil {
+			log.Exitf("-mysql-server-compression-algorithms: %v", err)
+		}
 		// Check for the connection threshold
 		if mysqlSlowConnectWarnThreshold != 0 {
 			log.Infof("setting mysql slow connection threshold to %v", mysqlSlowConnectWarnThreshold)
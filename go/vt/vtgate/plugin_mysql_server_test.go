@@ -376,6 +376,42 @@ func TestGracefulShutdown(t *testing.T) {
 	require.True(t, mysqlConn.IsMarkedForClose())
 }
 
+func TestComChangeUser(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+
+	vh := newVtgateHandler(&VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected, queryTextCharsProcessed: queryTextCharsProcessed})
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), &testHandler{}, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.ConnectionID = 1
+	mysqlConn.User = "user1"
+	mysqlConn.UserData = &mysql.StaticUserData{}
+	vh.connections[1] = mysqlConn
+
+	for _, query := range []string{"set @foo = 1", "begin", "select 1"} {
+		err := vh.ComQuery(mysqlConn, query, func(result *sqltypes.Result) error {
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	session := vh.session(mysqlConn)
+	require.True(t, session.InTransaction)
+	require.NotEmpty(t, session.UserDefinedVariables)
+	require.EqualValues(t, 1, vh.busyConnections.Load())
+
+	// The transaction is rolled back, and the new user gets a new session.
+	mysqlConn.User = "user2"
+	vh.ComChangeUser(mysqlConn)
+	assert.EqualValues(t, 0, vh.busyConnections.Load())
+	newSession := vh.session(mysqlConn)
+	assert.NotSame(t, session, newSession)
+	assert.False(t, newSession.InTransaction)
+	assert.Empty(t, newSession.UserDefinedVariables)
+	assert.NotEqual(t, session.SessionUUID, newSession.SessionUUID)
+}
+
 func TestGracefulShutdownWithTransaction(t *testing.T) {
 	executor, _, _, _, _ := createExecutorEnv(t)
 
@@ -955,9 +955,6 @@ func TestInvalidQueries(t *testing.T) {
 	}, {
 		sql:  "select is_free_lock('xyz') from user",
 		serr: "is_free_lock('xyz') allowed only with dual",
-	}, {
-		sql: "SELECT * FROM JSON_TABLE('[ {\"c1\": null} ]','$[*]' COLUMNS( c1 INT PATH '$.c1' ERROR ON ERROR )) as jt",
-		err: &JSONTablesError{},
 	}, {
 		sql:             "select does_not_exist from t1",
 		notUnshardedErr: "column 'does_not_exist' not found in table 't1'",
@@ -1165,6 +1162,29 @@ func TestJoinPredicateDependencies(t *testing.T) {
 	}
 }
 
+func TestBindingJSONTable(t *testing.T) {
+	query := "select jt.a, b, t2.name from t2, json_table(t2.name, '$[*]' columns(a int path '$.a', nested path '$.b[*]' columns(b varchar(10) path '$'))) as jt"
+	stmt, semTable := parseAndAnalyze(t, query, "d")
+	sel := stmt.(*sqlparser.Select)
+
+	jt := sel.From[1].(*sqlparser.JSONTableExpr)
+	assert.Equal(t, TS1, semTable.TableSetForJSONTable(jt))
+	assert.Equal(t, TS0, semTable.RecursiveDeps(jt.Expr), "document")
+
+	assert.Equal(t, TS1, semTable.RecursiveDeps(extract(sel, 0)), "jt.a")
+	assert.Equal(t, TS1, semTable.RecursiveDeps(extract(sel, 1)), "b")
+	assert.Equal(t, TS0, semTable.RecursiveDeps(extract(sel, 2)), "t2.name")
+	typ, found := semTable.TypeForExpr(extract(sel, 0))
+	require.True(t, found)
+	assert.Equal(t, sqltypes.Int32, typ.Type())
+
+	parse, err := sqlparser.NewTestParser().Parse("select jt.c from t2, json_table(t2.name, '$[*]' columns(a int path '$.a')) as jt")
+	require.NoError(t, err)
+	st, err := Analyze(parse, "d", fakeSchemaInfo())
+	require.NoError(t, err)
+	require.ErrorContains(t, st.NotUnshardedErr, "column 'jt.c' not found")
+}
+
 func TestScopingWVindexTables(t *testing.T) {
 	queries := []struct {
 		query                string
@@ -168,7 +168,7 @@ func (b *binder) findDependentTableSet(current *scope, target sqlparser.TableNam
 		if tblName.Name.String() != target.Name.String() {
 			continue
 		}
-		ts := b.org.tableSetFor(table.GetAliasedTableExpr())
+		ts := table.getTableSet(b.org)
 		c := createCertain(ts, ts, evalengine.NewUnknownType())
 		deps = deps.merge(c, false)
 	}
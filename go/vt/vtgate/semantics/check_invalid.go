@@ -36,8 +36,6 @@ func (a *analyzer) checkForInvalidConstructs(cursor *sqlparser.Cursor) error {
 		return &LockOnlyWithDualError{Node: node}
 	case *sqlparser.Union:
 		return checkUnion(node)
-	case *sqlparser.JSONTableExpr:
-		return &JSONTablesError{}
 	case *sqlparser.DerivedTable:
 		return checkDerived(node)
 	case *sqlparser.AssignmentExpr:
@@ -52,10 +50,6 @@ func (a *analyzer) checkForInvalidConstructs(cursor *sqlparser.Cursor) error {
 		if !a.singleUnshardedKeyspace && node.Action == sqlparser.ReplaceAct {
 			return ShardedError{Inner: &UnsupportedConstruct{errString: "REPLACE INTO with sharded keyspace"}}
 		}
-	case *sqlparser.OverClause:
-		if !a.singleUnshardedKeyspace {
-			return ShardedError{Inner: &UnsupportedConstruct{errString: "OVER CLAUSE with sharded keyspace"}}
-		}
 	}
 
 	return nil
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package semantics
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// JSONTable contains the information about a JSON_TABLE table function used in the FROM clause.
+// The columns are the ones declared in the COLUMNS clause, including the columns of nested paths.
+// Since JSON_TABLE can only reference tables to the left of it, the document expression is bound
+// like any other expression in the FROM clause, and the columns produced depend only on this table.
+type JSONTable struct {
+	tableName string
+	ASTNode   *sqlparser.JSONTableExpr
+	columns   []ColumnInfo
+	tables    TableSet
+}
+
+var _ TableInfo = (*JSONTable)(nil)
+
+func newJSONTable(node *sqlparser.JSONTableExpr, id TableSet, env *collations.Environment) (*JSONTable, error) {
+	if node.Alias.IsEmpty() {
+		return nil, vterrors.VT03012("every table function must have an alias")
+	}
+	jt := &JSONTable{
+		tableName: node.Alias.String(),
+		ASTNode:   node,
+		tables:    id,
+	}
+	jt.addColumns(node.Columns, env)
+	return jt, nil
+}
+
+func (jt *JSONTable) addColumns(defs []*sqlparser.JtColumnDefinition, env *collations.Environment) {
+	for _, def := range defs {
+		switch {
+		case def.JtOrdinal != nil:
+			jt.columns = append(jt.columns, ColumnInfo{
+				Name: def.JtOrdinal.Name.String(),
+				Type: evalengine.NewType(sqltypes.Uint32, collations.CollationBinaryID),
+			})
+		case def.JtPath != nil:
+			typ := def.JtPath.Type.SQLType()
+			jt.columns = append(jt.columns, ColumnInfo{
+				Name: def.JtPath.Name.String(),
+				Type: evalengine.NewType(typ, collations.CollationForType(typ, env.DefaultConnectionCharset())),
+			})
+		case def.JtNestedPath != nil:
+			jt.addColumns(def.JtNestedPath.Columns, env)
+		}
+	}
+}
+
+// Name implements the TableInfo interface
+func (jt *JSONTable) Name() (sqlparser.TableName, error) {
+	return sqlparser.NewTableName(jt.tableName), nil
+}
+
+// GetVindexTable implements the TableInfo interface
+func (jt *JSONTable) GetVindexTable() *vindexes.BaseTable {
+	return nil
+}
+
+// IsInfSchema implements the TableInfo interface
+func (jt *JSONTable) IsInfSchema() bool {
+	return false
+}
+
+func (jt *JSONTable) matches(name sqlparser.TableName) bool {
+	return jt.tableName == name.Name.String() && name.Qualifier.IsEmpty()
+}
+
+func (jt *JSONTable) authoritative() bool {
+	return true
+}
+
+// GetAliasedTableExpr implements the TableInfo interface
+func (jt *JSONTable) GetAliasedTableExpr() *sqlparser.AliasedTableExpr {
+	return nil
+}
+
+func (jt *JSONTable) canShortCut() shortCut {
+	return canShortCut
+}
+
+func (jt *JSONTable) getColumns(bool) []ColumnInfo {
+	return jt.columns
+}
+
+func (jt *JSONTable) dependencies(colName string, _ originable) (dependencies, error) {
+	for _, col := range jt.columns {
+		if strings.EqualFold(col.Name, colName) {
+			return createCertain(jt.tables, jt.tables, col.Type), nil
+		}
+	}
+	return &nothing{}, nil
+}
+
+func (jt *JSONTable) getExprFor(s string) (sqlparser.Expr, error) {
+	return nil, vterrors.VT03022(s, "field list")
+}
+
+func (jt *JSONTable) getTableSet(originable) TableSet {
+	return jt.tables
+}
+
+// GetMirrorRule implements TableInfo.
+func (jt *JSONTable) GetMirrorRule() *vindexes.MirrorRule {
+	return nil
+}
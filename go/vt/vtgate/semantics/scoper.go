@@ -74,6 +74,9 @@ func (s *scoper) down(cursor *sqlparser.Cursor) error {
 		s.pushSelectScope(node)
 	case *sqlparser.Union:
 		s.pushUnionScope(node)
+	case *sqlparser.JSONTableExpr:
+		// JSON_TABLE can reference the tables to the left of it in the FROM clause,
+		// so it does not get a join scope of its own, and is bound in the scope of the query instead
 	case sqlparser.TableExpr:
 		s.enterJoinScope(cursor)
 	case *sqlparser.SelectExprs:
@@ -242,6 +245,8 @@ func (s *scoper) up(cursor *sqlparser.Cursor) error {
 		s.popScope()
 	case sqlparser.AggrFunc:
 		s.currentScope().inHavingAggr = false
+	case *sqlparser.JSONTableExpr:
+		// no join scope was created for JSON_TABLE, see down()
 	case sqlparser.TableExpr:
 		// inside joins and derived tables, we can only see the tables in the table/join.
 		// we also want the tables available in the outer query, for SELECT expressions and the WHERE clause,
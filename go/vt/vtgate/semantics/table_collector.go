@@ -130,6 +130,8 @@ func (tc *tableCollector) up(cursor *sqlparser.Cursor) error {
 	switch node := cursor.Node().(type) {
 	case *sqlparser.AliasedTableExpr:
 		return tc.visitAliasedTableExpr(node)
+	case *sqlparser.JSONTableExpr:
+		return tc.visitJSONTable(node)
 	case *sqlparser.Union:
 		return tc.visitUnion(node)
 	case *sqlparser.RowAlias:
@@ -153,6 +155,17 @@ func (tc *tableCollector) visitAliasedTableExpr(node *sqlparser.AliasedTableExpr
 	return nil
 }
 
+func (tc *tableCollector) visitJSONTable(node *sqlparser.JSONTableExpr) error {
+	tableInfo, err := newJSONTable(node, SingleTableSet(len(tc.Tables)), tc.org.collationEnv())
+	if err != nil {
+		return err
+	}
+
+	tc.Tables = append(tc.Tables, tableInfo)
+	scope := tc.scoper.currentScope()
+	return scope.addTable(tableInfo)
+}
+
 func (tc *tableCollector) visitUnion(union *sqlparser.Union) error {
 	firstSelect, err := sqlparser.GetFirstSelect(union)
 	if err != nil {
@@ -18,6 +18,7 @@ package semantics
 
 import (
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
@@ -61,10 +62,36 @@ func (t *typer) up(cursor *sqlparser.Cursor) error {
 			}
 		}
 		t.m[node] = code.ResolveType(inputType, t.collationEnv)
+	case *sqlparser.ArgumentLessWindowExpr:
+		switch node.Type {
+		case sqlparser.PercentRankExprType, sqlparser.CumeDistExprType:
+			t.m[node] = evalengine.NewTypeEx(sqltypes.Float64, collations.CollationBinaryID, false, 0, 0, nil)
+		default:
+			t.m[node] = evalengine.NewTypeEx(sqltypes.Uint64, collations.CollationBinaryID, false, 0, 0, nil)
+		}
+	case *sqlparser.NtileExpr:
+		t.m[node] = evalengine.NewTypeEx(sqltypes.Uint64, collations.CollationBinaryID, true, 0, 0, nil)
+	case *sqlparser.LagLeadExpr:
+		t.setNullableTypeFromArg(node, node.Expr)
+	case *sqlparser.FirstOrLastValueExpr:
+		t.setNullableTypeFromArg(node, node.Expr)
+	case *sqlparser.NTHValueExpr:
+		t.setNullableTypeFromArg(node, node.Expr)
 	}
 	return nil
 }
 
+// setNullableTypeFromArg is used for window functions that return a value of their argument,
+// or NULL if there is no row to get the value from
+func (t *typer) setNullableTypeFromArg(node, arg sqlparser.Expr) {
+	tt, ok := t.m[arg]
+	if !ok {
+		return
+	}
+	tt.SetNullability(true)
+	t.m[node] = tt
+}
+
 func (t *typer) setTypeFor(node *sqlparser.ColName, typ evalengine.Type) {
 	t.m[node] = typ
 }
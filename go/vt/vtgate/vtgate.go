@@ -76,6 +76,8 @@ var (
 
 	maxMemoryRows   = 300000
 	warnMemoryRows  = 30000
+	spillDir        string
+	spillDiskQuota  int64
 	maxPayloadSize  int
 	warnPayloadSize int
 
@@ -176,6 +178,8 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&streamBufferSize, "stream_buffer_size", streamBufferSize, "the number of bytes sent from vtgate for each stream call. It's recommended to keep this value in sync with vttablet's query-server-config-stream-buffer-size.")
 	fs.Int64Var(&queryPlanCacheMemory, "gate_query_cache_memory", queryPlanCacheMemory, "gate server query cache size in bytes, maximum amount of memory to be cached. vtgate analyzes every incoming query and generate a query plan, these plans are being cached in a lru cache. This config controls the capacity of the lru cache.")
 	fs.IntVar(&maxMemoryRows, "max_memory_rows", maxMemoryRows, "Maximum number of rows that will be held in memory for intermediate results as well as the final result.")
+	fs.StringVar(&spillDir, "spill-dir", spillDir, "Directory of the temporary files that sorts, hash joins and distincts spill their intermediate results to when they exceed --max_memory_rows. Defaults to the system temporary directory.")
+	fs.Int64Var(&spillDiskQuota, "spill-disk-quota", spillDiskQuota, "Maximum number of bytes of intermediate results that the queries of this vtgate can spill to disk. Spilling is disabled when 0, and queries exceeding --max_memory_rows fail instead.")
 	fs.IntVar(&warnMemoryRows, "warn_memory_rows", warnMemoryRows, "Warning threshold for in-memory results. A row count higher than this amount will cause the VtGateWarnings.ResultsExceeded counter to be incremented.")
 	fs.StringVar(&defaultDDLStrategy, "ddl_strategy", defaultDDLStrategy, "Set default strategy for DDL statements. Override with @@ddl_strategy session variable")
 	fs.StringVar(&dbDDLPlugin, "dbddl_plugin", dbDDLPlugin, "controls how to handle CREATE/DROP DATABASE. use it if you are using your own database provisioning service")
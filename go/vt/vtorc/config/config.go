@@ -280,6 +280,11 @@ func GetPreventCrossCellFailover() bool {
 	return preventCrossCellFailover.Get()
 }
 
+// SetPreventCrossCellFailover sets the value for the preventCrossCellFailover variable. This should only be used from tests.
+func SetPreventCrossCellFailover(val bool) {
+	preventCrossCellFailover.Set(val)
+}
+
 // GetDiscoveryWorkers is a getter function.
 func GetDiscoveryWorkers() uint {
 	return uint(discoveryWorkers.Get())
@@ -290,6 +295,11 @@ func GetSQLiteDataFile() string {
 	return sqliteDataFile.Get()
 }
 
+// SetSQLiteDataFile is a setter function.
+func SetSQLiteDataFile(v string) {
+	sqliteDataFile.Set(v)
+}
+
 // GetReasonableReplicationLagSeconds gets the reasonable replication lag but in seconds.
 func GetReasonableReplicationLagSeconds() int64 {
 	return int64(reasonableReplicationLag.Get() / time.Second)
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -68,6 +69,7 @@ var (
 
 var (
 	emptyQuotesRegexp            = regexp.MustCompile(`^""$`)
+	columnNameRegexp             = regexp.MustCompile(`^[a-z_]+$`)
 	cacheInitializationCompleted atomic.Bool
 )
 
@@ -710,6 +712,16 @@ func ReadInstancesWithErrantGTIds(keyspace string, shard string) ([]*Instance, e
 	return readInstancesByCondition(condition, args, "")
 }
 
+// ReadInstancesInKeyspaceShard reads all the instances of the given keyspace and shard
+func ReadInstancesInKeyspaceShard(keyspace string, shard string) ([]*Instance, error) {
+	condition := `
+		keyspace = ?
+		AND shard = ?`
+
+	args := sqlutils.Args(keyspace, shard)
+	return readInstancesByCondition(condition, args, "")
+}
+
 // GetKeyspaceShardName gets the keyspace shard name for the given instance key
 func GetKeyspaceShardName(tabletAlias string) (keyspace string, shard string, err error) {
 	query := `SELECT
@@ -1234,3 +1246,63 @@ func GetDatabaseState() (string, error) {
 
 	return string(jsonData), nil
 }
+
+// LoadDatabaseState replaces the contents of the database with a snapshot taken by GetDatabaseState.
+func LoadDatabaseState(state string) error {
+	// The rows are read as maps of pointers rather than as sqlutils.RowMap, which can't tell the NULL values apart.
+	var dbState []struct {
+		TableName string
+		Rows      []map[string]*string
+	}
+	if err := json.Unmarshal([]byte(state), &dbState); err != nil {
+		return fmt.Errorf("cannot parse the database state: %w", err)
+	}
+	for _, ts := range dbState {
+		if !slices.Contains(db.TableNames, ts.TableName) {
+			return fmt.Errorf("unknown table %q in the database state", ts.TableName)
+		}
+	}
+	for _, tableName := range db.TableNames {
+		if _, err := db.ExecVTOrc("DELETE FROM " + tableName); err != nil {
+			return err
+		}
+	}
+	for _, ts := range dbState {
+		for _, row := range ts.Rows {
+			columns := make([]string, 0, len(row))
+			for column := range row {
+				if !columnNameRegexp.MatchString(column) {
+					return fmt.Errorf("unknown column %q of table %s in the database state", column, ts.TableName)
+				}
+				columns = append(columns, column)
+			}
+			slices.Sort(columns)
+			var args []any
+			for _, column := range columns {
+				args = append(args, databaseStateValue(row[column]))
+			}
+			query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+				ts.TableName,
+				strings.Join(columns, ", "),
+				strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "),
+			)
+			if _, err := db.ExecVTOrc(query, args...); err != nil {
+				return fmt.Errorf("cannot load a row of table %s: %w", ts.TableName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// databaseStateValue returns the value to store for a cell of a database snapshot. The timestamps
+// are read back in the RFC 3339 format, but they are compared with the values of DATETIME(), so
+// they are stored in its format instead.
+func databaseStateValue(cell *string) any {
+	if cell == nil {
+		return nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, *cell); err == nil {
+		return t.UTC().Format(time.DateTime + ".999999999")
+	}
+	return *cell
+}
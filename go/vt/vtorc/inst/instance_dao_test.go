@@ -840,6 +840,39 @@ func TestGetDatabaseState(t *testing.T) {
 	require.Contains(t, ds, `"alias": "zone1-0000000112"`)
 }
 
+func TestLoadDatabaseState(t *testing.T) {
+	// Clear the database after the test. The easiest way to do that is to run all the initialization commands again.
+	defer func() {
+		db.ClearVTOrcDatabase()
+	}()
+
+	for _, query := range initialSQL {
+		_, err := db.ExecVTOrc(query)
+		require.NoError(t, err)
+	}
+	instance, _, err := ReadInstance("zone1-0000000112")
+	require.NoError(t, err)
+
+	ds, err := GetDatabaseState()
+	require.NoError(t, err)
+	db.ClearVTOrcDatabase()
+
+	err = LoadDatabaseState(ds)
+	require.NoError(t, err)
+	loadedDs, err := GetDatabaseState()
+	require.NoError(t, err)
+	require.Equal(t, ds, loadedDs)
+	loadedInstance, _, err := ReadInstance("zone1-0000000112")
+	require.NoError(t, err)
+	require.Equal(t, instance.IsLastCheckValid, loadedInstance.IsLastCheckValid)
+	require.Equal(t, instance.ExecutedGtidSet, loadedInstance.ExecutedGtidSet)
+
+	err = LoadDatabaseState(`[{"TableName": "unknown_table"}]`)
+	require.EqualError(t, err, `unknown table "unknown_table" in the database state`)
+	err = LoadDatabaseState(`[{"TableName": "vitess_keyspace", "Rows": [{"keyspace; DROP TABLE vitess_tablet": "ks"}]}]`)
+	require.EqualError(t, err, `unknown column "keyspace; DROP TABLE vitess_tablet" of table vitess_keyspace in the database state`)
+}
+
 func TestExpireTableData(t *testing.T) {
 	oldVal := config.GetAuditPurgeDays()
 	config.SetAuditPurgeDays(10)
@@ -80,6 +80,15 @@ func TestSaveAndReadKeyspace(t *testing.T) {
 				DurabilityPolicy: policy.DurabilityNone,
 			},
 			semiSyncAckersWanted: 0,
+		}, {
+			name:         "Success with declarative durability",
+			keyspaceName: "ks6",
+			keyspace: &topodatapb.Keyspace{
+				KeyspaceType:     topodatapb.KeyspaceType_NORMAL,
+				DurabilityPolicy: "declarative:\nsemi_sync:\n- ackers: 2\n  cross_cell: true\n",
+			},
+			keyspaceWanted:       nil,
+			semiSyncAckersWanted: 2,
 		}, {
 			name:           "No keyspace found",
 			keyspaceName:   "ks5",
@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/mysql/replication"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil/policy"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil/promotionrule"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+// SimulatedRecovery is the recovery that VTOrc would choose for a problem found by the replication analysis.
+type SimulatedRecovery struct {
+	TabletAlias string
+	Keyspace    string
+	Shard       string
+	Analysis    inst.AnalysisCode
+	Description string
+	// RecoveryName is the name of the recovery chosen for the problem, empty if there is none.
+	RecoveryName  string
+	IsActionable  bool
+	IsClusterWide bool
+	// WouldRecover is true when VTOrc would run the recovery now. Before running it, VTOrc still locks the shard,
+	// refreshes the tablets and runs the analysis again, and gives up if the problem is gone by then.
+	WouldRecover bool
+	// CandidatePrimary is the tablet that the reparent run by the recovery would likely promote.
+	CandidatePrimary string
+	// Reasons explains the choices of the simulation.
+	Reasons []string
+}
+
+// SimulateRecoveries runs the replication analysis of the given keyspace and shard, which can both be empty,
+// and returns the recoveries that VTOrc would choose for the problems found, without running them.
+func SimulateRecoveries(keyspace string, shard string) ([]*SimulatedRecovery, error) {
+	replicationAnalysis, err := inst.GetReplicationAnalysis(keyspace, shard, &inst.ReplicationAnalysisHints{})
+	if err != nil {
+		return nil, err
+	}
+	recoveryDisabledGlobally, err := IsRecoveryDisabled()
+	if err != nil {
+		return nil, err
+	}
+	simulatedRecoveries := make([]*SimulatedRecovery, 0, len(replicationAnalysis))
+	for _, analysisEntry := range replicationAnalysis {
+		simulatedRecoveries = append(simulatedRecoveries, simulateRecovery(analysisEntry, recoveryDisabledGlobally))
+	}
+	return simulatedRecoveries, nil
+}
+
+// simulateRecovery goes through the checks of executeCheckAndRecoverFunction that only read the backend
+// database, and returns the recovery that it would run for the analysis entry.
+func simulateRecovery(analysisEntry *inst.ReplicationAnalysis, recoveryDisabledGlobally bool) *SimulatedRecovery {
+	checkAndRecoverFunctionCode := getCheckAndRecoverFunctionCode(analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias)
+	simulatedRecovery := &SimulatedRecovery{
+		TabletAlias:   analysisEntry.AnalyzedInstanceAlias,
+		Keyspace:      analysisEntry.AnalyzedKeyspace,
+		Shard:         analysisEntry.AnalyzedShard,
+		Analysis:      analysisEntry.Analysis,
+		Description:   analysisEntry.Description,
+		RecoveryName:  getRecoverFunctionName(checkAndRecoverFunctionCode),
+		IsActionable:  hasActionableRecovery(checkAndRecoverFunctionCode),
+		IsClusterWide: isClusterWideRecovery(checkAndRecoverFunctionCode),
+	}
+	addReason := func(format string, args ...any) {
+		simulatedRecovery.Reasons = append(simulatedRecovery.Reasons, fmt.Sprintf(format, args...))
+	}
+
+	if checkAndRecoverFunctionCode == noRecoveryFunc {
+		addReason("%s", noRecoveryReason(analysisEntry.Analysis))
+		return simulatedRecovery
+	}
+	if !simulatedRecovery.IsActionable {
+		addReason("%v is only reported, %v takes no action", analysisEntry.Analysis, simulatedRecovery.RecoveryName)
+		return simulatedRecovery
+	}
+	addReason("%v on %v is handled by %v", analysisEntry.Analysis, analysisEntry.AnalyzedInstanceAlias, simulatedRecovery.RecoveryName)
+	if recoveryDisabledGlobally {
+		addReason("recoveries are disabled globally")
+		return simulatedRecovery
+	}
+	recoveries, err := ReadActiveClusterRecoveries(analysisEntry.AnalyzedKeyspace, analysisEntry.AnalyzedShard)
+	if err != nil {
+		addReason("cannot read the active recoveries of the shard: %v", err)
+		return simulatedRecovery
+	}
+	if len(recoveries) > 0 {
+		addReason("recovery %v for %v is still active in the shard, a new one is not registered until it ends", recoveries[0].ID, recoveries[0].AnalysisEntry.Analysis)
+		return simulatedRecovery
+	}
+	simulatedRecovery.WouldRecover = true
+
+	switch checkAndRecoverFunctionCode {
+	case recoverDeadPrimaryFunc, recoverPrimaryTabletDeletedFunc:
+		addReason("the recovery runs EmergencyReparentShard")
+		simulatedRecovery.CandidatePrimary = simulateNewPrimary(analysisEntry, true, addReason)
+	case electNewPrimaryFunc:
+		addReason("the recovery runs PlannedReparentShard")
+		simulatedRecovery.CandidatePrimary = simulateNewPrimary(analysisEntry, false, addReason)
+	}
+	return simulatedRecovery
+}
+
+// noRecoveryReason explains why there is no recovery for an analysis code.
+func noRecoveryReason(analysisCode inst.AnalysisCode) string {
+	switch analysisCode {
+	case inst.DeadPrimary, inst.DeadPrimaryAndSomeReplicas, inst.PrimaryDiskStalled, inst.PrimarySemiSyncBlocked, inst.PrimaryTabletDeleted:
+		if !config.ERSEnabled() {
+			return fmt.Sprintf("VTOrc is not allowed to run ERS, which is needed to recover %v", analysisCode)
+		}
+	case inst.ErrantGTIDDetected:
+		if !config.ConvertTabletWithErrantGTIDs() {
+			return fmt.Sprintf("VTOrc is not configured to change the type of tablets with errant GTIDs, skipping %v", analysisCode)
+		}
+	case inst.NoProblem:
+		return "there is no problem to recover"
+	}
+	return fmt.Sprintf("VTOrc has no recovery for %v", analysisCode)
+}
+
+// simulateNewPrimary predicts the tablet that the reparent of a recovery would promote, emergencyReparent telling
+// whether it is an EmergencyReparentShard or a PlannedReparentShard. The reparents read the replication positions
+// from the tablets themselves, so this only estimates their choice from the positions stored in the backend database.
+func simulateNewPrimary(analysisEntry *inst.ReplicationAnalysis, emergencyReparent bool, addReason func(format string, args ...any)) string {
+	keyspace, shard := analysisEntry.AnalyzedKeyspace, analysisEntry.AnalyzedShard
+	durability, err := inst.GetDurabilityPolicy(keyspace)
+	if err != nil {
+		addReason("cannot read the durability policy of keyspace %v: %v", keyspace, err)
+		return ""
+	}
+	var primaryCell string
+	primaryAlias, _, err := inst.ReadShardPrimaryInformation(keyspace, shard)
+	if err != nil {
+		addReason("cannot read the shard record of %v/%v: %v", keyspace, shard, err)
+		return ""
+	}
+	if primaryAlias != "" {
+		if alias, err := topoproto.ParseTabletAlias(primaryAlias); err == nil {
+			primaryCell = alias.Cell
+		}
+	}
+	// PlannedReparentShard never promotes a tablet in another cell when it is run by VTOrc.
+	preventCrossCellPromotion := !emergencyReparent || config.GetPreventCrossCellFailover()
+
+	instances, err := inst.ReadInstancesInKeyspaceShard(keyspace, shard)
+	if err != nil {
+		addReason("cannot read the tablets of %v/%v: %v", keyspace, shard, err)
+		return ""
+	}
+	var (
+		validTablets []*topodatapb.Tablet
+		positions    []replication.Position
+	)
+	for _, instance := range instances {
+		tablet, err := inst.ReadTablet(instance.InstanceAlias)
+		if err != nil {
+			addReason("cannot read tablet %v: %v", instance.InstanceAlias, err)
+			continue
+		}
+		switch {
+		case emergencyReparent && instance.InstanceAlias == analysisEntry.AnalyzedInstanceAlias:
+			// This is the failed primary that is being replaced.
+			continue
+		case !emergencyReparent && tablet.Type != topodatapb.TabletType_REPLICA:
+			addReason("%v is not a replica", instance.InstanceAlias)
+			continue
+		case !instance.IsLastCheckValid:
+			addReason("%v is unreachable", instance.InstanceAlias)
+			continue
+		case preventCrossCellPromotion && primaryCell != "" && tablet.Alias.Cell != primaryCell:
+			addReason("%v is not in the same cell as the previous primary", instance.InstanceAlias)
+			continue
+		case emergencyReparent && policy.PromotionRule(durability, tablet) == promotionrule.MustNot:
+			addReason("%v has the %v promotion rule", instance.InstanceAlias, promotionrule.MustNot)
+			continue
+		case !emergencyReparent && config.GetTolerableReplicationLag() > 0 && instance.ReplicationLagSeconds.Valid &&
+			time.Duration(instance.ReplicationLagSeconds.Int64)*time.Second > config.GetTolerableReplicationLag():
+			addReason("%v has %vs of replication lag, which is more than the tolerable amount", instance.InstanceAlias, instance.ReplicationLagSeconds.Int64)
+			continue
+		}
+		gtidSet, err := replication.ParseMysql56GTIDSet(instance.ExecutedGtidSet)
+		if err != nil {
+			addReason("cannot parse the executed GTID set of %v: %v", instance.InstanceAlias, err)
+			continue
+		}
+		validTablets = append(validTablets, tablet)
+		positions = append(positions, replication.Position{GTIDSet: gtidSet})
+	}
+	if len(validTablets) == 0 {
+		addReason("no tablet of %v/%v can be promoted, the reparent would fail", keyspace, shard)
+		return ""
+	}
+	if err := reparentutil.SortTabletsForReparent(validTablets, positions, durability); err != nil {
+		addReason("cannot sort the tablets of %v/%v: %v", keyspace, shard, err)
+		return ""
+	}
+
+	// The tablets are sorted by replication position, ties broken by the promotion rules, which is the
+	// choice of PlannedReparentShard.
+	if !emergencyReparent {
+		candidateAlias := topoproto.TabletAliasString(validTablets[0].Alias)
+		addReason("%v is the most advanced tablet, with the %v promotion rule", candidateAlias, policy.PromotionRule(durability, validTablets[0]))
+		return candidateAlias
+	}
+	// EmergencyReparentShard promotes the most advanced tablet with the best promotion rule, after the
+	// others caught up with it.
+	for _, promotionRule := range promotionrule.AllPromotionRules() {
+		for _, tablet := range validTablets {
+			if policy.PromotionRule(durability, tablet) == promotionRule {
+				candidateAlias := topoproto.TabletAliasString(tablet.Alias)
+				addReason("%v is the most advanced of the tablets with the best promotion rule, %v", candidateAlias, promotionRule)
+				return candidateAlias
+			}
+		}
+	}
+	return ""
+}
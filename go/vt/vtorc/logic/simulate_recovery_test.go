@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/db"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+func init() {
+	config.MarkConfigurationLoaded()
+}
+
+func TestSimulateRecovery(t *testing.T) {
+	oldERSEnabled := config.ERSEnabled()
+	defer config.SetERSEnabled(oldERSEnabled)
+	defer db.ClearVTOrcDatabase()
+
+	tests := []struct {
+		name                     string
+		analysis                 inst.AnalysisCode
+		ersDisabled              bool
+		recoveryDisabledGlobally bool
+		activeRecovery           bool
+		wantRecoveryName         string
+		wantActionable           bool
+		wantRecover              bool
+		wantReason               string
+	}{
+		{
+			name:             "actionable recovery",
+			analysis:         inst.ReplicationStopped,
+			wantRecoveryName: FixReplicaRecoveryName,
+			wantActionable:   true,
+			wantRecover:      true,
+			wantReason:       "ReplicationStopped on zone1-0000000101 is handled by FixReplica",
+		}, {
+			name:             "non actionable recovery",
+			analysis:         inst.UnreachablePrimary,
+			wantRecoveryName: CheckAndRecoverGenericProblemRecoveryName,
+			wantReason:       "UnreachablePrimary is only reported, CheckAndRecoverGenericProblem takes no action",
+		}, {
+			name:        "ERS disabled",
+			analysis:    inst.DeadPrimary,
+			ersDisabled: true,
+			wantReason:  "VTOrc is not allowed to run ERS, which is needed to recover DeadPrimary",
+		}, {
+			name:       "no recovery",
+			analysis:   inst.DeadPrimaryWithoutReplicas,
+			wantReason: "VTOrc has no recovery for DeadPrimaryWithoutReplicas",
+		}, {
+			name:                     "recoveries disabled globally",
+			analysis:                 inst.PrimaryIsReadOnly,
+			recoveryDisabledGlobally: true,
+			wantRecoveryName:         FixPrimaryRecoveryName,
+			wantActionable:           true,
+			wantReason:               "recoveries are disabled globally",
+		}, {
+			name:             "active recovery",
+			analysis:         inst.PrimaryIsReadOnly,
+			activeRecovery:   true,
+			wantRecoveryName: FixPrimaryRecoveryName,
+			wantActionable:   true,
+			wantReason:       "for ReplicationStopped is still active in the shard",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.ClearVTOrcDatabase()
+			config.SetERSEnabled(!tt.ersDisabled)
+			if tt.activeRecovery {
+				_, err := AttemptRecoveryRegistration(&inst.ReplicationAnalysis{
+					AnalyzedInstanceAlias: "zone1-0000000102",
+					Analysis:              inst.ReplicationStopped,
+					ClusterDetails: inst.ClusterInfo{
+						Keyspace: "ks",
+						Shard:    "0",
+					},
+				})
+				require.NoError(t, err)
+			}
+
+			simulatedRecovery := simulateRecovery(&inst.ReplicationAnalysis{
+				AnalyzedInstanceAlias: "zone1-0000000101",
+				AnalyzedKeyspace:      "ks",
+				AnalyzedShard:         "0",
+				Analysis:              tt.analysis,
+			}, tt.recoveryDisabledGlobally)
+			require.Equal(t, tt.analysis, simulatedRecovery.Analysis)
+			require.Equal(t, tt.wantRecoveryName, simulatedRecovery.RecoveryName)
+			require.Equal(t, tt.wantActionable, simulatedRecovery.IsActionable)
+			require.Equal(t, tt.wantRecover, simulatedRecovery.WouldRecover)
+			require.Contains(t, simulatedRecovery.Reasons[len(simulatedRecovery.Reasons)-1], tt.wantReason)
+		})
+	}
+}
+
+func TestSimulateNewPrimary(t *testing.T) {
+	oldPreventCrossCellFailover := config.GetPreventCrossCellFailover()
+	defer config.SetPreventCrossCellFailover(oldPreventCrossCellFailover)
+	defer db.ClearVTOrcDatabase()
+
+	tablets := []struct {
+		tablet      *topodatapb.Tablet
+		gtidSet     string
+		unreachable bool
+	}{
+		{
+			tablet:  newSimulationTablet("zone1", 100, topodatapb.TabletType_PRIMARY),
+			gtidSet: "00000000-0000-0000-0000-000000000001:1-100",
+		}, {
+			tablet:  newSimulationTablet("zone1", 101, topodatapb.TabletType_REPLICA),
+			gtidSet: "00000000-0000-0000-0000-000000000001:1-10",
+		}, {
+			tablet:  newSimulationTablet("zone1", 102, topodatapb.TabletType_REPLICA),
+			gtidSet: "00000000-0000-0000-0000-000000000001:1-20",
+		}, {
+			tablet:  newSimulationTablet("zone2", 103, topodatapb.TabletType_REPLICA),
+			gtidSet: "00000000-0000-0000-0000-000000000001:1-30",
+		}, {
+			tablet:  newSimulationTablet("zone1", 104, topodatapb.TabletType_RDONLY),
+			gtidSet: "00000000-0000-0000-0000-000000000001:1-40",
+		}, {
+			tablet:      newSimulationTablet("zone1", 105, topodatapb.TabletType_REPLICA),
+			gtidSet:     "00000000-0000-0000-0000-000000000001:1-50",
+			unreachable: true,
+		},
+	}
+
+	tests := []struct {
+		name                     string
+		emergencyReparent        bool
+		preventCrossCellFailover bool
+		wantCandidate            string
+		wantReasons              []string
+	}{
+		{
+			name:              "emergency reparent",
+			emergencyReparent: true,
+			wantCandidate:     "zone2-0000000103",
+			wantReasons: []string{
+				"zone1-0000000104 has the must_not promotion rule",
+				"zone1-0000000105 is unreachable",
+				"zone2-0000000103 is the most advanced of the tablets with the best promotion rule, neutral",
+			},
+		}, {
+			name:                     "emergency reparent preventing cross cell failover",
+			emergencyReparent:        true,
+			preventCrossCellFailover: true,
+			wantCandidate:            "zone1-0000000102",
+			wantReasons: []string{
+				"zone1-0000000104 has the must_not promotion rule",
+				"zone1-0000000105 is unreachable",
+				"zone2-0000000103 is not in the same cell as the previous primary",
+				"zone1-0000000102 is the most advanced of the tablets with the best promotion rule, neutral",
+			},
+		}, {
+			name:          "planned reparent",
+			wantCandidate: "zone1-0000000102",
+			wantReasons: []string{
+				"zone1-0000000100 is not a replica",
+				"zone1-0000000104 is not a replica",
+				"zone1-0000000105 is unreachable",
+				"zone2-0000000103 is not in the same cell as the previous primary",
+				"zone1-0000000102 is the most advanced tablet, with the neutral promotion rule",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.ClearVTOrcDatabase()
+			config.SetPreventCrossCellFailover(tt.preventCrossCellFailover)
+
+			keyspaceInfo := &topo.KeyspaceInfo{
+				Keyspace: &topodatapb.Keyspace{
+					DurabilityPolicy: "semi_sync",
+				},
+			}
+			keyspaceInfo.SetKeyspaceName("ks")
+			require.NoError(t, inst.SaveKeyspace(keyspaceInfo))
+			require.NoError(t, inst.SaveShard(topo.NewShardInfo("ks", "0", &topodatapb.Shard{
+				PrimaryAlias: tablets[0].tablet.Alias,
+			}, nil)))
+			for _, tablet := range tablets {
+				require.NoError(t, inst.SaveTablet(tablet.tablet))
+				instance := inst.NewInstance()
+				instance.InstanceAlias = topoproto.TabletAliasString(tablet.tablet.Alias)
+				instance.Hostname = tablet.tablet.MysqlHostname
+				instance.Port = int(tablet.tablet.MysqlPort)
+				instance.ExecutedGtidSet = tablet.gtidSet
+				require.NoError(t, inst.WriteInstance(instance, true, nil))
+				if tablet.unreachable {
+					_, err := db.ExecVTOrc("UPDATE database_instance SET last_seen = DATETIME('now', '-1 HOUR') WHERE alias = ?", instance.InstanceAlias)
+					require.NoError(t, err)
+				}
+			}
+
+			var reasons []string
+			candidate := simulateNewPrimary(&inst.ReplicationAnalysis{
+				AnalyzedInstanceAlias: "zone1-0000000100",
+				AnalyzedKeyspace:      "ks",
+				AnalyzedShard:         "0",
+			}, tt.emergencyReparent, func(format string, args ...any) {
+				reasons = append(reasons, fmt.Sprintf(format, args...))
+			})
+			require.Equal(t, tt.wantCandidate, candidate)
+			require.ElementsMatch(t, tt.wantReasons, reasons)
+		})
+	}
+}
+
+func newSimulationTablet(cell string, uid uint32, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+	return &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{
+			Cell: cell,
+			Uid:  uid,
+		},
+		Hostname:      fmt.Sprintf("host%d", uid),
+		MysqlHostname: fmt.Sprintf("host%d", uid),
+		MysqlPort:     3306,
+		Keyspace:      "ks",
+		Shard:         "0",
+		Type:          tabletType,
+	}
+}
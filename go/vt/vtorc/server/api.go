@@ -46,6 +46,7 @@ const (
 	disableGlobalRecoveriesAPI    = "/api/disable-global-recoveries"
 	enableGlobalRecoveriesAPI     = "/api/enable-global-recoveries"
 	replicationAnalysisAPI        = "/api/replication-analysis"
+	simulateRecoveryAPI           = "/api/simulate-recovery"
 	databaseStateAPI              = "/api/database-state"
 	configAPI                     = "/api/config"
 	healthAPI                     = "/debug/health"
@@ -63,6 +64,7 @@ var (
 		disableGlobalRecoveriesAPI,
 		enableGlobalRecoveriesAPI,
 		replicationAnalysisAPI,
+		simulateRecoveryAPI,
 		databaseStateAPI,
 		configAPI,
 		healthAPI,
@@ -91,6 +93,8 @@ func (v *vtorcAPI) ServeHTTP(response http.ResponseWriter, request *http.Request
 		errantGTIDsAPIHandler(response, request)
 	case replicationAnalysisAPI:
 		replicationAnalysisAPIHandler(response, request)
+	case simulateRecoveryAPI:
+		simulateRecoveryAPIHandler(response, request)
 	case databaseStateAPI:
 		databaseStateAPIHandler(response)
 	case configAPI:
@@ -111,7 +115,7 @@ func getACLPermissionLevelForAPI(apiEndpoint string) string {
 		return acl.MONITORING
 	case disableGlobalRecoveriesAPI, enableGlobalRecoveriesAPI:
 		return acl.ADMIN
-	case replicationAnalysisAPI, configAPI:
+	case replicationAnalysisAPI, simulateRecoveryAPI, configAPI:
 		return acl.MONITORING
 	case healthAPI, databaseStateAPI:
 		return acl.MONITORING
@@ -261,6 +265,23 @@ func replicationAnalysisAPIHandler(response http.ResponseWriter, request *http.R
 	returnAsJSON(response, http.StatusOK, analysis)
 }
 
+// simulateRecoveryAPIHandler is the handler for the simulateRecoveryAPI endpoint
+func simulateRecoveryAPIHandler(response http.ResponseWriter, request *http.Request) {
+	// This api also supports filtering by shard and keyspace provided.
+	shard := request.URL.Query().Get("shard")
+	keyspace := request.URL.Query().Get("keyspace")
+	if shard != "" && keyspace == "" {
+		http.Error(response, shardWithoutKeyspaceFilteringErrorStr, http.StatusBadRequest)
+		return
+	}
+	simulatedRecoveries, err := logic.SimulateRecoveries(keyspace, shard)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	returnAsJSON(response, http.StatusOK, simulatedRecoveries)
+}
+
 // healthAPIHandler is the handler for the healthAPI endpoint
 func healthAPIHandler(response http.ResponseWriter, request *http.Request) {
 	health, discoveredOnce := process.HealthTest()
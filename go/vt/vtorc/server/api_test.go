@@ -28,6 +28,9 @@ func TestGetACLPermissionLevelForAPI(t *testing.T) {
 		}, {
 			apiEndpoint: replicationAnalysisAPI,
 			want:        acl.MONITORING,
+		}, {
+			apiEndpoint: simulateRecoveryAPI,
+			want:        acl.MONITORING,
 		}, {
 			apiEndpoint: healthAPI,
 			want:        acl.MONITORING,
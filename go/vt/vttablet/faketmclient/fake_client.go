@@ -200,6 +200,11 @@ func (client *FakeTabletManagerClient) ApplySchema(ctx context.Context, tablet *
 	return &tabletmanagerdatapb.SchemaChangeResult{}, nil
 }
 
+// AnalyzeSchemaMigration is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) AnalyzeSchemaMigration(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.AnalyzeSchemaMigrationRequest) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error) {
+	return &tabletmanagerdatapb.AnalyzeSchemaMigrationResponse{}, nil
+}
+
 // ExecuteQuery is part of the tmclient.TabletManagerClient interface.
 func (client *FakeTabletManagerClient) ExecuteQuery(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ExecuteQueryRequest) (*querypb.QueryResult, error) {
 	return &querypb.QueryResult{}, nil
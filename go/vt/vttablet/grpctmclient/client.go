@@ -501,6 +501,16 @@ func (client *Client) ApplySchema(ctx context.Context, tablet *topodatapb.Tablet
 	}, nil
 }
 
+// AnalyzeSchemaMigration is part of the tmclient.TabletManagerClient interface.
+func (client *Client) AnalyzeSchemaMigration(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.AnalyzeSchemaMigrationRequest) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return c.AnalyzeSchemaMigration(ctx, req)
+}
+
 // LockTables is part of the tmclient.TabletManagerClient interface.
 func (client *Client) LockTables(ctx context.Context, tablet *topodatapb.Tablet) error {
 	c, closer, err := client.dialer.dial(ctx, tablet)
@@ -196,6 +196,12 @@ func (s *server) ApplySchema(ctx context.Context, request *tabletmanagerdatapb.A
 	return response, err
 }
 
+func (s *server) AnalyzeSchemaMigration(ctx context.Context, request *tabletmanagerdatapb.AnalyzeSchemaMigrationRequest) (response *tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "AnalyzeSchemaMigration", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	return s.tm.AnalyzeSchemaMigration(ctx, request)
+}
+
 func (s *server) ResetSequences(ctx context.Context, request *tabletmanagerdatapb.ResetSequencesRequest) (response *tabletmanagerdatapb.ResetSequencesResponse, err error) {
 	defer s.tm.HandleRPCPanic(ctx, "ResetSequences", request, response, true /*verbose*/, &err)
 	response = &tabletmanagerdatapb.ResetSequencesResponse{}
@@ -19,13 +19,34 @@ package onlineddl
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
 
+	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/capabilities"
-	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconnpool"
 	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/schemadiff"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// defaultCopyRowsPerSecond is the copy rate assumed by a migration analysis
+	// when there are no completed migrations to learn the actual rate from.
+	defaultCopyRowsPerSecond = 10000
+	// copyRateMigrationsSample is the number of recently completed migrations
+	// a migration analysis learns the copy rate from.
+	copyRateMigrationsSample = 10
 )
 
 type specialAlterOperation string
@@ -134,3 +155,186 @@ func (e *Executor) analyzeSpecialAlterPlan(ctx context.Context, onlineDDL *schem
 	}
 	return nil, nil
 }
+
+// analyzeAlterTableMigration analyzes how an ALTER TABLE migration would run on a table, given the
+// table's current CreateTable, without running it. It reports whether the migration can run
+// with ALGORITHM=INSTANT or with a special plan, the unique key that the table copy would iterate by,
+// and anything that blocks the migration.
+func analyzeAlterTableMigration(
+	env *vtenv.Environment,
+	createTable *sqlparser.CreateTable,
+	alterTable *sqlparser.AlterTable,
+	setting *schema.DDLStrategySetting,
+	capableOf capabilities.CapableOf,
+	participatesInFK bool,
+) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error) {
+	analysis := &tabletmanagerdatapb.AnalyzeSchemaMigrationResponse{
+		Table: createTable.GetTable().Name.String(),
+	}
+	isRangeRotation, err := schemadiff.AlterTableRotatesRangePartition(createTable, alterTable)
+	if err != nil {
+		return nil, err
+	}
+	if isRangeRotation {
+		analysis.SpecialPlan = NewSpecialAlterOperation(rangePartitionSpecialOperation, alterTable, createTable).String()
+	}
+	analysis.InstantDdlCapable, err = schemadiff.AlterTableCapableOfInstantDDL(alterTable, createTable, capableOf)
+	if err != nil {
+		return nil, err
+	}
+	if analysis.InstantDdlCapable && analysis.SpecialPlan == "" && setting.IsPreferInstantDDL() {
+		analysis.SpecialPlan = NewSpecialAlterOperation(instantDDLSpecialOperation, alterTable, createTable).String()
+	}
+
+	alterTableAnalysis := schemadiff.OnlineDDLAlterTableAnalysis(alterTable)
+	if alterTableAnalysis.IsRenameTable {
+		analysis.Blockers = append(analysis.Blockers, "renaming the table is not supported in ALTER TABLE")
+	}
+	senv := schemadiff.NewEnv(env, env.CollationEnv().DefaultConnectionCharset())
+	sourceEntity, err := schemadiff.NewCreateTableEntity(senv, createTable)
+	if err != nil {
+		return nil, err
+	}
+	// The migration target is computed in memory, rather than by altering a shadow table.
+	targetEntity, err := sourceEntity.Apply(schemadiff.EntityDiffByStatement(alterTable))
+	if err != nil {
+		analysis.Blockers = append(analysis.Blockers, err.Error())
+		return analysis, nil
+	}
+	targetCreateTableEntity := targetEntity.(*schemadiff.CreateTableEntity)
+	if !setting.IsAllowForeignKeysFlag() && (participatesInFK || definesForeignKey(targetCreateTableEntity.CreateTable)) {
+		analysis.Blockers = append(analysis.Blockers, fmt.Sprintf("table %s participates in a FOREIGN KEY constraint and FOREIGN KEY constraints are not supported in Online DDL unless the *experimental and unsafe* --unsafe-allow-foreign-keys strategy flag is specified", analysis.Table))
+	}
+	tablesAnalysis, err := schemadiff.OnlineDDLMigrationTablesAnalysis(sourceEntity, targetCreateTableEntity, alterTableAnalysis)
+	if err != nil {
+		analysis.Blockers = append(analysis.Blockers, err.Error())
+		return analysis, nil
+	}
+	analysis.UniqueKey = tablesAnalysis.ChosenSourceUniqueKey.Name()
+	analysis.UniqueKeyColumns = tablesAnalysis.ChosenSourceUniqueKey.ColumnList.Names()
+	return analysis, nil
+}
+
+// definesForeignKey returns true when the table has a FOREIGN KEY constraint.
+func definesForeignKey(createTable *sqlparser.CreateTable) bool {
+	found := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		if _, ok := node.(*sqlparser.ForeignKeyDefinition); ok {
+			found = true
+			return false, nil
+		}
+		return true, nil
+	}, createTable)
+	return found
+}
+
+// estimateCopyDuration estimates the time it takes to copy the given number of rows at the given
+// rate, when Online DDL is throttled by the given ratio. It returns false when there is no estimate.
+func estimateCopyDuration(rows int64, rowsPerSecond int64, throttleRatio float64) (time.Duration, bool) {
+	if rowsPerSecond <= 0 || throttleRatio >= 1 {
+		return 0, false
+	}
+	seconds := float64(rows) / (float64(rowsPerSecond) * (1 - throttleRatio))
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+// readCopyRowsPerSecond returns the copy rate of the recently completed migrations, or
+// the default copy rate when there are none.
+func (e *Executor) readCopyRowsPerSecond(ctx context.Context) (int64, error) {
+	query, err := sqlparser.ParseAndBind(sqlSelectRecentCopyRate, sqltypes.Int64BindVariable(copyRateMigrationsSample))
+	if err != nil {
+		return 0, err
+	}
+	rs, err := e.execQuery(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	row := rs.Named().Row()
+	if row == nil {
+		return defaultCopyRowsPerSecond, nil
+	}
+	rowsCopied := row.AsInt64("rows_copied", 0)
+	copySeconds := row.AsInt64("copy_seconds", 0)
+	if rowsCopied <= 0 || copySeconds <= 0 {
+		return defaultCopyRowsPerSecond, nil
+	}
+	return rowsCopied / copySeconds, nil
+}
+
+// AnalyzeMigration analyzes an ALTER TABLE statement as if it were submitted with the given
+// DDL strategy, without submitting it. On top of the analysis of the statement, it estimates
+// the duration of the table copy based on the table's row count, the copy rate of recent
+// migrations and the throttler's state.
+func (e *Executor) AnalyzeMigration(ctx context.Context, sql string, ddlStrategy string) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error) {
+	if atomic.LoadInt64(&e.isOpen) == 0 {
+		return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, schema.ErrOnlineDDLDisabled.Error())
+	}
+	setting, err := schema.ParseDDLStrategy(ddlStrategy)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid DDL strategy %q", ddlStrategy)
+	}
+	switch setting.Strategy {
+	case schema.DDLStrategyVitess, schema.DDLStrategyOnline:
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "migration analysis is only supported for the 'vitess' strategy, got '%s'", setting.Strategy)
+	}
+	stmt, err := e.env.Environment().Parser().ParseStrictDDL(sql)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot parse %s", sql)
+	}
+	alterTable, ok := stmt.(*sqlparser.AlterTable)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "migration analysis is only supported for ALTER TABLE, got: %s", sqlparser.CanonicalString(stmt))
+	}
+	tableName := alterTable.GetTable().Name.String()
+	createTable, err := e.getCreateTableStatement(ctx, tableName)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot read table %s", tableName)
+	}
+
+	conn, err := dbconnpool.NewDBConnection(ctx, e.env.Config().DB.DbaWithDB())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	participatesInFK, err := e.tableParticipatesInForeignKeyRelationship(ctx, e.dbName, tableName)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "error while attempting to validate whether table %s participates in FOREIGN KEY constraint", tableName)
+	}
+	analysis, err := analyzeAlterTableMigration(e.env.Environment(), createTable, alterTable, setting, mysql.ServerVersionCapableOf(conn.ServerVersion), participatesInFK)
+	if err != nil {
+		return nil, err
+	}
+	if analysis.EstimatedRows, err = readTableStatus(ctx, conn, tableName); err != nil {
+		return nil, err
+	}
+
+	var throttleRatio float64
+	for _, app := range e.lagThrottler.ThrottledApps() {
+		if throttlerapp.OnlineDDLName.Equals(app.AppName) {
+			throttleRatio = app.Ratio
+			break
+		}
+	}
+	checkResult := e.lagThrottler.Check(ctx, throttlerapp.Concatenate(throttlerapp.VReplicationName.String(), throttlerapp.OnlineDDLName.String()), nil, &throttle.CheckFlags{SkipRequestHeartbeats: true})
+	if !checkResult.IsOK() {
+		analysis.Throttled = true
+		analysis.ThrottleReason = checkResult.Message
+		if analysis.ThrottleReason == "" {
+			analysis.ThrottleReason = checkResult.ResponseCode.String()
+		}
+	}
+
+	if analysis.SpecialPlan != "" || len(analysis.Blockers) > 0 {
+		// There is no table copy to estimate.
+		return analysis, nil
+	}
+	if analysis.CopyRowsPerSecond, err = e.readCopyRowsPerSecond(ctx); err != nil {
+		return nil, err
+	}
+	if d, ok := estimateCopyDuration(analysis.EstimatedRows, analysis.CopyRowsPerSecond, throttleRatio); ok {
+		analysis.EstimatedCopyDuration = protoutil.DurationToProto(d)
+	}
+	return analysis, nil
+}
@@ -22,12 +22,15 @@ package onlineddl
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
 )
 
 func TestAnalyzeInstantDDL(t *testing.T) {
@@ -238,3 +241,147 @@ func TestAnalyzeInstantDDL(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzeAlterTableMigration(t *testing.T) {
+	tt := []struct {
+		name             string
+		create           string
+		alter            string
+		strategy         string
+		participatesInFK bool
+		instant          bool
+		specialPlan      bool
+		uniqueKey        string
+		uniqueKeyColumns []string
+		blockers         int
+	}{
+		{
+			name:             "add column",
+			create:           "create table t(id int, i1 int not null, primary key(id))",
+			alter:            "alter table t add column i2 int not null",
+			strategy:         "vitess",
+			instant:          true,
+			uniqueKey:        "PRIMARY",
+			uniqueKeyColumns: []string{"id"},
+		},
+		{
+			name:             "add column, prefer instant",
+			create:           "create table t(id int, i1 int not null, primary key(id))",
+			alter:            "alter table t add column i2 int not null",
+			strategy:         "vitess --prefer-instant-ddl",
+			instant:          true,
+			specialPlan:      true,
+			uniqueKey:        "PRIMARY",
+			uniqueKeyColumns: []string{"id"},
+		},
+		{
+			name:             "unique key",
+			create:           "create table t(id int, i1 int not null, i2 int, unique key i1_uidx(i1))",
+			alter:            "alter table t drop column i2",
+			strategy:         "vitess",
+			instant:          true,
+			uniqueKey:        "i1_uidx",
+			uniqueKeyColumns: []string{"i1"},
+		},
+		{
+			name:     "no unique key",
+			create:   "create table t(id int, i1 int not null)",
+			alter:    "alter table t drop column i1",
+			strategy: "vitess",
+			instant:  true,
+			blockers: 1,
+		},
+		{
+			name:             "participates in foreign key",
+			create:           "create table t(id int, i1 int not null, primary key(id))",
+			alter:            "alter table t add column i2 int not null",
+			strategy:         "vitess",
+			participatesInFK: true,
+			instant:          true,
+			uniqueKey:        "PRIMARY",
+			uniqueKeyColumns: []string{"id"},
+			blockers:         1,
+		},
+		{
+			name:             "participates in foreign key, allowed",
+			create:           "create table t(id int, i1 int not null, primary key(id))",
+			alter:            "alter table t add column i2 int not null",
+			strategy:         "vitess --unsafe-allow-foreign-keys",
+			participatesInFK: true,
+			instant:          true,
+			uniqueKey:        "PRIMARY",
+			uniqueKeyColumns: []string{"id"},
+		},
+		{
+			name:     "invalid alter",
+			create:   "create table t(id int, i1 int not null, primary key(id))",
+			alter:    "alter table t drop column i9",
+			strategy: "vitess",
+			blockers: 1,
+		},
+	}
+	env := vtenv.NewTestEnv()
+	capableOf := mysql.ServerVersionCapableOf("8.0.32")
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			stmt, err := env.Parser().ParseStrictDDL(tc.create)
+			require.NoError(t, err)
+			createTable, ok := stmt.(*sqlparser.CreateTable)
+			require.True(t, ok)
+
+			stmt, err = env.Parser().ParseStrictDDL(tc.alter)
+			require.NoError(t, err)
+			alterTable, ok := stmt.(*sqlparser.AlterTable)
+			require.True(t, ok)
+
+			setting, err := schema.ParseDDLStrategy(tc.strategy)
+			require.NoError(t, err)
+
+			analysis, err := analyzeAlterTableMigration(env, createTable, alterTable, setting, capableOf, tc.participatesInFK)
+			require.NoError(t, err)
+			assert.Equal(t, "t", analysis.Table)
+			assert.Equal(t, tc.instant, analysis.InstantDdlCapable)
+			assert.Equal(t, tc.specialPlan, analysis.SpecialPlan != "")
+			assert.Equal(t, tc.uniqueKey, analysis.UniqueKey)
+			assert.Equal(t, tc.uniqueKeyColumns, analysis.UniqueKeyColumns)
+			assert.Len(t, analysis.Blockers, tc.blockers, "blockers: %v", analysis.Blockers)
+		})
+	}
+}
+
+func TestEstimateCopyDuration(t *testing.T) {
+	tt := []struct {
+		rows          int64
+		rowsPerSecond int64
+		throttleRatio float64
+		expect        time.Duration
+		ok            bool
+	}{
+		{
+			rows:          10000,
+			rowsPerSecond: 1000,
+			expect:        10 * time.Second,
+			ok:            true,
+		},
+		{
+			rows:          10000,
+			rowsPerSecond: 1000,
+			throttleRatio: 0.5,
+			expect:        20 * time.Second,
+			ok:            true,
+		},
+		{
+			rows:          10000,
+			rowsPerSecond: 1000,
+			throttleRatio: 1,
+		},
+		{
+			rows: 10000,
+		},
+	}
+	for _, tc := range tt {
+		d, ok := estimateCopyDuration(tc.rows, tc.rowsPerSecond, tc.throttleRatio)
+		assert.Equal(t, tc.ok, ok)
+		assert.Equal(t, tc.expect, d)
+	}
+}
@@ -2977,6 +2977,26 @@ func shouldCutOverAccordingToBackoff(
 	return false, false
 }
 
+// shouldCutOverAccordingToWindows is called when a vitess migration (ALTER TABLE) is generally ready to cut-over.
+// It determines whether the migration is allowed to cut-over now, according to the `--cut-over-window` DDL strategy
+// windows. A forced cut-over, via user command, overrides the windows. When the migration is not allowed to cut-over,
+// the function also returns the time when the next window opens.
+func shouldCutOverAccordingToWindows(
+	shouldForceCutOverIndicator bool,
+	windows schema.CutOverWindows,
+	now time.Time,
+) (
+	shouldCutOver bool, nextWindow time.Time,
+) {
+	if shouldForceCutOverIndicator {
+		return true, time.Time{}
+	}
+	if windows.Contains(now) {
+		return true, time.Time{}
+	}
+	return false, windows.Next(now)
+}
+
 // reviewRunningMigrations iterates migrations in 'running' state. Normally there's only one running, which was
 // spawned by this tablet; but vreplication migrations could also resume from failure.
 func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning int, cancellable []*cancellableMigration, err error) {
@@ -3026,6 +3046,11 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 		if errForceCutOverAfter != nil {
 			forceCutOverAfter = 0
 		}
+		// Likewise for --cut-over-window: we choose to cut-over at any time rather than never.
+		cutOverWindows, errCutOverWindows := strategySetting.CutOverWindows()
+		if errCutOverWindows != nil {
+			cutOverWindows = nil
+		}
 
 		uuidsFoundRunning[uuid] = true
 
@@ -3136,6 +3161,14 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 						return nil
 					}
 				}
+				if len(cutOverWindows) > 0 {
+					inCutOverWindow, nextCutOverWindow := shouldCutOverAccordingToWindows(shouldForceCutOver, cutOverWindows, time.Now())
+					// Let outside observers know when the migration is going to cut-over.
+					_ = e.updateMigrationNextCutOverWindow(ctx, uuid, nextCutOverWindow)
+					if !inCutOverWindow {
+						return nil
+					}
+				}
 				shouldCutOver, shouldForceCutOver := shouldCutOverAccordingToBackoff(
 					shouldForceCutOver, forceCutOverAfter, sinceReadyToComplete, sinceLastCutoverAttempt, cutoverAttempts,
 				)
@@ -3877,6 +3910,30 @@ func (e *Executor) updateMigrationReadyToComplete(ctx context.Context, uuid stri
 	return nil
 }
 
+// updateMigrationNextCutOverWindow sets the time when the next cut-over window of the migration
+// opens, or clears it when given a zero time.
+func (e *Executor) updateMigrationNextCutOverWindow(ctx context.Context, uuid string, nextWindow time.Time) error {
+	var query string
+	var err error
+	if nextWindow.IsZero() {
+		query, err = sqlparser.ParseAndBind(sqlClearNextCutOverWindow,
+			sqltypes.StringBindVariable(uuid),
+		)
+	} else {
+		nextWindowTimestamp := nextWindow.Local().Format(sqltypes.TimestampFormat)
+		query, err = sqlparser.ParseAndBind(sqlUpdateNextCutOverWindow,
+			sqltypes.StringBindVariable(nextWindowTimestamp),
+			sqltypes.StringBindVariable(uuid),
+			sqltypes.StringBindVariable(nextWindowTimestamp),
+		)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = e.execQuery(ctx, query)
+	return err
+}
+
 func (e *Executor) updateMigrationUserThrottleRatio(ctx context.Context, uuid string, ratio float64) error {
 	query, err := sqlparser.ParseAndBind(sqlUpdateMigrationUserThrottleRatio,
 		sqltypes.Float64BindVariable(ratio),
@@ -26,6 +26,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/schema"
 )
 
 func TestShouldCutOverAccordingToBackoff(t *testing.T) {
@@ -166,6 +168,63 @@ func TestShouldCutOverAccordingToBackoff(t *testing.T) {
 	}
 }
 
+func TestShouldCutOverAccordingToWindows(t *testing.T) {
+	windows, err := schema.ParseCutOverWindows("Sat,Sun 01:00-05:00")
+	require.NoError(t, err)
+	// 2024-01-06 is a Saturday.
+	saturdayNight := time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC)
+	mondayNoon := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	nextSaturday := time.Date(2024, 1, 13, 1, 0, 0, 0, time.UTC)
+
+	tcases := []struct {
+		name string
+
+		shouldForceCutOverIndicator bool
+		windows                     schema.CutOverWindows
+		now                         time.Time
+
+		expectShouldCutOver bool
+		expectNextWindow    time.Time
+	}{
+		{
+			name:                "no windows",
+			now:                 mondayNoon,
+			expectShouldCutOver: true,
+		},
+		{
+			name:                "in window",
+			windows:             windows,
+			now:                 saturdayNight,
+			expectShouldCutOver: true,
+		},
+		{
+			name:                "out of window",
+			windows:             windows,
+			now:                 mondayNoon,
+			expectShouldCutOver: false,
+			expectNextWindow:    nextSaturday,
+		},
+		{
+			name:                        "force cutover overrides windows",
+			shouldForceCutOverIndicator: true,
+			windows:                     windows,
+			now:                         mondayNoon,
+			expectShouldCutOver:         true,
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			shouldCutOver, nextWindow := shouldCutOverAccordingToWindows(
+				tcase.shouldForceCutOverIndicator,
+				tcase.windows,
+				tcase.now,
+			)
+			assert.Equal(t, tcase.expectShouldCutOver, shouldCutOver)
+			assert.True(t, tcase.expectNextWindow.Equal(nextWindow), "expected %v, got %v", tcase.expectNextWindow, nextWindow)
+		})
+	}
+}
+
 func TestSafeMigrationCutOverThreshold(t *testing.T) {
 	require.NotZero(t, defaultCutOverThreshold)
 	require.GreaterOrEqual(t, defaultCutOverThreshold, minCutOverThreshold)
@@ -192,6 +192,18 @@ const (
 		WHERE
 			migration_uuid=%a
 	`
+	sqlUpdateNextCutOverWindow = `UPDATE _vt.schema_migrations
+			SET next_cutover_window_timestamp=%a
+		WHERE
+			migration_uuid=%a
+			AND (next_cutover_window_timestamp IS NULL OR next_cutover_window_timestamp != %a)
+	`
+	sqlClearNextCutOverWindow = `UPDATE _vt.schema_migrations
+			SET next_cutover_window_timestamp=NULL
+		WHERE
+			migration_uuid=%a
+			AND next_cutover_window_timestamp IS NOT NULL
+	`
 	sqlUpdateLaunchMigration = `UPDATE _vt.schema_migrations
 			SET postpone_launch=0
 		WHERE
@@ -468,6 +480,24 @@ const (
 			migration_status='ready'
 		ORDER BY id
 	`
+	sqlSelectRecentCopyRate = `SELECT
+			IFNULL(SUM(rows_copied), 0) AS rows_copied,
+			IFNULL(SUM(TIMESTAMPDIFF(SECOND, started_timestamp, ready_to_complete_timestamp)), 0) AS copy_seconds
+		FROM (
+			SELECT
+				rows_copied,
+				started_timestamp,
+				ready_to_complete_timestamp
+			FROM _vt.schema_migrations
+			WHERE
+				migration_status='complete'
+				AND strategy IN ('vitess', 'online')
+				AND rows_copied > 0
+				AND ready_to_complete_timestamp > started_timestamp
+			ORDER BY id DESC
+			LIMIT %a
+		) AS recent_migrations
+	`
 	selSelectCountFKParentConstraints = `
 		SELECT
 			COUNT(*) as num_fk_constraints
@@ -212,7 +212,7 @@ func (v *VRepl) executeAnalyzeTable(ctx context.Context, conn *dbconnpool.DBConn
 }
 
 // readTableStatus reads table status information
-func (v *VRepl) readTableStatus(ctx context.Context, conn *dbconnpool.DBConnection, tableName string) (tableRows int64, err error) {
+func readTableStatus(ctx context.Context, conn *dbconnpool.DBConnection, tableName string) (tableRows int64, err error) {
 	parsed := sqlparser.BuildParsedQuery(sqlShowTableStatus, tableName)
 	rs, err := conn.ExecuteFetch(parsed.Query, -1, true)
 	if err != nil {
@@ -250,7 +250,7 @@ func (v *VRepl) analyzeTableStatus(ctx context.Context, conn *dbconnpool.DBConne
 			return err
 		}
 	}
-	v.tableRows, err = v.readTableStatus(ctx, conn, v.sourceTableName())
+	v.tableRows, err = readTableStatus(ctx, conn, v.sourceTableName())
 	if err != nil {
 		return err
 	}
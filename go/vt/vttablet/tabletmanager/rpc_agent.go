@@ -69,6 +69,8 @@ type RPCTM interface {
 
 	ApplySchema(ctx context.Context, change *tmutils.SchemaChange) (*tabletmanagerdatapb.SchemaChangeResult, error)
 
+	AnalyzeSchemaMigration(ctx context.Context, req *tabletmanagerdatapb.AnalyzeSchemaMigrationRequest) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error)
+
 	ResetSequences(ctx context.Context, tables []string) error
 
 	LockTables(ctx context.Context) error
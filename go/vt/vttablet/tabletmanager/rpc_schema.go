@@ -58,6 +58,11 @@ func (tm *TabletManager) ReloadSchema(ctx context.Context, waitPosition string)
 	return tm.QueryServiceControl.ReloadSchema(ctx)
 }
 
+// AnalyzeSchemaMigration analyzes an Online DDL migration without submitting it.
+func (tm *TabletManager) AnalyzeSchemaMigration(ctx context.Context, req *tabletmanagerdatapb.AnalyzeSchemaMigrationRequest) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error) {
+	return tm.QueryServiceControl.AnalyzeSchemaMigration(ctx, req.Sql, req.DdlStrategy)
+}
+
 // ResetSequences will reset the auto-inc counters on the specified tables.
 func (tm *TabletManager) ResetSequences(ctx context.Context, tables []string) error {
 	return tm.QueryServiceControl.SchemaEngine().ResetSequences(tables)
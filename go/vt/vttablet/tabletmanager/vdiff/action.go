@@ -33,6 +33,7 @@ import (
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
@@ -45,6 +46,8 @@ const (
 	StopAction    VDiffAction = "stop"
 	ResumeAction  VDiffAction = "resume"
 	DeleteAction  VDiffAction = "delete"
+	RepairAction  VDiffAction = "repair"
+	ApplyAction   VDiffAction = "apply"
 	AllActionArg              = "all"
 	LastActionArg             = "last"
 
@@ -52,7 +55,7 @@ const (
 )
 
 var (
-	Actions    = []VDiffAction{CreateAction, ShowAction, StopAction, ResumeAction, DeleteAction}
+	Actions    = []VDiffAction{CreateAction, ShowAction, StopAction, ResumeAction, DeleteAction, RepairAction, ApplyAction}
 	ActionArgs = []string{AllActionArg, LastActionArg}
 
 	// The real zero value has nested nil pointers.
@@ -108,6 +111,10 @@ func (vde *Engine) PerformVDiffAction(ctx context.Context, req *tabletmanagerdat
 		if err := vde.handleDeleteAction(ctx, dbClient, req, resp); err != nil {
 			return nil, err
 		}
+	case RepairAction, ApplyAction:
+		if err := vde.handleRepairAction(ctx, dbClient, action, req, resp); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("action %s not supported", action)
 	}
@@ -459,3 +466,112 @@ func (vde *Engine) handleDeleteAction(ctx context.Context, dbClient binlogplayer
 
 	return nil
 }
+
+// handleRepairAction returns the statements recorded by a vdiff that used the
+// repair option. The apply action first runs the ones that were not applied
+// yet, in the order they were recorded.
+func (vde *Engine) handleRepairAction(ctx context.Context, dbClient binlogplayer.DBClient, action VDiffAction, req *tabletmanagerdatapb.VDiffRequest, resp *tabletmanagerdatapb.VDiffResponse) error {
+	query, err := sqlparser.ParseAndBind(sqlGetVDiffByKeyspaceWorkflowUUID,
+		sqltypes.StringBindVariable(req.Keyspace),
+		sqltypes.StringBindVariable(req.Workflow),
+		sqltypes.StringBindVariable(req.VdiffUuid),
+	)
+	if err != nil {
+		return err
+	}
+	qr, err := dbClient.ExecuteFetch(query, 1)
+	if err != nil {
+		return err
+	}
+	row := qr.Named().Row()
+	if row == nil {
+		return vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "no vdiff found for UUID %s keyspace %s and workflow %s on tablet %s",
+			req.VdiffUuid, req.Keyspace, req.Workflow, topoproto.TabletAliasString(vde.thisTablet.Alias))
+	}
+	vdiffID, err := row.ToInt64("id")
+	if err != nil {
+		return err
+	}
+	resp.Id = vdiffID
+	resp.VdiffUuid = req.VdiffUuid
+
+	if action == ApplyAction {
+		if err := vde.applyRepairs(ctx, dbClient, req, vdiffID, VDiffState(row.AsString("state", ""))); err != nil {
+			return err
+		}
+	}
+
+	query, err = sqlparser.ParseAndBind(sqlGetVDiffRepairs, sqltypes.Int64BindVariable(vdiffID))
+	if err != nil {
+		return err
+	}
+	if qr, err = dbClient.ExecuteFetch(query, -1); err != nil {
+		return err
+	}
+	resp.Output = sqltypes.ResultToProto3(qr)
+	return nil
+}
+
+// applyRepairs runs the repair statements of the vdiff that were not applied
+// yet. The target must not change while we do so, which means that the vdiff
+// is done and all of the workflow's streams are stopped.
+func (vde *Engine) applyRepairs(ctx context.Context, dbClient binlogplayer.DBClient, req *tabletmanagerdatapb.VDiffRequest, vdiffID int64, state VDiffState) error {
+	if state == PendingState || state == StartedState {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "vdiff %s is %s, it must be completed or stopped before applying its repairs",
+			req.VdiffUuid, state)
+	}
+	query, err := sqlparser.ParseAndBind(sqlGetVReplicationStates,
+		sqltypes.StringBindVariable(req.Workflow),
+		sqltypes.StringBindVariable(vde.dbName),
+	)
+	if err != nil {
+		return err
+	}
+	qr, err := dbClient.ExecuteFetch(query, -1)
+	if err != nil {
+		return err
+	}
+	for _, row := range qr.Named().Rows {
+		if streamState := row.AsString("state", ""); streamState != binlogdatapb.VReplicationWorkflowState_Stopped.String() {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "workflow %s.%s has streams in the %s state, it must be stopped before applying the repairs of vdiff %s",
+				req.Keyspace, req.Workflow, streamState, req.VdiffUuid)
+		}
+	}
+
+	query, err = sqlparser.ParseAndBind(sqlGetUnappliedRepairs, sqltypes.Int64BindVariable(vdiffID))
+	if err != nil {
+		return err
+	}
+	if qr, err = dbClient.ExecuteFetch(query, -1); err != nil {
+		return err
+	}
+	for _, row := range qr.Named().Rows {
+		select {
+		case <-ctx.Done():
+			return vterrors.Errorf(vtrpcpb.Code_CANCELED, "context has expired")
+		default:
+		}
+		id := row.AsInt64("id", 0)
+		markApplied, err := sqlparser.ParseAndBind(sqlMarkRepairApplied, sqltypes.Int64BindVariable(id))
+		if err != nil {
+			return err
+		}
+		// The statement and its applied_at are committed together, so that
+		// a failure never leaves a statement applied without being marked.
+		if err := dbClient.Begin(); err != nil {
+			return err
+		}
+		if _, err := dbClient.ExecuteFetch(row.AsString("statement", ""), 1); err != nil {
+			dbClient.Rollback()
+			return vterrors.Wrapf(err, "failed to apply repair statement %d of vdiff %s", id, req.VdiffUuid)
+		}
+		if _, err := dbClient.ExecuteFetch(markApplied, 1); err != nil {
+			dbClient.Rollback()
+			return err
+		}
+		if err := dbClient.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
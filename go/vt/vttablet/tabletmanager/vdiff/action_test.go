@@ -244,7 +244,8 @@ func TestPerformVDiffAction(t *testing.T) {
 					),
 				},
 				{
-					query: fmt.Sprintf(`delete from vd, vdt using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
+					query: fmt.Sprintf(`delete from vd, vdt, vdr using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
+							left join _vt.vdiff_repair as vdr on (vd.id = vdr.vdiff_id)
 							where vd.vdiff_uuid = %s`, encodeString(uuid)),
 				},
 			},
@@ -270,12 +271,73 @@ func TestPerformVDiffAction(t *testing.T) {
 					),
 				},
 				{
-					query: fmt.Sprintf(`delete from vd, vdt, vdl using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
+					query: fmt.Sprintf(`delete from vd, vdt, vdl, vdr using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
 										left join _vt.vdiff_log as vdl on (vd.id = vdl.vdiff_id)
+										left join _vt.vdiff_repair as vdr on (vd.id = vdr.vdiff_id)
 										where vd.keyspace = %s and vd.workflow = %s`, encodeString(keyspace), encodeString(workflow)),
 				},
 			},
 		},
+		{
+			name: "repair",
+			req: &tabletmanagerdatapb.VDiffRequest{
+				Action:    string(RepairAction),
+				VdiffUuid: uuid,
+				Keyspace:  keyspace,
+				Workflow:  workflow,
+			},
+			expectQueries: []queryAndResult{
+				{
+					query: fmt.Sprintf("select * from _vt.vdiff where keyspace = %s and workflow = %s and vdiff_uuid = %s",
+						encodeString(keyspace), encodeString(workflow), encodeString(uuid)),
+					result: sqltypes.MakeTestResult(
+						sqltypes.MakeTestFields(
+							"id|state",
+							"int64|varbinary",
+						),
+						"1|completed",
+					),
+				},
+				{
+					query: "select id as id, table_name as table_name, statement as statement, applied_at as applied_at from _vt.vdiff_repair where vdiff_id = 1 order by id",
+				},
+			},
+		},
+		{
+			name: "apply while the workflow is running",
+			req: &tabletmanagerdatapb.VDiffRequest{
+				Action:    string(ApplyAction),
+				VdiffUuid: uuid,
+				Keyspace:  keyspace,
+				Workflow:  workflow,
+			},
+			expectQueries: []queryAndResult{
+				{
+					query: fmt.Sprintf("select * from _vt.vdiff where keyspace = %s and workflow = %s and vdiff_uuid = %s",
+						encodeString(keyspace), encodeString(workflow), encodeString(uuid)),
+					result: sqltypes.MakeTestResult(
+						sqltypes.MakeTestFields(
+							"id|state",
+							"int64|varbinary",
+						),
+						"1|completed",
+					),
+				},
+				{
+					query: fmt.Sprintf("select distinct state as state from _vt.vreplication where workflow = %s and db_name = %s",
+						encodeString(workflow), encodeString(vdiffDBName)),
+					result: sqltypes.MakeTestResult(
+						sqltypes.MakeTestFields(
+							"state",
+							"varbinary",
+						),
+						"Running",
+					),
+				},
+			},
+			wantErr: vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "workflow %s.%s has streams in the Running state, it must be stopped before applying the repairs of vdiff %s",
+				keyspace, workflow, uuid),
+		},
 		{
 			name: "show last",
 			req: &tabletmanagerdatapb.VDiffRequest{
@@ -225,6 +225,8 @@ func shortCircuitTestAfterQuery(query string, dbClient *binlogplayer.MockDBClien
 type fakeTabletConn struct {
 	queryservice.QueryService
 	tablet *topodatapb.Tablet
+	// results returns the result of the queries of VStreamResults.
+	results func(query string) (*sqltypes.Result, error)
 }
 
 // StreamHealth is part of queryservice.QueryService.
@@ -282,6 +284,22 @@ func (ftc *fakeTabletConn) VStreamRows(ctx context.Context, request *binlogdatap
 	}, nil)
 }
 
+// VStreamResults returns the result of the query in a single response.
+func (ftc *fakeTabletConn) VStreamResults(ctx context.Context, target *querypb.Target, query string, send func(*binlogdatapb.VStreamResultsResponse) error) error {
+	if ftc.results == nil {
+		return fmt.Errorf("unexpected VStreamResults query on tablet %d: %s", ftc.tablet.Alias.Uid, query)
+	}
+	qr, err := ftc.results(query)
+	if err != nil {
+		return err
+	}
+	return send(&binlogdatapb.VStreamResultsResponse{
+		Fields: qr.Fields,
+		Gtid:   vdiffSourceGtid,
+		Rows:   sqltypes.ResultToProto3(qr).Rows,
+	})
+}
+
 func (ftc *fakeTabletConn) Close(ctx context.Context) error {
 	return nil
 }
@@ -0,0 +1,568 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletconn"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+/*
+	Checksum and sampled diffs split the table in ranges of primary keys. Each range has up to
+	diffRangeRows rows on each source shard, and is compared with queries bounded by its primary
+	keys, instead of streaming the whole table.
+		* With the checksum option, MySQL computes the checksum of the range on both sides, and the
+		  rows are only fetched and compared one by one when the checksums don't match.
+		* With a sample percentage, only that percentage of the ranges is queried. Whether a range
+		  is part of the sample only depends on the primary key it starts after, so diffing the
+		  same data again compares the same ranges.
+	Each source query runs in a snapshot of its own. The target streams are then run up to the
+	positions of the source snapshots, and stopped there while the target is queried.
+*/
+
+// diffRangeRows is the number of source rows of each shard in a range of a checksum or sampled diff.
+var diffRangeRows = 1000
+
+// samplingRanges returns whether a diff with the given sample percentage
+// compares only some of the ranges. No percentage means all the rows.
+func samplingRanges(samplePct int64) bool {
+	return samplePct > 0 && samplePct < 100
+}
+
+// diffsRanges returns whether the table is diffed in ranges of primary keys,
+// for the checksum and sample options. The ranges are queried in MySQL, so
+// tables whose source rows are aggregated, or filtered by key range, which
+// only vttablet can do, are diffed row by row.
+func (td *tableDiffer) diffsRanges(coreOpts *tabletmanagerdatapb.VDiffCoreOptions) bool {
+	if !coreOpts.GetChecksum() && !samplingRanges(coreOpts.GetSamplePct()) {
+		return false
+	}
+	stmt, err := td.wd.ct.vde.parser.Parse(td.tablePlan.sourceQuery)
+	if err != nil {
+		log.Errorf("Cannot parse the source query of table %s: %v", td.table.Name, err)
+		return false
+	}
+	if len(td.tablePlan.aggregates) != 0 || hasKeyRangeExpressions(stmt.(*sqlparser.Select).Where) {
+		log.Infof("Table %s cannot be diffed in ranges of primary keys, so it is diffed row by row", td.table.Name)
+		return false
+	}
+	return true
+}
+
+// rangeQueries builds the queries of one side of a checksum or sampled diff.
+type rangeQueries struct {
+	// sel is the query of the rows of the side, in the order of their primary keys.
+	sel *sqlparser.Select
+	// pks are the expressions of the primary key columns, in the order of comparePKs.
+	pks []sqlparser.Expr
+}
+
+func newRangeQueries(parser *sqlparser.Parser, query string, comparePKs []compareColInfo) (*rangeQueries, error) {
+	stmt, err := parser.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return nil, fmt.Errorf("unexpected: %v", sqlparser.String(stmt))
+	}
+	rq := &rangeQueries{sel: sel}
+	cols := sel.GetColumns()
+	for _, pk := range comparePKs {
+		aliased, ok := cols[pk.colIndex].(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, fmt.Errorf("unexpected: %v", sqlparser.String(cols[pk.colIndex]))
+		}
+		rq.pks = append(rq.pks, aliased.Expr)
+	}
+	return rq, nil
+}
+
+// boundQuery returns the query of the n-th row after the lower bound, which
+// is the last row of the range on this side.
+func (rq *rangeQueries) boundQuery(lower []sqltypes.Value, n int64) (string, error) {
+	sel := sqlparser.CloneRefOfSelect(rq.sel)
+	sel.SetLimit(sqlparser.NewLimit(int(n-1), 1))
+	return rq.generate(sel, lower, nil)
+}
+
+// rowsQuery returns the query of the rows of the range.
+func (rq *rangeQueries) rowsQuery(lower, upper []sqltypes.Value) (string, error) {
+	return rq.generate(sqlparser.CloneRefOfSelect(rq.sel), lower, upper)
+}
+
+// checksumQuery returns the query of the number of rows of the range, and of
+// the checksum of their columns. The checksum is the xor of the CRC32 of each
+// row, so that the checksums of the shards of a range can be combined.
+func (rq *rangeQueries) checksumQuery(lower, upper []sqltypes.Value) (string, error) {
+	sel := sqlparser.CloneRefOfSelect(rq.sel)
+	cols := make([]sqlparser.Expr, 0, len(sel.GetColumns()))
+	var nulls []sqlparser.Expr
+	for _, col := range sel.GetColumns() {
+		aliased, ok := col.(*sqlparser.AliasedExpr)
+		if !ok {
+			return "", fmt.Errorf("unexpected: %v", sqlparser.String(col))
+		}
+		cols = append(cols, aliased.Expr)
+		nulls = append(nulls, &sqlparser.FuncExpr{Name: sqlparser.NewIdentifierCI("isnull"), Exprs: []sqlparser.Expr{aliased.Expr}})
+	}
+	// The NULL flags of the columns are added, as concat_ws skips NULLs.
+	concat := &sqlparser.FuncExpr{
+		Name:  sqlparser.NewIdentifierCI("concat_ws"),
+		Exprs: append(append([]sqlparser.Expr{sqlparser.NewStrLiteral("#")}, cols...), &sqlparser.FuncExpr{Name: sqlparser.NewIdentifierCI("concat"), Exprs: nulls}),
+	}
+	sel.SetSelectExprs(
+		&sqlparser.AliasedExpr{Expr: &sqlparser.CountStar{}},
+		&sqlparser.AliasedExpr{Expr: &sqlparser.BitXor{Arg: &sqlparser.FuncExpr{Name: sqlparser.NewIdentifierCI("crc32"), Exprs: []sqlparser.Expr{concat}}}},
+	)
+	sel.OrderBy = nil
+	return rq.generate(sel, lower, upper)
+}
+
+// generate adds the bounds of the range to the query, and returns it. A nil
+// bound is no bound.
+func (rq *rangeQueries) generate(sel *sqlparser.Select, lower, upper []sqltypes.Value) (string, error) {
+	bindVars := make(map[string]*querypb.BindVariable)
+	bound := func(op sqlparser.ComparisonExprOperator, prefix string, values []sqltypes.Value) {
+		var left, right sqlparser.Expr
+		var tuple sqlparser.ValTuple
+		for i, value := range values {
+			name := fmt.Sprintf("%s%d", prefix, i)
+			bindVars[name] = sqltypes.ValueBindVariable(value)
+			tuple = append(tuple, sqlparser.NewArgument(name))
+		}
+		if len(rq.pks) == 1 {
+			left, right = rq.pks[0], tuple[0]
+		} else {
+			left, right = sqlparser.ValTuple(rq.pks), tuple
+		}
+		sel.AddWhere(&sqlparser.ComparisonExpr{Operator: op, Left: left, Right: right})
+	}
+	if lower != nil {
+		bound(sqlparser.GreaterThanOp, "lower", lower)
+	}
+	if upper != nil {
+		bound(sqlparser.LessEqualOp, "upper", upper)
+	}
+	return sqlparser.NewParsedQuery(sel).GenerateQuery(bindVars, nil)
+}
+
+// diffRanges is the version of diff for checksum and sampled diffs.
+func (td *tableDiffer) diffRanges(ctx context.Context, dbClient binlogplayer.DBClient, dr *DiffReport, mismatch bool,
+	coreOpts *tabletmanagerdatapb.VDiffCoreOptions, reportOpts *tabletmanagerdatapb.VDiffReportOptions,
+	stop <-chan time.Time, lastProcessedRow *[]sqltypes.Value) (*DiffReport, error) {
+	source, err := newRangeQueries(td.wd.ct.vde.parser, td.tablePlan.sourceQuery, td.tablePlan.comparePKs)
+	if err != nil {
+		return nil, err
+	}
+	target, err := newRangeQueries(td.wd.ct.vde.parser, td.tablePlan.targetQuery, td.tablePlan.comparePKs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The target streams are stopped and restarted for each range, so we
+	// hold the workflow lock until we are done.
+	lockName := fmt.Sprintf("%s/%s", td.wd.ct.vde.thisTablet.Keyspace, td.wd.ct.workflow)
+	log.Infof("Locking workflow %s", lockName)
+	ctx, unlock, err := td.wd.ct.ts.LockName(ctx, lockName, "vdiff")
+	if err != nil {
+		log.Errorf("Locking workflow %s failed: %v", lockName, err)
+		return nil, err
+	}
+	defer func() {
+		var unlockErr error
+		unlock(&unlockErr)
+		if unlockErr != nil {
+			log.Errorf("Unlocking workflow %s failed: %v", lockName, unlockErr)
+		}
+	}()
+
+	if err := td.stopTargetVReplicationStreams(ctx, dbClient); err != nil {
+		return nil, err
+	}
+	defer func() {
+		// We use a new context as we want to reset the state even
+		// when the parent context has timed out or been canceled.
+		restartCtx, restartCancel := context.WithTimeout(context.Background(), BackgroundOperationTimeout)
+		defer restartCancel()
+		if err := td.restartTargetVReplicationStreams(restartCtx); err != nil {
+			log.Errorf("error restarting target streams: %v", err)
+		}
+	}()
+	// The source snapshots must not be behind the target.
+	if err := td.syncSourceStreams(ctx); err != nil {
+		return nil, err
+	}
+
+	// lower is the primary key that the next range starts after.
+	var lower []sqltypes.Value
+	if td.lastTargetPK != nil {
+		lower = sqltypes.Proto3ToResult(td.lastTargetPK).Rows[0]
+	}
+	rowsToCompare := coreOpts.GetMaxRows()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, vterrors.Errorf(vtrpcpb.Code_CANCELED, "context has expired")
+		case <-td.wd.ct.done:
+			return nil, ErrVDiffStoppedByUser
+		case <-stop:
+			globalStats.RestartedTableDiffs.Add(td.table.Name, 1)
+			return nil, ErrMaxDiffDurationExceeded
+		default:
+		}
+
+		if !mismatch && dr.MismatchedRows > 0 {
+			mismatch = true
+			log.Infof("Flagging mismatch for %s: %+v", td.table.Name, dr)
+			if err := updateTableMismatch(dbClient, td.wd.ct.id, td.table.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		if rowsToCompare <= 0 {
+			log.Infof("Stopping vdiff, specified row limit reached")
+			return dr, nil
+		}
+
+		// The range ends with the first of the last rows of the source
+		// shards, or with the table once no shard has a full range left.
+		lastRow, err := td.rangeLastRow(ctx, source, lower, min(int64(diffRangeRows), rowsToCompare))
+		if err != nil {
+			return nil, err
+		}
+		var upper []sqltypes.Value
+		if lastRow != nil {
+			upper = td.pkValues(lastRow)
+		}
+
+		processedRows := dr.ProcessedRows
+		if err := td.diffRange(ctx, dbClient, dr, source, target, lower, upper, coreOpts, reportOpts); err != nil {
+			return nil, err
+		}
+		rowsToCompare -= dr.ProcessedRows - processedRows
+		if lastRow == nil {
+			return dr, nil
+		}
+		lower = upper
+		*lastProcessedRow = lastRow
+
+		// Update progress every 10,000 rows as we go along, like diff does.
+		if processedRows/1e4 != dr.ProcessedRows/1e4 {
+			if err := td.updateTableProgress(dbClient, dr, lastRow); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// rangeLastRow returns the last source row of the range that starts after
+// lower and has up to n rows on each source shard, or nil if the range goes
+// to the end of the table. The shards with fewer than n rows left don't bound
+// the range, as all their rows up to its end are part of it.
+func (td *tableDiffer) rangeLastRow(ctx context.Context, source *rangeQueries, lower []sqltypes.Value, n int64) ([]sqltypes.Value, error) {
+	query, err := source.boundQuery(lower, n)
+	if err != nil {
+		return nil, err
+	}
+	results, err := td.querySources(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var lastRow []sqltypes.Value
+	for _, result := range results {
+		if len(result.Rows) == 0 {
+			continue
+		}
+		if lastRow == nil {
+			lastRow = result.Rows[0]
+			continue
+		}
+		c, err := td.compare(result.Rows[0], lastRow, td.tablePlan.comparePKs, false)
+		if err != nil {
+			return nil, err
+		}
+		if c < 0 {
+			lastRow = result.Rows[0]
+		}
+	}
+	return lastRow, nil
+}
+
+// pkValues returns the values of the primary key columns of the row.
+func (td *tableDiffer) pkValues(row []sqltypes.Value) []sqltypes.Value {
+	values := make([]sqltypes.Value, 0, len(td.tablePlan.comparePKs))
+	for _, pk := range td.tablePlan.comparePKs {
+		values = append(values, row[pk.colIndex])
+	}
+	return values
+}
+
+// diffRange compares one range of primary keys and records the result in
+// the report.
+func (td *tableDiffer) diffRange(ctx context.Context, dbClient binlogplayer.DBClient, dr *DiffReport, source, target *rangeQueries,
+	lower, upper []sqltypes.Value, coreOpts *tabletmanagerdatapb.VDiffCoreOptions, reportOpts *tabletmanagerdatapb.VDiffReportOptions) error {
+	if !td.sampleRange(lower, coreOpts.GetSamplePct()) {
+		dr.SkippedRanges++
+		return nil
+	}
+
+	if coreOpts.GetChecksum() {
+		rows, match, err := td.compareRangeChecksums(ctx, dbClient, source, target, lower, upper)
+		if err != nil {
+			return err
+		}
+		if match {
+			dr.MatchingRanges++
+			dr.MatchingRows += rows
+			dr.ProcessedRows += rows
+			return nil
+		}
+		// The checksums can also differ for rows that only differ in the
+		// way they are encoded, so we narrow the range down to its rows.
+		dr.MismatchedRanges++
+	}
+
+	query, err := source.rowsQuery(lower, upper)
+	if err != nil {
+		return err
+	}
+	results, err := td.querySources(ctx, query)
+	if err != nil {
+		return err
+	}
+	sourceRows, err := td.mergeRows(results)
+	if err != nil {
+		return err
+	}
+	if query, err = target.rowsQuery(lower, upper); err != nil {
+		return err
+	}
+	result, err := td.queryTarget(ctx, dbClient, query)
+	if err != nil {
+		return err
+	}
+	return td.diffRangeRows(dr, sourceRows, result.Rows, coreOpts, reportOpts)
+}
+
+// compareRangeChecksums returns the number of source rows of the range, and
+// whether the target has the same number of rows with the same checksum.
+func (td *tableDiffer) compareRangeChecksums(ctx context.Context, dbClient binlogplayer.DBClient, source, target *rangeQueries,
+	lower, upper []sqltypes.Value) (int64, bool, error) {
+	checksum := func(results ...*sqltypes.Result) (rows int64, sum uint64, err error) {
+		for _, result := range results {
+			if len(result.Rows) != 1 || len(result.Rows[0]) != 2 {
+				return 0, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected checksum result: %+v", result)
+			}
+			n, err := result.Rows[0][0].ToInt64()
+			if err != nil {
+				return 0, 0, err
+			}
+			crc, err := result.Rows[0][1].ToUint64()
+			if err != nil {
+				return 0, 0, err
+			}
+			rows += n
+			sum ^= crc
+		}
+		return rows, sum, nil
+	}
+
+	query, err := source.checksumQuery(lower, upper)
+	if err != nil {
+		return 0, false, err
+	}
+	results, err := td.querySources(ctx, query)
+	if err != nil {
+		return 0, false, err
+	}
+	sourceRows, sourceSum, err := checksum(results...)
+	if err != nil {
+		return 0, false, err
+	}
+	if query, err = target.checksumQuery(lower, upper); err != nil {
+		return 0, false, err
+	}
+	result, err := td.queryTarget(ctx, dbClient, query)
+	if err != nil {
+		return 0, false, err
+	}
+	targetRows, targetSum, err := checksum(result)
+	if err != nil {
+		return 0, false, err
+	}
+	return sourceRows, sourceRows == targetRows && sourceSum == targetSum, nil
+}
+
+// querySources runs the query on all the source shards, each in a snapshot
+// of its own, and records the positions of the snapshots.
+func (td *tableDiffer) querySources(ctx context.Context, query string) ([]*sqltypes.Result, error) {
+	var mu sync.Mutex
+	var results []*sqltypes.Result
+	err := td.forEachSource(func(source *migrationSource) error {
+		result, gtid, err := td.streamResults(ctx, source.shardStreamer, query)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		source.snapshotPosition = gtid
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// streamResults runs the query on the tablet of the shard, and returns its
+// result with the position of its snapshot.
+func (td *tableDiffer) streamResults(ctx context.Context, participant *shardStreamer, query string) (*sqltypes.Result, string, error) {
+	conn, err := tabletconn.GetDialer()(ctx, participant.tablet, false)
+	if err != nil {
+		return nil, "", err
+	}
+	defer conn.Close(ctx)
+
+	target := &querypb.Target{
+		Keyspace:   participant.tablet.Keyspace,
+		Shard:      participant.shard,
+		TabletType: participant.tablet.Type,
+	}
+	result := &sqltypes.Result{}
+	var gtid string
+	err = conn.VStreamResults(ctx, target, query, func(vrsRaw *binlogdatapb.VStreamResultsResponse) error {
+		// We clone the response, as streamOneShard does, as it can be
+		// recycled once we return.
+		vrs := vrsRaw.CloneVT()
+		if len(vrs.Fields) != 0 {
+			result.Fields = vrs.Fields
+			gtid = vrs.Gtid
+		}
+		qr := sqltypes.Proto3ToResult(&querypb.QueryResult{Fields: result.Fields, Rows: vrs.Rows})
+		result.Rows = append(result.Rows, qr.Rows...)
+		return nil
+	})
+	if err != nil {
+		return nil, "", vterrors.Wrapf(err, "VStreamResults on tablet %v", participant.tablet.Alias)
+	}
+	return result, gtid, nil
+}
+
+// queryTarget runs the query on the target, once its streams reached the
+// positions of the last source snapshots.
+func (td *tableDiffer) queryTarget(ctx context.Context, dbClient binlogplayer.DBClient, query string) (*sqltypes.Result, error) {
+	if err := td.syncTargetStreams(ctx); err != nil {
+		return nil, err
+	}
+	return dbClient.ExecuteFetch(query, -1)
+}
+
+// mergeRows returns the rows of the source shards in the order of their
+// primary keys.
+func (td *tableDiffer) mergeRows(results []*sqltypes.Result) ([][]sqltypes.Value, error) {
+	var rows [][]sqltypes.Value
+	for _, result := range results {
+		rows = append(rows, result.Rows...)
+	}
+	if len(results) < 2 {
+		return rows, nil
+	}
+	var err error
+	slices.SortStableFunc(rows, func(a, b []sqltypes.Value) int {
+		c, cmpErr := td.compare(a, b, td.tablePlan.comparePKs, false)
+		if cmpErr != nil && err == nil {
+			err = cmpErr
+		}
+		return c
+	})
+	return rows, err
+}
+
+// diffRangeRows compares the rows of one range of primary keys, and records
+// the result in the report.
+func (td *tableDiffer) diffRangeRows(dr *DiffReport, sourceRows, targetRows [][]sqltypes.Value,
+	coreOpts *tabletmanagerdatapb.VDiffCoreOptions, reportOpts *tabletmanagerdatapb.VDiffReportOptions) error {
+	maxExtraRowsToCompare := coreOpts.GetMaxExtraRowsToCompare()
+	maxReportSampleRows := reportOpts.GetMaxSampleRows()
+	for len(sourceRows) > 0 || len(targetRows) > 0 {
+		var sourceRow, targetRow []sqltypes.Value
+		if len(sourceRows) > 0 {
+			sourceRow = sourceRows[0]
+		}
+		if len(targetRows) > 0 {
+			targetRow = targetRows[0]
+		}
+		advanceSource, advanceTarget, err := td.diffRows(dr, sourceRow, targetRow, reportOpts, maxExtraRowsToCompare, maxReportSampleRows)
+		if err != nil {
+			return err
+		}
+		if advanceSource {
+			sourceRows = sourceRows[1:]
+		}
+		if advanceTarget {
+			targetRows = targetRows[1:]
+		}
+	}
+	return nil
+}
+
+// sampleRange returns whether the range that starts after the given primary
+// key is part of the sample.
+func (td *tableDiffer) sampleRange(lower []sqltypes.Value, samplePct int64) bool {
+	if !samplingRanges(samplePct) {
+		return true
+	}
+	h := fnv.New64a()
+	for _, v := range lower {
+		hashValue(h, v)
+	}
+	return int64(h.Sum64()%100) < samplePct
+}
+
+// hashValue writes the value to the hash, with its length so that the
+// values of consecutive columns can't be mistaken for each other.
+func hashValue(h hash.Hash64, v sqltypes.Value) {
+	if v.IsNull() {
+		h.Write([]byte{0})
+		return
+	}
+	var buf [9]byte
+	buf[0] = 1
+	binary.BigEndian.PutUint64(buf[1:], uint64(v.Len()))
+	h.Write(buf[:])
+	h.Write(v.Raw())
+}
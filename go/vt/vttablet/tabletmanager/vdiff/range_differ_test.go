@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestDiffRangeRows(t *testing.T) {
+	wd := &workflowDiffer{
+		ct: &controller{
+			vde: &Engine{parser: sqlparser.NewTestParser()},
+		},
+		collationEnv: collations.MySQL8(),
+	}
+	query := "select c1, c2 from t1 order by c1 asc"
+	td := &tableDiffer{
+		wd:          wd,
+		sourceQuery: query,
+		tablePlan: &tablePlan{
+			sourceQuery: query,
+			targetQuery: query,
+			selectPks:   []int{0},
+			comparePKs:  []compareColInfo{{colIndex: 0, isPK: true, colName: "c1"}},
+			compareCols: []compareColInfo{
+				{colIndex: 0, isPK: true, colName: "c1"},
+				{colIndex: 1, colName: "c2"},
+			},
+		},
+	}
+
+	row := func(c1 int64, c2 string) []sqltypes.Value {
+		return []sqltypes.Value{sqltypes.NewInt64(c1), sqltypes.NewVarChar(c2)}
+	}
+	rows := [][]sqltypes.Value{row(1, "a"), row(2, "b"), row(3, "c")}
+
+	testCases := []struct {
+		name       string
+		coreOpts   *tabletmanagerdatapb.VDiffCoreOptions
+		targetRows [][]sqltypes.Value
+		want       DiffReport
+	}{
+		{
+			name:       "matching rows",
+			coreOpts:   &tabletmanagerdatapb.VDiffCoreOptions{},
+			targetRows: rows,
+			want:       DiffReport{ProcessedRows: 3, MatchingRows: 3},
+		},
+		{
+			name:       "mismatched row",
+			coreOpts:   &tabletmanagerdatapb.VDiffCoreOptions{},
+			targetRows: [][]sqltypes.Value{row(1, "a"), row(2, "x"), row(3, "c")},
+			want:       DiffReport{ProcessedRows: 3, MatchingRows: 2, MismatchedRows: 1},
+		},
+		{
+			name:       "extra rows",
+			coreOpts:   &tabletmanagerdatapb.VDiffCoreOptions{MaxExtraRowsToCompare: 10},
+			targetRows: [][]sqltypes.Value{row(1, "a"), row(3, "c"), row(4, "d")},
+			want:       DiffReport{ProcessedRows: 4, MatchingRows: 2, ExtraRowsSource: 1, ExtraRowsTarget: 1},
+		},
+		{
+			name:     "no target rows",
+			coreOpts: &tabletmanagerdatapb.VDiffCoreOptions{},
+			want:     DiffReport{ProcessedRows: 3, ExtraRowsSource: 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dr := &DiffReport{}
+			err := td.diffRangeRows(dr, rows, tc.targetRows, tc.coreOpts, &tabletmanagerdatapb.VDiffReportOptions{})
+			require.NoError(t, err)
+			dr.ExtraRowsSourceDiffs = nil
+			dr.ExtraRowsTargetDiffs = nil
+			dr.MismatchedRowsDiffs = nil
+			require.Equal(t, tc.want, *dr)
+		})
+	}
+}
+
+func TestRangeQueries(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	testCases := []struct {
+		name         string
+		query        string
+		comparePKs   []compareColInfo
+		lower, upper []sqltypes.Value
+		bound        string
+		rows         string
+		checksum     string
+	}{
+		{
+			name:       "first range",
+			query:      "select c1, c2 from t1 order by c1 asc",
+			comparePKs: []compareColInfo{{colIndex: 0, isPK: true, colName: "c1"}},
+			upper:      []sqltypes.Value{sqltypes.NewInt64(10)},
+			bound:      "select c1, c2 from t1 order by c1 asc limit 9, 1",
+			rows:       "select c1, c2 from t1 where c1 <= 10 order by c1 asc",
+			checksum:   "select count(*), bit_xor(crc32(concat_ws('#', c1, c2, concat(isnull(c1), isnull(c2))))) from t1 where c1 <= 10",
+		},
+		{
+			name:       "last range",
+			query:      "select c1, c2 from t1 where c2 != 'x' order by c1 asc",
+			comparePKs: []compareColInfo{{colIndex: 0, isPK: true, colName: "c1"}},
+			lower:      []sqltypes.Value{sqltypes.NewInt64(10)},
+			bound:      "select c1, c2 from t1 where c2 != 'x' and c1 > 10 order by c1 asc limit 9, 1",
+			rows:       "select c1, c2 from t1 where c2 != 'x' and c1 > 10 order by c1 asc",
+			checksum:   "select count(*), bit_xor(crc32(concat_ws('#', c1, c2, concat(isnull(c1), isnull(c2))))) from t1 where c2 != 'x' and c1 > 10",
+		},
+		{
+			name:  "composite primary key",
+			query: "select c0 as c1, c2, c3 from t2 order by c1 asc, c2 asc",
+			comparePKs: []compareColInfo{
+				{colIndex: 0, isPK: true, colName: "c1"},
+				{colIndex: 1, isPK: true, colName: "c2"},
+			},
+			lower:    []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")},
+			upper:    []sqltypes.Value{sqltypes.NewInt64(2), sqltypes.NewVarChar("b")},
+			bound:    "select c0 as c1, c2, c3 from t2 where (c0, c2) > (1, 'a') order by c1 asc, c2 asc limit 9, 1",
+			rows:     "select c0 as c1, c2, c3 from t2 where (c0, c2) > (1, 'a') and (c0, c2) <= (2, 'b') order by c1 asc, c2 asc",
+			checksum: "select count(*), bit_xor(crc32(concat_ws('#', c0, c2, c3, concat(isnull(c0), isnull(c2), isnull(c3))))) from t2 where (c0, c2) > (1, 'a') and (c0, c2) <= (2, 'b')",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rq, err := newRangeQueries(parser, tc.query, tc.comparePKs)
+			require.NoError(t, err)
+
+			bound, err := rq.boundQuery(tc.lower, 10)
+			require.NoError(t, err)
+			require.Equal(t, tc.bound, bound)
+			rows, err := rq.rowsQuery(tc.lower, tc.upper)
+			require.NoError(t, err)
+			require.Equal(t, tc.rows, rows)
+			checksum, err := rq.checksumQuery(tc.lower, tc.upper)
+			require.NoError(t, err)
+			require.Equal(t, tc.checksum, checksum)
+		})
+	}
+}
+
+func TestSampleRange(t *testing.T) {
+	td := &tableDiffer{
+		tablePlan: &tablePlan{
+			comparePKs: []compareColInfo{{colIndex: 0, isPK: true}},
+		},
+	}
+	for _, samplePct := range []int64{0, 100} {
+		require.True(t, td.sampleRange([]sqltypes.Value{sqltypes.NewInt64(1)}, samplePct))
+	}
+
+	sampled := 0
+	for i := range 10000 {
+		firstRow := []sqltypes.Value{sqltypes.NewInt64(int64(i))}
+		in := td.sampleRange(firstRow, 10)
+		// The same range is always part of the sample, or never.
+		require.Equal(t, in, td.sampleRange(firstRow, 10))
+		if in {
+			sampled++
+		}
+	}
+	require.InDelta(t, 1000, sampled, 150)
+}
+
+// rangeShard answers the bound queries of TestRangeLastRow with the rows of
+// a shard, which are the values of c1 in order.
+func rangeShard(t *testing.T, parser *sqlparser.Parser, rows []int64) func(query string) (*sqltypes.Result, error) {
+	fields := sqltypes.MakeTestFields("c1|c2", "int64|varchar")
+	return func(query string) (*sqltypes.Result, error) {
+		stmt, err := parser.Parse(query)
+		require.NoError(t, err)
+		sel := stmt.(*sqlparser.Select)
+		var lower int64
+		if sel.Where != nil {
+			cmp := sel.Where.Expr.(*sqlparser.ComparisonExpr)
+			require.Equal(t, sqlparser.GreaterThanOp, cmp.Operator)
+			lower, err = strconv.ParseInt(cmp.Right.(*sqlparser.Literal).Val, 10, 64)
+			require.NoError(t, err)
+		}
+		offset, err := strconv.Atoi(sel.Limit.Offset.(*sqlparser.Literal).Val)
+		require.NoError(t, err)
+
+		result := &sqltypes.Result{Fields: fields}
+		for _, c1 := range rows {
+			if c1 <= lower {
+				continue
+			}
+			if offset == 0 {
+				result.Rows = append(result.Rows, []sqltypes.Value{sqltypes.NewInt64(c1), sqltypes.NewVarChar("a")})
+				break
+			}
+			offset--
+		}
+		return result, nil
+	}
+}
+
+func TestRangeLastRow(t *testing.T) {
+	ctx := context.Background()
+	parser := sqlparser.NewTestParser()
+
+	// The first shard runs out of rows after the first range, while the
+	// second one still has ten ranges of rows.
+	shardRows := map[string][]int64{"-80": {1, 3, 5}}
+	for c1 := int64(2); c1 <= 200; c1 += 2 {
+		shardRows["80-"] = append(shardRows["80-"], c1)
+	}
+	tablets := make(map[int]*fakeTabletConn)
+	sources := make(map[string]*migrationSource)
+	for i, shard := range []string{"-80", "80-"} {
+		tablet := &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: uint32(200 + i)},
+			Keyspace: vdiffDBName,
+			Shard:    shard,
+			Type:     topodatapb.TabletType_PRIMARY,
+		}
+		tablets[200+i] = &fakeTabletConn{tablet: tablet, results: rangeShard(t, parser, shardRows[shard])}
+		sources[shard] = &migrationSource{shardStreamer: &shardStreamer{tablet: tablet, shard: shard}}
+	}
+	oldEnv := vdiffenv
+	vdiffenv = &testVDiffEnv{tablets: tablets}
+	defer func() { vdiffenv = oldEnv }()
+
+	query := "select c1, c2 from t1 order by c1 asc"
+	td := &tableDiffer{
+		wd: &workflowDiffer{
+			ct: &controller{
+				vde:     &Engine{parser: parser},
+				sources: sources,
+			},
+			collationEnv: collations.MySQL8(),
+		},
+		tablePlan: &tablePlan{
+			sourceQuery: query,
+			comparePKs:  []compareColInfo{{colIndex: 0, isPK: true, colName: "c1"}},
+		},
+	}
+	source, err := newRangeQueries(parser, query, td.tablePlan.comparePKs)
+	require.NoError(t, err)
+
+	var upperBounds []int64
+	var lower []sqltypes.Value
+	for {
+		lastRow, err := td.rangeLastRow(ctx, source, lower, 10)
+		require.NoError(t, err)
+		if lastRow == nil {
+			break
+		}
+		upper, err := lastRow[0].ToInt64()
+		require.NoError(t, err)
+		upperBounds = append(upperBounds, upper)
+		lower = td.pkValues(lastRow)
+	}
+	// Each range has up to ten rows of the second shard, and only the range
+	// after its last row goes to the end of the table.
+	require.Equal(t, []int64{20, 40, 60, 80, 100, 120, 140, 160, 180, 200}, upperBounds)
+	for _, source := range sources {
+		require.Equal(t, vdiffSourceGtid, source.snapshotPosition)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"slices"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+/*
+	With the repair option, the table differ records a statement for each difference it finds,
+	which makes the target row match the source row:
+		* A row that is missing in the target is inserted, or updated if it was inserted since.
+		* A row with mismatched columns is updated.
+		* An extra row in the target is deleted.
+	The statements are stored in the _vt.vdiff_repair table, where the repair action returns
+	them for review and the apply action runs them on the target while the workflow is stopped.
+	Running a statement more than once has the same effect as running it once, so the rows
+	that are diffed again when a vdiff resumes can safely record theirs again.
+*/
+
+// repair records the statement that repairs a difference, when the repair
+// option is used. A nil row is missing on that side.
+func (td *tableDiffer) repair(dr *DiffReport, sourceRow, targetRow []sqltypes.Value) error {
+	if td.repairer == nil {
+		return nil
+	}
+	if err := td.repairer.record(sourceRow, targetRow); err != nil {
+		return err
+	}
+	dr.RepairStatements++
+	return nil
+}
+
+// tableRepairer records the statements that repair the differences of one table.
+type tableRepairer struct {
+	dbClient binlogplayer.DBClient
+	vdiffID  int64
+	table    string
+
+	// columns are the target columns of the diffed rows, in order.
+	columns []sqlparser.IdentifierCI
+	// pks are the indexes of the primary key columns in the rows.
+	pks []int
+	// convertTZ is set for the datetime columns that the workflow converts
+	// from the source time zone to the target one.
+	convertTZ                      []bool
+	sourceTimeZone, targetTimeZone string
+}
+
+func (td *tableDiffer) newTableRepairer(dbClient binlogplayer.DBClient) (*tableRepairer, error) {
+	stmt, err := td.wd.ct.vde.parser.Parse(td.tablePlan.targetQuery)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected target query: %s", td.tablePlan.targetQuery)
+	}
+	tr := &tableRepairer{
+		dbClient:       dbClient,
+		vdiffID:        td.wd.ct.id,
+		table:          td.table.Name,
+		pks:            td.tablePlan.selectPks,
+		sourceTimeZone: td.wd.ct.sourceTimeZone,
+		targetTimeZone: td.wd.ct.targetTimeZone,
+	}
+	for _, expr := range sel.GetColumns() {
+		colName, err := getColumnNameForSelectExpr(expr)
+		if err != nil {
+			return nil, err
+		}
+		_, isFunc := expr.(*sqlparser.AliasedExpr).Expr.(*sqlparser.FuncExpr)
+		tr.columns = append(tr.columns, sqlparser.NewIdentifierCI(colName))
+		tr.convertTZ = append(tr.convertTZ, isFunc)
+	}
+	return tr, nil
+}
+
+// record saves the statement that makes the target row match the source row.
+// A nil row is missing on that side.
+func (tr *tableRepairer) record(sourceRow, targetRow []sqltypes.Value) error {
+	query, err := sqlparser.ParseAndBind(sqlNewVDiffRepair,
+		sqltypes.Int64BindVariable(tr.vdiffID),
+		sqltypes.StringBindVariable(tr.table),
+		sqltypes.StringBindVariable(tr.statement(sourceRow, targetRow)),
+	)
+	if err != nil {
+		return err
+	}
+	if _, err := tr.dbClient.ExecuteFetch(query, 1); err != nil {
+		return vterrors.Wrapf(err, "failed to record the repair statement for table %s", tr.table)
+	}
+	return nil
+}
+
+// statement returns the statement that makes the target row match the
+// source row. A nil row is missing on that side.
+func (tr *tableRepairer) statement(sourceRow, targetRow []sqltypes.Value) string {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	table := sqlparser.NewIdentifierCS(tr.table)
+	switch {
+	case sourceRow == nil:
+		buf.Myprintf("delete from %v where ", table)
+		tr.formatPKs(buf, targetRow)
+	case targetRow == nil:
+		if len(tr.pks) == len(tr.columns) {
+			buf.Myprintf("insert ignore into %v(", table)
+		} else {
+			buf.Myprintf("insert into %v(", table)
+		}
+		for i, col := range tr.columns {
+			if i > 0 {
+				buf.Myprintf(", ")
+			}
+			buf.Myprintf("%v", col)
+		}
+		buf.Myprintf(") values (")
+		for i := range tr.columns {
+			if i > 0 {
+				buf.Myprintf(", ")
+			}
+			tr.formatValue(buf, i, sourceRow[i])
+		}
+		buf.Myprintf(")")
+		if len(tr.pks) < len(tr.columns) {
+			buf.Myprintf(" on duplicate key update ")
+			tr.formatNonPKs(buf, sourceRow)
+		}
+	default:
+		buf.Myprintf("update %v set ", table)
+		tr.formatNonPKs(buf, sourceRow)
+		buf.Myprintf(" where ")
+		tr.formatPKs(buf, targetRow)
+	}
+	return buf.String()
+}
+
+// formatNonPKs writes the assignments of the non primary key columns.
+func (tr *tableRepairer) formatNonPKs(buf *sqlparser.TrackedBuffer, row []sqltypes.Value) {
+	first := true
+	for i, col := range tr.columns {
+		if slices.Contains(tr.pks, i) {
+			continue
+		}
+		if !first {
+			buf.Myprintf(", ")
+		}
+		first = false
+		buf.Myprintf("%v = ", col)
+		tr.formatValue(buf, i, row[i])
+	}
+}
+
+// formatPKs writes the condition that matches the primary key of the row.
+func (tr *tableRepairer) formatPKs(buf *sqlparser.TrackedBuffer, row []sqltypes.Value) {
+	for i, pk := range tr.pks {
+		if i > 0 {
+			buf.Myprintf(" and ")
+		}
+		buf.Myprintf("%v", tr.columns[pk])
+		if row[pk].IsNull() {
+			buf.Myprintf(" is null")
+			continue
+		}
+		buf.Myprintf(" = ")
+		tr.formatValue(buf, pk, row[pk])
+	}
+}
+
+// formatValue writes the value of a diffed row as it is stored in the target.
+// The diff compares datetime columns in the source time zone, so they are
+// converted back to the target one.
+func (tr *tableRepairer) formatValue(buf *sqlparser.TrackedBuffer, col int, v sqltypes.Value) {
+	if !tr.convertTZ[col] || v.IsNull() {
+		v.EncodeSQL(buf)
+		return
+	}
+	buf.Myprintf("convert_tz(")
+	v.EncodeSQL(buf)
+	buf.Myprintf(", %s, %s)", encodeString(tr.sourceTimeZone), encodeString(tr.targetTimeZone))
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func TestRepairStatement(t *testing.T) {
+	newRepairer := func(t *testing.T, targetQuery string, pks []int) *tableRepairer {
+		td := &tableDiffer{
+			wd: &workflowDiffer{
+				ct: &controller{
+					vde:            &Engine{parser: sqlparser.NewTestParser()},
+					sourceTimeZone: "US/Pacific",
+					targetTimeZone: "UTC",
+				},
+				collationEnv: collations.MySQL8(),
+			},
+			table:     &tabletmanagerdatapb.TableDefinition{Name: "t1"},
+			tablePlan: &tablePlan{targetQuery: targetQuery, selectPks: pks},
+		}
+		tr, err := td.newTableRepairer(nil)
+		require.NoError(t, err)
+		return tr
+	}
+	row := func(vals ...sqltypes.Value) []sqltypes.Value {
+		return vals
+	}
+
+	tr := newRepairer(t, "select c1, c2, `order` from t1 order by c1 asc", []int{0})
+	source := row(sqltypes.NewInt64(1), sqltypes.NewVarChar("it's"), sqltypes.NULL)
+	target := row(sqltypes.NewInt64(1), sqltypes.NewVarChar("x"), sqltypes.NewInt64(2))
+
+	testCases := []struct {
+		name           string
+		tr             *tableRepairer
+		source, target []sqltypes.Value
+		want           string
+	}{
+		{
+			name:   "missing row",
+			tr:     tr,
+			source: source,
+			want:   "insert into t1(c1, c2, `order`) values (1, 'it\\'s', null) on duplicate key update c2 = 'it\\'s', `order` = null",
+		},
+		{
+			name:   "mismatched row",
+			tr:     tr,
+			source: source,
+			target: target,
+			want:   "update t1 set c2 = 'it\\'s', `order` = null where c1 = 1",
+		},
+		{
+			name:   "extra row",
+			tr:     tr,
+			target: target,
+			want:   "delete from t1 where c1 = 1",
+		},
+		{
+			name:   "only primary key columns",
+			tr:     newRepairer(t, "select c1, c2 from t1 order by c1 asc, c2 asc", []int{0, 1}),
+			source: row(sqltypes.NewInt64(1), sqltypes.NewVarChar("a")),
+			want:   "insert ignore into t1(c1, c2) values (1, 'a')",
+		},
+		{
+			name:   "null primary key column",
+			tr:     newRepairer(t, "select c1, c2 from t1 order by c1 asc, c2 asc", []int{0, 1}),
+			target: row(sqltypes.NewInt64(1), sqltypes.NULL),
+			want:   "delete from t1 where c1 = 1 and c2 is null",
+		},
+		{
+			name:   "converted time zone",
+			tr:     newRepairer(t, "select c1, convert_tz(dt, 'UTC', 'US/Pacific') as dt from t1 order by c1 asc", []int{0}),
+			source: row(sqltypes.NewInt64(1), sqltypes.NewDatetime("2025-01-01 00:00:00")),
+			target: row(sqltypes.NewInt64(1), sqltypes.NewDatetime("2025-01-02 00:00:00")),
+			want:   "update t1 set dt = convert_tz('2025-01-01 00:00:00', 'US/Pacific', 'UTC') where c1 = 1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.tr.statement(tc.source, tc.target))
+		})
+	}
+}
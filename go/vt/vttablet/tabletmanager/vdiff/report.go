@@ -40,6 +40,14 @@ type DiffReport struct {
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
 
+	// counts of the primary key ranges compared by checksum and sampled diffs
+	MatchingRanges   int64 `json:"MatchingRanges,omitempty"`
+	MismatchedRanges int64 `json:"MismatchedRanges,omitempty"`
+	SkippedRanges    int64 `json:"SkippedRanges,omitempty"`
+	// RepairStatements is the number of statements recorded to fix the
+	// differences, with the repair option.
+	RepairStatements int64 `json:"RepairStatements,omitempty"`
+
 	// actual data for a few sample rows
 	ExtraRowsSourceDiffs []*RowDiff      `json:"ExtraRowsSourceSample,omitempty"`
 	ExtraRowsTargetDiffs []*RowDiff      `json:"ExtraRowsTargetSample,omitempty"`
@@ -30,10 +30,12 @@ const (
 	sqlGetVDiffByKeyspaceWorkflowUUID       = "select * from _vt.vdiff where keyspace = %a and workflow = %a and vdiff_uuid = %a"
 	sqlGetMostRecentVDiffByKeyspaceWorkflow = "select * from _vt.vdiff where keyspace = %a and workflow = %a order by id desc limit %a"
 	sqlGetVDiffByID                         = "select * from _vt.vdiff where id = %a"
-	sqlDeleteVDiffs                         = `delete from vd, vdt, vdl using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
+	sqlDeleteVDiffs                         = `delete from vd, vdt, vdl, vdr using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
 										left join _vt.vdiff_log as vdl on (vd.id = vdl.vdiff_id)
+										left join _vt.vdiff_repair as vdr on (vd.id = vdr.vdiff_id)
 										where vd.keyspace = %a and vd.workflow = %a`
-	sqlDeleteVDiffByUUID = `delete from vd, vdt using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
+	sqlDeleteVDiffByUUID = `delete from vd, vdt, vdr using _vt.vdiff as vd left join _vt.vdiff_table as vdt on (vd.id = vdt.vdiff_id)
+							left join _vt.vdiff_repair as vdr on (vd.id = vdr.vdiff_id)
 							where vd.vdiff_uuid = %a`
 	sqlVDiffSummary = `select vd.state as vdiff_state, vd.last_error as last_error, vdt.table_name as table_name,
 						vd.vdiff_uuid as 'uuid', vdt.state as table_state, vdt.table_rows as table_rows,
@@ -66,4 +68,11 @@ const (
 	sqlUpdateTableMismatch       = "update _vt.vdiff_table set mismatch = true where vdiff_id = %a and table_name = %a"
 
 	sqlGetIncompleteTables = "select table_name as table_name from _vt.vdiff_table where vdiff_id = %a and state != 'completed' order by table_name"
+
+	sqlNewVDiffRepair         = "insert into _vt.vdiff_repair(vdiff_id, table_name, statement) values (%a, %a, %a)"
+	sqlDeleteUnappliedRepairs = "delete from _vt.vdiff_repair where vdiff_id = %a and table_name = %a and applied_at is null"
+	sqlGetVDiffRepairs        = "select id as id, table_name as table_name, statement as statement, applied_at as applied_at from _vt.vdiff_repair where vdiff_id = %a order by id"
+	sqlGetUnappliedRepairs    = "select id as id, statement as statement from _vt.vdiff_repair where vdiff_id = %a and applied_at is null order by id"
+	sqlMarkRepairApplied      = "update _vt.vdiff_repair set applied_at = now() where id = %a"
+	sqlGetVReplicationStates  = "select distinct state as state from _vt.vreplication where workflow = %a and db_name = %a"
 )
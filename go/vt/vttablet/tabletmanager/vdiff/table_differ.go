@@ -94,6 +94,10 @@ type tableDiffer struct {
 	lastSourcePK *querypb.QueryResult
 	lastTargetPK *querypb.QueryResult
 
+	// repairer records the statements that fix the differences, when the
+	// repair option is used.
+	repairer *tableRepairer
+
 	// wgShardStreamers is used, with a cancellable context, to wait for all shard streamers
 	// to finish after each diff is complete.
 	wgShardStreamers   sync.WaitGroup
@@ -108,6 +112,11 @@ func newTableDiffer(wd *workflowDiffer, table *tabletmanagerdatapb.TableDefiniti
 // initialize
 func (td *tableDiffer) initialize(ctx context.Context) error {
 	defer td.wd.ct.TableDiffPhaseTimings.Record(fmt.Sprintf("%s.%s", td.table.Name, initializing), time.Now())
+	// Checksum and sampled diffs synchronize the source and the target for
+	// each of their queries instead.
+	if td.diffsRanges(td.wd.opts.CoreOptions) {
+		return td.selectTablets(ctx)
+	}
 	vdiffEngine := td.wd.ct.vde
 	vdiffEngine.snapshotMu.Lock()
 	defer vdiffEngine.snapshotMu.Unlock()
@@ -523,8 +532,26 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 	}
 	dr.TableName = td.table.Name
 
-	sourceExecutor := newPrimitiveExecutor(ctx, td.sourcePrimitive, "source")
-	targetExecutor := newPrimitiveExecutor(ctx, td.targetPrimitive, "target")
+	if coreOpts.GetRepair() {
+		if len(curState.AsBytes("lastpk", nil)) == 0 {
+			// We are starting from scratch, so the rows that were diffed
+			// before will record their statements again.
+			query, err := sqlparser.ParseAndBind(sqlDeleteUnappliedRepairs,
+				sqltypes.Int64BindVariable(td.wd.ct.id),
+				sqltypes.StringBindVariable(td.table.Name),
+			)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := dbClient.ExecuteFetch(query, -1); err != nil {
+				return nil, err
+			}
+		}
+		if td.repairer, err = td.newTableRepairer(dbClient); err != nil {
+			return nil, err
+		}
+	}
+
 	var sourceRow, lastProcessedRow, targetRow []sqltypes.Value
 	advanceSource := true
 	advanceTarget := true
@@ -537,6 +564,14 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 		globalStats.RowsDiffedCount.Add(dr.ProcessedRows)
 	}()
 
+	// Checksum and sampled diffs compare the rows in ranges of primary keys
+	// instead of streaming them.
+	if td.diffsRanges(coreOpts) {
+		return td.diffRanges(ctx, dbClient, dr, mismatch, coreOpts, reportOpts, stop, &lastProcessedRow)
+	}
+
+	sourceExecutor := newPrimitiveExecutor(ctx, td.sourcePrimitive, "source")
+	targetExecutor := newPrimitiveExecutor(ctx, td.targetPrimitive, "target")
 	rowsToCompare := coreOpts.GetMaxRows()
 	maxExtraRowsToCompare := coreOpts.GetMaxExtraRowsToCompare()
 	maxReportSampleRows := reportOpts.GetMaxSampleRows()
@@ -587,9 +622,9 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 			return dr, nil
 		}
 
-		advanceSource = true
-		advanceTarget = true
-		if sourceRow == nil {
+		// When repairing, every extra row needs its own statement so we
+		// compare them one by one instead of draining them.
+		if sourceRow == nil && td.repairer == nil {
 			diffRow, err := td.genRowDiff(td.tablePlan.sourceQuery, targetRow, reportOpts)
 			if err != nil {
 				return nil, vterrors.Wrap(err, "unexpected error generating diff")
@@ -605,7 +640,7 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 			dr.ProcessedRows += 1 + count
 			return dr, nil
 		}
-		if targetRow == nil {
+		if targetRow == nil && td.repairer == nil {
 			// No more rows from the target but we know we have more rows from
 			// source, so drain them and update the counts.
 			diffRow, err := td.genRowDiff(td.tablePlan.sourceQuery, sourceRow, reportOpts)
@@ -622,59 +657,12 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 			return dr, nil
 		}
 
-		dr.ProcessedRows++
-
-		// Compare pk values.
-		c, err := td.compare(sourceRow, targetRow, td.tablePlan.comparePKs, false)
-		switch {
-		case err != nil:
+		advanceSource, advanceTarget, err = td.diffRows(dr, sourceRow, targetRow, reportOpts, maxExtraRowsToCompare, maxReportSampleRows)
+		if err != nil {
 			return nil, err
-		case c < 0:
-			if dr.ExtraRowsSource < maxExtraRowsToCompare {
-				diffRow, err := td.genRowDiff(td.tablePlan.sourceQuery, sourceRow, reportOpts)
-				if err != nil {
-					return nil, vterrors.Wrap(err, "unexpected error generating diff")
-				}
-				dr.ExtraRowsSourceDiffs = append(dr.ExtraRowsSourceDiffs, diffRow)
-			}
-			dr.ExtraRowsSource++
-			advanceTarget = false
-			continue
-		case c > 0:
-			if dr.ExtraRowsTarget < maxExtraRowsToCompare {
-				diffRow, err := td.genRowDiff(td.tablePlan.targetQuery, targetRow, reportOpts)
-				if err != nil {
-					return nil, vterrors.Wrap(err, "unexpected error generating diff")
-				}
-				dr.ExtraRowsTargetDiffs = append(dr.ExtraRowsTargetDiffs, diffRow)
-			}
-			dr.ExtraRowsTarget++
-			advanceSource = false
-			continue
 		}
-
-		// c == 0
-		// Compare the non-pk values.
-		c, err = td.compare(sourceRow, targetRow, td.tablePlan.compareCols, true)
-		switch {
-		case err != nil:
-			return nil, err
-		case c != 0:
-			// We don't do a second pass to compare mismatched rows so we can cap the slice here.
-			if maxReportSampleRows == 0 || dr.MismatchedRows < maxReportSampleRows {
-				sourceDiffRow, err := td.genRowDiff(td.tablePlan.targetQuery, sourceRow, reportOpts)
-				if err != nil {
-					return nil, vterrors.Wrap(err, "unexpected error generating diff")
-				}
-				targetDiffRow, err := td.genRowDiff(td.tablePlan.targetQuery, targetRow, reportOpts)
-				if err != nil {
-					return nil, vterrors.Wrap(err, "unexpected error generating diff")
-				}
-				dr.MismatchedRowsDiffs = append(dr.MismatchedRowsDiffs, &DiffMismatch{Source: sourceDiffRow, Target: targetDiffRow})
-			}
-			dr.MismatchedRows++
-		default:
-			dr.MatchingRows++
+		if !advanceSource || !advanceTarget {
+			continue
 		}
 
 		// Update progress every 10,000 rows as we go along. This will allow us to provide
@@ -688,6 +676,83 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 	}
 }
 
+// diffRows compares a source row with a target row and records the result in
+// the report. A nil row is missing, so the other one is an extra row. It returns
+// which of the two rows were consumed by the comparison.
+func (td *tableDiffer) diffRows(dr *DiffReport, sourceRow, targetRow []sqltypes.Value, reportOpts *tabletmanagerdatapb.VDiffReportOptions,
+	maxExtraRowsToCompare, maxReportSampleRows int64) (advanceSource, advanceTarget bool, err error) {
+	dr.ProcessedRows++
+
+	// Compare pk values.
+	var c int
+	switch {
+	case targetRow == nil:
+		c = -1
+	case sourceRow == nil:
+		c = 1
+	default:
+		if c, err = td.compare(sourceRow, targetRow, td.tablePlan.comparePKs, false); err != nil {
+			return false, false, err
+		}
+	}
+	switch {
+	case c < 0:
+		if dr.ExtraRowsSource < maxExtraRowsToCompare {
+			diffRow, err := td.genRowDiff(td.tablePlan.sourceQuery, sourceRow, reportOpts)
+			if err != nil {
+				return false, false, vterrors.Wrap(err, "unexpected error generating diff")
+			}
+			dr.ExtraRowsSourceDiffs = append(dr.ExtraRowsSourceDiffs, diffRow)
+		}
+		dr.ExtraRowsSource++
+		if err := td.repair(dr, sourceRow, nil); err != nil {
+			return false, false, err
+		}
+		return true, false, nil
+	case c > 0:
+		if dr.ExtraRowsTarget < maxExtraRowsToCompare {
+			diffRow, err := td.genRowDiff(td.tablePlan.targetQuery, targetRow, reportOpts)
+			if err != nil {
+				return false, false, vterrors.Wrap(err, "unexpected error generating diff")
+			}
+			dr.ExtraRowsTargetDiffs = append(dr.ExtraRowsTargetDiffs, diffRow)
+		}
+		dr.ExtraRowsTarget++
+		if err := td.repair(dr, nil, targetRow); err != nil {
+			return false, false, err
+		}
+		return false, true, nil
+	}
+
+	// c == 0
+	// Compare the non-pk values.
+	c, err = td.compare(sourceRow, targetRow, td.tablePlan.compareCols, true)
+	switch {
+	case err != nil:
+		return false, false, err
+	case c != 0:
+		// We don't do a second pass to compare mismatched rows so we can cap the slice here.
+		if maxReportSampleRows == 0 || dr.MismatchedRows < maxReportSampleRows {
+			sourceDiffRow, err := td.genRowDiff(td.tablePlan.targetQuery, sourceRow, reportOpts)
+			if err != nil {
+				return false, false, vterrors.Wrap(err, "unexpected error generating diff")
+			}
+			targetDiffRow, err := td.genRowDiff(td.tablePlan.targetQuery, targetRow, reportOpts)
+			if err != nil {
+				return false, false, vterrors.Wrap(err, "unexpected error generating diff")
+			}
+			dr.MismatchedRowsDiffs = append(dr.MismatchedRowsDiffs, &DiffMismatch{Source: sourceDiffRow, Target: targetDiffRow})
+		}
+		dr.MismatchedRows++
+		if err := td.repair(dr, sourceRow, targetRow); err != nil {
+			return false, false, err
+		}
+	default:
+		dr.MatchingRows++
+	}
+	return true, true, nil
+}
+
 func (td *tableDiffer) compare(sourceRow, targetRow []sqltypes.Value, cols []compareColInfo, compareOnlyNonPKs bool) (int, error) {
 	for _, col := range cols {
 		if col.isPK && compareOnlyNonPKs {
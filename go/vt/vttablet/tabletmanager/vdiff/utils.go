@@ -96,3 +96,17 @@ func copyNonKeyRangeExpressions(where *sqlparser.Where) *sqlparser.Where {
 	}
 	return newWhere
 }
+
+// hasKeyRangeExpressions returns whether the WHERE clause has in_keyrange()
+// expressions.
+func hasKeyRangeExpressions(where *sqlparser.Where) bool {
+	if where == nil {
+		return false
+	}
+	for _, expr := range sqlparser.SplitAndExpression(nil, where.Expr) {
+		if expr, ok := expr.(*sqlparser.FuncExpr); ok && expr.Name.EqualString("in_keyrange") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The built-in column transformations of binlogdatapb.ColumnTransform.
+const (
+	columnTransformSHA256   = "sha256"
+	columnTransformRedact   = "redact"
+	columnTransformConstant = "constant"
+	columnTransformTruncate = "truncate"
+)
+
+// columnTransformBindVar is the name of the bind variable that holds the
+// original value of the column in the transformation expression.
+const columnTransformBindVar = "val"
+
+// columnTransform is a column transformation compiled into an evalengine
+// expression.
+type columnTransform struct {
+	column    string
+	expr      evalengine.Expr
+	env       *vtenv.Environment
+	collation collations.ID
+}
+
+// compileColumnTransforms compiles the transformations of a rule, keyed by
+// the name of the source column they apply to.
+func compileColumnTransforms(transforms map[string]*binlogdatapb.ColumnTransform, env *vtenv.Environment) (map[string]*columnTransform, error) {
+	if len(transforms) == 0 {
+		return nil, nil
+	}
+	compiled := make(map[string]*columnTransform, len(transforms))
+	for column, transform := range transforms {
+		ct, err := compileColumnTransform(column, transform, env)
+		if err != nil {
+			return nil, err
+		}
+		compiled[column] = ct
+	}
+	return compiled, nil
+}
+
+func compileColumnTransform(column string, transform *binlogdatapb.ColumnTransform, env *vtenv.Environment) (*columnTransform, error) {
+	val := sqlparser.NewArgument(columnTransformBindVar)
+	var expr sqlparser.Expr
+	switch transform.GetFunction() {
+	case columnTransformSHA256:
+		expr = &sqlparser.FuncExpr{
+			Name:  sqlparser.NewIdentifierCI("sha2"),
+			Exprs: []sqlparser.Expr{val, sqlparser.NewIntLiteral("256")},
+		}
+	case columnTransformRedact:
+		expr = &sqlparser.NullVal{}
+	case columnTransformConstant:
+		expr = sqlparser.NewStrLiteral(transform.Value)
+	case columnTransformTruncate:
+		if transform.Length <= 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid length %d for the truncate transformation of column %s", transform.Length, column)
+		}
+		expr = &sqlparser.FuncExpr{
+			Name:  sqlparser.NewIdentifierCI("left"),
+			Exprs: []sqlparser.Expr{val, sqlparser.NewIntLiteral(fmt.Sprintf("%d", transform.Length))},
+		}
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported transformation %q for column %s", transform.GetFunction(), column)
+	}
+	collation := env.CollationEnv().DefaultConnectionCharset()
+	compiled, err := evalengine.Translate(expr, &evalengine.Config{
+		Collation:   collation,
+		Environment: env,
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to compile the transformation of column %s", column)
+	}
+	return &columnTransform{
+		column:    column,
+		expr:      compiled,
+		env:       env,
+		collation: collation,
+	}, nil
+}
+
+// apply returns the transformed value.
+func (ct *columnTransform) apply(val sqltypes.Value) (sqltypes.Value, error) {
+	bindVars := map[string]*querypb.BindVariable{
+		columnTransformBindVar: sqltypes.ValueBindVariable(val),
+	}
+	exprEnv := evalengine.NewExpressionEnv(context.Background(), bindVars, evalengine.NewEmptyVCursor(ct.env, time.Local))
+	res, err := exprEnv.Evaluate(ct.expr)
+	if err != nil {
+		return sqltypes.Value{}, vterrors.Wrapf(err, "failed to transform the value of column %s", ct.column)
+	}
+	return res.Value(ct.collation), nil
+}
+
+// validateColumnTransforms verifies that the transformed columns can be
+// transformed: the values of primary key columns are compared with the
+// last copied primary key and JSON values are sent in their own encoding.
+func (tp *TablePlan) validateColumnTransforms() error {
+	if len(tp.ColumnTransforms) == 0 {
+		return nil
+	}
+	for _, pk := range tp.PKReferences {
+		if _, ok := tp.ColumnTransforms[pk]; ok {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "primary key column %s of table %s cannot be transformed", pk, tp.TargetName)
+		}
+	}
+	for _, field := range tp.Fields {
+		if _, ok := tp.ColumnTransforms[field.Name]; ok && field.Type == querypb.Type_JSON {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "JSON column %s of table %s cannot be transformed", field.Name, tp.TargetName)
+		}
+	}
+	return nil
+}
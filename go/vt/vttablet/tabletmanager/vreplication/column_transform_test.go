@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/bytes2"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vttablet "vitess.io/vitess/go/vt/vttablet/common"
+)
+
+func TestColumnTransforms(t *testing.T) {
+	env := vtenv.NewTestEnv()
+	testCases := []struct {
+		name      string
+		transform *binlogdatapb.ColumnTransform
+		in        sqltypes.Value
+		want      sqltypes.Value
+		wantErr   string
+	}{{
+		name:      "sha256",
+		transform: &binlogdatapb.ColumnTransform{Function: "sha256"},
+		in:        sqltypes.NewVarChar("abc"),
+		want:      sqltypes.NewVarChar("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"),
+	}, {
+		name:      "sha256 of null",
+		transform: &binlogdatapb.ColumnTransform{Function: "sha256"},
+		in:        sqltypes.NULL,
+		want:      sqltypes.NULL,
+	}, {
+		name:      "redact",
+		transform: &binlogdatapb.ColumnTransform{Function: "redact"},
+		in:        sqltypes.NewVarChar("secret"),
+		want:      sqltypes.NULL,
+	}, {
+		name:      "constant",
+		transform: &binlogdatapb.ColumnTransform{Function: "constant", Value: "xxx"},
+		in:        sqltypes.NewVarChar("secret"),
+		want:      sqltypes.NewVarChar("xxx"),
+	}, {
+		name:      "truncate",
+		transform: &binlogdatapb.ColumnTransform{Function: "truncate", Length: 3},
+		in:        sqltypes.NewVarChar("secret"),
+		want:      sqltypes.NewVarChar("sec"),
+	}, {
+		name:      "truncate without length",
+		transform: &binlogdatapb.ColumnTransform{Function: "truncate"},
+		wantErr:   "invalid length 0 for the truncate transformation of column c1",
+	}, {
+		name:      "unknown function",
+		transform: &binlogdatapb.ColumnTransform{Function: "md5"},
+		wantErr:   `unsupported transformation "md5" for column c1`,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ct, err := compileColumnTransform("c1", tc.transform, env)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got, err := ct.apply(tc.in)
+			require.NoError(t, err)
+			require.Equal(t, tc.want.String(), got.String())
+		})
+	}
+}
+
+func TestColumnTransformsApply(t *testing.T) {
+	transforms, err := compileColumnTransforms(map[string]*binlogdatapb.ColumnTransform{
+		"c2": {Function: "truncate", Length: 2},
+		"c3": {Function: "redact"},
+	}, vtenv.NewTestEnv())
+	require.NoError(t, err)
+	tp := &TablePlan{
+		BulkInsertValues: sqlparser.BuildParsedQuery("values (%a, %a, %a)",
+			":c1", ":c2", ":c3",
+		),
+		Fields: []*querypb.Field{
+			{Name: "c1", Type: querypb.Type_INT64},
+			{Name: "c2", Type: querypb.Type_VARCHAR},
+			{Name: "c3", Type: querypb.Type_VARCHAR},
+		},
+		ColumnTransforms: transforms,
+	}
+	row := sqltypes.RowToProto3([]sqltypes.Value{
+		sqltypes.NewInt64(1),
+		sqltypes.NewVarChar("abc"),
+		sqltypes.NewVarChar("def"),
+	})
+
+	// vcopier
+	bb := &bytes2.Buffer{}
+	require.NoError(t, tp.appendFromRow(bb, row))
+	require.Equal(t, "values (1, 'ab', null)", bb.String())
+
+	// vplayer
+	bv, err := tp.bindFieldVal(tp.Fields[1], ptrValue(sqltypes.NewVarChar("abc")))
+	require.NoError(t, err)
+	require.Equal(t, sqltypes.StringBindVariable("ab"), bv)
+	bv, err = tp.bindFieldVal(tp.Fields[0], ptrValue(sqltypes.NewInt64(1)))
+	require.NoError(t, err)
+	require.Equal(t, sqltypes.Int64BindVariable(1), bv)
+}
+
+func TestBuildPlayerPlanColumnTransforms(t *testing.T) {
+	colInfos := map[string][]*ColumnInfo{
+		"t1": {{Name: "c1", IsPK: true}, {Name: "c2"}},
+	}
+	vttablet.InitVReplicationConfigDefaults()
+	vr := &vreplicator{
+		workflowConfig: vttablet.DefaultVReplicationConfig,
+	}
+	fields := []*querypb.Field{
+		{Name: "c1", Type: querypb.Type_INT64},
+		{Name: "c2", Type: querypb.Type_JSON},
+	}
+	testCases := []struct {
+		name       string
+		filter     string
+		transforms map[string]*binlogdatapb.ColumnTransform
+		wantErr    string
+	}{{
+		name:       "column list",
+		filter:     "select c1, c2 from t1",
+		transforms: map[string]*binlogdatapb.ColumnTransform{"c1": {Function: "sha256"}},
+		wantErr:    "primary key column c1 of table t1 cannot be transformed",
+	}, {
+		name:       "select star",
+		filter:     "select * from t1",
+		transforms: map[string]*binlogdatapb.ColumnTransform{"c1": {Function: "sha256"}},
+		wantErr:    "primary key column c1 of table t1 cannot be transformed",
+	}, {
+		name:       "json column",
+		filter:     "select * from t1",
+		transforms: map[string]*binlogdatapb.ColumnTransform{"c2": {Function: "redact"}},
+		wantErr:    "JSON column c2 of table t1 cannot be transformed",
+	}, {
+		name:       "unknown function",
+		filter:     "select * from t1",
+		transforms: map[string]*binlogdatapb.ColumnTransform{"c2": {Function: "rot13"}},
+		wantErr:    `unsupported transformation "rot13" for column c2`,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			source := getSource(&binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:            "t1",
+					Filter:           tc.filter,
+					ColumnTransforms: tc.transforms,
+				}},
+			})
+			plan, err := vr.buildReplicatorPlan(source, colInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
+			if err == nil {
+				_, err = plan.buildExecutionPlan(&binlogdatapb.FieldEvent{TableName: "t1", Fields: fields})
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func ptrValue(v sqltypes.Value) *sqltypes.Value {
+	return &v
+}
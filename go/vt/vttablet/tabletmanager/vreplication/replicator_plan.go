@@ -89,6 +89,9 @@ func (rp *ReplicatorPlan) buildExecutionPlan(fieldEvent *binlogdatapb.FieldEvent
 			trimmed.Name = strings.Trim(trimmed.Name, "`")
 			tplanv.Fields = append(tplanv.Fields, trimmed)
 		}
+		if err := tplanv.validateColumnTransforms(); err != nil {
+			return nil, err
+		}
 		return &tplanv, nil
 	}
 	// select * construct was used. We need to use the field names.
@@ -97,6 +100,10 @@ func (rp *ReplicatorPlan) buildExecutionPlan(fieldEvent *binlogdatapb.FieldEvent
 		return nil, vterrors.Wrapf(err, "failed to build replication plan for %s table", fieldEvent.TableName)
 	}
 	tplan.Fields = fieldEvent.Fields
+	tplan.ColumnTransforms = prelim.ColumnTransforms
+	if err := tplan.validateColumnTransforms(); err != nil {
+		return nil, err
+	}
 	return tplan, nil
 }
 
@@ -217,6 +224,10 @@ type TablePlan struct {
 	FieldsToSkip            map[string]bool
 	ConvertCharset          map[string](*binlogdatapb.CharsetConversion)
 	HasExtraSourcePkColumns bool
+	// ColumnTransforms maps the names of the source columns whose values are
+	// transformed before being written to the target to their compiled
+	// transformation. See column_transform.go.
+	ColumnTransforms map[string]*columnTransform
 
 	TablePlanBuilder *tablePlanBuilder
 	// PartialInserts is a dynamically generated cache of insert ParsedQueries, which update only some columns.
@@ -348,8 +359,16 @@ func (tp *TablePlan) convertStringCharset(raw []byte, conversion *binlogdatapb.C
 // Most values will just bind directly. But some values may need manipulation:
 // - text values with charset conversion
 // - enum values converted to text via Online DDL
+// - values of columns with a transformation
 // - ...any other future possible values
 func (tp *TablePlan) bindFieldVal(field *querypb.Field, val *sqltypes.Value) (*querypb.BindVariable, error) {
+	if transform, ok := tp.ColumnTransforms[field.Name]; ok {
+		out, err := transform.apply(*val)
+		if err != nil {
+			return nil, err
+		}
+		return sqltypes.ValueBindVariable(out), nil
+	}
 	if conversion, ok := tp.ConvertCharset[field.Name]; ok && !val.IsNull() {
 		// Non-null string value, for which we have a charset conversion instruction
 		out, err := tp.convertStringCharset(val.Raw(), conversion, field.Name)
@@ -766,7 +785,17 @@ func (tp *TablePlan) appendFromRow(buf *bytes2.Buffer, row *querypb.Row) error {
 				buf.WriteString(vv.RawStr())
 			}
 		default:
-			if length < 0 {
+			if transform, ok := tp.ColumnTransforms[field.Name]; ok {
+				var vv sqltypes.Value
+				if length >= 0 {
+					vv = sqltypes.MakeTrusted(typ, row.Values[offset:offset+length])
+				}
+				out, err := transform.apply(vv)
+				if err != nil {
+					return err
+				}
+				out.EncodeSQLBytes2(buf)
+			} else if length < 0 {
 				// -1 means a null variable; serialize it directly
 				buf.WriteString(sqltypes.NullStr)
 			} else {
@@ -25,10 +25,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/bytes2"
-	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -743,7 +743,7 @@ func TestBuildPlayerPlan(t *testing.T) {
 		vr := &vreplicator{
 			workflowConfig: vttablet.DefaultVReplicationConfig,
 		}
-		plan, err := vr.buildReplicatorPlan(getSource(tcase.input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+		plan, err := vr.buildReplicatorPlan(getSource(tcase.input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
 		gotErr := ""
 		if err != nil {
 			gotErr = err.Error()
@@ -752,7 +752,7 @@ func TestBuildPlayerPlan(t *testing.T) {
 		gotPlan, _ := json.Marshal(plan)
 		wantPlan, _ := json.Marshal(tcase.plan)
 		require.Equal(t, string(wantPlan), string(gotPlan), "Filter(%v):\n%s, want\n%s", tcase.input, gotPlan, wantPlan)
-		plan, err = vr.buildReplicatorPlan(getSource(tcase.input), PrimaryKeyInfos, copyState, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+		plan, err = vr.buildReplicatorPlan(getSource(tcase.input), PrimaryKeyInfos, copyState, binlogplayer.NewStats(), vtenv.NewTestEnv())
 		if err != nil {
 			continue
 		}
@@ -783,7 +783,7 @@ func TestBuildPlayerPlanNoDup(t *testing.T) {
 	vr := &vreplicator{
 		workflowConfig: vttablet.DefaultVReplicationConfig,
 	}
-	_, err := vr.buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+	_, err := vr.buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
 	want := "more than one target for source table t"
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("buildReplicatorPlan err: %v, must contain: %v", err, want)
@@ -807,7 +807,7 @@ func TestBuildPlayerPlanExclude(t *testing.T) {
 	vr := &vreplicator{
 		workflowConfig: vttablet.DefaultVReplicationConfig,
 	}
-	plan, err := vr.buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+	plan, err := vr.buildReplicatorPlan(getSource(input), PrimaryKeyInfos, nil, binlogplayer.NewStats(), vtenv.NewTestEnv())
 	assert.NoError(t, err)
 
 	want := &TestReplicatorPlan{
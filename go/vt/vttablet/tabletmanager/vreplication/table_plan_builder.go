@@ -28,6 +28,7 @@ import (
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -133,7 +134,7 @@ const (
 // The TablePlan built is a partial plan. The full plan for a table is built
 // when we receive field information from events or rows sent by the source.
 // buildExecutionPlan is the function that builds the full plan.
-func (vr *vreplicator) buildReplicatorPlan(source *binlogdatapb.BinlogSource, colInfoMap map[string][]*ColumnInfo, copyState map[string]*sqltypes.Result, stats *binlogplayer.Stats, collationEnv *collations.Environment, parser *sqlparser.Parser) (*ReplicatorPlan, error) {
+func (vr *vreplicator) buildReplicatorPlan(source *binlogdatapb.BinlogSource, colInfoMap map[string][]*ColumnInfo, copyState map[string]*sqltypes.Result, stats *binlogplayer.Stats, env *vtenv.Environment) (*ReplicatorPlan, error) {
 	filter := source.Filter
 	plan := &ReplicatorPlan{
 		VStreamFilter:  &binlogdatapb.Filter{FieldEventMode: filter.FieldEventMode},
@@ -142,7 +143,7 @@ func (vr *vreplicator) buildReplicatorPlan(source *binlogdatapb.BinlogSource, co
 		ColInfoMap:     colInfoMap,
 		stats:          stats,
 		Source:         source,
-		collationEnv:   collationEnv,
+		collationEnv:   env.CollationEnv(),
 		workflowConfig: vr.workflowConfig,
 	}
 	for tableName := range colInfoMap {
@@ -162,7 +163,7 @@ func (vr *vreplicator) buildReplicatorPlan(source *binlogdatapb.BinlogSource, co
 		if !ok {
 			return nil, fmt.Errorf("table %s not found in schema", tableName)
 		}
-		tablePlan, err := buildTablePlan(tableName, rule, colInfos, lastpk, stats, source, collationEnv, parser, vr.workflowConfig)
+		tablePlan, err := buildTablePlan(tableName, rule, colInfos, lastpk, stats, source, env, vr.workflowConfig)
 		if err != nil {
 			return nil, vterrors.Wrapf(err, "failed to build table replication plan for %s table", tableName)
 		}
@@ -202,8 +203,8 @@ func MatchTable(tableName string, filter *binlogdatapb.Filter) (*binlogdatapb.Ru
 }
 
 func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*ColumnInfo, lastpk *sqltypes.Result,
-	stats *binlogplayer.Stats, source *binlogdatapb.BinlogSource, collationEnv *collations.Environment,
-	parser *sqlparser.Parser, workflowConfig *vttablet.VReplicationConfig) (*TablePlan, error) {
+	stats *binlogplayer.Stats, source *binlogdatapb.BinlogSource, env *vtenv.Environment,
+	workflowConfig *vttablet.VReplicationConfig) (*TablePlan, error) {
 
 	planError := func(err error, query string) error {
 		// Use the error string here to ensure things are uniform across
@@ -226,10 +227,14 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 	case filter == ExcludeStr:
 		return nil, nil
 	}
-	sel, fromTable, err := analyzeSelectFrom(query, parser)
+	sel, fromTable, err := analyzeSelectFrom(query, env.Parser())
 	if err != nil {
 		return nil, planError(err, query)
 	}
+	columnTransforms, err := compileColumnTransforms(rule.ColumnTransforms, env)
+	if err != nil {
+		return nil, err
+	}
 	sendRule := &binlogdatapb.Rule{
 		Match: fromTable,
 	}
@@ -251,7 +256,8 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 			Stats:            stats,
 			ConvertCharset:   rule.ConvertCharset,
 			ConvertIntToEnum: rule.ConvertIntToEnum,
-			CollationEnv:     collationEnv,
+			ColumnTransforms: columnTransforms,
+			CollationEnv:     env.CollationEnv(),
 			WorkflowConfig:   workflowConfig,
 		}
 
@@ -268,7 +274,7 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 		colInfos:       colInfos,
 		stats:          stats,
 		source:         source,
-		collationEnv:   collationEnv,
+		collationEnv:   env.CollationEnv(),
 		workflowConfig: workflowConfig,
 	}
 
@@ -333,6 +339,10 @@ func buildTablePlan(tableName string, rule *binlogdatapb.Rule, colInfos []*Colum
 	tablePlan.SendRule = sendRule
 	tablePlan.ConvertCharset = rule.ConvertCharset
 	tablePlan.ConvertIntToEnum = rule.ConvertIntToEnum
+	tablePlan.ColumnTransforms = columnTransforms
+	if err := tablePlan.validateColumnTransforms(); err != nil {
+		return nil, err
+	}
 	return tablePlan, nil
 }
 
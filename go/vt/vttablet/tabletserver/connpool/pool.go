@@ -73,6 +73,9 @@ func NewPool(env tabletenv.Env, name string, cfg tabletenv.ConnPoolConfig) *Pool
 		MaxLifetime:     cfg.MaxLifetime,
 		RefreshInterval: mysqlctl.PoolDynamicHostnameResolution,
 	}
+	if env.Config() != nil {
+		config.Workloads = env.Config().PoolWorkloads
+	}
 
 	if name != "" {
 		config.LogWait = func(start time.Time) {
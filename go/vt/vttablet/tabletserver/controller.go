@@ -82,6 +82,9 @@ type Controller interface {
 	// QueryService returns the QueryService object used by this Controller
 	QueryService() queryservice.QueryService
 
+	// AnalyzeSchemaMigration analyzes an Online DDL migration without submitting it
+	AnalyzeSchemaMigration(ctx context.Context, sql string, ddlStrategy string) (*tabletmanagerdata.AnalyzeSchemaMigrationResponse, error)
+
 	// SchemaEngine returns the SchemaEngine object used by this Controller
 	SchemaEngine() *schema.Engine
 
@@ -40,6 +40,7 @@ type TabletService interface {
 	tabletenv.Env
 	PostponeMessages(ctx context.Context, target *querypb.Target, querygen QueryGenerator, ids []string) (count int64, err error)
 	PurgeMessages(ctx context.Context, target *querypb.Target, querygen QueryGenerator, timeCutoff int64) (count int64, err error)
+	DeadLetterMessages(ctx context.Context, target *querypb.Target, querygen QueryGenerator, ids []string) (count int64, err error)
 }
 
 // VStreamer defines  the functions of VStreamer
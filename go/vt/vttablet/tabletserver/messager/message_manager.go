@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand/v2"
+	"strings"
 	"sync"
 	"time"
 
@@ -53,6 +54,9 @@ type QueryGenerator interface {
 	GenerateAckQuery(ids []string) (string, map[string]*querypb.BindVariable)
 	GeneratePostponeQuery(ids []string) (string, map[string]*querypb.BindVariable)
 	GeneratePurgeQuery(timeCutoff int64) (string, map[string]*querypb.BindVariable)
+	GenerateDeadLetterQueries(ids []string) ([]string, map[string]*querypb.BindVariable)
+	GenerateDeadLettersQuery(where *sqlparser.Where, lock bool) *sqlparser.ParsedQuery
+	GenerateReplayQueries(ids []string) ([]*sqlparser.ParsedQuery, map[string]*querypb.BindVariable)
 }
 
 type messageReceiver struct {
@@ -166,6 +170,25 @@ type receiverWithStatus struct {
 // The Purge thread
 // This thread is mostly independent. It wakes up periodically
 // to delete old rows that were successfully acked.
+//
+// Dead-lettering
+// If the table specifies vt_max_attempts, messages that have already
+// been sent that many times are not sent again when they become due.
+// Instead, the send loop hands them off to be moved to the dead-letter
+// table, or marked as failed by setting time_next to null if there is
+// no such table. Dead-lettered messages can be listed and replayed
+// with SHOW VITESS_DEAD_LETTERS and REPLAY VITESS_DEAD_LETTERS.
+//
+// Ordered delivery
+// If the table specifies vt_group_column, messages that share the same
+// group key are delivered one at a time in the order of their ids.
+// The poller only loads a message if there is no older message in its
+// group that is still unacked and not dead-lettered. The vstream does
+// not add messages to the cache for such tables because it cannot
+// verify this. Instead, it triggers the poller whenever a message
+// becomes due or gets acked. Messages with a null group key are not
+// ordered. An index on (group column, time_acked, id) keeps the
+// poller efficient.
 type messageManager struct {
 	tsv TabletService
 	vs  VStreamer
@@ -177,6 +200,8 @@ type messageManager struct {
 	minBackoff   time.Duration
 	maxBackoff   time.Duration
 	batchSize    int
+	maxAttempts  int64
+	ordered      bool
 	pollerTicks  *timer.Timer
 	purgeTicks   *timer.Timer
 	postponeSema *semaphore.Weighted
@@ -236,6 +261,10 @@ type messageManager struct {
 	ackQuery                  *sqlparser.ParsedQuery
 	postponeQuery             *sqlparser.ParsedQuery
 	purgeQuery                *sqlparser.ParsedQuery
+	deadLetterQueries         []*sqlparser.ParsedQuery
+	deadLettersQuery          string
+	deadLetterIDsQuery        string
+	replayQueries             []*sqlparser.ParsedQuery
 
 	// idType is the type of the id column in the message table.
 	idType sqltypes.Type
@@ -257,6 +286,8 @@ func newMessageManager(tsv TabletService, vs VStreamer, table *schema.Table, pos
 		minBackoff:      table.MessageInfo.MinBackoff,
 		maxBackoff:      table.MessageInfo.MaxBackoff,
 		batchSize:       table.MessageInfo.BatchSize,
+		maxAttempts:     int64(table.MessageInfo.MaxAttempts),
+		ordered:         table.MessageInfo.GroupColumn != "",
 		cache:           newCache(table.MessageInfo.CacheSize),
 		pollerTicks:     timer.NewTimer(table.MessageInfo.PollInterval),
 		purgeTicks:      timer.NewTimer(table.MessageInfo.PollInterval),
@@ -274,11 +305,20 @@ func newMessageManager(tsv TabletService, vs VStreamer, table *schema.Table, pos
 			Filter: vsQuery,
 		}},
 	}
-	mm.readByPriorityAndTimeNext = sqlparser.BuildParsedQuery(
-		// There should be a poller_idx defined on (time_acked, priority, time_next desc)
-		// for this to be as efficient as possible
-		"select priority, time_next, epoch, time_acked, %s from %v where time_acked is null and time_next < %a order by priority, time_next desc limit %a",
-		columnList, mm.name, ":time_next", ":max")
+	if mm.ordered {
+		groupColumn := sqlparser.NewIdentifierCI(table.MessageInfo.GroupColumn)
+		mm.readByPriorityAndTimeNext = sqlparser.BuildParsedQuery(
+			"select priority, time_next, epoch, time_acked, %s from %v where time_acked is null and time_next < %a and not exists ("+
+				"select 1 from %v as pending where pending.%v = %v.%v and pending.id < %v.id and pending.time_acked is null and pending.time_next is not null"+
+				") order by priority, time_next desc limit %a",
+			columnList, mm.name, ":time_next", mm.name, groupColumn, mm.name, groupColumn, mm.name, ":max")
+	} else {
+		mm.readByPriorityAndTimeNext = sqlparser.BuildParsedQuery(
+			// There should be a poller_idx defined on (time_acked, priority, time_next desc)
+			// for this to be as efficient as possible
+			"select priority, time_next, epoch, time_acked, %s from %v where time_acked is null and time_next < %a order by priority, time_next desc limit %a",
+			columnList, mm.name, ":time_next", ":max")
+	}
 	mm.ackQuery = sqlparser.BuildParsedQuery(
 		"update %v set time_acked = %a, time_next = null where id in %a and time_acked is null",
 		mm.name, ":time_acked", "::ids")
@@ -287,9 +327,70 @@ func newMessageManager(tsv TabletService, vs VStreamer, table *schema.Table, pos
 
 	mm.postponeQuery = buildPostponeQuery(mm.name, mm.minBackoff, mm.maxBackoff)
 
+	if table.MessageInfo.DeadLetterTable == "" {
+		// Without a dead-letter table, failed messages stay in the
+		// message table with a null time_next, which the poller
+		// never picks up.
+		mm.deadLetterQueries = []*sqlparser.ParsedQuery{sqlparser.BuildParsedQuery(
+			"update %v set time_next = null where id in %a and time_acked is null",
+			mm.name, "::ids")}
+		mm.deadLettersQuery = fmt.Sprintf(
+			"select priority, time_next, epoch, time_acked, %s from %v where time_acked is null and time_next is null",
+			columnList, mm.name)
+		mm.deadLetterIDsQuery = fmt.Sprintf(
+			"select id from %v where time_acked is null and time_next is null", mm.name)
+		mm.replayQueries = []*sqlparser.ParsedQuery{sqlparser.BuildParsedQuery(
+			"update %v set time_next = %a, epoch = null where id in %a and time_acked is null and time_next is null",
+			mm.name, ":time_now", "::ids")}
+	} else {
+		dlq := sqlparser.NewIdentifierCS(table.MessageInfo.DeadLetterTable)
+		mm.deadLetterQueries = []*sqlparser.ParsedQuery{
+			buildMoveQuery(dlq, mm.name, table.Fields),
+			sqlparser.BuildParsedQuery("delete from %v where id in %a and time_acked is null", mm.name, "::ids"),
+		}
+		mm.deadLettersQuery = fmt.Sprintf(
+			"select priority, time_next, epoch, time_acked, %s from %v where time_acked is null",
+			columnList, dlq)
+		mm.deadLetterIDsQuery = fmt.Sprintf("select id from %v where time_acked is null", dlq)
+		mm.replayQueries = []*sqlparser.ParsedQuery{
+			buildMoveQuery(mm.name, dlq, table.Fields),
+			sqlparser.BuildParsedQuery("delete from %v where id in %a and time_acked is null", dlq, "::ids"),
+		}
+	}
+
 	return mm
 }
 
+// buildMoveQuery builds the query that copies the unacked messages
+// with the given ids from src to dst. The copies are due immediately
+// and start over with no send attempts.
+func buildMoveQuery(dst, src sqlparser.IdentifierCS, fields []*querypb.Field) *sqlparser.ParsedQuery {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("insert into %v(", dst)
+	for i, field := range fields {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		buf.Myprintf("%v", sqlparser.NewIdentifierCI(field.Name))
+	}
+	buf.WriteString(") select ")
+	for i, field := range fields {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		switch strings.ToLower(field.Name) {
+		case "time_next":
+			buf.Myprintf("%a", ":time_now")
+		case "epoch", "time_acked":
+			buf.WriteString("null")
+		default:
+			buf.Myprintf("%v", sqlparser.NewIdentifierCI(field.Name))
+		}
+	}
+	buf.Myprintf(" from %v where id in %a and time_acked is null", src, "::ids")
+	return buf.ParsedQuery()
+}
+
 func buildPostponeQuery(name sqlparser.IdentifierCS, minBackoff, maxBackoff time.Duration) *sqlparser.ParsedQuery {
 	var args []any
 
@@ -553,17 +654,26 @@ func (mm *messageManager) runSend() {
 
 			// Fetch rows from cache.
 			lateCount := int64(0)
+			var deadIDs []string
 			for i := 0; i < mm.batchSize; i++ {
 				mr := mm.cache.Pop()
 				if mr == nil {
 					break
 				}
+				if mm.maxAttempts > 0 && mr.Epoch >= mm.maxAttempts {
+					deadIDs = append(deadIDs, mr.Row[0].ToString())
+					continue
+				}
 				if mr.Epoch >= 1 {
 					lateCount++
 				}
 				rows = append(rows, mr.Row)
 			}
 			MessageStats.Add([]string{mm.name.String(), "Delayed"}, lateCount)
+			if deadIDs != nil {
+				mm.wg.Add(1)
+				go mm.deadLetter(context.Background(), deadIDs) // calls the offsetting mm.wg.Done()
+			}
 
 			// If we have rows to send, break out of this loop.
 			if rows != nil {
@@ -646,6 +756,39 @@ func (mm *messageManager) postpone(ctx context.Context, tsv TabletService, ackWa
 	return nil
 }
 
+// deadLetter moves the messages that have exhausted their send
+// attempts out of the way, and removes them from the cache.
+func (mm *messageManager) deadLetter(ctx context.Context, ids []string) {
+	defer func() {
+		mm.tsv.LogError()
+		mm.wg.Done()
+	}()
+
+	defer func() {
+		// Hold cacheManagementMu for the same reason as send.
+		mm.cacheManagementMu.Lock()
+		defer mm.cacheManagementMu.Unlock()
+		mm.cache.Discard(ids)
+	}()
+
+	// Dead-lettering shares the semaphore with postpone because
+	// it also occupies a tx pool connection.
+	if err := mm.postponeSema.Acquire(ctx, 1); err != nil {
+		return
+	}
+	defer mm.postponeSema.Release(1)
+	ctx, cancel := context.WithTimeout(tabletenv.LocalContext(), mm.ackWaitTime)
+	defer cancel()
+	count, err := mm.tsv.DeadLetterMessages(ctx, nil, mm, ids)
+	if err != nil {
+		// The messages will be retried the next time the poller loads them.
+		MessageStats.Add([]string{mm.name.String(), "DeadLetterFailed"}, 1)
+		log.Errorf("messageManager (%v) - Unable to dead-letter messages: %v", mm.name, err)
+		return
+	}
+	MessageStats.Add([]string{mm.name.String(), "DeadLettered"}, count)
+}
+
 func (mm *messageManager) startVStream() {
 	if mm.streamCancel != nil {
 		return
@@ -683,6 +826,8 @@ func (mm *messageManager) runVStream(ctx context.Context) {
 // row indicates that the message is eligible to be sent, it's added to
 // the cache.
 // Deletes are ignored.
+// For ordered tables, rows are never added to the cache. The changes
+// that may make a message eligible trigger the poller instead.
 // If the poller updates lastPollPosition, then all GTIDs up to that
 // point are deemed obsolete and are skipped.
 func (mm *messageManager) runOneVStream(ctx context.Context) error {
@@ -757,8 +902,14 @@ func (mm *messageManager) processRowEvent(fields []*querypb.Field, rowEvent *bin
 	}
 
 	now := time.Now().UnixNano()
+	trigger := false
 	for _, rc := range rowEvent.RowChanges {
 		if rc.After == nil {
+			// A dead-lettered message may have been deleted,
+			// which unblocks the next message of its group.
+			if mm.ordered {
+				trigger = true
+			}
 			continue
 		}
 		row := sqltypes.MakeRowTrusted(fields, rc.After)
@@ -766,11 +917,24 @@ func (mm *messageManager) processRowEvent(fields []*querypb.Field, rowEvent *bin
 		if err != nil {
 			return err
 		}
-		if mr.TimeAcked != 0 || mr.TimeNext > now {
+		if mm.ordered {
+			// Let the poller pick the next message of the group,
+			// unless this message was just postponed.
+			if mr.TimeAcked != 0 || mr.TimeNext <= now {
+				trigger = true
+			}
+			continue
+		}
+		// A null time_next means that the message was acked or failed.
+		if mr.TimeAcked != 0 || mr.TimeNext == 0 || mr.TimeNext > now {
 			continue
 		}
 		mm.Add(mr)
 	}
+	if trigger {
+		// The poller needs cacheManagementMu, which is held by the caller.
+		go mm.pollerTicks.Trigger()
+	}
 	return nil
 }
 
@@ -905,6 +1069,67 @@ func (mm *messageManager) GeneratePurgeQuery(timeCutoff int64) (string, map[stri
 	}
 }
 
+// GenerateDeadLetterQueries returns the queries and bind vars for
+// dead-lettering messages. The queries must be executed in order
+// within a single transaction.
+func (mm *messageManager) GenerateDeadLetterQueries(ids []string) ([]string, map[string]*querypb.BindVariable) {
+	return parsedQueries(mm.deadLetterQueries), map[string]*querypb.BindVariable{
+		"time_now": sqltypes.Int64BindVariable(time.Now().UnixNano()),
+		"ids":      mm.idsBindVariable(ids),
+	}
+}
+
+// GenerateDeadLettersQuery returns the query that lists the dead-lettered
+// messages matching where. If lock is set, it instead selects the ids
+// of those messages for update.
+func (mm *messageManager) GenerateDeadLettersQuery(where *sqlparser.Where, lock bool) *sqlparser.ParsedQuery {
+	buf := sqlparser.NewTrackedBuffer(nil)
+	if lock {
+		buf.WriteString(mm.deadLetterIDsQuery)
+	} else {
+		buf.WriteString(mm.deadLettersQuery)
+	}
+	if where != nil {
+		buf.Myprintf(" and (%v)", where.Expr)
+	}
+	if lock {
+		buf.WriteString(" for update")
+	}
+	return buf.ParsedQuery()
+}
+
+// GenerateReplayQueries returns the queries and bind vars for replaying
+// dead-lettered messages. The queries must be executed in order within
+// a single transaction.
+func (mm *messageManager) GenerateReplayQueries(ids []string) ([]*sqlparser.ParsedQuery, map[string]*querypb.BindVariable) {
+	return mm.replayQueries, map[string]*querypb.BindVariable{
+		"time_now": sqltypes.Int64BindVariable(time.Now().UnixNano()),
+		"ids":      mm.idsBindVariable(ids),
+	}
+}
+
+func (mm *messageManager) idsBindVariable(ids []string) *querypb.BindVariable {
+	idbvs := &querypb.BindVariable{
+		Type:   querypb.Type_TUPLE,
+		Values: make([]*querypb.Value, 0, len(ids)),
+	}
+	for _, id := range ids {
+		idbvs.Values = append(idbvs.Values, &querypb.Value{
+			Type:  mm.idType,
+			Value: []byte(id),
+		})
+	}
+	return idbvs
+}
+
+func parsedQueries(pqs []*sqlparser.ParsedQuery) []string {
+	queries := make([]string, 0, len(pqs))
+	for _, pq := range pqs {
+		queries = append(queries, pq.Query)
+	}
+	return queries
+}
+
 // BuildMessageRow builds a MessageRow from a db row.
 func BuildMessageRow(row []sqltypes.Value) (*MessageRow, error) {
 	mr := &MessageRow{Row: row[4:]}
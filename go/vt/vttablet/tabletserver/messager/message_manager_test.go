@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/semaphore"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -576,6 +577,56 @@ func TestMessageManagerStreamerAndPoller(t *testing.T) {
 	}
 }
 
+func TestMessageManagerOrdered(t *testing.T) {
+	ti := newMMTable()
+	ti.MessageInfo.GroupColumn = "message"
+	ti.MessageInfo.PollInterval = 20 * time.Second
+	fvs := newFakeVStreamer()
+	fvs.setPollerResponse([]*binlogdatapb.VStreamResultsResponse{{
+		Fields: testDBFields,
+	}})
+	fvs.setStreamerResponse([][]*binlogdatapb.VEvent{{{
+		Type: binlogdatapb.VEventType_FIELD,
+		FieldEvent: &binlogdatapb.FieldEvent{
+			TableName: "foo",
+			Fields:    testDBFields,
+		},
+	}}, {{
+		Type: binlogdatapb.VEventType_ROW,
+		RowEvent: &binlogdatapb.RowEvent{
+			TableName: "foo",
+			RowChanges: []*binlogdatapb.RowChange{{
+				After: newMMRow(1),
+			}},
+		},
+	}, {
+		Type: binlogdatapb.VEventType_COMMIT,
+	}}})
+	mm := newMessageManager(newFakeTabletServer(), fvs, ti, semaphore.NewWeighted(1))
+	mm.Open()
+	defer mm.Close()
+
+	r1 := newTestReceiver(1)
+	mm.Subscribe(context.Background(), r1.rcv)
+	<-r1.ch
+
+	// The new message must trigger the poller instead of being sent
+	// before the older messages of its group.
+	assert.Eventually(t, func() bool {
+		return len(fvs.getPollerQueries()) >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+	wantQuery := "select priority, time_next, epoch, time_acked, id, message from foo where time_acked is null and time_next < "
+	for _, query := range fvs.getPollerQueries() {
+		assert.Contains(t, query, wantQuery)
+		assert.Contains(t, query, " and not exists (select 1 from foo as pending where pending.message = foo.message and pending.id < foo.id and pending.time_acked is null and pending.time_next is not null) order by priority, time_next desc limit 10")
+	}
+	select {
+	case qr := <-r1.ch:
+		t.Errorf("Expecting no value, got: %v", qr)
+	default:
+	}
+}
+
 func TestMessageManagerPoller(t *testing.T) {
 	ti := newMMTable()
 	ti.MessageInfo.BatchSize = 2
@@ -824,10 +875,121 @@ func TestMMGenerateWithBackoff(t *testing.T) {
 	}
 }
 
+func TestMessageManagerDeadLetter(t *testing.T) {
+	ti := newMMTable()
+	ti.MessageInfo.BatchSize = 2
+	ti.MessageInfo.MaxAttempts = 3
+	ti.MessageInfo.PollInterval = 20 * time.Second
+	fvs := newFakeVStreamer()
+	// This message has already been sent vt_max_attempts times.
+	dead := sqltypes.RowToProto3([]sqltypes.Value{
+		sqltypes.NewInt64(1),
+		sqltypes.NewInt64(1),
+		sqltypes.NewInt64(3),
+		sqltypes.NULL,
+		sqltypes.NewInt64(2),
+		sqltypes.NewVarBinary("2"),
+	})
+	fvs.setPollerResponse([]*binlogdatapb.VStreamResultsResponse{{
+		Fields: testDBFields,
+		Gtid:   "MySQL56/33333333-3333-3333-3333-333333333333:1-100",
+	}, {
+		Rows: []*querypb.Row{
+			newMMRow(1),
+			dead,
+		},
+	}})
+	tsv := newFakeTabletServer()
+	mm := newMessageManager(tsv, fvs, ti, semaphore.NewWeighted(1))
+	mm.Open()
+	defer mm.Close()
+	deadLettered := MessageStats.Counts()["foo.DeadLettered"]
+
+	r1 := newTestReceiver(1)
+	mm.Subscribe(context.Background(), r1.rcv)
+	<-r1.ch
+
+	want := [][]sqltypes.Value{{
+		sqltypes.NewInt64(1),
+		sqltypes.NewVarBinary("1"),
+	}}
+	qr := <-r1.ch
+	utils.MustMatch(t, want, qr.Rows)
+	assert.Eventually(t, func() bool {
+		return MessageStats.Counts()["foo.DeadLettered"] == deadLettered+1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, 1, tsv.deadLetterCount.Load())
+
+	// The dead-lettered message must not be sent.
+	runtime.Gosched()
+	select {
+	case qr := <-r1.ch:
+		t.Errorf("Expecting no value, got: %v", qr)
+	default:
+	}
+}
+
+func TestMMGenerateDeadLetter(t *testing.T) {
+	mm := newMessageManager(newFakeTabletServer(), newFakeVStreamer(), newMMTable(), semaphore.NewWeighted(1))
+	wantids := sqltypes.TestBindVariable([]any{[]byte{'1'}, []byte{'2'}})
+
+	queries, bv := mm.GenerateDeadLetterQueries([]string{"1", "2"})
+	assert.Equal(t, []string{"update foo set time_next = null where id in ::ids and time_acked is null"}, queries)
+	utils.MustMatch(t, wantids, bv["ids"])
+
+	where := &sqlparser.Where{Type: sqlparser.WhereClause, Expr: &sqlparser.ComparisonExpr{
+		Operator: sqlparser.GreaterThanOp,
+		Left:     sqlparser.NewColName("epoch"),
+		Right:    sqlparser.NewArgument("epoch"),
+	}}
+	pq := mm.GenerateDeadLettersQuery(where, false)
+	assert.Equal(t, "select priority, time_next, epoch, time_acked, id, message from foo where time_acked is null and time_next is null and (epoch > :epoch)", pq.Query)
+	query, err := pq.GenerateQuery(map[string]*querypb.BindVariable{"epoch": sqltypes.Int64BindVariable(3)}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "select priority, time_next, epoch, time_acked, id, message from foo where time_acked is null and time_next is null and (epoch > 3)", query)
+	pq = mm.GenerateDeadLettersQuery(nil, true)
+	assert.Equal(t, "select id from foo where time_acked is null and time_next is null for update", pq.Query)
+
+	replayQueries, bv := mm.GenerateReplayQueries([]string{"1", "2"})
+	require.Len(t, replayQueries, 1)
+	assert.Equal(t, "update foo set time_next = :time_now, epoch = null where id in ::ids and time_acked is null and time_next is null", replayQueries[0].Query)
+	assert.Contains(t, bv, "time_now")
+	utils.MustMatch(t, wantids, bv["ids"])
+}
+
+func TestMMGenerateDeadLetterTable(t *testing.T) {
+	ti := newMMTable()
+	ti.MessageInfo.DeadLetterTable = "foo_dlq"
+	ti.Fields = []*querypb.Field{
+		{Name: "id"},
+		{Name: "priority"},
+		{Name: "time_next"},
+		{Name: "epoch"},
+		{Name: "time_acked"},
+		{Name: "message"},
+	}
+	mm := newMessageManager(newFakeTabletServer(), newFakeVStreamer(), ti, semaphore.NewWeighted(1))
+
+	queries, _ := mm.GenerateDeadLetterQueries([]string{"1"})
+	assert.Equal(t, []string{
+		"insert into foo_dlq(id, priority, time_next, epoch, time_acked, message) select id, priority, :time_now, null, null, message from foo where id in ::ids and time_acked is null",
+		"delete from foo where id in ::ids and time_acked is null",
+	}, queries)
+
+	assert.Equal(t, "select priority, time_next, epoch, time_acked, id, message from foo_dlq where time_acked is null", mm.GenerateDeadLettersQuery(nil, false).Query)
+	assert.Equal(t, "select id from foo_dlq where time_acked is null for update", mm.GenerateDeadLettersQuery(nil, true).Query)
+
+	replayQueries, _ := mm.GenerateReplayQueries([]string{"1"})
+	require.Len(t, replayQueries, 2)
+	assert.Equal(t, "insert into foo(id, priority, time_next, epoch, time_acked, message) select id, priority, :time_now, null, null, message from foo_dlq where id in ::ids and time_acked is null", replayQueries[0].Query)
+	assert.Equal(t, "delete from foo_dlq where id in ::ids and time_acked is null", replayQueries[1].Query)
+}
+
 type fakeTabletServer struct {
 	tabletenv.Env
-	postponeCount atomic.Int64
-	purgeCount    atomic.Int64
+	postponeCount   atomic.Int64
+	purgeCount      atomic.Int64
+	deadLetterCount atomic.Int64
 
 	mu sync.Mutex
 	ch chan string
@@ -870,8 +1032,20 @@ func (fts *fakeTabletServer) PurgeMessages(ctx context.Context, target *querypb.
 	return 0, nil
 }
 
+func (fts *fakeTabletServer) DeadLetterMessages(ctx context.Context, target *querypb.Target, gen QueryGenerator, ids []string) (count int64, err error) {
+	fts.deadLetterCount.Add(1)
+	fts.mu.Lock()
+	ch := fts.ch
+	fts.mu.Unlock()
+	if ch != nil {
+		ch <- "deadletter"
+	}
+	return int64(len(ids)), nil
+}
+
 type fakeVStreamer struct {
 	streamInvocations atomic.Int64
+	pollerQueries     []string
 	mu                sync.Mutex
 	streamerResponse  [][]*binlogdatapb.VEvent
 	pollerResponse    []*binlogdatapb.VStreamResultsResponse
@@ -885,6 +1059,12 @@ func (fv *fakeVStreamer) setStreamerResponse(sr [][]*binlogdatapb.VEvent) {
 	fv.streamerResponse = sr
 }
 
+func (fv *fakeVStreamer) getPollerQueries() []string {
+	fv.mu.Lock()
+	defer fv.mu.Unlock()
+	return append([]string(nil), fv.pollerQueries...)
+}
+
 func (fv *fakeVStreamer) setPollerResponse(pr []*binlogdatapb.VStreamResultsResponse) {
 	fv.mu.Lock()
 	defer fv.mu.Unlock()
@@ -917,6 +1097,7 @@ func (fv *fakeVStreamer) Stream(ctx context.Context, startPos string, tablePKs [
 func (fv *fakeVStreamer) StreamResults(ctx context.Context, query string, send func(*binlogdatapb.VStreamResultsResponse) error) error {
 	fv.mu.Lock()
 	defer fv.mu.Unlock()
+	fv.pollerQueries = append(fv.pollerQueries, query)
 	for _, r := range fv.pollerResponse {
 		if err := send(r); err != nil {
 			return err
@@ -262,3 +262,15 @@ func analyzeFlush(stmt *sqlparser.Flush, tables map[string]*schema.Table) (*Plan
 	}
 	return plan, nil
 }
+
+func analyzeDeadLetters(planID PlanType, stmt sqlparser.Statement, tableName sqlparser.TableName, tables map[string]*schema.Table) (*Plan, error) {
+	name := tableName.Name.String()
+	table := tables[name]
+	if table == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "table %s not found in schema", name)
+	}
+	if table.Type != schema.Message {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "'%s' is not a message table", name)
+	}
+	return &Plan{PlanID: planID, Table: table, FullStmt: stmt}, nil
+}
@@ -64,6 +64,10 @@ func BuildPermissions(stmt sqlparser.Statement) []Permission {
 		*sqlparser.ShowThrottledApps,
 		*sqlparser.ShowThrottlerStatus:
 		permissions = []Permission{} // TODO(shlomi) what are the correct permissions here? Table is unknown
+	case *sqlparser.ShowDeadLetters:
+		permissions = buildTableNamePermissions(node.Table, tableacl.READER, nil, permissions)
+	case *sqlparser.ReplayDeadLetters:
+		permissions = buildTableNamePermissions(node.Table, tableacl.WRITER, nil, permissions)
 	case *sqlparser.Flush:
 		for _, t := range node.TableNames {
 			permissions = buildTableNamePermissions(t, tableacl.ADMIN, nil, permissions)
@@ -80,6 +80,10 @@ const (
 	PlanShowMigrationLogs
 	PlanShowThrottledApps
 	PlanShowThrottlerStatus
+	// PlanShowDeadLetters is for "show vitess_dead_letters" statements.
+	PlanShowDeadLetters
+	// PlanReplayDeadLetters is for "replay vitess_dead_letters" statements.
+	PlanReplayDeadLetters
 	NumPlans
 )
 
@@ -116,6 +120,8 @@ var planName = []string{
 	"ShowMigrationLogs",
 	"ShowThrottledApps",
 	"ShowThrottlerStatus",
+	"ShowDeadLetters",
+	"ReplayDeadLetters",
 }
 
 func (pt PlanType) String() string {
@@ -228,6 +234,10 @@ func Build(env *vtenv.Environment, statement sqlparser.Statement, tables map[str
 		plan = &Plan{PlanID: PlanShowThrottledApps, FullStmt: stmt}
 	case *sqlparser.ShowThrottlerStatus:
 		plan = &Plan{PlanID: PlanShowThrottlerStatus, FullStmt: stmt}
+	case *sqlparser.ShowDeadLetters:
+		plan, err = analyzeDeadLetters(PlanShowDeadLetters, stmt, stmt.Table, tables)
+	case *sqlparser.ReplayDeadLetters:
+		plan, err = analyzeDeadLetters(PlanReplayDeadLetters, stmt, stmt.Table, tables)
 	case *sqlparser.Show:
 		plan, err = analyzeShow(stmt, dbName)
 	case *sqlparser.Analyze, sqlparser.Explain:
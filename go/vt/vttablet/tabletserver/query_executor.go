@@ -43,6 +43,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/messager"
 	p "vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 	eschema "vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
@@ -155,6 +156,11 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 	if err = qre.checkPermissions(); err != nil {
 		return nil, err
 	}
+	release, err := qre.admit()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	if qre.plan.PlanID == p.PlanNextval {
 		return qre.execNextval()
@@ -219,6 +225,10 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		return qre.execShowThrottledApps()
 	case p.PlanShowThrottlerStatus:
 		return qre.execShowThrottlerStatus()
+	case p.PlanShowDeadLetters:
+		return qre.execShowDeadLetters()
+	case p.PlanReplayDeadLetters:
+		return qre.execReplayDeadLetters()
 	case p.PlanUnlockTables:
 		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "unlock tables should be executed with an existing connection")
 	case p.PlanSet:
@@ -347,6 +357,11 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 	if err := qre.checkPermissions(); err != nil {
 		return err
 	}
+	release, err := qre.admit()
+	if err != nil {
+		return err
+	}
+	defer release()
 
 	switch qre.plan.PlanID {
 	case p.PlanSelectStream:
@@ -437,6 +452,11 @@ func (qre *QueryExecutor) MessageStream(callback StreamCallback) error {
 	if err := qre.checkPermissions(); err != nil {
 		return err
 	}
+	release, err := qre.admit()
+	if err != nil {
+		return err
+	}
+	defer release()
 
 	done, err := qre.tsv.messager.Subscribe(qre.ctx, qre.plan.TableName().String(), func(r *sqltypes.Result) error {
 		select {
@@ -529,6 +549,35 @@ func (qre *QueryExecutor) checkPermissions() error {
 	return nil
 }
 
+// admit returns an error if the query is rejected by a rate or
+// concurrency limiting rule. Otherwise, release must be called once
+// the query is done.
+func (qre *QueryExecutor) admit() (release func(), err error) {
+	// Skip limits if the context is local.
+	if tabletenv.IsLocalContext(qre.ctx) {
+		return func() {}, nil
+	}
+
+	remoteAddr := ""
+	username := ""
+	ci, ok := callinfo.FromContext(qre.ctx)
+	if ok {
+		remoteAddr = ci.RemoteAddr()
+		username = ci.Username()
+	}
+
+	release, action, name, desc := qre.plan.Rules.Admit(remoteAddr, username, qre.bindVars, qre.marginComments)
+	switch action {
+	case rules.QRRateLimit:
+		qre.tsv.Stats().QueryRulesLimited.Add(name, 1)
+		return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "rate limited due to rule: %s", desc)
+	case rules.QRConcurrencyLimit:
+		qre.tsv.Stats().QueryRulesLimited.Add(name, 1)
+		return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "concurrency limited due to rule: %s", desc)
+	}
+	return release, nil
+}
+
 func (qre *QueryExecutor) checkAccess(authorized *tableacl.ACLResult, tableName string, callerID *querypb.VTGateCallerID) error {
 	statsKey := []string{tableName, authorized.GroupName, qre.plan.PlanID.String(), callerID.Username}
 	if !authorized.IsMember(callerID) {
@@ -1103,6 +1152,72 @@ func (qre *QueryExecutor) execShowThrottlerStatus() (*sqltypes.Result, error) {
 	return result, nil
 }
 
+func (qre *QueryExecutor) execShowDeadLetters() (*sqltypes.Result, error) {
+	showDeadLetters, ok := qre.plan.FullStmt.(*sqlparser.ShowDeadLetters)
+	if !ok {
+		return nil, vterrors.New(vtrpcpb.Code_INTERNAL, "Expecting SHOW VITESS_DEAD_LETTERS plan")
+	}
+	querygen, err := qre.tsv.messager.GetGenerator(qre.plan.Table.Name.String())
+	if err != nil {
+		return nil, err
+	}
+	sql, _, err := qre.generateFinalSQL(querygen.GenerateDeadLettersQuery(showDeadLetters.Where, false /* lock */), qre.bindVars)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := qre.getConn()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+	return qre.execDBConn(conn.Conn, sql, true)
+}
+
+func (qre *QueryExecutor) execReplayDeadLetters() (*sqltypes.Result, error) {
+	replayDeadLetters, ok := qre.plan.FullStmt.(*sqlparser.ReplayDeadLetters)
+	if !ok {
+		return nil, vterrors.New(vtrpcpb.Code_INTERNAL, "Expecting REPLAY VITESS_DEAD_LETTERS plan")
+	}
+	name := qre.plan.Table.Name.String()
+	querygen, err := qre.tsv.messager.GetGenerator(name)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := qre.execAsTransaction(func(conn *StatefulConnection) (*sqltypes.Result, error) {
+		sql, _, err := qre.generateFinalSQL(querygen.GenerateDeadLettersQuery(replayDeadLetters.Where, true /* lock */), qre.bindVars)
+		if err != nil {
+			return nil, err
+		}
+		qr, err := qre.execStatefulConn(conn, sql, false)
+		if err != nil {
+			return nil, err
+		}
+		if len(qr.Rows) == 0 {
+			return &sqltypes.Result{}, nil
+		}
+		ids := make([]string, 0, len(qr.Rows))
+		for _, row := range qr.Rows {
+			ids = append(ids, row[0].ToString())
+		}
+		queries, bv := querygen.GenerateReplayQueries(ids)
+		for _, query := range queries {
+			sql, _, err := qre.generateFinalSQL(query, bv)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := qre.execStatefulConn(conn, sql, false); err != nil {
+				return nil, err
+			}
+		}
+		return &sqltypes.Result{RowsAffected: uint64(len(ids))}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	messager.MessageStats.Add([]string{name, "Replayed"}, int64(qr.RowsAffected))
+	return qr, nil
+}
+
 func (qre *QueryExecutor) drainResultSetOnConn(conn *connpool.Conn) error {
 	more := true
 	for more {
@@ -1270,6 +1270,95 @@ func TestQueryExecutorDenyListQRRetry(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorRateLimitRule(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table limit 1000"
+	db.AddQuery(query, &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+
+	limitRule := rules.NewQueryRule("limit select", "limit_select", rules.QRRateLimit)
+	limitRule.SetUserCond("u1")
+	limitRule.SetQueryCond("select.*")
+	limitRule.AddTableCond("test_table")
+	require.NoError(t, limitRule.SetRateLimit(0.001, 1))
+
+	rulesName := "rateLimitRules"
+	rules := rules.New()
+	rules.Add(limitRule)
+
+	ctx := callinfo.NewContext(context.Background(), &fakecallinfo.FakeCallInfo{User: "u1"})
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, rules))
+
+	before := tsv.stats.QueryRulesLimited.Counts()["limit_select"]
+
+	_, err := newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+	require.NoError(t, err)
+
+	_, err = newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "rate limited due to rule: limit select")
+	assert.EqualValues(t, 1, tsv.stats.QueryRulesLimited.Counts()["limit_select"]-before)
+
+	// Other users are not limited.
+	otherCtx := callinfo.NewContext(context.Background(), &fakecallinfo.FakeCallInfo{User: "u2"})
+	_, err = newTestQueryExecutor(otherCtx, tsv, query, 0).Execute()
+	require.NoError(t, err)
+}
+
+func TestQueryExecutorConcurrencyLimitRule(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table limit 1000"
+	db.AddQuery(query, &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+
+	limitRule := rules.NewQueryRule("limit select", "limit_select", rules.QRConcurrencyLimit)
+	limitRule.AddPlanCond(planbuilder.PlanSelect)
+	require.NoError(t, limitRule.SetConcurrencyLimit(1))
+
+	rulesName := "concurrencyLimitRules"
+	rules := rules.New()
+	rules.Add(limitRule)
+
+	ctx := callinfo.NewContext(context.Background(), &fakecallinfo.FakeCallInfo{User: "u1"})
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, rules))
+
+	// Queries release their slot once done.
+	for i := 0; i < 2; i++ {
+		_, err := newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+		require.NoError(t, err)
+	}
+
+	// Hold the only slot, as an in-flight query would.
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	release, err := qre.admit()
+	require.NoError(t, err)
+
+	_, err = newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "concurrency limited due to rule: limit select")
+
+	err = newTestQueryExecutor(ctx, tsv, query, 0).Stream(func(*sqltypes.Result) error { return nil })
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+
+	release()
+	_, err = newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+	require.NoError(t, err)
+}
+
 func TestReplaceSchemaName(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
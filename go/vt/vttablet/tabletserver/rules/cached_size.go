@@ -45,7 +45,7 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(256)
+		size += int64(288)
 	}
 	// field Description string
 	size += hack.RuntimeAllocSize(int64(len(cached.Description)))
@@ -79,6 +79,8 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(false)
 		}
 	}
+	// field limiter *vitess.io/vitess/go/vt/vttablet/tabletserver/rules.limiter
+	size += cached.limiter.CachedSize(true)
 	return size
 }
 func (cached *Rules) CachedSize(alloc bool) int64 {
@@ -113,6 +115,16 @@ func (cached *bvcre) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *limiter) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	return size
+}
 func (cached *namedRegexp) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -21,9 +21,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"strconv"
+	"sync"
 	"time"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -181,13 +183,55 @@ func (qrs *Rules) GetAction(
 	timeout time.Duration,
 	desc string) {
 	for _, qr := range qrs.rules {
-		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue {
+		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue && !act.limits() {
 			return act, qr.cancelCtx, qr.timeout, qr.Description
 		}
 	}
 	return QRContinue, nil, 0, ""
 }
 
+// Admit runs the input against the rate and concurrency limiting rules.
+// If one of them rejects the query, Admit returns its action, name and
+// description. Otherwise it returns QRContinue, and release must be called
+// once the query is done to give back the concurrency slots it holds.
+// Limiting rules are skipped by GetAction.
+func (qrs *Rules) Admit(
+	ip,
+	user string,
+	bindVars map[string]*querypb.BindVariable,
+	marginComments sqlparser.MarginComments,
+) (
+	release func(),
+	action Action,
+	name string,
+	desc string) {
+	var held []*Rule
+	release = func() {
+		for _, qr := range held {
+			qr.limiter.release()
+		}
+	}
+	for _, qr := range qrs.rules {
+		if qr.limiter == nil {
+			continue
+		}
+		switch act := qr.GetAction(ip, user, bindVars, marginComments); act {
+		case QRRateLimit:
+			if !qr.limiter.allow(qr.maxQPS, qr.effectiveBurst()) {
+				release()
+				return func() {}, act, qr.Name, qr.Description
+			}
+		case QRConcurrencyLimit:
+			if !qr.limiter.acquire(qr.maxConcurrency) {
+				release()
+				return func() {}, act, qr.Name, qr.Description
+			}
+			held = append(held, qr)
+		}
+	}
+	return release, QRContinue, "", ""
+}
+
 // -----------------------------------------------
 
 // Rule represents one rule (conditions-action).
@@ -224,6 +268,18 @@ type Rule struct {
 
 	// a rule can timeout.
 	timeout time.Duration
+
+	// maxQPS and burst size the token bucket of a QRRateLimit rule.
+	maxQPS float64
+	burst  int
+
+	// maxConcurrency caps the in-flight queries of a QRConcurrencyLimit rule.
+	maxConcurrency int
+
+	// limiter tracks the admissions of a limiting rule. It is shared
+	// by the copies of the rule, so that the limit applies across all
+	// the query plans the rule was filtered into.
+	limiter *limiter
 }
 
 type namedRegexp struct {
@@ -269,6 +325,9 @@ func (qr *Rule) Equal(other *Rule) bool {
 		qr.leadingComment.Equal(other.leadingComment) &&
 		qr.trailingComment.Equal(other.trailingComment) &&
 		qr.timeout == other.timeout &&
+		qr.maxQPS == other.maxQPS &&
+		qr.burst == other.burst &&
+		qr.maxConcurrency == other.maxConcurrency &&
 		reflect.DeepEqual(qr.plans, other.plans) &&
 		reflect.DeepEqual(qr.tableNames, other.tableNames) &&
 		reflect.DeepEqual(qr.bindVarConds, other.bindVarConds) &&
@@ -288,6 +347,10 @@ func (qr *Rule) Copy() (newqr *Rule) {
 		act:             qr.act,
 		cancelCtx:       qr.cancelCtx,
 		timeout:         qr.timeout,
+		maxQPS:          qr.maxQPS,
+		burst:           qr.burst,
+		maxConcurrency:  qr.maxConcurrency,
+		limiter:         qr.limiter,
 	}
 	if qr.plans != nil {
 		newqr.plans = make([]planbuilder.PlanType, len(qr.plans))
@@ -339,6 +402,15 @@ func (qr *Rule) MarshalJSON() ([]byte, error) {
 	if qr.timeout != 0 {
 		safeEncode(b, `,"Timeout":`, qr.timeout)
 	}
+	if qr.maxQPS != 0 {
+		safeEncode(b, `,"MaxQPS":`, qr.maxQPS)
+	}
+	if qr.burst != 0 {
+		safeEncode(b, `,"Burst":`, qr.burst)
+	}
+	if qr.maxConcurrency != 0 {
+		safeEncode(b, `,"MaxConcurrency":`, qr.maxConcurrency)
+	}
 	_, _ = b.WriteString("}")
 	return b.Bytes(), nil
 }
@@ -394,6 +466,37 @@ func (qr *Rule) SetTrailingCommentCond(pattern string) (err error) {
 	return
 }
 
+// SetRateLimit sets the token bucket of a QRRateLimit rule. Matching
+// queries are admitted at up to maxQPS per second, with bursts of up
+// to burst queries. A burst of 0 defaults to maxQPS rounded up.
+func (qr *Rule) SetRateLimit(maxQPS float64, burst int) error {
+	if maxQPS <= 0 || math.IsInf(maxQPS, 0) || burst < 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid rate limit: %v qps with a burst of %d", maxQPS, burst)
+	}
+	qr.maxQPS = maxQPS
+	qr.burst = burst
+	qr.limiter = &limiter{}
+	return nil
+}
+
+// SetConcurrencyLimit sets the maximum number of matching queries
+// a QRConcurrencyLimit rule lets run at the same time.
+func (qr *Rule) SetConcurrencyLimit(maxConcurrency int) error {
+	if maxConcurrency <= 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid concurrency limit: %d", maxConcurrency)
+	}
+	qr.maxConcurrency = maxConcurrency
+	qr.limiter = &limiter{}
+	return nil
+}
+
+func (qr *Rule) effectiveBurst() int {
+	if qr.burst > 0 {
+		return qr.burst
+	}
+	return max(1, int(math.Ceil(qr.maxQPS)))
+}
+
 // makeExact forces a full string match for the regex instead of substring
 func makeExact(pattern string) string {
 	return fmt.Sprintf("^%s$", pattern)
@@ -573,8 +676,16 @@ const (
 	QRFail
 	QRFailRetry
 	QRBuffer
+	QRRateLimit
+	QRConcurrencyLimit
 )
 
+// limits returns true if the action admits queries up to a limit
+// instead of applying to all of them.
+func (act Action) limits() bool {
+	return act == QRRateLimit || act == QRConcurrencyLimit
+}
+
 // MarshalJSON marshals to JSON.
 func (act Action) MarshalJSON() ([]byte, error) {
 	// If we add more actions, we'll need to use a map.
@@ -586,12 +697,61 @@ func (act Action) MarshalJSON() ([]byte, error) {
 		str = "FAIL_RETRY"
 	case QRBuffer:
 		str = "BUFFER"
+	case QRRateLimit:
+		str = "RATE_LIMIT"
+	case QRConcurrencyLimit:
+		str = "CONCURRENCY_LIMIT"
 	default:
 		str = "INVALID"
 	}
 	return json.Marshal(str)
 }
 
+// limiter holds the admission state of a limiting Rule.
+type limiter struct {
+	mu       sync.Mutex
+	tokens   float64
+	last     time.Time
+	inflight int
+}
+
+// allow takes a token from the bucket, refilled at maxQPS up to burst
+// tokens. It returns false if the bucket is empty.
+func (l *limiter) allow(maxQPS float64, burst int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if l.last.IsZero() {
+		l.tokens = float64(burst)
+	} else {
+		l.tokens = min(float64(burst), l.tokens+now.Sub(l.last).Seconds()*maxQPS)
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// acquire takes a concurrency slot. It returns false if all
+// maxConcurrency slots are in use.
+func (l *limiter) acquire(maxConcurrency int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.inflight >= maxConcurrency {
+		return false
+	}
+	l.inflight++
+	return true
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inflight--
+}
+
 // BindVarCond represents a bind var condition.
 type BindVarCond struct {
 	name       string
@@ -871,9 +1031,12 @@ func MapStrOperator(strop string) (op Operator, err error) {
 // BuildQueryRule builds a query rule from a ruleInfo.
 func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 	qr = NewQueryRule("", "", QRFail)
+	var maxQPS float64
+	var burst, maxConcurrency int
 	for k, v := range ruleInfo {
 		var sv string
 		var lv []any
+		var fv float64
 		var ok bool
 		switch k {
 		case "Name", "Description", "RequestIP", "User", "Query", "Action", "LeadingComment", "TrailingComment":
@@ -886,6 +1049,16 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for %s", k)
 			}
+		case "MaxQPS":
+			fv, ok = getNumber(v)
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want number for %s", k)
+			}
+		case "Burst", "MaxConcurrency":
+			fv, ok = getNumber(v)
+			if !ok || fv != math.Trunc(fv) {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want integer for %s", k)
+			}
 		default:
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s", k)
 		}
@@ -958,14 +1131,57 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 				qr.act = QRFailRetry
 			case "BUFFER":
 				qr.act = QRBuffer
+			case "RATE_LIMIT":
+				qr.act = QRRateLimit
+			case "CONCURRENCY_LIMIT":
+				qr.act = QRConcurrencyLimit
 			default:
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Action %s", sv)
 			}
+		case "MaxQPS":
+			maxQPS = fv
+		case "Burst":
+			burst = int(fv)
+		case "MaxConcurrency":
+			maxConcurrency = int(fv)
+		}
+	}
+	switch qr.act {
+	case QRRateLimit:
+		if maxConcurrency != 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "MaxConcurrency not allowed for Action RATE_LIMIT")
+		}
+		if err := qr.SetRateLimit(maxQPS, burst); err != nil {
+			return nil, err
+		}
+	case QRConcurrencyLimit:
+		if maxQPS != 0 || burst != 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "MaxQPS and Burst not allowed for Action CONCURRENCY_LIMIT")
+		}
+		if err := qr.SetConcurrencyLimit(maxConcurrency); err != nil {
+			return nil, err
+		}
+	default:
+		if maxQPS != 0 || burst != 0 || maxConcurrency != 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "MaxQPS, Burst and MaxConcurrency are only allowed for Actions RATE_LIMIT and CONCURRENCY_LIMIT")
 		}
 	}
 	return qr, nil
 }
 
+// getNumber accepts both json.Number and float64, depending on
+// how the rule was decoded.
+func getNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
 func buildBindVarCondition(bvc any) (name string, onAbsent, onMismatch bool, op Operator, value any, err error) {
 	bvcinfo, ok := bvc.(map[string]any)
 	if !ok {
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -586,6 +587,104 @@ func TestAction(t *testing.T) {
 	assert.Equalf(t, desc, "rule 5", "want rule 5, got %s", desc)
 }
 
+func TestRateLimit(t *testing.T) {
+	qrs := New()
+
+	qr1 := NewQueryRule("rule 1", "r1", QRRateLimit)
+	qr1.SetUserCond("user")
+	require.NoError(t, qr1.SetRateLimit(0.001, 2))
+
+	qr2 := NewQueryRule("rule 2", "r2", QRFail)
+	qr2.SetIPCond("123")
+
+	qrs.Add(qr1)
+	qrs.Add(qr2)
+
+	// Limiting rules are skipped by GetAction.
+	action, _, _, desc := qrs.GetAction("123", "user", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRFail, action)
+	assert.Equal(t, "rule 2", desc)
+
+	// The limiter is shared by the filtered copies of the rules.
+	filtered := qrs.FilterByPlan("select * from a", planbuilder.PlanSelect, "a")
+	for _, rules := range []*Rules{qrs, filtered} {
+		release, action, _, _ := rules.Admit("1234", "user", nil, sqlparser.MarginComments{})
+		assert.Equal(t, QRContinue, action)
+		release()
+	}
+	_, action, name, desc := filtered.Admit("1234", "user", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRRateLimit, action)
+	assert.Equal(t, "r1", name)
+	assert.Equal(t, "rule 1", desc)
+
+	// Queries that don't match are not limited.
+	_, action, _, _ = qrs.Admit("1234", "user1", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRContinue, action)
+
+	// Copies share the bucket, while reloaded rules start with a full one.
+	_, action, _, _ = qrs.Copy().Admit("1234", "user", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRRateLimit, action)
+	reloaded := New()
+	require.NoError(t, reloaded.UnmarshalJSON([]byte(marshalled(qrs))))
+	_, action, _, _ = reloaded.Admit("1234", "user", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRContinue, action)
+
+	// The bucket refills at MaxQPS.
+	qr3 := NewQueryRule("rule 3", "r3", QRRateLimit)
+	require.NoError(t, qr3.SetRateLimit(1000, 0))
+	assert.Equal(t, 1000, qr3.effectiveBurst())
+	qr3.limiter.tokens, qr3.limiter.last = 0, time.Now().Add(-10*time.Millisecond)
+	assert.True(t, qr3.limiter.allow(qr3.maxQPS, qr3.effectiveBurst()))
+
+	assert.Error(t, qr3.SetRateLimit(0, 1))
+	assert.Error(t, qr3.SetRateLimit(1, -1))
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	qrs := New()
+
+	qr1 := NewQueryRule("rule 1", "r1", QRConcurrencyLimit)
+	qr1.AddBindVarCond("a", false, false, QREqual, int64(1))
+	require.NoError(t, qr1.SetConcurrencyLimit(1))
+
+	qr2 := NewQueryRule("rule 2", "r2", QRConcurrencyLimit)
+	qr2.SetLeadingCommentCond(".*batch.*")
+	require.NoError(t, qr2.SetConcurrencyLimit(2))
+
+	qrs.Add(qr1)
+	qrs.Add(qr2)
+
+	bv := map[string]*querypb.BindVariable{"a": sqltypes.Int64BindVariable(1)}
+	mc := sqlparser.MarginComments{Leading: "/* batch */ "}
+
+	release1, action, _, _ := qrs.Admit("", "", bv, mc)
+	assert.Equal(t, QRContinue, action)
+
+	// qr1 is full: the slot taken on qr2 is given back.
+	_, action, name, desc := qrs.Admit("", "", bv, mc)
+	assert.Equal(t, QRConcurrencyLimit, action)
+	assert.Equal(t, "r1", name)
+	assert.Equal(t, "rule 1", desc)
+	assert.Equal(t, 1, qr2.limiter.inflight)
+
+	release2, action, _, _ := qrs.Admit("", "", nil, mc)
+	assert.Equal(t, QRContinue, action)
+	_, action, name, _ = qrs.Admit("", "", nil, mc)
+	assert.Equal(t, QRConcurrencyLimit, action)
+	assert.Equal(t, "r2", name)
+
+	release1()
+	release2()
+	assert.Equal(t, 0, qr1.limiter.inflight)
+	assert.Equal(t, 0, qr2.limiter.inflight)
+
+	release, action, _, _ := qrs.Admit("", "", bv, mc)
+	assert.Equal(t, QRContinue, action)
+	release()
+
+	assert.Error(t, qr1.SetConcurrencyLimit(0))
+}
+
 func TestImport(t *testing.T) {
 	var qrs = New()
 	jsondata := `[{
@@ -734,6 +833,14 @@ var invalidjsons = []InvalidJSONCase{
 	{`[{"BindVarConds": [{"Name": "a", "OnAbsent": true, "OnMismatch": true, "Operator": "NOMATCH", "Value": "["}]}]`, "processing [: error parsing regexp: missing closing ]: `[$`"},
 	{`[{"Action": 1 }]`, "want string for Action"},
 	{`[{"Action": "foo" }]`, "invalid Action foo"},
+	{`[{"Action": "RATE_LIMIT", "MaxQPS": "1" }]`, "want number for MaxQPS"},
+	{`[{"Action": "RATE_LIMIT", "MaxQPS": 1, "Burst": 1.5 }]`, "want integer for Burst"},
+	{`[{"Action": "CONCURRENCY_LIMIT", "MaxConcurrency": "1" }]`, "want integer for MaxConcurrency"},
+	{`[{"Action": "RATE_LIMIT" }]`, "invalid rate limit: 0 qps with a burst of 0"},
+	{`[{"Action": "RATE_LIMIT", "MaxQPS": 1, "MaxConcurrency": 1 }]`, "MaxConcurrency not allowed for Action RATE_LIMIT"},
+	{`[{"Action": "CONCURRENCY_LIMIT" }]`, "invalid concurrency limit: 0"},
+	{`[{"Action": "CONCURRENCY_LIMIT", "MaxConcurrency": 1, "Burst": 1 }]`, "MaxQPS and Burst not allowed for Action CONCURRENCY_LIMIT"},
+	{`[{"Action": "FAIL", "MaxQPS": 1 }]`, "MaxQPS, Burst and MaxConcurrency are only allowed for Actions RATE_LIMIT and CONCURRENCY_LIMIT"},
 }
 
 func TestInvalidJSON(t *testing.T) {
@@ -756,6 +863,30 @@ func TestInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestImportLimits(t *testing.T) {
+	var qrs = New()
+	jsondata := `[{
+		"Description": "desc1",
+		"Name": "name1",
+		"Query": "select .* from a",
+		"Action": "RATE_LIMIT",
+		"MaxQPS": 2.5,
+		"Burst": 10
+	},{
+		"Description": "desc2",
+		"Name": "name2",
+		"TableNames": ["b"],
+		"Action": "CONCURRENCY_LIMIT",
+		"MaxConcurrency": 5
+	}]`
+	err := qrs.UnmarshalJSON([]byte(jsondata))
+	require.NoError(t, err)
+	got := marshalled(qrs)
+	want := compacted(jsondata)
+	assert.Equal(t, want, got)
+	assert.True(t, qrs.Equal(qrs.Copy()))
+}
+
 func TestBuildQueryRuleActionFail(t *testing.T) {
 	var ruleInfo map[string]any
 	err := json.Unmarshal([]byte(`{"Action": "FAIL" }`), &ruleInfo)
@@ -107,6 +107,12 @@ func loadMessageInfo(ta *Table, comment string, collationEnv *collations.Environ
 
 	ta.MessageInfo.MaxBackoff, _ = getDuration(keyvals, "vt_max_backoff")
 
+	ta.MessageInfo.MaxAttempts, _ = getNum(keyvals, "vt_max_attempts")
+	ta.MessageInfo.DeadLetterTable = keyvals["vt_dead_letter_table"]
+	if ta.MessageInfo.DeadLetterTable == ta.Name.String() {
+		return fmt.Errorf("vt_dead_letter_table must be a different table: %s", ta.Name.String())
+	}
+
 	// these columns are required for message manager to function properly, but only
 	// id is required to be streamed to subscribers
 	requiredCols := []string{
@@ -134,6 +140,11 @@ func loadMessageInfo(ta *Table, comment string, collationEnv *collations.Environ
 		}
 	}
 
+	ta.MessageInfo.GroupColumn = keyvals["vt_group_column"]
+	if ta.MessageInfo.GroupColumn != "" && ta.FindColumn(sqlparser.NewIdentifierCI(ta.MessageInfo.GroupColumn)) == -1 {
+		return fmt.Errorf("%s missing from message table: %s", ta.MessageInfo.GroupColumn, ta.Name.String())
+	}
+
 	// check to see if the user has specified columns to stream to subscribers
 	specifiedCols := parseMessageCols(keyvals, "vt_message_cols")
 
@@ -126,12 +126,26 @@ type MessageInfo struct {
 	// should wait before rescheduling a message
 	MaxBackoff time.Duration
 
+	// MaxAttempts specifies the number of times a message is
+	// sent before it is dead-lettered. 0 means no limit.
+	MaxAttempts int
+
+	// DeadLetterTable specifies the message table that the
+	// dead-lettered messages are moved to. If it is empty,
+	// they are kept in the message table, marked as failed.
+	DeadLetterTable string
+
+	// GroupColumn specifies the column that groups the messages
+	// for ordered delivery. If set, only the oldest unacked message
+	// of a group, by id, is sent at any time.
+	GroupColumn string
+
 	// IDType specifies the type of the ID column
 	IDType sqltypes.Type
 }
 
 func (mi *MessageInfo) String() string {
-	return fmt.Sprintf("MessageInfo: AckWaitDuration: %v, PurgeAfterDuration: %v, BatchSize: %v, CacheSize: %v, PollInterval: %v, MinBackoff: %v, MaxBackoff: %v, IDType: %v", mi.AckWaitDuration, mi.PurgeAfterDuration, mi.BatchSize, mi.CacheSize, mi.PollInterval, mi.MinBackoff, mi.MaxBackoff, mi.IDType)
+	return fmt.Sprintf("MessageInfo: AckWaitDuration: %v, PurgeAfterDuration: %v, BatchSize: %v, CacheSize: %v, PollInterval: %v, MinBackoff: %v, MaxBackoff: %v, MaxAttempts: %v, DeadLetterTable: %v, GroupColumn: %v, IDType: %v", mi.AckWaitDuration, mi.PurgeAfterDuration, mi.BatchSize, mi.CacheSize, mi.PollInterval, mi.MinBackoff, mi.MaxBackoff, mi.MaxAttempts, mi.DeadLetterTable, mi.GroupColumn, mi.IDType)
 }
 
 // NewTable creates a new Table.
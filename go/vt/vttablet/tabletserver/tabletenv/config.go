@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 
 	"vitess.io/vitess/go/flagutil"
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/pools/smartconnpool"
 	"vitess.io/vitess/go/streamlog"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/log"
@@ -80,6 +82,8 @@ var (
 	unhealthyThreshold           time.Duration
 	transitionGracePeriod        time.Duration
 	enableReplicationReporter    bool
+	poolWorkloadMaxConcurrency   flagutil.StringMapValue
+	poolWorkloadQueueTimeout     flagutil.StringMapValue
 )
 
 func init() {
@@ -144,6 +148,8 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&currentConfig.OltpReadPool.Timeout, "queryserver-config-query-pool-timeout", defaultConfig.OltpReadPool.Timeout, "query server query pool timeout, it is how long vttablet waits for a connection from the query pool. If set to 0 (default) then the overall query timeout is used instead.")
 	fs.DurationVar(&currentConfig.OlapReadPool.Timeout, "queryserver-config-stream-pool-timeout", defaultConfig.OlapReadPool.Timeout, "query server stream pool timeout, it is how long vttablet waits for a connection from the stream pool. If set to 0 (default) then there is no timeout.")
 	fs.DurationVar(&currentConfig.TxPool.Timeout, "queryserver-config-txpool-timeout", defaultConfig.TxPool.Timeout, "query server transaction pool timeout, it is how long vttablet waits if tx pool is full")
+	fs.Var(&poolWorkloadMaxConcurrency, "queryserver-config-pool-workload-max-concurrency", "comma separated list of workload:count pairs, that limit the number of connections of each connection pool that the queries of a workload, named with the WORKLOAD_NAME directive, can use at once.")
+	fs.Var(&poolWorkloadQueueTimeout, "queryserver-config-pool-workload-queue-timeout", "comma separated list of workload:duration pairs, that limit how long the queries of a workload, named with the WORKLOAD_NAME directive, wait for a connection from a connection pool. When a pool is exhausted, queries are handed over connections in the order of their PRIORITY directive.")
 	fs.IntVar(&currentConfig.OltpReadPool.MaxIdleCount, "queryserver-config-query-pool-max-idle-count", defaultConfig.OltpReadPool.MaxIdleCount, "query server query pool - maximum number of idle connections to retain in the pool. Use this to balance between faster response times during traffic bursts and resource efficiency during low-traffic periods.")
 	fs.IntVar(&currentConfig.OlapReadPool.MaxIdleCount, "queryserver-config-stream-pool-max-idle-count", defaultConfig.OlapReadPool.MaxIdleCount, "query server stream pool - maximum number of idle connections to retain in the pool. Use this to balance between faster response times during traffic bursts and resource efficiency during low-traffic periods.")
 	fs.IntVar(&currentConfig.TxPool.MaxIdleCount, "queryserver-config-txpool-max-idle-count", defaultConfig.TxPool.MaxIdleCount, "query server transaction pool - maximum number of idle connections to retain in the pool. Use this to balance between faster response times during traffic bursts and resource efficiency during low-traffic periods.")
@@ -274,6 +280,12 @@ func Init() {
 		currentConfig.ReplicationTracker.Mode = Disable
 	}
 
+	poolWorkloads, err := parsePoolWorkloads(poolWorkloadMaxConcurrency, poolWorkloadQueueTimeout)
+	if err != nil {
+		log.Exitf("Invalid connection pool workloads: %v", err)
+	}
+	currentConfig.PoolWorkloads = poolWorkloads
+
 	currentConfig.Healthcheck.Interval = healthCheckInterval
 	currentConfig.Healthcheck.DegradedThreshold = degradedThreshold
 	currentConfig.Healthcheck.UnhealthyThreshold = unhealthyThreshold
@@ -370,6 +382,10 @@ type TabletConfig struct {
 	EnableViews bool `json:"-"`
 
 	EnablePerWorkloadTableMetrics bool `json:"-"`
+
+	// PoolWorkloads configures the admission of the queries of each workload
+	// to the connection pools.
+	PoolWorkloads map[string]smartconnpool.WorkloadConfig `json:"-"`
 }
 
 func (cfg *TabletConfig) MarshalJSON() ([]byte, error) {
@@ -914,6 +930,34 @@ func (c *TabletConfig) Verify() error {
 	return nil
 }
 
+// parsePoolWorkloads returns the config of the connection pool workloads from
+// their max concurrency and queue timeout flags.
+func parsePoolWorkloads(maxConcurrency, queueTimeout map[string]string) (map[string]smartconnpool.WorkloadConfig, error) {
+	if len(maxConcurrency) == 0 && len(queueTimeout) == 0 {
+		return nil, nil
+	}
+	workloads := make(map[string]smartconnpool.WorkloadConfig)
+	for name, value := range maxConcurrency {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid max concurrency %q for workload %q: must be a positive integer", value, name)
+		}
+		wc := workloads[name]
+		wc.MaxConcurrency = n
+		workloads[name] = wc
+	}
+	for name, value := range queueTimeout {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid queue timeout %q for workload %q: must be a positive duration", value, name)
+		}
+		wc := workloads[name]
+		wc.QueueTimeout = d
+		workloads[name] = wc
+	}
+	return workloads, nil
+}
+
 // verifyUnmanagedTabletConfig checks unmanaged tablet related config for sanity
 func (c *TabletConfig) verifyUnmanagedTabletConfig() error {
 	// Skip checks if tablet is not unmanaged
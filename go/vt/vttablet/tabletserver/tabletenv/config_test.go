@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/pools/smartconnpool"
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/mysqlctl"
@@ -503,3 +504,27 @@ func TestVerifyUnmanagedTabletConfig(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "testPassword", config.DB.App.Password)
 }
+
+func TestParsePoolWorkloads(t *testing.T) {
+	workloads, err := parsePoolWorkloads(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, workloads)
+
+	workloads, err = parsePoolWorkloads(
+		map[string]string{"batch": "4", "etl": "2"},
+		map[string]string{"batch": "500ms", "olap": "2s"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]smartconnpool.WorkloadConfig{
+		"batch": {MaxConcurrency: 4, QueueTimeout: 500 * time.Millisecond},
+		"etl":   {MaxConcurrency: 2},
+		"olap":  {QueueTimeout: 2 * time.Second},
+	}, workloads)
+
+	_, err = parsePoolWorkloads(map[string]string{"batch": "0"}, nil)
+	assert.ErrorContains(t, err, `invalid max concurrency "0" for workload "batch"`)
+	_, err = parsePoolWorkloads(map[string]string{"batch": "many"}, nil)
+	assert.ErrorContains(t, err, `invalid max concurrency "many" for workload "batch"`)
+	_, err = parsePoolWorkloads(nil, map[string]string{"batch": "-1s"})
+	assert.ErrorContains(t, err, `invalid queue timeout "-1s" for workload "batch"`)
+}
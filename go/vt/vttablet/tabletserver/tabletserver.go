@@ -508,6 +508,11 @@ func (tsv *TabletServer) SchemaEngine() *schema.Engine {
 	return tsv.se
 }
 
+// AnalyzeSchemaMigration analyzes an Online DDL migration without submitting it.
+func (tsv *TabletServer) AnalyzeSchemaMigration(ctx context.Context, sql string, ddlStrategy string) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error) {
+	return tsv.onlineDDLExecutor.AnalyzeMigration(ctx, sql, ddlStrategy)
+}
+
 // Begin starts a new transaction. This is allowed only if the state is StateServing.
 func (tsv *TabletServer) Begin(ctx context.Context, target *querypb.Target, options *querypb.ExecuteOptions) (state queryservice.TransactionState, err error) {
 	return tsv.begin(ctx, target, nil, 0, nil, options)
@@ -1232,18 +1237,47 @@ func (tsv *TabletServer) PurgeMessages(ctx context.Context, target *querypb.Targ
 	})
 }
 
+// DeadLetterMessages moves the list of messages for a given message table
+// to its dead-letter table, or marks them as failed if there is none.
+// It returns the number of messages successfully dead-lettered.
+func (tsv *TabletServer) DeadLetterMessages(ctx context.Context, target *querypb.Target, querygen messager.QueryGenerator, ids []string) (count int64, err error) {
+	return tsv.execInTransaction(ctx, target, func(transactionID int64) (int64, error) {
+		queries, bv := querygen.GenerateDeadLetterQueries(ids)
+		var count int64
+		for _, query := range queries {
+			qr, err := tsv.Execute(ctx, target, query, bv, transactionID, 0, nil)
+			if err != nil {
+				return 0, err
+			}
+			count = int64(qr.RowsAffected)
+		}
+		return count, nil
+	})
+}
+
 func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, queryGenerator func() (string, map[string]*querypb.BindVariable, error)) (count int64, err error) {
+	return tsv.execInTransaction(ctx, target, func(transactionID int64) (int64, error) {
+		query, bv, err := queryGenerator()
+		if err != nil {
+			return 0, err
+		}
+		qr, err := tsv.Execute(ctx, target, query, bv, transactionID, 0, nil)
+		if err != nil {
+			return 0, err
+		}
+		return int64(qr.RowsAffected), nil
+	})
+}
+
+// execInTransaction runs exec within a new transaction, which is
+// committed if exec succeeds and rolled back otherwise.
+func (tsv *TabletServer) execInTransaction(ctx context.Context, target *querypb.Target, exec func(transactionID int64) (int64, error)) (count int64, err error) {
 	if err = tsv.sm.StartRequest(ctx, target, false /* allowOnShutdown */); err != nil {
 		return 0, err
 	}
 	defer tsv.sm.EndRequest()
 	defer tsv.handlePanicAndSendLogStats("ack", nil, nil)
 
-	query, bv, err := queryGenerator()
-	if err != nil {
-		return 0, err
-	}
-
 	state, err := tsv.Begin(ctx, target, nil)
 	if err != nil {
 		return 0, err
@@ -1255,7 +1289,7 @@ func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, qu
 			tsv.Rollback(ctx, target, state.TransactionID)
 		}
 	}()
-	qr, err := tsv.Execute(ctx, target, query, bv, state.TransactionID, 0, nil)
+	count, err = exec(state.TransactionID)
 	if err != nil {
 		return 0, err
 	}
@@ -1264,7 +1298,7 @@ func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, qu
 		return 0, err
 	}
 	state.TransactionID = 0
-	return int64(qr.RowsAffected), nil
+	return count, nil
 }
 
 // VStream streams VReplication events.
@@ -1575,6 +1609,10 @@ func (tsv *TabletServer) execRequest(
 		tsv.sm.EndRequest()
 	}()
 
+	// Under contention, the connection pools serve the requests in the order
+	// of their priority, and limit the connections of their workload.
+	ctx = smartconnpool.NewAdmissionContext(ctx, options.GetWorkloadName(), tsv.getPriorityFromOptions(options))
+
 	err = exec(ctx, logStats)
 	if err != nil {
 		return tsv.convertAndLogError(ctx, sql, bindVariables, err, logStats)
@@ -1657,6 +1657,73 @@ func TestPurgeMessages(t *testing.T) {
 	require.EqualValues(t, 1, count)
 }
 
+func TestDeadLetterMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, tsv, db, closer := newTestTxExecutor(t, ctx)
+	defer closer()
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+
+	gen, err := tsv.messager.GetGenerator("msg")
+	require.NoError(t, err)
+
+	_, err = tsv.DeadLetterMessages(ctx, &target, gen, []string{"1", "2"})
+	want := "query: 'update msg set time_next = null"
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), want)
+
+	db.AddQueryPattern(`update msg set time_next = null where id in \(1, 2\) and time_acked is null.*`, &sqltypes.Result{RowsAffected: 2})
+	count, err := tsv.DeadLetterMessages(ctx, &target, gen, []string{"1", "2"})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+}
+
+func TestDeadLetters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, tsv, db, closer := newTestTxExecutor(t, ctx)
+	defer closer()
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+
+	db.AddQuery("select priority, time_next, epoch, time_acked, id, message from msg where time_acked is null and time_next is null and (id = 1)", &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "priority", Type: sqltypes.Int64},
+			{Name: "time_next", Type: sqltypes.Int64},
+			{Name: "epoch", Type: sqltypes.Int64},
+			{Name: "time_acked", Type: sqltypes.Int64},
+			{Name: "id", Type: sqltypes.Int64},
+			{Name: "message", Type: sqltypes.VarBinary},
+		},
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewInt64(0),
+			sqltypes.NULL,
+			sqltypes.NewInt64(3),
+			sqltypes.NULL,
+			sqltypes.NewInt64(1),
+			sqltypes.NewVarBinary("hello"),
+		}},
+	})
+	qr, err := tsv.Execute(ctx, &target, "show vitess_dead_letters from msg where id = :id", map[string]*querypb.BindVariable{"id": sqltypes.Int64BindVariable(1)}, 0, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	assert.Equal(t, "hello", qr.Rows[0][5].ToString())
+
+	_, err = tsv.Execute(ctx, &target, "show vitess_dead_letters from test_table", nil, 0, 0, nil)
+	require.ErrorContains(t, err, "'test_table' is not a message table")
+
+	db.AddQuery("select id from msg where time_acked is null and time_next is null for update", &sqltypes.Result{
+		Fields: []*querypb.Field{{Name: "id", Type: sqltypes.Int64}},
+		Rows: [][]sqltypes.Value{
+			{sqltypes.NewInt64(1)},
+			{sqltypes.NewInt64(2)},
+		},
+	})
+	db.AddQueryPattern(`update msg set time_next = \d+, epoch = null where id in \(1, 2\) and time_acked is null and time_next is null`, &sqltypes.Result{RowsAffected: 2})
+	qr, err = tsv.Execute(ctx, &target, "replay vitess_dead_letters from msg", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, qr.RowsAffected)
+}
+
 func TestHandleExecUnknownError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -62,14 +62,18 @@ func (names MetricNames) Unique() MetricNames {
 }
 
 const (
-	DefaultMetricName                MetricName = "default"
-	LagMetricName                    MetricName = "lag"
-	ThreadsRunningMetricName         MetricName = "threads_running"
-	CustomMetricName                 MetricName = "custom"
-	LoadAvgMetricName                MetricName = "loadavg"
-	HistoryListLengthMetricName      MetricName = "history_list_length"
-	MysqldLoadAvgMetricName          MetricName = "mysqld-loadavg"
-	MysqldDatadirUsedRatioMetricName MetricName = "mysqld-datadir-used-ratio"
+	DefaultMetricName                    MetricName = "default"
+	LagMetricName                        MetricName = "lag"
+	ThreadsRunningMetricName             MetricName = "threads_running"
+	CustomMetricName                     MetricName = "custom"
+	LoadAvgMetricName                    MetricName = "loadavg"
+	HistoryListLengthMetricName          MetricName = "history_list_length"
+	MysqldLoadAvgMetricName              MetricName = "mysqld-loadavg"
+	MysqldDatadirUsedRatioMetricName     MetricName = "mysqld-datadir-used-ratio"
+	MysqldDatadirIOUtilizationMetricName MetricName = "mysqld-datadir-io-utilization"
+	MysqldDatadirIOLatencyMetricName     MetricName = "mysqld-datadir-io-latency"
+	InnodbCheckpointAgeRatioMetricName   MetricName = "innodb_checkpoint_age_ratio"
+	InnodbDirtyPagesRatioMetricName      MetricName = "innodb_dirty_pages_ratio"
 )
 
 func (metric MetricName) DefaultScope() Scope {
@@ -244,18 +244,26 @@ func TestKnownMetricNames(t *testing.T) {
 	assert.Contains(t, KnownMetricNames, HistoryListLengthMetricName)
 	assert.Contains(t, KnownMetricNames, MysqldLoadAvgMetricName)
 	assert.Contains(t, KnownMetricNames, MysqldDatadirUsedRatioMetricName)
+	assert.Contains(t, KnownMetricNames, MysqldDatadirIOUtilizationMetricName)
+	assert.Contains(t, KnownMetricNames, MysqldDatadirIOLatencyMetricName)
+	assert.Contains(t, KnownMetricNames, InnodbCheckpointAgeRatioMetricName)
+	assert.Contains(t, KnownMetricNames, InnodbDirtyPagesRatioMetricName)
 }
 
 func TestKnownMetricNamesPascalCase(t *testing.T) {
 	expectCases := map[MetricName]string{
-		LagMetricName:                    "Lag",
-		ThreadsRunningMetricName:         "ThreadsRunning",
-		LoadAvgMetricName:                "Loadavg",
-		HistoryListLengthMetricName:      "HistoryListLength",
-		CustomMetricName:                 "Custom",
-		DefaultMetricName:                "Default",
-		MysqldLoadAvgMetricName:          "MysqldLoadavg",
-		MysqldDatadirUsedRatioMetricName: "MysqldDatadirUsedRatio",
+		LagMetricName:                        "Lag",
+		ThreadsRunningMetricName:             "ThreadsRunning",
+		LoadAvgMetricName:                    "Loadavg",
+		HistoryListLengthMetricName:          "HistoryListLength",
+		CustomMetricName:                     "Custom",
+		DefaultMetricName:                    "Default",
+		MysqldLoadAvgMetricName:              "MysqldLoadavg",
+		MysqldDatadirUsedRatioMetricName:     "MysqldDatadirUsedRatio",
+		MysqldDatadirIOUtilizationMetricName: "MysqldDatadirIoUtilization",
+		MysqldDatadirIOLatencyMetricName:     "MysqldDatadirIoLatency",
+		InnodbCheckpointAgeRatioMetricName:   "InnodbCheckpointAgeRatio",
+		InnodbDirtyPagesRatioMetricName:      "InnodbDirtyPagesRatio",
 	}
 	for _, metricName := range KnownMetricNames {
 		t.Run(metricName.String(), func(t *testing.T) {
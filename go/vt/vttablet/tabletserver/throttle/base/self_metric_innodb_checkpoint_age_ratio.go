@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	innodbStatusQuery          = "show engine innodb status"
+	redoLogCapacityQuery       = "select @@global.innodb_redo_log_capacity"
+	redoLogCapacityQueryLegacy = "select @@global.innodb_log_file_size * @@global.innodb_log_files_in_group"
+
+	innodbLogSequenceNumberRegexp = regexp.MustCompile(`(?m)^Log sequence number\s+([0-9]+)`)
+	innodbLastCheckpointRegexp    = regexp.MustCompile(`(?m)^Last checkpoint at\s+([0-9]+)`)
+
+	cachedCheckpointAgeRatioMetric     atomic.Pointer[ThrottleMetric]
+	checkpointAgeRatioCacheDuration    = 5 * time.Second
+	checkpointAgeRatioDefaultThreshold = 0.7
+)
+
+var _ SelfMetric = registerSelfMetric(&InnodbCheckpointAgeRatioSelfMetric{})
+
+// InnodbCheckpointAgeRatioSelfMetric stands for the InnoDB checkpoint age, i.e. the amount of redo log that is not
+// yet checkpointed, as a ratio of the redo log capacity. As the ratio grows, InnoDB flushes dirty pages more
+// aggressively, and eventually stalls writes.
+// Range: 0.0 (fully checkpointed) - 1.0 (redo log is full)
+type InnodbCheckpointAgeRatioSelfMetric struct {
+}
+
+func (m *InnodbCheckpointAgeRatioSelfMetric) Name() MetricName {
+	return InnodbCheckpointAgeRatioMetricName
+}
+
+func (m *InnodbCheckpointAgeRatioSelfMetric) DefaultScope() Scope {
+	return SelfScope
+}
+
+func (m *InnodbCheckpointAgeRatioSelfMetric) DefaultThreshold() float64 {
+	return checkpointAgeRatioDefaultThreshold
+}
+
+func (m *InnodbCheckpointAgeRatioSelfMetric) RequiresConn() bool {
+	return true
+}
+
+func (m *InnodbCheckpointAgeRatioSelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
+	// This function will be called sequentially, and therefore does not need strong mutex protection. Still, we use atomics
+	// to ensure correctness in case an external goroutine tries to read the metric concurrently.
+	metric := cachedCheckpointAgeRatioMetric.Load()
+	if metric != nil {
+		return metric
+	}
+	metric = readCheckpointAgeRatio(ctx, params)
+	cachedCheckpointAgeRatioMetric.Store(metric)
+	time.AfterFunc(checkpointAgeRatioCacheDuration, func() {
+		cachedCheckpointAgeRatioMetric.Store(nil)
+	})
+	return metric
+}
+
+func readCheckpointAgeRatio(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
+	metric := &ThrottleMetric{
+		Scope: SelfScope,
+	}
+	if params.Conn == nil {
+		return metric.WithError(fmt.Errorf("conn is nil"))
+	}
+	tm, err := params.Conn.Exec(ctx, innodbStatusQuery, 1, true)
+	if err != nil {
+		return metric.WithError(err)
+	}
+	if len(tm.Rows) != 1 || len(tm.Rows[0]) < 3 {
+		return metric.WithError(fmt.Errorf("unexpected result for query %s", innodbStatusQuery))
+	}
+	// Columns are [Type, Name, Status]
+	checkpointAge, err := parseInnodbCheckpointAge(tm.Rows[0][2].ToString())
+	if err != nil {
+		return metric.WithError(err)
+	}
+	// innodb_redo_log_capacity was introduced in MySQL 8.0.30
+	capacityMetric := ReadSelfMySQLThrottleMetric(ctx, params.Conn, redoLogCapacityQuery)
+	if capacityMetric.Err != nil {
+		capacityMetric = ReadSelfMySQLThrottleMetric(ctx, params.Conn, redoLogCapacityQueryLegacy)
+	}
+	if capacityMetric.Err != nil {
+		return metric.WithError(capacityMetric.Err)
+	}
+	if capacityMetric.Value <= 0 {
+		return metric.WithError(fmt.Errorf("unexpected redo log capacity %v", capacityMetric.Value))
+	}
+	metric.Value = float64(checkpointAge) / capacityMetric.Value
+	return metric
+}
+
+// parseInnodbCheckpointAge computes the checkpoint age out of the LOG section of SHOW ENGINE INNODB STATUS, e.g.:
+//
+//	Log sequence number          21536436
+//	Log flushed up to            21536436
+//	Last checkpoint at           21536380
+func parseInnodbCheckpointAge(status string) (int64, error) {
+	parse := func(re *regexp.Regexp, what string) (int64, error) {
+		match := re.FindStringSubmatch(status)
+		if match == nil {
+			return 0, fmt.Errorf("%s not found in InnoDB status", what)
+		}
+		return strconv.ParseInt(match[1], 10, 64)
+	}
+	lsn, err := parse(innodbLogSequenceNumberRegexp, "log sequence number")
+	if err != nil {
+		return 0, err
+	}
+	checkpoint, err := parse(innodbLastCheckpointRegexp, "last checkpoint")
+	if err != nil {
+		return 0, err
+	}
+	return max(lsn-checkpoint, 0), nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInnodbCheckpointAge(t *testing.T) {
+	tcases := []struct {
+		name    string
+		status  string
+		age     int64
+		isError bool
+	}{
+		{
+			name: "valid",
+			status: `---
+LOG
+---
+Log sequence number          21536436
+Log buffer assigned up to    21536436
+Log buffer completed up to   21536436
+Log written up to            21536436
+Log flushed up to            21536436
+Added dirty pages up to      21536436
+Pages flushed up to          21536380
+Last checkpoint at           21536380
+`,
+			age: 56,
+		},
+		{
+			name:    "no checkpoint",
+			status:  "Log sequence number          21536436\n",
+			isError: true,
+		},
+		{
+			name:    "empty",
+			isError: true,
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			age, err := parseInnodbCheckpointAge(tcase.status)
+			if tcase.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tcase.age, age)
+		})
+	}
+}
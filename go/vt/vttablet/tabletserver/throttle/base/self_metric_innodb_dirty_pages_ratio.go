@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"context"
+)
+
+var (
+	dirtyPagesRatioQuery = `select
+		(select variable_value from performance_schema.global_status where variable_name = 'Innodb_buffer_pool_pages_dirty') /
+		(select variable_value from performance_schema.global_status where variable_name = 'Innodb_buffer_pool_pages_total')
+		as dirty_pages_ratio`
+)
+
+var _ SelfMetric = registerSelfMetric(&InnodbDirtyPagesRatioSelfMetric{})
+
+// InnodbDirtyPagesRatioSelfMetric stands for the ratio of dirty pages in the InnoDB buffer pool. A high ratio
+// means InnoDB is not keeping up with flushing the writes to disk.
+// Range: 0.0 (no dirty pages) - 1.0 (all pages are dirty)
+type InnodbDirtyPagesRatioSelfMetric struct {
+}
+
+func (m *InnodbDirtyPagesRatioSelfMetric) Name() MetricName {
+	return InnodbDirtyPagesRatioMetricName
+}
+
+func (m *InnodbDirtyPagesRatioSelfMetric) DefaultScope() Scope {
+	return SelfScope
+}
+
+func (m *InnodbDirtyPagesRatioSelfMetric) DefaultThreshold() float64 {
+	return 0.75
+}
+
+func (m *InnodbDirtyPagesRatioSelfMetric) RequiresConn() bool {
+	return true
+}
+
+func (m *InnodbDirtyPagesRatioSelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
+	return ReadSelfMySQLThrottleMetric(ctx, params.Conn, dirtyPagesRatioQuery)
+}
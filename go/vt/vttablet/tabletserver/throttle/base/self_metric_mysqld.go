@@ -105,6 +105,8 @@ func getMysqlHostMetric(ctx context.Context, params *SelfMetricReadParams, mysql
 
 var _ SelfMetric = registerSelfMetric(&MysqldLoadAvgSelfMetric{})
 var _ SelfMetric = registerSelfMetric(&MysqldDatadirUsedRatioSelfMetric{})
+var _ SelfMetric = registerSelfMetric(&MysqldDatadirIOUtilizationSelfMetric{})
+var _ SelfMetric = registerSelfMetric(&MysqldDatadirIOLatencySelfMetric{})
 
 // MysqldLoadAvgSelfMetric stands for the load average per cpu, on the MySQL host.
 type MysqldLoadAvgSelfMetric struct {
@@ -154,3 +156,54 @@ func (m *MysqldDatadirUsedRatioSelfMetric) RequiresConn() bool {
 func (m *MysqldDatadirUsedRatioSelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
 	return getMysqlHostMetric(ctx, params, "datadir-used-ratio")
 }
+
+// MysqldDatadirIOUtilizationSelfMetric stands for the I/O utilization of the device where MySQL's datadir is located,
+// i.e. the ratio of time in which the device was busy serving I/O requests.
+// Range: 0.0 (idle) - 1.0 (saturated)
+type MysqldDatadirIOUtilizationSelfMetric struct {
+}
+
+func (m *MysqldDatadirIOUtilizationSelfMetric) Name() MetricName {
+	return MysqldDatadirIOUtilizationMetricName
+}
+
+func (m *MysqldDatadirIOUtilizationSelfMetric) DefaultScope() Scope {
+	return SelfScope
+}
+
+func (m *MysqldDatadirIOUtilizationSelfMetric) DefaultThreshold() float64 {
+	return 0.9
+}
+
+func (m *MysqldDatadirIOUtilizationSelfMetric) RequiresConn() bool {
+	return false
+}
+
+func (m *MysqldDatadirIOUtilizationSelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
+	return getMysqlHostMetric(ctx, params, "datadir-io-utilization")
+}
+
+// MysqldDatadirIOLatencySelfMetric stands for the average time, in milliseconds, it takes the device where MySQL's
+// datadir is located to serve a read or write request.
+type MysqldDatadirIOLatencySelfMetric struct {
+}
+
+func (m *MysqldDatadirIOLatencySelfMetric) Name() MetricName {
+	return MysqldDatadirIOLatencyMetricName
+}
+
+func (m *MysqldDatadirIOLatencySelfMetric) DefaultScope() Scope {
+	return SelfScope
+}
+
+func (m *MysqldDatadirIOLatencySelfMetric) DefaultThreshold() float64 {
+	return 50
+}
+
+func (m *MysqldDatadirIOLatencySelfMetric) RequiresConn() bool {
+	return false
+}
+
+func (m *MysqldDatadirIOLatencySelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
+	return getMysqlHostMetric(ctx, params, "datadir-io-latency")
+}
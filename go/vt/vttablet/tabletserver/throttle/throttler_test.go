@@ -88,6 +88,30 @@ var (
 			Value: 0.85,
 			Err:   nil,
 		},
+		base.MysqldDatadirIOUtilizationMetricName: &base.ThrottleMetric{
+			Scope: base.SelfScope,
+			Alias: "",
+			Value: 0.4,
+			Err:   nil,
+		},
+		base.MysqldDatadirIOLatencyMetricName: &base.ThrottleMetric{
+			Scope: base.SelfScope,
+			Alias: "",
+			Value: 2.5,
+			Err:   nil,
+		},
+		base.InnodbCheckpointAgeRatioMetricName: &base.ThrottleMetric{
+			Scope: base.SelfScope,
+			Alias: "",
+			Value: 0.2,
+			Err:   nil,
+		},
+		base.InnodbDirtyPagesRatioMetricName: &base.ThrottleMetric{
+			Scope: base.SelfScope,
+			Alias: "",
+			Value: 0.1,
+			Err:   nil,
+		},
 	}
 	replicaMetrics = map[string]*MetricResult{
 		base.LagMetricName.String(): {
@@ -118,6 +142,22 @@ var (
 			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
 			Value:        0.87,
 		},
+		base.MysqldDatadirIOUtilizationMetricName.String(): {
+			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
+			Value:        0.45,
+		},
+		base.MysqldDatadirIOLatencyMetricName.String(): {
+			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
+			Value:        3.5,
+		},
+		base.InnodbCheckpointAgeRatioMetricName.String(): {
+			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
+			Value:        0.25,
+		},
+		base.InnodbDirtyPagesRatioMetricName.String(): {
+			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
+			Value:        0.15,
+		},
 	}
 	nonPrimaryTabletType atomic.Int32
 )
@@ -2116,7 +2156,11 @@ func TestReplica(t *testing.T) {
 					case base.ThreadsRunningMetricName,
 						base.HistoryListLengthMetricName,
 						base.MysqldLoadAvgMetricName,
-						base.MysqldDatadirUsedRatioMetricName:
+						base.MysqldDatadirUsedRatioMetricName,
+						base.MysqldDatadirIOUtilizationMetricName,
+						base.MysqldDatadirIOLatencyMetricName,
+						base.InnodbCheckpointAgeRatioMetricName,
+						base.InnodbDirtyPagesRatioMetricName:
 						assert.NoError(t, metricResult.Error, "metricName=%v, value=%v, threshold=%v", metricName, metricResult.Value, metricResult.Threshold)
 					default:
 						assert.Fail(t, "unexpected metric", "name=%v", metricName)
@@ -200,6 +200,11 @@ func (tqsc *Controller) QueryService() queryservice.QueryService {
 	return nil
 }
 
+// AnalyzeSchemaMigration is part of the tabletserver.Controller interface
+func (tqsc *Controller) AnalyzeSchemaMigration(ctx context.Context, sql string, ddlStrategy string) (*tabletmanagerdata.AnalyzeSchemaMigrationResponse, error) {
+	return nil, nil
+}
+
 // SchemaEngine is part of the tabletserver.Controller interface
 func (tqsc *Controller) SchemaEngine() *schema.Engine {
 	return nil
@@ -118,6 +118,9 @@ type TabletManagerClient interface {
 	// ApplySchema will apply a schema change
 	ApplySchema(ctx context.Context, tablet *topodatapb.Tablet, change *tmutils.SchemaChange) (*tabletmanagerdatapb.SchemaChangeResult, error)
 
+	// AnalyzeSchemaMigration analyzes an Online DDL migration without submitting it
+	AnalyzeSchemaMigration(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.AnalyzeSchemaMigrationRequest) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error)
+
 	LockTables(ctx context.Context, tablet *topodatapb.Tablet) error
 
 	UnlockTables(ctx context.Context, tablet *topodatapb.Tablet) error
@@ -713,6 +713,37 @@ func tmRPCTestApplySchemaPanic(ctx context.Context, t *testing.T, client tmclien
 	expectHandleRPCPanic(t, "ApplySchema", true /*verbose*/, err)
 }
 
+var testAnalyzeSchemaMigrationRequest = &tabletmanagerdatapb.AnalyzeSchemaMigrationRequest{
+	Sql:         "alter table t add column fruit varchar(32)",
+	DdlStrategy: "vitess",
+}
+
+var testAnalyzeSchemaMigrationResponse = &tabletmanagerdatapb.AnalyzeSchemaMigrationResponse{
+	Table:             "t",
+	InstantDdlCapable: true,
+	UniqueKey:         "PRIMARY",
+	UniqueKeyColumns:  []string{"id"},
+	EstimatedRows:     1000,
+}
+
+func (fra *fakeRPCTM) AnalyzeSchemaMigration(ctx context.Context, req *tabletmanagerdatapb.AnalyzeSchemaMigrationRequest) (*tabletmanagerdatapb.AnalyzeSchemaMigrationResponse, error) {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	compare(fra.t, "AnalyzeSchemaMigration request", req, testAnalyzeSchemaMigrationRequest)
+	return testAnalyzeSchemaMigrationResponse, nil
+}
+
+func tmRPCTestAnalyzeSchemaMigration(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	resp, err := client.AnalyzeSchemaMigration(ctx, tablet, testAnalyzeSchemaMigrationRequest)
+	compareError(t, "AnalyzeSchemaMigration", err, resp, testAnalyzeSchemaMigrationResponse)
+}
+
+func tmRPCTestAnalyzeSchemaMigrationPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.AnalyzeSchemaMigration(ctx, tablet, testAnalyzeSchemaMigrationRequest)
+	expectHandleRPCPanic(t, "AnalyzeSchemaMigration", false /*verbose*/, err)
+}
+
 var testExecuteQueryQuery = []byte("drop table t")
 
 func (fra *fakeRPCTM) ExecuteQuery(ctx context.Context, req *tabletmanagerdatapb.ExecuteQueryRequest) (*querypb.QueryResult, error) {
@@ -1559,6 +1590,7 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestReloadSchema(ctx, t, client, tablet)
 	tmRPCTestPreflightSchema(ctx, t, client, tablet)
 	tmRPCTestApplySchema(ctx, t, client, tablet)
+	tmRPCTestAnalyzeSchemaMigration(ctx, t, client, tablet)
 	tmRPCTestExecuteFetch(ctx, t, client, tablet)
 
 	// Replication related methods
@@ -1624,6 +1656,7 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestReloadSchemaPanic(ctx, t, client, tablet)
 	tmRPCTestPreflightSchemaPanic(ctx, t, client, tablet)
 	tmRPCTestApplySchemaPanic(ctx, t, client, tablet)
+	tmRPCTestAnalyzeSchemaMigrationPanic(ctx, t, client, tablet)
 	tmRPCTestExecuteFetchPanic(ctx, t, client, tablet)
 
 	// Replication related methods